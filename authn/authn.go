@@ -0,0 +1,162 @@
+// Package authn provides bearer token authentication: it extracts the token
+// of a request, verifies it with a pluggable Verifier (e.g., a JWT library),
+// validates the expiry, activation time and audience of its claims, and
+// places the claims in the request context. Failures are reported as
+// `exception.Unauthorized` exceptions whose details "error" is a machine
+// readable reason code.
+package authn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Reason codes reported under the details "error" key of authentication
+// failures.
+const (
+	ReasonMissingToken     = "missing_token"       // ReasonMissingToken reports a request without bearer token.
+	ReasonMalformedToken   = "malformed_token"     // ReasonMalformedToken reports an Authorization header or token that cannot be parsed.
+	ReasonInvalidToken     = "invalid_token"       // ReasonInvalidToken reports a token rejected by the Verifier (e.g., a bad signature).
+	ReasonExpiredToken     = "expired_token"       // ReasonExpiredToken reports a token past its expiry.
+	ReasonTokenNotYetValid = "token_not_yet_valid" // ReasonTokenNotYetValid reports a token before its activation time.
+	ReasonInvalidAudience  = "invalid_audience"    // ReasonInvalidAudience reports a token issued for another audience.
+)
+
+// DefaultLeeway is the default clock skew tolerated when validating times.
+const DefaultLeeway = 30 * time.Second
+
+// Claims are the verified statements carried by a token.
+type Claims struct {
+	Subject   string                 // The principal the token was issued to ("sub").
+	Issuer    string                 // The issuer of the token ("iss").
+	Audience  []string               // The recipients the token is intended for ("aud").
+	ExpiresAt time.Time              // The expiry of the token ("exp"); zero when it does not expire.
+	NotBefore time.Time              // The activation time of the token ("nbf"); zero when immediately valid.
+	IssuedAt  time.Time              // When the token was issued ("iat").
+	Extra     map[string]interface{} // The other claims (e.g., "scope", "tenant").
+}
+
+// Verifier checks the integrity of a token (its signature, its issuer, ...)
+// and returns its claims. It does not need to validate the times and the
+// audience, which the Authenticator checks. A Verifier reports a rejected
+// token with a plain error or an `exception.Unauthorized`, and a failure to
+// verify (e.g., an unreachable key set) with another exception, which is
+// returned unchanged.
+type Verifier interface {
+	// Verify returns the claims of a valid token.
+	Verify(ctx context.Context, token string) (Claims, error)
+}
+
+// VerifierFunc adapts a function to the Verifier interface.
+type VerifierFunc func(ctx context.Context, token string) (Claims, error)
+
+// Verify calls f.
+func (f VerifierFunc) Verify(ctx context.Context, token string) (Claims, error) {
+	return f(ctx, token)
+}
+
+// Config holds the settings of an Authenticator. Zero values fall back to
+// the defaults.
+type Config struct {
+	// Verifier checks the tokens. It is required.
+	Verifier Verifier
+
+	// Audience, when set, must be one of the audiences of the claims.
+	Audience string
+
+	// Leeway is the clock skew tolerated when validating ExpiresAt and
+	// NotBefore. Defaults to DefaultLeeway; a negative value disables it.
+	Leeway time.Duration
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// Authenticator authenticates bearer tokens. It is safe for concurrent use.
+type Authenticator struct {
+	config Config
+}
+
+// New creates an Authenticator.
+//
+// Parameters:
+//
+//	config: The authenticator settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Authenticator.
+func New(config Config) *Authenticator {
+	if config.Leeway == 0 {
+		config.Leeway = DefaultLeeway
+	}
+	if config.Leeway < 0 {
+		config.Leeway = 0
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Authenticator{config: config}
+}
+
+// Authenticate verifies a token and validates its claims.
+//
+// Parameters:
+//
+//	ctx: The context passed to the Verifier.
+//	token: The bearer token.
+//
+// Returns:
+//
+//	The claims of the token, or an `exception.Unauthorized` whose details
+//	"error" is one of the Reason constants. Exceptions returned by the
+//	Verifier are returned unchanged.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (Claims, error) {
+	if token == "" {
+		return Claims{}, failure("The bearer token is missing.", ReasonMissingToken, nil)
+	}
+
+	claims, err := a.config.Verifier.Verify(ctx, token)
+	if err != nil {
+		var coreErr exception.CoreInterface
+		if errors.As(err, &coreErr) {
+			return Claims{}, err // A rejection or a failure to verify reported by the Verifier itself.
+		}
+		return Claims{}, failure("The bearer token is invalid.", ReasonInvalidToken, nil)
+	}
+
+	now := a.config.Now()
+	if !claims.ExpiresAt.IsZero() && !now.Before(claims.ExpiresAt.Add(a.config.Leeway)) {
+		return Claims{}, failure("The bearer token has expired.", ReasonExpiredToken, map[string]interface{}{
+			"expired_at": claims.ExpiresAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if !claims.NotBefore.IsZero() && now.Add(a.config.Leeway).Before(claims.NotBefore) {
+		return Claims{}, failure("The bearer token is not valid yet.", ReasonTokenNotYetValid, map[string]interface{}{
+			"not_before": claims.NotBefore.UTC().Format(time.RFC3339),
+		})
+	}
+	if a.config.Audience != "" && !slices.Contains(claims.Audience, a.config.Audience) {
+		return Claims{}, failure(fmt.Sprintf("The bearer token is not intended for %q.", a.config.Audience), ReasonInvalidAudience, map[string]interface{}{
+			"audience": a.config.Audience,
+		})
+	}
+	return claims, nil
+}
+
+// failure builds the exception reporting an authentication failure.
+func failure(message string, reason string, extra map[string]interface{}) error {
+	details := map[string]interface{}{"error": reason}
+	for key, value := range extra {
+		details[key] = value
+	}
+	return exception.NewUnauthorized(map[string]interface{}{
+		"message": message,
+		"details": details,
+	})
+}
@@ -0,0 +1,80 @@
+// Package authn provides bearer token authentication. This file defines the
+// claims carried by contexts and the HTTP middleware authenticating requests.
+package authn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// contextKey is the unexported type of the keys defined by this package.
+type contextKey struct{}
+
+// WithClaims returns a copy of ctx carrying the claims of the authenticated
+// caller.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims carried by ctx, and false when the
+// caller is not authenticated.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(Claims)
+	return claims, ok
+}
+
+// AuthenticateRequest authenticates the bearer token of a request. See
+// BearerToken and Authenticator.Authenticate.
+func (a *Authenticator) AuthenticateRequest(r *http.Request) (Claims, error) {
+	token, err := BearerToken(r)
+	if err != nil {
+		return Claims{}, err
+	}
+	return a.Authenticate(r.Context(), token)
+}
+
+// Middleware authenticates each request. Authenticated requests reach next
+// with their claims in the context (see ClaimsFromContext). Other requests
+// receive the error envelope rendered by `response.WriteErrorContext`, along
+// with a WWW-Authenticate challenge for 401 responses, and next is not called.
+//
+// Parameters:
+//
+//	authenticator: The authenticator checking the tokens.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the authentication.
+func Middleware(authenticator *Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := authenticator.AuthenticateRequest(r)
+			if err != nil {
+				var unauthorized *exception.Unauthorized
+				if errors.As(err, &unauthorized) {
+					w.Header().Set("WWW-Authenticate", challenge(unauthorized.GetDetailsMessage()))
+				}
+				_ = response.WriteErrorContext(r.Context(), w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// challenge builds the WWW-Authenticate header of a failure. As required by
+// RFC 6750, a request without credentials receives no error code.
+func challenge(reason string) string {
+	switch reason {
+	case ReasonMissingToken:
+		return "Bearer"
+	case ReasonMalformedToken:
+		return `Bearer error="invalid_request"`
+	default:
+		return `Bearer error="invalid_token"`
+	}
+}
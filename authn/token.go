@@ -0,0 +1,34 @@
+// Package authn provides bearer token authentication. This file defines the
+// extraction of bearer tokens from requests.
+package authn
+
+import (
+	"net/http"
+	"strings"
+)
+
+// BearerToken extracts the bearer token of a request from its Authorization
+// header, whose scheme is matched case-insensitively (RFC 6750).
+//
+// Parameters:
+//
+//	r: The request.
+//
+// Returns:
+//
+//	The token, or an `exception.Unauthorized` with the ReasonMissingToken
+//	reason when the header is absent, or the ReasonMalformedToken reason
+//	when it does not hold a bearer token.
+func BearerToken(r *http.Request) (string, error) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	if header == "" {
+		return "", failure("The Authorization header is missing.", ReasonMissingToken, nil)
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" || strings.ContainsAny(token, " \t") {
+		return "", failure("The Authorization header does not hold a bearer token.", ReasonMalformedToken, nil)
+	}
+	return token, nil
+}
@@ -0,0 +1,183 @@
+// Package authz provides authorization: policies deciding whether a subject
+// may perform an action on a resource, their composition with AnyOf and
+// AllOf, and the Authorize guard turning a denial into an
+// `exception.Forbidden` naming the policies that denied the request.
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// PolicyChecker decides whether a subject may perform an action on a
+// resource.
+type PolicyChecker interface {
+	// Allow reports whether the subject may perform the action on the
+	// resource. An error reports a failure to decide (e.g., an unreachable
+	// permission store), not a denial.
+	Allow(ctx context.Context, subject interface{}, action string, resource interface{}) (bool, error)
+}
+
+// Named is implemented by policy checkers carrying a name, reported when they
+// deny a request. Other checkers are named by their Go type.
+type Named interface {
+	// Name returns the name of the policy (e.g., "document.owner").
+	Name() string
+}
+
+// CheckerFunc adapts a function to the PolicyChecker interface.
+type CheckerFunc func(ctx context.Context, subject interface{}, action string, resource interface{}) (bool, error)
+
+// Allow calls f.
+func (f CheckerFunc) Allow(ctx context.Context, subject interface{}, action string, resource interface{}) (bool, error) {
+	return f(ctx, subject, action, resource)
+}
+
+// Policy is the PolicyChecker built by this package.
+type Policy struct {
+	name  string
+	check func(ctx context.Context, subject interface{}, action string, resource interface{}) ([]string, error)
+}
+
+// New creates a named policy from a function.
+//
+// Parameters:
+//
+//	name: The name of the policy (e.g., "document.owner").
+//	allow: The function deciding whether the subject may perform the action
+//	       on the resource.
+//
+// Returns:
+//
+//	The Policy.
+func New(name string, allow CheckerFunc) Policy {
+	return Policy{name: name, check: func(ctx context.Context, subject interface{}, action string, resource interface{}) ([]string, error) {
+		allowed, err := allow(ctx, subject, action, resource)
+		if err != nil || allowed {
+			return nil, err
+		}
+		return []string{name}, nil
+	}}
+}
+
+// Name returns the name of the policy. Composite policies are named by the
+// composition of their operands, e.g. "any_of(admin,all_of(owner,draft))".
+func (p Policy) Name() string {
+	return p.name
+}
+
+// Allow reports whether the subject may perform the action on the resource.
+func (p Policy) Allow(ctx context.Context, subject interface{}, action string, resource interface{}) (bool, error) {
+	denied, err := p.check(ctx, subject, action, resource)
+	return err == nil && len(denied) == 0, err
+}
+
+// AllOf returns a policy allowing a request when every checker allows it.
+// The checkers are evaluated in order and the first denial stops the
+// evaluation; it is reported as the denying policy.
+func AllOf(checkers ...PolicyChecker) Policy {
+	return Policy{
+		name: composite("all_of", checkers),
+		check: func(ctx context.Context, subject interface{}, action string, resource interface{}) ([]string, error) {
+			for _, checker := range checkers {
+				if denied, err := denials(ctx, checker, subject, action, resource); err != nil || len(denied) > 0 {
+					return denied, err
+				}
+			}
+			return nil, nil
+		},
+	}
+}
+
+// AnyOf returns a policy allowing a request when at least one checker allows
+// it. The checkers are evaluated in order and the first approval stops the
+// evaluation; when every checker denies the request, they are all reported.
+func AnyOf(checkers ...PolicyChecker) Policy {
+	return Policy{
+		name: composite("any_of", checkers),
+		check: func(ctx context.Context, subject interface{}, action string, resource interface{}) ([]string, error) {
+			var denied []string
+			for _, checker := range checkers {
+				failed, err := denials(ctx, checker, subject, action, resource)
+				if err != nil {
+					return nil, err
+				}
+				if len(failed) == 0 {
+					return nil, nil
+				}
+				denied = append(denied, failed...)
+			}
+			if len(denied) == 0 {
+				denied = []string{composite("any_of", checkers)} // No checker at all.
+			}
+			return denied, nil
+		},
+	}
+}
+
+// Authorize enforces a policy, e.g. at the start of a use case.
+//
+// Parameters:
+//
+//	ctx: The context passed to the checker.
+//	checker: The policy the request must satisfy.
+//	subject: The caller (e.g., the authenticated principal).
+//	action: The action requested (e.g., "document.delete").
+//	resource: The resource the action applies to, or nil.
+//
+// Returns:
+//
+//	Nil when the request is allowed, the error of the checker when it fails
+//	to decide, or an `exception.Forbidden` otherwise. Its details hold the
+//	action, the name of the first denying policy under "policy" and those of
+//	every denying policy under "policies".
+func Authorize(ctx context.Context, checker PolicyChecker, subject interface{}, action string, resource interface{}) error {
+	denied, err := denials(ctx, checker, subject, action, resource)
+	if err != nil || len(denied) == 0 {
+		return err
+	}
+
+	return exception.NewForbidden(map[string]interface{}{
+		"message": fmt.Sprintf("The action %q is denied by the policy %q.", action, denied[0]),
+		"details": map[string]interface{}{
+			"action":   action,
+			"policy":   denied[0],
+			"policies": denied,
+			"error":    "access_denied",
+		},
+	})
+}
+
+// denials returns the names of the policies of checker denying the request.
+func denials(ctx context.Context, checker PolicyChecker, subject interface{}, action string, resource interface{}) ([]string, error) {
+	if p, ok := checker.(Policy); ok && p.check != nil {
+		return p.check(ctx, subject, action, resource)
+	}
+	allowed, err := checker.Allow(ctx, subject, action, resource)
+	if err != nil || allowed {
+		return nil, err
+	}
+	return []string{nameOf(checker)}, nil
+}
+
+// nameOf returns the name of a checker.
+func nameOf(checker PolicyChecker) string {
+	if named, ok := checker.(Named); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", checker)
+}
+
+// composite names a composition of checkers, e.g. "all_of(a,b)".
+func composite(operator string, checkers []PolicyChecker) string {
+	name := operator + "("
+	for i, checker := range checkers {
+		if i > 0 {
+			name += ","
+		}
+		name += nameOf(checker)
+	}
+	return name + ")"
+}
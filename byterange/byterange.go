@@ -0,0 +1,147 @@
+// Package byterange parses the Range header of download requests (RFC 9110
+// section 14) against the size of the resource. It returns the byte ranges
+// to serve with 206 Partial Content, or an `exception.RangeNotSatisfiable`
+// carrying the Content-Range value of the 416 response when none of them
+// overlaps the resource.
+package byterange
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// MaxRanges is the maximum number of ranges of a Range header. Headers
+// listing more ranges are ignored, as serving many small or overlapping
+// ranges is a known denial of service vector.
+const MaxRanges = 16
+
+// Range is a satisfiable byte range of a resource.
+type Range struct {
+	Start int64 // The offset of the first byte.
+	End   int64 // The offset of the last byte, inclusive.
+}
+
+// Length returns the number of bytes of the range.
+func (r Range) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// ContentRange formats the Content-Range header of the range, e.g.
+// "bytes 0-499/1234".
+func (r Range) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.End, size)
+}
+
+// Parse parses a Range header against the size of a resource. Ranges ending
+// beyond the resource are truncated to it and unsatisfiable ranges are
+// dropped. As RFC 9110 allows, headers that are malformed, that use another
+// unit than "bytes" or that list more than MaxRanges ranges are ignored, so
+// that the whole resource is served.
+//
+// Parameters:
+//
+//	header: The value of the Range header.
+//	size: The size of the resource in bytes.
+//
+// Returns:
+//
+//	The ranges to serve, in the requested order, or nil when the whole
+//	resource must be served. When no range is satisfiable, an
+//	`exception.RangeNotSatisfiable` whose details hold the "size" and the
+//	"content_range" of the 416 response (e.g., "bytes */1234").
+func Parse(header string, size int64) ([]Range, error) {
+	specs, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok {
+		return nil, nil
+	}
+	parts := strings.Split(specs, ",")
+	if len(parts) > MaxRanges {
+		return nil, nil
+	}
+
+	var ranges []Range
+	for _, part := range parts {
+		r, satisfiable, valid := parseSpec(strings.TrimSpace(part), size)
+		if !valid {
+			return nil, nil
+		}
+		if satisfiable {
+			ranges = append(ranges, r)
+		}
+	}
+	if len(ranges) == 0 {
+		return nil, exception.NewRangeNotSatisfiable(map[string]interface{}{
+			"message": "None of the requested ranges overlaps the resource.",
+			"details": map[string]interface{}{
+				"size":          size,
+				"content_range": fmt.Sprintf("bytes */%d", size),
+				"error":         "range_not_satisfiable",
+			},
+		})
+	}
+	return ranges, nil
+}
+
+// ParseRequest parses the Range header of a GET request. Other methods and
+// requests without Range header yield nil, so that the whole resource is
+// served.
+func ParseRequest(r *http.Request, size int64) ([]Range, error) {
+	header := r.Header.Get("Range")
+	if r.Method != http.MethodGet || header == "" {
+		return nil, nil
+	}
+	return Parse(header, size)
+}
+
+// parseSpec parses a range spec: "first-last", "first-" or "-suffix".
+//
+// Returns:
+//
+//	The range truncated to the resource, whether it is satisfiable, and
+//	whether the spec is syntactically valid.
+func parseSpec(spec string, size int64) (Range, bool, bool) {
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok || (first == "" && last == "") {
+		return Range{}, false, false
+	}
+
+	if first == "" {
+		suffix, ok := parseOffset(last)
+		if !ok {
+			return Range{}, false, false
+		}
+		if suffix == 0 || size == 0 {
+			return Range{}, false, true
+		}
+		return Range{Start: max(size-suffix, 0), End: size - 1}, true, true
+	}
+
+	start, ok := parseOffset(first)
+	if !ok {
+		return Range{}, false, false
+	}
+	end := size - 1
+	if last != "" {
+		if end, ok = parseOffset(last); !ok || end < start {
+			return Range{}, false, false
+		}
+		end = min(end, size-1)
+	}
+	if start >= size {
+		return Range{}, false, true
+	}
+	return Range{Start: start, End: end}, true, true
+}
+
+// parseOffset parses a non negative decimal offset.
+func parseOffset(value string) (int64, bool) {
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return 0, false
+	}
+	offset, err := strconv.ParseInt(value, 10, 64)
+	return offset, err == nil
+}
@@ -0,0 +1,58 @@
+// Package cache defines the caching contract of the core: a generic Cache
+// with per-entry time-to-live, the `exception.CacheMiss` reporting a missing
+// entry (so that callers can tell a miss from an infrastructure failure with
+// `errors.As` or IsMiss), an in-memory LRU implementation and a decorator
+// recording hits and misses through a `metrics.Meter`.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Cache stores values of type V by key. Implementations report a missing,
+// expired or evicted entry with an `exception.CacheMiss` and any other
+// failure (unreachable server, undecodable value, ...) with another error.
+type Cache[V any] interface {
+	// Get returns the value stored under key, or an `exception.CacheMiss`
+	// when there is none.
+	Get(ctx context.Context, key string) (V, error)
+
+	// Set stores a value under key for the given time-to-live. A zero or
+	// negative ttl stores the value without expiration (an implementation may
+	// still evict it).
+	Set(ctx context.Context, key string, value V, ttl time.Duration) error
+
+	// Delete removes the entry stored under key. Deleting a missing entry is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewMiss creates the exception reporting a missing entry.
+//
+// Parameters:
+//
+//	key: The key that matched nothing.
+//
+// Returns:
+//
+//	A pointer to an `exception.CacheMiss` with the key in its details.
+func NewMiss(key string) *exception.CacheMiss {
+	return exception.NewCacheMiss(map[string]interface{}{
+		"message": fmt.Sprintf("The cache holds no entry for the key %q.", key),
+		"details": map[string]interface{}{
+			"key":   key,
+			"error": "cache_miss",
+		},
+	})
+}
+
+// IsMiss reports whether the chain of err contains an `exception.CacheMiss`.
+func IsMiss(err error) bool {
+	var miss *exception.CacheMiss
+	return errors.As(err, &miss)
+}
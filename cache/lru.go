@@ -0,0 +1,144 @@
+// Package cache defines the caching contract of the core. This file defines
+// the in-memory LRU Cache.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is the default maximum number of entries of an LRU.
+const DefaultCapacity = 1024
+
+// LRUConfig holds the settings of an LRU. Zero values fall back to the
+// defaults.
+type LRUConfig struct {
+	// Capacity is the maximum number of entries; setting an entry beyond it
+	// evicts the least recently used one. Defaults to DefaultCapacity.
+	Capacity int
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// LRU is an in-memory Cache evicting its least recently used entries once
+// full. Expired entries are removed when they are accessed or evicted. It is
+// safe for concurrent use.
+type LRU[V any] struct {
+	config LRUConfig
+
+	mu      sync.Mutex
+	order   *list.List               // The entries, most recently used first.
+	entries map[string]*list.Element // The elements of order by key.
+}
+
+// entry is an element of the LRU order.
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time // Zero when the entry does not expire.
+}
+
+// NewLRU creates an empty LRU.
+//
+// Parameters:
+//
+//	config: The LRU settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new LRU.
+func NewLRU[V any](config LRUConfig) *LRU[V] {
+	if config.Capacity <= 0 {
+		config.Capacity = DefaultCapacity
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &LRU[V]{config: config, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns the value stored under key, or an `exception.CacheMiss` when
+// there is none or it expired.
+func (c *LRU[V]) Get(_ context.Context, key string) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, NewMiss(key)
+	}
+	e := element.Value.(*entry[V])
+	if c.expired(e) {
+		c.remove(element)
+		var zero V
+		return zero, NewMiss(key)
+	}
+	c.order.MoveToFront(element)
+	return e.value, nil
+}
+
+// Set stores a value under key, evicting the least recently used entry when
+// the LRU is full.
+func (c *LRU[V]) Set(_ context.Context, key string, value V, ttl time.Duration) error {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.config.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[V])
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.config.Capacity {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// Delete removes the entry stored under key.
+func (c *LRU[V]) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Purge removes every entry.
+func (c *LRU[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+}
+
+// expired reports whether an entry expired.
+func (c *LRU[V]) expired(e *entry[V]) bool {
+	return !e.expiresAt.IsZero() && !c.config.Now().Before(e.expiresAt)
+}
+
+// remove removes an element. The lock must be held.
+func (c *LRU[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[V]).key)
+}
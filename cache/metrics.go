@@ -0,0 +1,83 @@
+// Package cache defines the caching contract of the core. This file defines
+// the decorator recording cache metrics.
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/osirisgate/golang-core/metrics"
+)
+
+// Results of a lookup, as recorded in the "result" label.
+const (
+	ResultHit   = "hit"   // ResultHit means the entry was found.
+	ResultMiss  = "miss"  // ResultMiss means the cache holds no entry for the key.
+	ResultError = "error" // ResultError means the lookup failed.
+)
+
+// instrumented is the Cache returned by WithMetrics.
+type instrumented[V any] struct {
+	next    Cache[V]
+	name    string
+	lookups metrics.Counter
+	errors  metrics.Counter
+}
+
+// WithMetrics decorates a Cache so that it records its activity through a
+// Meter, labeled with the name of the cache:
+//
+//	cache_lookups_total{cache,result}: every Get, by result (hit, miss or
+//	error), from which the hit ratio is derived.
+//	cache_errors_total{cache,operation}: every failed Set or Delete.
+//
+// Parameters:
+//
+//	next: The decorated Cache.
+//	m: The Meter to emit through. Nil uses `metrics.Nop()`.
+//	name: The name of the cache (e.g., "sessions").
+//
+// Returns:
+//
+//	The decorated Cache.
+func WithMetrics[V any](next Cache[V], m metrics.Meter, name string) Cache[V] {
+	m = metrics.OrNop(m)
+	return &instrumented[V]{
+		next:    next,
+		name:    name,
+		lookups: m.Counter("cache_lookups_total", "Number of cache lookups by result."),
+		errors:  m.Counter("cache_errors_total", "Number of failed cache writes by operation."),
+	}
+}
+
+// Get returns the value of the decorated cache and records the result.
+func (c *instrumented[V]) Get(ctx context.Context, key string) (V, error) {
+	value, err := c.next.Get(ctx, key)
+	result := ResultHit
+	switch {
+	case IsMiss(err):
+		result = ResultMiss
+	case err != nil:
+		result = ResultError
+	}
+	metrics.Inc(c.lookups, metrics.Labels{"cache": c.name, "result": result})
+	return value, err
+}
+
+// Set stores the value in the decorated cache and records a failure.
+func (c *instrumented[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	err := c.next.Set(ctx, key, value, ttl)
+	if err != nil {
+		metrics.Inc(c.errors, metrics.Labels{"cache": c.name, "operation": "set"})
+	}
+	return err
+}
+
+// Delete removes the entry from the decorated cache and records a failure.
+func (c *instrumented[V]) Delete(ctx context.Context, key string) error {
+	err := c.next.Delete(ctx, key)
+	if err != nil {
+		metrics.Inc(c.errors, metrics.Labels{"cache": c.name, "operation": "delete"})
+	}
+	return err
+}
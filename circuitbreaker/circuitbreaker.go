@@ -0,0 +1,368 @@
+// Package circuitbreaker provides a circuit breaker protecting callers from a
+// failing dependency. After too many consecutive failures the breaker opens
+// and rejects calls with an `exception.ServiceUnavailable` naming the breaker
+// and the time at which it will probe the dependency again. Which failures
+// count is configured per status class of the returned exceptions.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusClass` type used to classify failures.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/metrics"
+)
+
+// State is the state of a Breaker.
+type State string
+
+const (
+	Closed   State = "closed"    // Closed lets every call through.
+	Open     State = "open"      // Open rejects every call until the open timeout elapses.
+	HalfOpen State = "half_open" // HalfOpen lets a limited number of probe calls through.
+)
+
+// Default breaker settings.
+const (
+	DefaultFailureThreshold = 5                // DefaultFailureThreshold is the number of consecutive server errors opening the breaker.
+	DefaultOpenTimeout      = 30 * time.Second // DefaultOpenTimeout is how long the breaker stays open before probing.
+	DefaultHalfOpenCalls    = 1                // DefaultHalfOpenCalls is the number of concurrent probe calls in the half-open state.
+)
+
+// Config holds the settings of a Breaker. Zero values fall back to the defaults.
+type Config struct {
+	// Thresholds maps a status class to the number of consecutive failures of
+	// that class opening the breaker. Failures of classes missing from the map
+	// do not count: a 4xx typically proves that the dependency is healthy.
+	// Errors that are not exceptions are classified as `status.ServerErrorClass`.
+	// Defaults to {ServerErrorClass: DefaultFailureThreshold}.
+	Thresholds map[status.StatusClass]int
+
+	// OpenTimeout is how long the breaker stays open before probing.
+	OpenTimeout time.Duration
+
+	// HalfOpenCalls is the number of concurrent probe calls in the half-open state.
+	HalfOpenCalls int
+
+	// OnStateChange, when set, is called after each state transition, once
+	// the lock of the breaker is released: it may call the breaker.
+	OnStateChange func(name string, from State, to State)
+
+	// Meter receives the metrics of the breaker, labeled with its name:
+	// circuit_breaker_state{breaker} (0 closed, 1 half-open, 2 open),
+	// circuit_breaker_transitions_total{breaker,from,to} and
+	// circuit_breaker_rejections_total{breaker}. Defaults to `metrics.Nop`.
+	Meter metrics.Meter
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// stateValues are the values of the circuit_breaker_state gauge.
+var stateValues = map[State]float64{Closed: 0, HalfOpen: 1, Open: 2}
+
+// stateChange is a transition awaiting its OnStateChange call.
+type stateChange struct {
+	from State
+	to   State
+}
+
+// admission is a call let through by the breaker.
+type admission struct {
+	probe bool   // Whether the call is a probe of the half-open state.
+	epoch uint64 // The epoch of the breaker when the call was admitted.
+}
+
+// errPanicked is the outcome recorded for a call of Execute that panicked.
+var errPanicked = errors.New("circuitbreaker: the protected call panicked")
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	name   string
+	config Config
+
+	mu       sync.Mutex
+	state    State
+	failures map[status.StatusClass]int // Consecutive failures per status class.
+	openedAt time.Time                  // When the breaker last opened.
+	probes   int                        // Probe calls in flight in the half-open state.
+	epoch    uint64                     // Incremented by every transition, to tell the outcomes of the calls admitted in an earlier state.
+	changes  []stateChange              // The transitions not yet passed to OnStateChange.
+
+	stateGauge  metrics.Gauge
+	transitions metrics.Counter
+	rejections  metrics.Counter
+}
+
+// New creates a closed Breaker.
+//
+// Parameters:
+//
+//	name: The name of the breaker, reported in rejections (e.g., "payments-api").
+//	config: The breaker settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Breaker.
+func New(name string, config Config) *Breaker {
+	if len(config.Thresholds) == 0 {
+		config.Thresholds = map[status.StatusClass]int{status.ServerErrorClass: DefaultFailureThreshold}
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = DefaultOpenTimeout
+	}
+	if config.HalfOpenCalls <= 0 {
+		config.HalfOpenCalls = DefaultHalfOpenCalls
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	config.Meter = metrics.OrNop(config.Meter)
+
+	b := &Breaker{
+		name:        name,
+		config:      config,
+		state:       Closed,
+		failures:    map[status.StatusClass]int{},
+		stateGauge:  config.Meter.Gauge("circuit_breaker_state", "State of the circuit breaker: 0 closed, 1 half-open, 2 open."),
+		transitions: config.Meter.Counter("circuit_breaker_transitions_total", "Number of state transitions of the circuit breaker."),
+		rejections:  config.Meter.Counter("circuit_breaker_rejections_total", "Number of calls rejected by the circuit breaker."),
+	}
+	b.stateGauge.Set(stateValues[Closed], metrics.Labels{"breaker": name})
+	return b
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	var state State
+	b.update(func() {
+		b.refresh()
+		state = b.state
+	})
+	return state
+}
+
+// Execute runs fn if the breaker allows it and records its outcome. A panic
+// of fn is recorded as a server error before it propagates, and the context
+// errors returned once ctx is done are not recorded: a caller giving up says
+// nothing about the dependency.
+//
+// Parameters:
+//
+//	ctx: The context passed to fn.
+//	fn: The operation protected by the breaker.
+//
+// Returns:
+//
+//	The error returned by fn, or an `exception.ServiceUnavailable` when the
+//	breaker rejected the call without running fn.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	admitted, err := b.admit()
+	if err != nil {
+		return err
+	}
+	completed := false
+	defer func() {
+		if !completed {
+			b.record(errPanicked, admitted)
+		}
+	}()
+
+	err = fn(ctx)
+	completed = true
+	if ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		b.release(admitted)
+	} else {
+		b.record(err, admitted)
+	}
+	return err
+}
+
+// Execute runs fn through the breaker for operations returning a value.
+// See `Breaker.Execute`.
+func Execute[T any](ctx context.Context, b *Breaker, fn func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	err := b.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = fn(ctx)
+		return err
+	})
+	return value, err
+}
+
+// Allow asks the breaker for permission to make a call. When granted, the
+// caller must report the outcome of the call through the returned function,
+// even when the call panics; unlike Execute, the context errors are
+// recorded as server errors.
+//
+// Returns:
+//
+//	A function recording the outcome of the call, or an
+//	`exception.ServiceUnavailable` when the call is rejected.
+func (b *Breaker) Allow() (done func(err error), err error) {
+	admitted, err := b.admit()
+	if err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.record(err, admitted) })
+	}, nil
+}
+
+// admit lets a call through, or returns the rejection of the breaker.
+func (b *Breaker) admit() (admitted admission, err error) {
+	b.update(func() {
+		b.refresh()
+		switch b.state {
+		case Open:
+			err = b.rejection()
+			return
+		case HalfOpen:
+			if b.probes >= b.config.HalfOpenCalls {
+				err = b.rejection()
+				return
+			}
+			b.probes++
+			admitted.probe = true
+		}
+		admitted.epoch = b.epoch
+	})
+	return admitted, err
+}
+
+// record updates the breaker with the outcome of an admitted call. The
+// outcomes of the calls admitted before the last transition are ignored: a
+// slow call started while the breaker was closed says nothing about the
+// dependency once it has reopened.
+func (b *Breaker) record(err error, admitted admission) {
+	b.update(func() {
+		if admitted.epoch != b.epoch {
+			return
+		}
+		if admitted.probe {
+			b.probes--
+		}
+
+		if exception.IsNil(err) {
+			clear(b.failures)
+			if admitted.probe {
+				b.transition(Closed)
+			}
+			return
+		}
+
+		class := classOf(err)
+		threshold, counted := b.config.Thresholds[class]
+		if !counted || threshold <= 0 {
+			return // This class of failure says nothing about the dependency's health.
+		}
+
+		b.failures[class]++
+		if admitted.probe || b.failures[class] >= threshold {
+			b.open()
+		}
+	})
+}
+
+// release frees the probe slot of an admitted call without recording its
+// outcome.
+func (b *Breaker) release(admitted admission) {
+	b.update(func() {
+		if admitted.probe && admitted.epoch == b.epoch {
+			b.probes--
+		}
+	})
+}
+
+// update runs fn under the lock of the breaker, then passes the transitions
+// it made to OnStateChange once the lock is released.
+func (b *Breaker) update(fn func()) {
+	var changes []stateChange
+	func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		fn()
+		changes, b.changes = b.changes, nil
+	}()
+
+	if b.config.OnStateChange != nil {
+		for _, change := range changes {
+			b.config.OnStateChange(b.name, change.from, change.to)
+		}
+	}
+}
+
+// refresh moves an open breaker to half-open once the open timeout elapsed.
+func (b *Breaker) refresh() {
+	if b.state == Open && !b.config.Now().Before(b.reopenAt()) {
+		b.probes = 0
+		b.transition(HalfOpen)
+	}
+}
+
+// open trips the breaker.
+func (b *Breaker) open() {
+	clear(b.failures)
+	b.openedAt = b.config.Now()
+	b.transition(Open)
+}
+
+// transition changes the state and queues the OnStateChange call (see update).
+func (b *Breaker) transition(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.epoch++
+	b.stateGauge.Set(stateValues[to], metrics.Labels{"breaker": b.name})
+	metrics.Inc(b.transitions, metrics.Labels{"breaker": b.name, "from": string(from), "to": string(to)})
+	if b.config.OnStateChange != nil {
+		b.changes = append(b.changes, stateChange{from: from, to: to})
+	}
+}
+
+// reopenAt returns the time at which an open breaker will start probing.
+func (b *Breaker) reopenAt() time.Time {
+	return b.openedAt.Add(b.config.OpenTimeout)
+}
+
+// rejection builds the exception returned for a rejected call.
+func (b *Breaker) rejection() error {
+	metrics.Inc(b.rejections, metrics.Labels{"breaker": b.name})
+	reopenAt := b.reopenAt()
+	retryAfter := max(int(math.Ceil(reopenAt.Sub(b.config.Now()).Seconds())), 0)
+
+	return exception.NewServiceUnavailable(map[string]interface{}{
+		"message": "Service temporarily unavailable: circuit breaker is open.",
+		"details": map[string]interface{}{
+			"breaker":     b.name,
+			"state":       string(b.state),
+			"reopen_at":   reopenAt.UTC().Format(time.RFC3339),
+			"retry_after": retryAfter,
+			"error":       "circuit_open",
+		},
+	})
+}
+
+// classOf returns the status class of an error; errors that are not
+// exceptions are classified as server errors.
+func classOf(err error) status.StatusClass {
+	var coreErr exception.CoreInterface
+	if errors.As(err, &coreErr) && !exception.IsNil(coreErr) {
+		return status.StatusCode(coreErr.GetStatusCode()).GetClass()
+	}
+	return status.ServerErrorClass
+}
@@ -0,0 +1,78 @@
+// Package clock provides the time source of the core. Components read the
+// current time through a Clock rather than calling `time.Now` directly, so
+// that tests can control time with a Fake clock. A Clock's Now method can be
+// passed wherever the core expects a `func() time.Time`.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock returns the current time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// Func adapts a function to the Clock interface.
+type Func func() time.Time
+
+// Now calls f.
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System returns the Clock reading the system time in UTC.
+func System() Clock {
+	return Func(func() time.Time { return time.Now().UTC() })
+}
+
+// OrSystem returns c, or the system Clock when c is nil.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System()
+	}
+	return c
+}
+
+// Fake is a Clock returning a time controlled by the caller, intended for
+// tests. It is safe for concurrent use.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake creates a Fake clock.
+//
+// Parameters:
+//
+//	now: The initial time of the clock.
+//
+// Returns:
+//
+//	A pointer to a new Fake clock.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the current time of the clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Set moves the clock to the given time.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
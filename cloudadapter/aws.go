@@ -0,0 +1,100 @@
+// Package cloudadapter classifies the errors of the cloud SDKs into
+// exceptions. This file defines the classifier of the AWS SDK for Go v2.
+package cloudadapter
+
+import (
+	"errors"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// awsAPIError holds the methods of `smithy.APIError`, implemented by the
+// errors returned by the AWS services.
+type awsAPIError interface {
+	error
+	ErrorCode() string
+	ErrorMessage() string
+}
+
+// awsOperationError holds the method of `smithy.OperationError` naming the
+// service.
+type awsOperationError interface {
+	error
+	Service() string
+}
+
+// awsResponseError holds the method of `awshttp.ResponseError` giving the
+// HTTP status of the response.
+type awsResponseError interface {
+	error
+	HTTPStatusCode() int
+}
+
+// awsCodes classifies the error codes of the AWS services; throttling codes
+// are those retried as such by the SDK.
+var awsCodes = map[string]string{
+	"Throttling":                             Throttled,
+	"ThrottlingException":                    Throttled,
+	"ThrottledException":                     Throttled,
+	"RequestThrottledException":              Throttled,
+	"TooManyRequestsException":               Throttled,
+	"ProvisionedThroughputExceededException": Throttled,
+	"TransactionInProgressException":         Throttled,
+	"RequestLimitExceeded":                   Throttled,
+	"BandwidthLimitExceeded":                 Throttled,
+	"LimitExceededException":                 Throttled,
+	"RequestThrottled":                       Throttled,
+	"SlowDown":                               Throttled,
+	"PriorRequestNotComplete":                Throttled,
+	"EC2ThrottledException":                  Throttled,
+	"AccessDenied":                           AccessDenied,
+	"AccessDeniedException":                  AccessDenied,
+	"UnauthorizedOperation":                  AccessDenied,
+	"NotFound":                               ResourceNotFound,
+	"NoSuchKey":                              ResourceNotFound,
+	"NoSuchBucket":                           ResourceNotFound,
+	"NoSuchEntity":                           ResourceNotFound,
+	"ResourceNotFoundException":              ResourceNotFound,
+	"ParameterNotFound":                      ResourceNotFound,
+	"InternalError":                          ServiceFailure,
+	"InternalFailure":                        ServiceFailure,
+	"InternalServerError":                    ServiceFailure,
+	"ServiceUnavailable":                     ServiceFailure,
+	"ServiceUnavailableException":            ServiceFailure,
+}
+
+// AWS classifies the errors of the AWS SDK for Go v2, by their error code
+// (e.g., "ThrottlingException", "NoSuchKey"), or else by the HTTP status of
+// the response: 429 `exception.TooManyRequests`, 403 `exception.Forbidden`,
+// 404 `exception.NotFound`, 5xx `exception.UpstreamFailure`. The service
+// is that of the operation error (e.g., "S3").
+//
+// Parameters:
+//
+//	err: The error to classify.
+//
+// Returns:
+//
+//	The exception, or nil when err is not a classified AWS error.
+func AWS(err error) exception.CoreInterface {
+	f := failure{provider: "aws"}
+
+	var apiErr awsAPIError
+	if errors.As(err, &apiErr) {
+		f.code = apiErr.ErrorCode()
+		f.kind = awsCodes[f.code]
+	}
+	var responseErr awsResponseError
+	if f.kind == "" && errors.As(err, &responseErr) {
+		f.kind = kindOfHTTPStatus(responseErr.HTTPStatusCode())
+	}
+	if f.kind == "" {
+		return nil
+	}
+
+	var operationErr awsOperationError
+	if errors.As(err, &operationErr) {
+		f.service = operationErr.Service()
+	}
+	return f.exception()
+}
@@ -0,0 +1,110 @@
+// Package cloudadapter classifies the errors of the cloud SDKs into
+// exceptions: throttling into `exception.TooManyRequests`, access denied into
+// `exception.Forbidden`, missing resources into `exception.NotFound`, and the
+// server errors of the cloud services into `exception.UpstreamFailure`,
+// with the name of the service in its details. It does not depend on the
+// SDKs: the errors are recognized through the methods of their types. The
+// classifiers plug into `exception.FromError`:
+//
+//	func main() {
+//		defer cloudadapter.Register()()
+//		...
+//	}
+//
+//	return exception.FromError(err) // e.g. a *exception.NotFound for an S3 NoSuchKey.
+package cloudadapter
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Register adds the AWS and GCP classifiers to the pipeline of
+// `exception.FromError`.
+//
+// Returns:
+//
+//	A function removing them.
+func Register() (unregister func()) {
+	unregisterAWS := exception.RegisterClassifier(AWS)
+	unregisterGCP := exception.RegisterClassifier(GCP)
+	return func() {
+		unregisterAWS()
+		unregisterGCP()
+	}
+}
+
+// Kinds of classified failures, stored as the "error" entry of the details.
+const (
+	Throttled        = "upstream_throttled"
+	AccessDenied     = "upstream_access_denied"
+	ResourceNotFound = "upstream_not_found"
+	ServiceFailure   = "upstream_failure"
+)
+
+// failure is a failure of a cloud service, as read from an SDK error.
+type failure struct {
+	provider string // "aws" or "gcp".
+	service  string // The name of the service (e.g., "S3"); may be empty.
+	code     string // The error code of the service (e.g., "NoSuchKey"); may be empty.
+	kind     string // The kind of the failure, or empty when not classified.
+}
+
+// exception builds the exception of a classified failure. The message of the
+// SDK error is not kept, as it may reveal the resources of the account.
+func (f failure) exception() exception.CoreInterface {
+	service := f.service
+	if service == "" {
+		service = "cloud"
+	}
+	details := map[string]interface{}{"error": f.kind, "provider": f.provider}
+	if f.service != "" {
+		details["service"] = f.service
+	}
+	if f.code != "" {
+		details["code"] = f.code
+	}
+
+	switch f.kind {
+	case Throttled:
+		return exception.NewTooManyRequests(map[string]interface{}{
+			"message": fmt.Sprintf("The %s service throttled the request.", service),
+			"details": details,
+		})
+	case AccessDenied:
+		return exception.NewForbidden(map[string]interface{}{
+			"message": fmt.Sprintf("The %s service denied access.", service),
+			"details": details,
+		})
+	case ResourceNotFound:
+		return exception.NewNotFound(map[string]interface{}{
+			"message": fmt.Sprintf("The %s resource was not found.", service),
+			"details": details,
+		})
+	case ServiceFailure:
+		return exception.NewUpstreamFailure(map[string]interface{}{
+			"message": fmt.Sprintf("The %s service failed.", service),
+			"details": details,
+		})
+	default:
+		return nil
+	}
+}
+
+// kindOfHTTPStatus classifies an HTTP status.
+func kindOfHTTPStatus(code int) string {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return Throttled
+	case code == http.StatusForbidden:
+		return AccessDenied
+	case code == http.StatusNotFound:
+		return ResourceNotFound
+	case code >= 500:
+		return ServiceFailure
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,135 @@
+// Package cloudadapter classifies the errors of the cloud SDKs into
+// exceptions. This file defines the classifier of the Google Cloud clients.
+package cloudadapter
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// googleAPIPackage is the last element of the package path of
+// `googleapi.Error` (google.golang.org/api/googleapi), returned by the REST
+// clients.
+const googleAPIPackage = "/googleapi"
+
+// gRPC status codes classified by GCP.
+const (
+	grpcUnknown           = 2
+	grpcNotFound          = 5
+	grpcPermissionDenied  = 7
+	grpcResourceExhausted = 8
+	grpcInternal          = 13
+	grpcUnavailable       = 14
+	grpcDataLoss          = 15
+)
+
+// gcpAPIError holds the methods of `apierror.APIError`, returned by the
+// gRPC and REST clients of the cloud.google.com/go libraries.
+type gcpAPIError interface {
+	error
+	HTTPCode() int
+	Reason() string
+	Domain() string
+}
+
+// GCP classifies the errors of the Google Cloud clients: `apierror.APIError`,
+// `googleapi.Error` and the gRPC status errors, by their gRPC code
+// (RESOURCE_EXHAUSTED `exception.TooManyRequests`, PERMISSION_DENIED
+// `exception.Forbidden`, NOT_FOUND `exception.NotFound`, UNKNOWN, INTERNAL,
+// UNAVAILABLE and DATA_LOSS `exception.UpstreamFailure`) or their HTTP status
+// (429, 403, 404 and 5xx). The service is the domain of the error (e.g.,
+// "storage.googleapis.com"), when given.
+//
+// Parameters:
+//
+//	err: The error to classify.
+//
+// Returns:
+//
+//	The exception, or nil when err is not a classified GCP error.
+func GCP(err error) exception.CoreInterface {
+	f := failure{provider: "gcp"}
+
+	var apiErr gcpAPIError
+	if errors.As(err, &apiErr) {
+		f.service, f.code = apiErr.Domain(), apiErr.Reason()
+		if code := apiErr.HTTPCode(); code > 0 {
+			f.kind = kindOfHTTPStatus(code)
+		}
+	}
+	if f.kind == "" {
+		if code, ok := grpcCode(err); ok {
+			f.kind = kindOfGRPCCode(code)
+		}
+	}
+	if f.kind == "" {
+		if code, ok := googleAPICode(err); ok {
+			f.kind = kindOfHTTPStatus(code)
+		}
+	}
+	if f.kind == "" {
+		return nil
+	}
+	return f.exception()
+}
+
+// kindOfGRPCCode classifies a gRPC status code.
+func kindOfGRPCCode(code uint64) string {
+	switch code {
+	case grpcResourceExhausted:
+		return Throttled
+	case grpcPermissionDenied:
+		return AccessDenied
+	case grpcNotFound:
+		return ResourceNotFound
+	case grpcUnknown, grpcInternal, grpcUnavailable, grpcDataLoss:
+		return ServiceFailure
+	default:
+		return ""
+	}
+}
+
+// grpcCode returns the code of the first error of the chain having a
+// `GRPCStatus() *status.Status` method, read through reflection so as not to
+// depend on grpc.
+func grpcCode(err error) (uint64, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		method := reflect.ValueOf(err).MethodByName("GRPCStatus")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		grpcStatus := method.Call(nil)[0]
+		if grpcStatus.Kind() == reflect.Pointer && grpcStatus.IsNil() {
+			continue
+		}
+		code := grpcStatus.MethodByName("Code")
+		if !code.IsValid() || code.Type().NumIn() != 0 || code.Type().NumOut() != 1 {
+			continue
+		}
+		if value := code.Call(nil)[0]; value.CanUint() {
+			return value.Uint(), true
+		}
+	}
+	return 0, false
+}
+
+// googleAPICode returns the HTTP status of the first `googleapi.Error` of
+// the chain.
+func googleAPICode(err error) (int, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		value := reflect.ValueOf(err)
+		if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		if !strings.HasSuffix(value.Elem().Type().PkgPath(), googleAPIPackage) {
+			continue
+		}
+		if code := value.Elem().FieldByName("Code"); code.IsValid() && code.CanInt() {
+			return int(code.Int()), true
+		}
+	}
+	return 0, false
+}
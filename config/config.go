@@ -0,0 +1,221 @@
+// Package config loads the configuration of an application into a tagged
+// struct, from defaults, optional files and environment variables, and
+// validates it. Every problem found (an unparsable value, a missing required
+// setting, a value out of range, ...) is reported at once in a single
+// `exception.Configuration`, so that a deployment can be fixed in one go.
+//
+// Settings are declared with struct tags:
+//
+//	type Config struct {
+//		Port     int           `env:"PORT" default:"8080" validate:"min=1,max=65535"`
+//		Mode     string        `env:"MODE" default:"production" validate:"oneof=development production"`
+//		Timeout  time.Duration `env:"TIMEOUT" default:"30s"`
+//		Database struct {
+//			URL string `env:"DATABASE_URL" validate:"required"`
+//		}
+//	}
+//
+// The `validate` tag accepts the rules of the validator package.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/validator"
+)
+
+// Struct tags read by Load.
+const (
+	EnvTag     = "env"     // EnvTag names the environment variable of a field.
+	DefaultTag = "default" // DefaultTag holds the value of a field when no source sets it.
+)
+
+// DefaultMessage is the primary message of the Configuration exceptions
+// produced by this package.
+const DefaultMessage = "The configuration is invalid."
+
+// loader holds the settings of Load.
+type loader struct {
+	prefix    string
+	files     []string
+	lookup    func(key string) (string, bool)
+	validator *validator.Validator
+}
+
+// Option configures Load.
+type Option func(*loader)
+
+// WithPrefix prefixes the names of the environment variables, e.g. "APP_"
+// reads the `env:"PORT"` field from APP_PORT.
+func WithPrefix(prefix string) Option {
+	return func(l *loader) { l.prefix = prefix }
+}
+
+// WithFile reads settings from a file, applied over the defaults and under
+// the environment variables. Files ending in ".json" are decoded into the
+// struct with `encoding/json`; other files are read as dotenv files of
+// KEY=VALUE lines, which provide environment variables that the process
+// environment overrides. A missing file is skipped. Later files take
+// precedence over earlier ones.
+//
+// Parameters:
+//
+//	path: The path of the file (e.g., ".env" or "config.json").
+func WithFile(path string) Option {
+	return func(l *loader) { l.files = append(l.files, path) }
+}
+
+// WithLookup sets the function reading environment variables. Defaults to
+// `os.LookupEnv`; overridable in tests.
+func WithLookup(lookup func(key string) (string, bool)) Option {
+	return func(l *loader) {
+		if lookup != nil {
+			l.lookup = lookup
+		}
+	}
+}
+
+// WithValidator sets the Validator applying the `validate` tags, e.g. one
+// with custom rules. Defaults to `validator.New()`.
+func WithValidator(v *validator.Validator) Option {
+	return func(l *loader) {
+		if v != nil {
+			l.validator = v
+		}
+	}
+}
+
+// Load fills a struct from its sources, by increasing precedence: the
+// `default` tags (for fields still holding their zero value), the files, and
+// the environment variables named by the `env` tags. The struct is then
+// validated according to its `validate` tags.
+//
+// Supported field types are strings, booleans, integers, floats,
+// `time.Duration`, types implementing `encoding.TextUnmarshaler`, pointers
+// to those, and slices of those written as comma separated lists. Nested
+// structs are loaded recursively.
+//
+// Parameters:
+//
+//	dst: A non-nil pointer to the configuration struct.
+//	opts: Options such as WithPrefix and WithFile.
+//
+// Returns:
+//
+//	Nil on success, or an `exception.Configuration` listing under its
+//	"errors" key every problem, keyed by environment variable name (or by
+//	field path for fields without one, and by file path for unreadable
+//	files). Values are never echoed in the messages as they may be secrets.
+//	An `exception.Logic` is returned when dst is not a pointer to a struct
+//	or holds a field of an unsupported type.
+func Load(dst interface{}, opts ...Option) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return exception.NewLogic(map[string]interface{}{
+			"message": "The destination of config.Load must be a non-nil pointer to a struct.",
+			"details": map[string]interface{}{"type": fmt.Sprintf("%T", dst), "error": "invalid_config_destination"},
+		})
+	}
+
+	l := &loader{lookup: os.LookupEnv}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.validator == nil {
+		l.validator = validator.New()
+	}
+
+	settings, err := collectSettings(target.Elem(), l.prefix)
+	if err != nil {
+		return err
+	}
+
+	problems := &validator.Errors{}
+	for _, setting := range settings {
+		if raw, ok := setting.field.Tag.Lookup(DefaultTag); ok && setting.value.IsZero() {
+			if message := decode(setting.value, raw); message != "" {
+				problems.Add(setting.key(), "default", message)
+			}
+		}
+	}
+
+	dotenv := map[string]string{}
+	for _, path := range l.files {
+		readFile(problems, path, dst, dotenv)
+	}
+
+	for _, setting := range settings {
+		if setting.env == "" {
+			continue
+		}
+		raw, ok := l.lookup(setting.env)
+		if !ok {
+			raw, ok = dotenv[setting.env]
+		}
+		if !ok {
+			continue
+		}
+		if message := decode(setting.value, raw); message != "" {
+			problems.Add(setting.env, "type", message)
+		}
+	}
+
+	if err := l.validate(problems, dst, settings); err != nil {
+		return err
+	}
+	if !problems.HasErrors() {
+		return nil
+	}
+
+	fields := problems.Fields()
+	return exception.NewConfiguration(map[string]interface{}{
+		"message": DefaultMessage,
+		"errors":  fields,
+		"details": map[string]interface{}{
+			"count": len(fields),
+			"error": "invalid_configuration",
+		},
+	})
+}
+
+// validate applies the `validate` tags and records their violations, keyed
+// by environment variable name. Settings that already failed to parse are
+// not reported twice.
+func (l *loader) validate(problems *validator.Errors, dst interface{}, settings []setting) error {
+	err := l.validator.Validate(dst)
+	if err == nil {
+		return nil
+	}
+	var invalid *exception.Validation
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	keys := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		keys[setting.path] = setting.key()
+	}
+	failed := problems.Fields()
+
+	violations, _ := invalid.GetErrors()["errors"].(map[string]interface{})
+	for path, entries := range violations {
+		key, ok := keys[path]
+		if !ok {
+			key = path
+		}
+		if _, parsed := failed[key]; parsed {
+			continue
+		}
+		list, _ := entries.([]map[string]interface{})
+		for _, entry := range list {
+			rule, _ := entry["rule"].(string)
+			message, _ := entry["message"].(string)
+			problems.Add(key, rule, message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Package config loads the configuration of an application into a tagged
+// struct. This file defines the reading of JSON and dotenv files.
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/osirisgate/golang-core/validator"
+)
+
+// readFile reads a configuration file: JSON files are decoded into dst and
+// dotenv files add their variables to dotenv. Problems are recorded under
+// the path of the file; a missing file is skipped.
+func readFile(problems *validator.Errors, path string, dst interface{}, dotenv map[string]string) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		problems.Add(path, "file", "The file cannot be read.")
+		return
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(content, dst); err != nil {
+			problems.Add(path, "file", jsonMessage(err))
+		}
+		return
+	}
+	parseDotenv(problems, path, content, dotenv)
+}
+
+// jsonMessage describes a JSON decoding error without echoing the values.
+func jsonMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("The file is not valid JSON (offset %d).", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		return fmt.Sprintf("The field %q must be of type %s.", typeErr.Field, typeErr.Type)
+	default:
+		return "The file cannot be decoded."
+	}
+}
+
+// parseDotenv parses KEY=VALUE lines. Blank lines and lines starting with
+// "#" are ignored, an "export " prefix is allowed, and values may be wrapped
+// in single or double quotes.
+func parseDotenv(problems *validator.Errors, path string, content []byte, dotenv map[string]string) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			problems.Add(fmt.Sprintf("%s:%d", path, line), "syntax", "Expected a KEY=VALUE line.")
+			continue
+		}
+		dotenv[key] = unquote(strings.TrimSpace(value))
+	}
+}
+
+// unquote removes the quotes wrapping a dotenv value.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,200 @@
+// Package config loads the configuration of an application into a tagged
+// struct. This file defines the discovery of the settings of a struct and the
+// decoding of their textual values.
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+// setting is a loadable field of a configuration struct.
+type setting struct {
+	field reflect.StructField
+	value reflect.Value // The settable field value.
+	path  string        // The field path, as reported by the validator package.
+	env   string        // The prefixed environment variable name, empty when the field has none.
+}
+
+// key returns the name under which the problems of a setting are reported.
+func (s setting) key() string {
+	if s.env != "" {
+		return s.env
+	}
+	return s.path
+}
+
+// collectSettings lists the settings of a struct value, recursing into
+// nested structs.
+func collectSettings(value reflect.Value, prefix string) ([]setting, error) {
+	var settings []setting
+	err := walk(&settings, value, "", prefix)
+	return settings, err
+}
+
+// walk appends the settings of a struct value to settings.
+func walk(settings *[]setting, value reflect.Value, path string, prefix string) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := joinPath(path, fieldName(field))
+		fieldValue := value.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && !decodable(field.Type) {
+			if err := walk(settings, fieldValue, fieldPath, prefix); err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, hasEnv := field.Tag.Lookup(EnvTag)
+		_, hasDefault := field.Tag.Lookup(DefaultTag)
+		if !hasEnv && !hasDefault {
+			continue
+		}
+		if !decodable(field.Type) {
+			return exception.NewLogic(map[string]interface{}{
+				"message": fmt.Sprintf("The configuration field %s has an unsupported type.", fieldPath),
+				"details": map[string]interface{}{
+					"field": fieldPath,
+					"type":  field.Type.String(),
+					"error": "unsupported_config_type",
+				},
+			})
+		}
+
+		env := ""
+		if name := field.Tag.Get(EnvTag); name != "" {
+			env = prefix + name
+		}
+		*settings = append(*settings, setting{field: field, value: fieldValue, path: fieldPath, env: env})
+	}
+	return nil
+}
+
+// decodable reports whether values of a type can be decoded from text.
+func decodable(typ reflect.Type) bool {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) || typ == durationType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer:
+		return decodable(typ.Elem())
+	case reflect.Slice:
+		return typ.Elem().Kind() != reflect.Slice && decodable(typ.Elem())
+	default:
+		return false
+	}
+}
+
+// decode parses a textual value into a settable value of a decodable type,
+// returning the reason of the failure or an empty string.
+func decode(value reflect.Value, raw string) string {
+	typ := value.Type()
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		if err := value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return fmt.Sprintf("Must be a valid %s.", typ)
+		}
+		return ""
+	}
+	if typ == durationType {
+		duration, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return `Must be a duration (e.g., "30s").`
+		}
+		value.SetInt(int64(duration))
+		return ""
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return "Must be a boolean."
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(raw), 10, typ.Bits())
+		if err != nil {
+			return fmt.Sprintf("Must be an integer fitting in %d bits.", typ.Bits())
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(strings.TrimSpace(raw), 10, typ.Bits())
+		if err != nil {
+			return fmt.Sprintf("Must be a non-negative integer fitting in %d bits.", typ.Bits())
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), typ.Bits())
+		if err != nil {
+			return "Must be a number."
+		}
+		value.SetFloat(parsed)
+	case reflect.Pointer:
+		elem := reflect.New(typ.Elem())
+		if message := decode(elem.Elem(), raw); message != "" {
+			return message
+		}
+		value.Set(elem)
+	case reflect.Slice:
+		return decodeList(value, raw)
+	}
+	return ""
+}
+
+// decodeList parses a comma separated list into a slice value. An empty
+// string yields an empty slice.
+func decodeList(value reflect.Value, raw string) string {
+	var parts []string
+	if strings.TrimSpace(raw) != "" {
+		parts = strings.Split(raw, ",")
+	}
+	list := reflect.MakeSlice(value.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if message := decode(list.Index(i), strings.TrimSpace(part)); message != "" {
+			return fmt.Sprintf("Item %d: %s", i, message)
+		}
+	}
+	value.Set(list)
+	return ""
+}
+
+// fieldName returns the name under which a struct field is reported: its
+// `json` tag name when set, its Go name otherwise, as in the validator
+// package.
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// joinPath appends a field name to a parent path.
+func joinPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,354 @@
+// Package connecterr converts exceptions to and from the errors of the
+// Connect RPC protocol (connect-go, connect-es, ...), so that the services
+// built on Connect share the exception catalog at their RPC boundary. It
+// implements the JSON error wire format of the protocol without depending on
+// connect-go: an Error is the `{"code", "message", "details"}` body of a
+// Connect unary error response, its codes are those of `connect.Code` (see
+// Code.Number to convert them), and the errors map of an exception travels
+// as a `google.protobuf.Struct` detail, which connect-go exposes through
+// `connect.ErrorDetail`:
+//
+//	// Server side, in a connect-go handler.
+//	return nil, connect.NewError(connect.Code(connecterr.CodeOf(err).Number()), err)
+//
+//	// Plain net/http handler of a Connect procedure.
+//	_ = connecterr.WriteError(w, err)
+package connecterr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` constants mapped to the Connect codes.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// StructType is the type of the detail carrying the errors map of an
+// exception.
+const StructType = "google.protobuf.Struct"
+
+// Code is a Connect error code.
+type Code string
+
+// Connect error codes.
+const (
+	CodeCanceled           Code = "canceled"
+	CodeUnknown            Code = "unknown"
+	CodeInvalidArgument    Code = "invalid_argument"
+	CodeDeadlineExceeded   Code = "deadline_exceeded"
+	CodeNotFound           Code = "not_found"
+	CodeAlreadyExists      Code = "already_exists"
+	CodePermissionDenied   Code = "permission_denied"
+	CodeResourceExhausted  Code = "resource_exhausted"
+	CodeFailedPrecondition Code = "failed_precondition"
+	CodeAborted            Code = "aborted"
+	CodeOutOfRange         Code = "out_of_range"
+	CodeUnimplemented      Code = "unimplemented"
+	CodeInternal           Code = "internal"
+	CodeUnavailable        Code = "unavailable"
+	CodeDataLoss           Code = "data_loss"
+	CodeUnauthenticated    Code = "unauthenticated"
+)
+
+// codes lists the codes by number, from 1.
+var codes = []Code{
+	CodeCanceled, CodeUnknown, CodeInvalidArgument, CodeDeadlineExceeded, CodeNotFound,
+	CodeAlreadyExists, CodePermissionDenied, CodeResourceExhausted, CodeFailedPrecondition,
+	CodeAborted, CodeOutOfRange, CodeUnimplemented, CodeInternal, CodeUnavailable,
+	CodeDataLoss, CodeUnauthenticated,
+}
+
+// httpStatuses maps the codes to the HTTP status of their unary responses,
+// as specified by the Connect protocol.
+var httpStatuses = map[Code]int{
+	CodeCanceled:           499,
+	CodeUnknown:            500,
+	CodeInvalidArgument:    400,
+	CodeDeadlineExceeded:   504,
+	CodeNotFound:           404,
+	CodeAlreadyExists:      409,
+	CodePermissionDenied:   403,
+	CodeResourceExhausted:  429,
+	CodeFailedPrecondition: 400,
+	CodeAborted:            409,
+	CodeOutOfRange:         400,
+	CodeUnimplemented:      501,
+	CodeInternal:           500,
+	CodeUnavailable:        503,
+	CodeDataLoss:           500,
+	CodeUnauthenticated:    401,
+}
+
+// exceptionStatuses maps the codes to the status code of the exceptions
+// built by ToException. It refines the HTTP statuses of the protocol where
+// the catalog has a better match, e.g. `exception.PreconditionFailed` for
+// failed_precondition.
+var exceptionStatuses = map[Code]status.StatusCode{
+	CodeCanceled:           status.RequestTimeout,
+	CodeUnknown:            status.InternalServerError,
+	CodeInvalidArgument:    status.BadRequest,
+	CodeDeadlineExceeded:   status.GatewayTimeout,
+	CodeNotFound:           status.NotFound,
+	CodeAlreadyExists:      status.Conflict,
+	CodePermissionDenied:   status.Forbidden,
+	CodeResourceExhausted:  status.TooManyRequests,
+	CodeFailedPrecondition: status.PreconditionFailed,
+	CodeAborted:            status.Conflict,
+	CodeOutOfRange:         status.RangeNotSatisfiable,
+	CodeUnimplemented:      status.NotImplemented,
+	CodeInternal:           status.InternalServerError,
+	CodeUnavailable:        status.ServiceUnavailable,
+	CodeDataLoss:           status.InternalServerError,
+	CodeUnauthenticated:    status.Unauthorized,
+}
+
+// Number returns the numeric value of the code, that of `connect.Code` and
+// of the gRPC status codes (e.g., 5 for not_found), or 2 (unknown) for an
+// unknown code.
+func (c Code) Number() uint32 {
+	for i, code := range codes {
+		if code == c {
+			return uint32(i + 1)
+		}
+	}
+	return 2
+}
+
+// HTTPStatus returns the HTTP status of the unary responses failing with
+// the code, or 500 for an unknown code.
+func (c Code) HTTPStatus() int {
+	if code, ok := httpStatuses[c]; ok {
+		return code
+	}
+	return 500
+}
+
+// CodeOf returns the Connect code of an error: canceled and
+// deadline_exceeded for the context errors, otherwise the code matching
+// the status code of its exception (e.g., not_found for 404), internal for
+// the other server errors and errors that are not exceptions, and
+// invalid_argument for the other client errors.
+func CodeOf(err error) Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	}
+
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || exception.IsNil(coreErr) {
+		return CodeInternal
+	}
+	switch code := status.StatusCode(coreErr.GetStatusCode()); code {
+	case status.BadRequest, status.UnprocessableContent:
+		return CodeInvalidArgument
+	case status.Unauthorized:
+		return CodeUnauthenticated
+	case status.Forbidden:
+		return CodePermissionDenied
+	case status.NotFound:
+		return CodeNotFound
+	case status.RequestTimeout, status.GatewayTimeout:
+		return CodeDeadlineExceeded
+	case status.Conflict:
+		return CodeAlreadyExists
+	case status.PreconditionFailed:
+		return CodeFailedPrecondition
+	case status.RangeNotSatisfiable:
+		return CodeOutOfRange
+	case status.TooManyRequests:
+		return CodeResourceExhausted
+	case status.NotImplemented:
+		return CodeUnimplemented
+	case status.ServiceUnavailable:
+		return CodeUnavailable
+	default:
+		if code.GetClass() == status.ClientErrorClass {
+			return CodeInvalidArgument
+		}
+		return CodeInternal
+	}
+}
+
+// Detail is a detail of a Connect error: an encoded protobuf message.
+type Detail struct {
+	Type  string      `json:"type"`            // The fully qualified name of the message (e.g., "google.protobuf.Struct").
+	Value string      `json:"value"`           // The message, encoded in protobuf then in unpadded base64.
+	Debug interface{} `json:"debug,omitempty"` // The message in JSON, for humans.
+}
+
+// Error is a Connect error, in its JSON wire format.
+type Error struct {
+	Code    Code     `json:"code"`
+	Message string   `json:"message,omitempty"`
+	Details []Detail `json:"details,omitempty"`
+}
+
+// Error returns the code and the message of the error, like `connect.Error`.
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return string(e.Code)
+	}
+	return string(e.Code) + ": " + e.Message
+}
+
+// FromException converts an error into a Connect error (see CodeOf). The
+// message is that of its exception (see `exception.Normalize`), and its
+// errors map, when not empty, travels as a `google.protobuf.Struct` detail.
+//
+// Parameters:
+//
+//	err: The error to convert. A nil error, or nil exception (see
+//	`exception.IsNil`), yields nil.
+//
+// Returns:
+//
+//	The Connect error.
+func FromException(err error) *Error {
+	if exception.IsNil(err) {
+		return nil
+	}
+
+	var coreErr exception.CoreInterface
+	errors.As(exception.Normalize(err), &coreErr)
+	connectErr := &Error{Code: CodeOf(err), Message: coreErr.Error()}
+
+	if fields := coreErr.GetErrors(); len(fields) > 0 {
+		if value, encodeErr := marshalStruct(fields); encodeErr == nil {
+			connectErr.Details = append(connectErr.Details, Detail{
+				Type:  StructType,
+				Value: base64.RawStdEncoding.EncodeToString(value),
+				Debug: fields,
+			})
+		}
+	}
+	return connectErr
+}
+
+// ToException converts a Connect error into the exception of the catalog
+// matching its code (e.g., `exception.NotFound` for not_found). Its errors
+// map is restored from the `google.protobuf.Struct` detail, when there is
+// one; otherwise the code is stored as the "error" entry of the details.
+//
+// Parameters:
+//
+//	connectErr: The Connect error to convert. A nil error yields nil.
+//
+// Returns:
+//
+//	The exception.
+func ToException(connectErr *Error) exception.CoreInterface {
+	if connectErr == nil {
+		return nil
+	}
+
+	fields := map[string]interface{}{}
+	for _, detail := range connectErr.Details {
+		if detail.Type != StructType {
+			continue
+		}
+		value, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(detail.Value, "="))
+		if err != nil {
+			continue
+		}
+		if decoded, err := unmarshalStruct(value); err == nil {
+			fields = decoded
+			break
+		}
+	}
+	if _, ok := fields["details"]; !ok {
+		fields["details"] = map[string]interface{}{"error": string(connectErr.Code)}
+	}
+	if connectErr.Message != "" {
+		fields["message"] = connectErr.Message
+	}
+
+	code, ok := exceptionStatuses[connectErr.Code]
+	if !ok {
+		code = status.InternalServerError
+	}
+	return exception.FromStatus(code, fields)
+}
+
+// WriteError writes an error as the response of a Connect unary procedure:
+// the JSON Connect error with the HTTP status of its code, and the headers
+// implied by its exception (see `exception.CoreInterface.Headers`), except
+// those already set.
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	err: The error to write. A nil error writes nothing.
+//
+// Returns:
+//
+//	An error if the error could not be encoded or written, nil otherwise.
+func WriteError(w http.ResponseWriter, err error) error {
+	connectErr := FromException(err)
+	if connectErr == nil {
+		return nil
+	}
+	body, encodeErr := json.Marshal(connectErr)
+	if encodeErr != nil {
+		return encodeErr
+	}
+	var coreErr exception.CoreInterface
+	if errors.As(exception.Normalize(err), &coreErr) {
+		for name, value := range coreErr.Headers() {
+			if w.Header().Get(name) == "" {
+				w.Header().Set(name, value)
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(connectErr.Code.HTTPStatus())
+	_, writeErr := w.Write(body)
+	return writeErr
+}
+
+// ReadError reads the Connect error of a failed unary response.
+//
+// Parameters:
+//
+//	resp: The response. Its body is read but not closed.
+//
+// Returns:
+//
+//	The Connect error. Bodies that are not Connect errors yield an error
+//	with the code matching the HTTP status, as specified by the protocol
+//	(e.g., unimplemented for 404).
+func ReadError(resp *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var connectErr Error
+	if err := json.Unmarshal(body, &connectErr); err == nil && connectErr.Code != "" {
+		return &connectErr
+	}
+	return &Error{Code: codeOfHTTPStatus(resp.StatusCode), Message: http.StatusText(resp.StatusCode)}
+}
+
+// codeOfHTTPStatus maps the HTTP status of a response that is not a Connect
+// error to a code, as specified by the protocol.
+func codeOfHTTPStatus(code int) Code {
+	switch code {
+	case 400:
+		return CodeInternal
+	case 401:
+		return CodeUnauthenticated
+	case 403:
+		return CodePermissionDenied
+	case 404:
+		return CodeUnimplemented
+	case 429, 502, 503, 504:
+		return CodeUnavailable
+	default:
+		return CodeUnknown
+	}
+}
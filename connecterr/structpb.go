@@ -0,0 +1,221 @@
+// Package connecterr converts exceptions to and from Connect errors. This
+// file defines the protobuf encoding of the `google.protobuf.Struct` details.
+package connecterr
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+)
+
+// Wire types of the protobuf encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// errMalformedStruct is returned when a detail is not a valid encoded Struct.
+var errMalformedStruct = errors.New("connecterr: malformed google.protobuf.Struct")
+
+// marshalStruct encodes a map as a `google.protobuf.Struct` message. The
+// values are first normalized through JSON, so that they are maps, lists,
+// strings, numbers, booleans and nulls; the keys are encoded in order, for
+// a deterministic output.
+func marshalStruct(fields map[string]interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, err
+	}
+	return appendStruct(nil, normalized), nil
+}
+
+// appendStruct appends the fields of a Struct: `map<string, Value> fields = 1`.
+func appendStruct(dst []byte, fields map[string]interface{}) []byte {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		// A map entry is a message with the key as field 1 and the value as
+		// field 2.
+		var entry []byte
+		entry = appendTag(entry, 1, wireBytes)
+		entry = appendBytes(entry, []byte(key))
+		entry = appendTag(entry, 2, wireBytes)
+		entry = appendBytes(entry, appendValue(nil, fields[key]))
+
+		dst = appendTag(dst, 1, wireBytes)
+		dst = appendBytes(dst, entry)
+	}
+	return dst
+}
+
+// appendValue appends the fields of a Value, whose kind is a oneof:
+// null_value = 1, number_value = 2, string_value = 3, bool_value = 4,
+// struct_value = 5, list_value = 6.
+func appendValue(dst []byte, value interface{}) []byte {
+	switch v := value.(type) {
+	case float64:
+		dst = appendTag(dst, 2, wireFixed64)
+		return binary.LittleEndian.AppendUint64(dst, math.Float64bits(v))
+	case string:
+		dst = appendTag(dst, 3, wireBytes)
+		return appendBytes(dst, []byte(v))
+	case bool:
+		dst = appendTag(dst, 4, wireVarint)
+		if v {
+			return binary.AppendUvarint(dst, 1)
+		}
+		return binary.AppendUvarint(dst, 0)
+	case map[string]interface{}:
+		dst = appendTag(dst, 5, wireBytes)
+		return appendBytes(dst, appendStruct(nil, v))
+	case []interface{}:
+		// A ListValue is `repeated Value values = 1`.
+		var list []byte
+		for _, item := range v {
+			list = appendTag(list, 1, wireBytes)
+			list = appendBytes(list, appendValue(nil, item))
+		}
+		dst = appendTag(dst, 6, wireBytes)
+		return appendBytes(dst, list)
+	default:
+		dst = appendTag(dst, 1, wireVarint)
+		return binary.AppendUvarint(dst, 0)
+	}
+}
+
+// appendTag appends the key of a field.
+func appendTag(dst []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(dst, uint64(field)<<3|uint64(wireType))
+}
+
+// appendBytes appends a length-delimited payload.
+func appendBytes(dst []byte, payload []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(payload)))
+	return append(dst, payload...)
+}
+
+// unmarshalStruct decodes a `google.protobuf.Struct` message.
+func unmarshalStruct(data []byte) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	err := walkFields(data, func(field int, wireType int, payload []byte, _ uint64) error {
+		if field != 1 || wireType != wireBytes {
+			return nil
+		}
+		var key string
+		var value interface{}
+		err := walkFields(payload, func(field int, wireType int, payload []byte, _ uint64) error {
+			switch {
+			case field == 1 && wireType == wireBytes:
+				key = string(payload)
+			case field == 2 && wireType == wireBytes:
+				var err error
+				value, err = unmarshalValue(payload)
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fields[key] = value
+		return nil
+	})
+	return fields, err
+}
+
+// unmarshalValue decodes a `google.protobuf.Value` message.
+func unmarshalValue(data []byte) (interface{}, error) {
+	var value interface{}
+	err := walkFields(data, func(field int, wireType int, payload []byte, number uint64) error {
+		var err error
+		switch {
+		case field == 1 && wireType == wireVarint:
+			value = nil
+		case field == 2 && wireType == wireFixed64:
+			value = math.Float64frombits(number)
+		case field == 3 && wireType == wireBytes:
+			value = string(payload)
+		case field == 4 && wireType == wireVarint:
+			value = number != 0
+		case field == 5 && wireType == wireBytes:
+			value, err = unmarshalStruct(payload)
+		case field == 6 && wireType == wireBytes:
+			list := []interface{}{}
+			err = walkFields(payload, func(field int, wireType int, payload []byte, _ uint64) error {
+				if field != 1 || wireType != wireBytes {
+					return nil
+				}
+				item, err := unmarshalValue(payload)
+				list = append(list, item)
+				return err
+			})
+			value = list
+		}
+		return err
+	})
+	return value, err
+}
+
+// walkFields calls fn for each field of an encoded message, with the payload
+// of the length-delimited fields and the number of the varint and fixed64
+// ones. Fixed32 fields are skipped; groups are not supported.
+func walkFields(data []byte, fn func(field int, wireType int, payload []byte, number uint64) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errMalformedStruct
+		}
+		data = data[n:]
+		field, wireType := int(key>>3), int(key&7)
+
+		switch wireType {
+		case wireVarint:
+			number, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errMalformedStruct
+			}
+			data = data[n:]
+			if err := fn(field, wireType, nil, number); err != nil {
+				return err
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return errMalformedStruct
+			}
+			number := binary.LittleEndian.Uint64(data)
+			data = data[8:]
+			if err := fn(field, wireType, nil, number); err != nil {
+				return err
+			}
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errMalformedStruct
+			}
+			payload := data[n : n+int(length)]
+			data = data[n+int(length):]
+			if err := fn(field, wireType, payload, 0); err != nil {
+				return err
+			}
+		case 5: // Fixed32.
+			if len(data) < 4 {
+				return errMalformedStruct
+			}
+			data = data[4:]
+		default:
+			return errMalformedStruct
+		}
+	}
+	return nil
+}
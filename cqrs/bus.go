@@ -0,0 +1,242 @@
+// Package cqrs provides a command and query bus. Commands change the state of
+// the application and return only an error; queries read it and return a
+// result. Each message type has exactly one handler, and every dispatch goes
+// through a chain of middlewares (logging, validation, transaction, metrics,
+// ...). Errors returned by handlers are normalized into
+// `exception.CoreInterface` exceptions, so that callers and middlewares can
+// always rely on a status code.
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Command is a request to change the state of the application.
+type Command interface {
+	// CommandName returns the name of the command (e.g., "user.register").
+	CommandName() string
+}
+
+// Query is a request to read the state of the application.
+type Query interface {
+	// QueryName returns the name of the query (e.g., "user.get").
+	QueryName() string
+}
+
+// Kind distinguishes commands from queries in a Message.
+type Kind string
+
+const (
+	KindCommand Kind = "command" // KindCommand identifies a Command.
+	KindQuery   Kind = "query"   // KindQuery identifies a Query.
+)
+
+// Message is a dispatched command or query, as seen by middlewares.
+type Message struct {
+	Kind    Kind        // Whether the payload is a command or a query.
+	Name    string      // The CommandName or QueryName of the payload.
+	Payload interface{} // The Command or Query itself.
+}
+
+// HandlerFunc handles a Message. The result is nil for commands.
+type HandlerFunc func(ctx context.Context, msg Message) (interface{}, error)
+
+// Middleware wraps a HandlerFunc with cross-cutting behavior.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// handlerKey identifies the handler of a message type.
+type handlerKey struct {
+	kind Kind
+	typ  reflect.Type
+}
+
+// Bus dispatches commands and queries to their handlers through the
+// middleware chain. The zero value is not usable; create buses with New.
+// It is safe for concurrent use.
+type Bus struct {
+	mu          sync.RWMutex
+	handlers    map[handlerKey]HandlerFunc
+	middlewares []Middleware
+}
+
+// New creates a Bus without handlers.
+//
+// Parameters:
+//
+//	middlewares: The middlewares wrapping every dispatch. The first one is
+//	             the outermost, i.e. the first to see the message.
+//
+// Returns:
+//
+//	A pointer to a new Bus.
+func New(middlewares ...Middleware) *Bus {
+	return &Bus{
+		handlers:    map[handlerKey]HandlerFunc{},
+		middlewares: middlewares,
+	}
+}
+
+// Use appends middlewares to the chain. They wrap the dispatches started
+// after the call, inside the middlewares already registered.
+func (b *Bus) Use(middlewares ...Middleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.middlewares = append(b.middlewares, middlewares...)
+}
+
+// HandleCommand registers the handler of the commands of type C.
+//
+// Parameters:
+//
+//	bus: The bus to register the handler on.
+//	handler: The function handling the commands.
+//
+// Returns:
+//
+//	Nil, or an `exception.Logic` if a handler is already registered for C.
+func HandleCommand[C Command](bus *Bus, handler func(ctx context.Context, cmd C) error) error {
+	return bus.register(KindCommand, reflect.TypeFor[C](), func(ctx context.Context, msg Message) (interface{}, error) {
+		return nil, handler(ctx, msg.Payload.(C))
+	})
+}
+
+// HandleQuery registers the handler of the queries of type Q.
+//
+// Parameters:
+//
+//	bus: The bus to register the handler on.
+//	handler: The function handling the queries and returning their result.
+//
+// Returns:
+//
+//	Nil, or an `exception.Logic` if a handler is already registered for Q.
+func HandleQuery[Q Query, R any](bus *Bus, handler func(ctx context.Context, query Q) (R, error)) error {
+	return bus.register(KindQuery, reflect.TypeFor[Q](), func(ctx context.Context, msg Message) (interface{}, error) {
+		return handler(ctx, msg.Payload.(Q))
+	})
+}
+
+// register stores the handler of a message type.
+func (b *Bus) register(kind Kind, typ reflect.Type, handler HandlerFunc) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := handlerKey{kind: kind, typ: typ}
+	if _, exists := b.handlers[key]; exists {
+		return exception.NewLogic(map[string]interface{}{
+			"message": fmt.Sprintf("A handler is already registered for %s %s.", kind, typ),
+			"details": map[string]interface{}{
+				"kind":  string(kind),
+				"type":  typ.String(),
+				"error": "handler_already_registered",
+			},
+		})
+	}
+	b.handlers[key] = handler
+	return nil
+}
+
+// Send dispatches a command to its handler.
+//
+// Parameters:
+//
+//	ctx: The context passed to the middlewares and the handler.
+//	cmd: The command to dispatch.
+//
+// Returns:
+//
+//	Nil on success, or an `exception.CoreInterface` error: the handler's
+//	exception, an internal server error wrapping any other handler error,
+//	or an `exception.Logic` if no handler is registered for the command.
+func (b *Bus) Send(ctx context.Context, cmd Command) error {
+	_, err := b.dispatch(ctx, Message{Kind: KindCommand, Name: cmd.CommandName(), Payload: cmd})
+	return err
+}
+
+// Ask dispatches a query to its handler and returns its result.
+//
+// Parameters:
+//
+//	ctx: The context passed to the middlewares and the handler.
+//	bus: The bus to dispatch the query on.
+//	query: The query to dispatch.
+//
+// Returns:
+//
+//	The result of the query, or an `exception.CoreInterface` error as for
+//	`Bus.Send`. An `exception.Logic` is also returned when the result of the
+//	handler is not of type R.
+func Ask[R any](ctx context.Context, bus *Bus, query Query) (R, error) {
+	var zero R
+	result, err := bus.dispatch(ctx, Message{Kind: KindQuery, Name: query.QueryName(), Payload: query})
+	if err != nil || result == nil {
+		return zero, err
+	}
+
+	typed, ok := result.(R)
+	if !ok {
+		return zero, exception.NewLogic(map[string]interface{}{
+			"message": fmt.Sprintf("The result of query %q is a %T, not a %s.", query.QueryName(), result, reflect.TypeFor[R]()),
+			"details": map[string]interface{}{
+				"query": query.QueryName(),
+				"error": "query_result_mismatch",
+			},
+		})
+	}
+	return typed, nil
+}
+
+// dispatch runs a message through the middlewares and its handler.
+func (b *Bus) dispatch(ctx context.Context, msg Message) (interface{}, error) {
+	b.mu.RLock()
+	handler, found := b.handlers[handlerKey{kind: msg.Kind, typ: reflect.TypeOf(msg.Payload)}]
+	middlewares := b.middlewares
+	b.mu.RUnlock()
+
+	if !found {
+		return nil, exception.NewLogic(map[string]interface{}{
+			"message": fmt.Sprintf("No handler registered for %s %q.", msg.Kind, msg.Name),
+			"details": map[string]interface{}{
+				"kind":  string(msg.Kind),
+				"name":  msg.Name,
+				"error": "handler_not_found",
+			},
+		})
+	}
+
+	next := guard(handler)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](next)
+	}
+
+	result, err := next(ctx, msg)
+	return result, exception.Normalize(err)
+}
+
+// guard wraps a handler so that its errors are normalized and its panics are
+// recovered into `exception.Runtime` errors.
+func guard(handler HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, msg Message) (result interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				result, err = nil, exception.NewRuntime(map[string]interface{}{
+					"message": fmt.Sprintf("The handler of %s %q panicked.", msg.Kind, msg.Name),
+					"details": map[string]interface{}{
+						"kind":  string(msg.Kind),
+						"name":  msg.Name,
+						"panic": fmt.Sprint(recovered),
+						"error": "handler_panicked",
+					},
+				})
+			}
+		}()
+
+		result, err = handler(ctx, msg)
+		return result, exception.Normalize(err)
+	}
+}
@@ -0,0 +1,138 @@
+// Package cqrs provides a command and query bus.
+// This file defines the standard middlewares of the bus.
+package cqrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/validator"
+)
+
+// Logging logs every dispatch: failures through `logger.LogExceptionContext`,
+// successes at the Debug level. Entries carry the kind and name of the
+// message and the duration of the dispatch.
+//
+// Parameters:
+//
+//	l: The Logger to write to.
+//
+// Returns:
+//
+//	The logging Middleware.
+func Logging(l logger.Logger) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (interface{}, error) {
+			start := time.Now()
+			result, err := next(ctx, msg)
+
+			entry := l.With(
+				logger.Any("kind", string(msg.Kind)),
+				logger.Any("name", msg.Name),
+				logger.Any("duration_ms", time.Since(start).Milliseconds()),
+			)
+			if err != nil {
+				logger.LogExceptionContext(ctx, entry, exception.Normalize(err))
+			} else {
+				entry.Debug("Message handled.")
+			}
+			return result, err
+		}
+	}
+}
+
+// Validatable is implemented by messages validating themselves.
+// See `validator.Validatable`.
+type Validatable = validator.Validatable
+
+// Validation validates every message before it reaches its handler with
+// `validator.Validator.ValidateInput`: struct messages, or pointers to struct
+// messages, are validated according to their `validate` tags, and messages
+// implementing Validatable are then validated by their Validate method. The
+// handler is not called when validation fails.
+//
+// Parameters:
+//
+//	v: The Validator checking the struct tags. Nil uses the default Validator.
+//
+// Returns:
+//
+//	The validation Middleware.
+func Validation(v *validator.Validator) Middleware {
+	validate := validator.ValidateInput
+	if v != nil {
+		validate = v.ValidateInput
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (interface{}, error) {
+			if err := validate(msg.Payload); err != nil {
+				return nil, err
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Transactor runs a function within a transaction, such as a database
+// transaction or a unit of work.
+type Transactor interface {
+	// WithinTransaction runs fn with a context carrying the transaction,
+	// committing it when fn returns nil and rolling it back otherwise.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Transaction runs the handler of every command within a transaction.
+// Queries are dispatched outside of any transaction.
+//
+// Parameters:
+//
+//	tx: The Transactor opening the transactions.
+//
+// Returns:
+//
+//	The transaction Middleware.
+func Transaction(tx Transactor) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (interface{}, error) {
+			if msg.Kind != KindCommand {
+				return next(ctx, msg)
+			}
+
+			var result interface{}
+			err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				result, err = next(ctx, msg)
+				return err
+			})
+			return result, err
+		}
+	}
+}
+
+// Recorder records the outcome of a dispatch, e.g. into a histogram labeled
+// by message name and status code. The error is nil on success and an
+// `exception.CoreInterface` otherwise.
+type Recorder func(msg Message, duration time.Duration, err error)
+
+// Metrics reports the duration and outcome of every dispatch to a Recorder.
+//
+// Parameters:
+//
+//	record: The function recording each dispatch.
+//
+// Returns:
+//
+//	The metrics Middleware.
+func Metrics(record Recorder) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg Message) (interface{}, error) {
+			start := time.Now()
+			result, err := next(ctx, msg)
+			record(msg, time.Since(start), exception.Normalize(err))
+			return result, err
+		}
+	}
+}
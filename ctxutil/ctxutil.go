@@ -0,0 +1,119 @@
+// Package ctxutil provides typed context keys and helpers for the identifiers
+// that follow a request across services: the request ID (unique per
+// request), the correlation ID (shared by every request of a business
+// transaction), the tenant ID, and the trace and span IDs of distributed
+// tracing. An HTTP middleware generates and propagates them, and helpers
+// inject them into exceptions and envelopes.
+package ctxutil
+
+import (
+	"context"
+)
+
+// contextKey is the unexported type of the keys defined by this package,
+// preventing collisions with keys defined by other packages.
+type contextKey int
+
+const (
+	requestIDKey     contextKey = iota // The key of the request ID.
+	correlationIDKey                   // The key of the correlation ID.
+	tenantIDKey                        // The key of the tenant ID.
+	traceIDKey                         // The key of the trace ID.
+	spanIDKey                          // The key of the span ID.
+)
+
+// Names under which the identifiers are reported in exceptions, envelope
+// meta blocks and logs.
+const (
+	RequestIDField     = "request_id"     // RequestIDField is the name of the request ID field.
+	CorrelationIDField = "correlation_id" // CorrelationIDField is the name of the correlation ID field.
+	TenantIDField      = "tenant_id"      // TenantIDField is the name of the tenant ID field.
+	TraceIDField       = "trace_id"       // TraceIDField is the name of the trace ID field.
+	SpanIDField        = "span_id"        // SpanIDField is the name of the span ID field.
+)
+
+// WithRequestID returns a copy of ctx carrying the given request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string.
+func RequestID(ctx context.Context) string {
+	return stringValue(ctx, requestIDKey)
+}
+
+// WithCorrelationID returns a copy of ctx carrying the given correlation ID.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	return stringValue(ctx, correlationIDKey)
+}
+
+// WithTenantID returns a copy of ctx carrying the given tenant ID.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// TenantID returns the tenant ID carried by ctx, or an empty string.
+func TenantID(ctx context.Context) string {
+	return stringValue(ctx, tenantIDKey)
+}
+
+// WithTraceID returns a copy of ctx carrying the given trace ID, typically
+// set by the tracing middleware.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// TraceID returns the trace ID carried by ctx, or an empty string.
+func TraceID(ctx context.Context) string {
+	return stringValue(ctx, traceIDKey)
+}
+
+// WithSpanID returns a copy of ctx carrying the given span ID, typically set
+// by the tracing middleware.
+func WithSpanID(ctx context.Context, spanID string) context.Context {
+	return context.WithValue(ctx, spanIDKey, spanID)
+}
+
+// SpanID returns the span ID carried by ctx, or an empty string.
+func SpanID(ctx context.Context) string {
+	return stringValue(ctx, spanIDKey)
+}
+
+// Fields returns the identifiers carried by ctx keyed by their field names
+// (`RequestIDField`, `CorrelationIDField`, `TenantIDField`, `TraceIDField`,
+// `SpanIDField`). Identifiers that are not set are omitted, so the map is
+// empty for a bare context.
+func Fields(ctx context.Context) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if ctx == nil {
+		return fields
+	}
+	if requestID := RequestID(ctx); requestID != "" {
+		fields[RequestIDField] = requestID
+	}
+	if correlationID := CorrelationID(ctx); correlationID != "" {
+		fields[CorrelationIDField] = correlationID
+	}
+	if tenantID := TenantID(ctx); tenantID != "" {
+		fields[TenantIDField] = tenantID
+	}
+	if traceID := TraceID(ctx); traceID != "" {
+		fields[TraceIDField] = traceID
+	}
+	if spanID := SpanID(ctx); spanID != "" {
+		fields[SpanIDField] = spanID
+	}
+	return fields
+}
+
+// stringValue reads a string value from ctx, returning an empty string when
+// it is absent or of another type.
+func stringValue(ctx context.Context, key contextKey) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -0,0 +1,49 @@
+// Package ctxutil provides typed context keys and helpers for request
+// identifiers. This file defines the injection of those identifiers into
+// exceptions.
+package ctxutil
+
+import (
+	"context"
+	"errors"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// errorSetter is implemented by exceptions that can be enriched after
+// creation, such as every type embedding `exception.CoreException`.
+type errorSetter interface {
+	error
+	SetError(key string, value interface{}) error
+}
+
+// Annotate adds the identifiers carried by ctx to the exception found in the
+// chain of err, so that they appear in its `Format()` and `GetErrorsForLog()`
+// output. Errors that are not exceptions, and sealed exceptions (see
+// `exception.CoreException.Seal`), are returned unchanged.
+//
+// Parameters:
+//
+//	ctx: The context carrying the identifiers.
+//	err: The error to annotate. It may be nil, or a nil exception (see
+//	     `exception.IsNil`).
+//
+// Returns:
+//
+//	The same error, for convenient use in return statements.
+func Annotate(ctx context.Context, err error) error {
+	if exception.IsNil(err) {
+		return err
+	}
+
+	var target errorSetter
+	if !errors.As(err, &target) || exception.IsNil(target) {
+		return err
+	}
+	for key, value := range Fields(ctx) {
+		if target.SetError(key, value) != nil {
+			break
+		}
+	}
+	return err
+}
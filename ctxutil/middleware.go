@@ -0,0 +1,126 @@
+// Package ctxutil provides typed context keys and helpers for request
+// identifiers. This file defines the HTTP middleware that generates and
+// propagates them.
+package ctxutil
+
+import (
+	"net/http"
+
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+// HTTP headers used to receive and propagate the identifiers.
+const (
+	RequestIDHeader     = "X-Request-ID"     // RequestIDHeader carries the request ID.
+	CorrelationIDHeader = "X-Correlation-ID" // CorrelationIDHeader carries the correlation ID.
+	TenantIDHeader      = "X-Tenant-ID"      // TenantIDHeader carries the tenant ID; see TenantFromHeader before trusting it.
+)
+
+// maxIDLength bounds the length of identifiers accepted from clients, so
+// that arbitrary payloads cannot be smuggled into logs through headers.
+const maxIDLength = 128
+
+// TenantResolver returns the tenant ID of a request, e.g. from the claims
+// of its authenticated principal, or an empty string when it has none.
+type TenantResolver func(r *http.Request) string
+
+// TenantFromHeader is a TenantResolver reading the X-Tenant-ID header. The
+// header is set by the client, which can claim any tenant: it is only
+// trustworthy behind an authenticating gateway that sets it and strips the
+// value sent by the client.
+//
+// Parameters:
+//
+//	r: The request.
+//
+// Returns:
+//
+//	The tenant ID, or an empty string when the header is missing, too long
+//	or not printable ASCII.
+func TenantFromHeader(r *http.Request) string {
+	return headerID(r, TenantIDHeader)
+}
+
+// Middleware stores the request and correlation IDs in the request context.
+// Incoming X-Request-ID and X-Correlation-ID headers are reused when present
+// and reasonable; otherwise a random UUID request ID is generated and the
+// correlation ID defaults to it. The request and correlation IDs are echoed
+// in the response headers so that clients can report them. The tenant ID is
+// not extracted; see MiddlewareWithTenant.
+//
+// Parameters:
+//
+//	next: The handler to call with the enriched request.
+//
+// Returns:
+//
+//	An `http.Handler` wrapping next.
+func Middleware(next http.Handler) http.Handler {
+	return MiddlewareWithTenant(nil)(next)
+}
+
+// MiddlewareWithTenant returns a Middleware additionally storing the tenant
+// ID resolved for each request in its context, from which it is added to the
+// logs, the exceptions and the envelopes:
+//
+//	handler = ctxutil.MiddlewareWithTenant(func(r *http.Request) string {
+//		claims, _ := authn.ClaimsFromContext(r.Context())
+//		tenant, _ := claims.Extra["tenant"].(string)
+//		return tenant
+//	})(handler)
+//
+// Parameters:
+//
+//	resolve: The resolver of the tenant ID, e.g. TenantFromHeader behind an
+//	         authenticating gateway. Nil resolves no tenant.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the middleware.
+func MiddlewareWithTenant(resolve TenantResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return serve(next, resolve)
+	}
+}
+
+// serve implements Middleware and MiddlewareWithTenant.
+func serve(next http.Handler, resolve TenantResolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := headerID(r, RequestIDHeader)
+		if requestID == "" {
+			requestID = valueobject.NewUUID().String()
+		}
+
+		correlationID := headerID(r, CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = requestID
+		}
+
+		ctx := WithCorrelationID(WithRequestID(r.Context(), requestID), correlationID)
+		if resolve != nil {
+			if tenantID := resolve(r); tenantID != "" {
+				ctx = WithTenantID(ctx, tenantID)
+			}
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		w.Header().Set(CorrelationIDHeader, correlationID)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// headerID reads an identifier from a request header, discarding values that
+// are too long or contain characters outside the printable ASCII range.
+func headerID(r *http.Request, name string) string {
+	value := r.Header.Get(name)
+	if len(value) > maxIDLength {
+		return ""
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] <= ' ' || value[i] > '~' {
+			return ""
+		}
+	}
+	return value
+}
@@ -0,0 +1,201 @@
+// Package dbadapter translates the errors of the database drivers and ORMs
+// into exceptions, so that the repositories built on them report missing
+// records and constraint violations like the rest of the catalog. It does not
+// depend on any driver: the PostgreSQL errors are recognized through their
+// `SQLState()` method, implemented by `pgconn.PgError` (pgx) and `pq.Error`
+// (lib/pq), and the "not found" errors of the ORMs are given in the Config:
+//
+//	var translator = dbadapter.New(dbadapter.Config{NotFound: []error{gorm.ErrRecordNotFound}})
+//
+//	if err := db.First(&user, id).Error; err != nil {
+//		return nil, translator.Translate(err)
+//	}
+package dbadapter
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// SQLSTATE codes translated by a Translator.
+const (
+	UniqueViolation      = "23505" // A unique constraint is violated; translated into `exception.Conflict`.
+	ForeignKeyViolation  = "23503" // A foreign key constraint is violated; translated into `exception.Conflict`.
+	SerializationFailure = "40001" // A concurrent transaction won; translated into `exception.ConcurrencyConflict`.
+	QueryCanceled        = "57014" // The statement timed out or was canceled; translated into `exception.Timeout`.
+)
+
+// sqlStateError is implemented by the errors of the PostgreSQL drivers.
+type sqlStateError interface {
+	error
+	SQLState() string
+}
+
+// Config configures a Translator.
+type Config struct {
+	// NotFound lists the errors meaning that no record matched, recognized
+	// with `errors.Is`, e.g. `gorm.ErrRecordNotFound`. `sql.ErrNoRows` is
+	// always recognized.
+	NotFound []error
+}
+
+// Translator translates database errors into exceptions.
+type Translator struct {
+	notFound []error
+}
+
+// New creates a Translator.
+//
+// Parameters:
+//
+//	config: The configuration of the translator.
+//
+// Returns:
+//
+//	A pointer to a new Translator.
+func New(config Config) *Translator {
+	return &Translator{notFound: append([]error{sql.ErrNoRows}, config.NotFound...)}
+}
+
+// defaultTranslator is the Translator of the package-level Translate.
+var defaultTranslator = New(Config{})
+
+// Translate translates a database error with a Translator recognizing
+// `sql.ErrNoRows` only (see `Translator.Translate`).
+func Translate(err error) error {
+	return defaultTranslator.Translate(err)
+}
+
+// Translate translates a database error into an exception:
+//
+//	sql.ErrNoRows and Config.NotFound   exception.NotFound
+//	23505 unique_violation              exception.Conflict
+//	23503 foreign_key_violation         exception.Conflict
+//	40001 serialization_failure         exception.ConcurrencyConflict
+//	57014 query_canceled                exception.Timeout
+//
+// The details of the exceptions of PostgreSQL errors hold the SQLSTATE code
+// and, when the driver provides them, the names of the constraint, table and
+// column; the message of the driver error, which may quote the values of the
+// row, is not kept. The exception unwraps to err (see
+// `exception.CoreException.WithCause`), so that `errors.Is(err, sql.ErrNoRows)`
+// and `errors.As` still reach the driver error, rendered in the log output.
+//
+// Parameters:
+//
+//	err: The error to translate.
+//
+// Returns:
+//
+//	The exception, or err unchanged when it is nil, already carries an
+//	exception, or is not recognized.
+func (t *Translator) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	var coreErr exception.CoreInterface
+	if errors.As(err, &coreErr) {
+		return err
+	}
+
+	for _, notFound := range t.notFound {
+		if errors.Is(err, notFound) {
+			return withCause(exception.NewNotFound(map[string]interface{}{
+				"message": "The record was not found.",
+				"details": map[string]interface{}{"error": "record_not_found"},
+			}), err)
+		}
+	}
+
+	var stateErr sqlStateError
+	if !errors.As(err, &stateErr) {
+		return err
+	}
+	code := stateErr.SQLState()
+	details := map[string]interface{}{"sqlstate": code}
+	for key, fields := range map[string][]string{
+		"constraint": {"ConstraintName", "Constraint"},
+		"table":      {"TableName", "Table"},
+		"column":     {"ColumnName", "Column"},
+	} {
+		if value := stringField(stateErr, fields...); value != "" {
+			details[key] = value
+		}
+	}
+
+	switch code {
+	case UniqueViolation:
+		details["error"] = "unique_violation"
+		return withCause(exception.NewConflict(map[string]interface{}{
+			"message": "The record conflicts with an existing one.",
+			"details": details,
+		}), err)
+	case ForeignKeyViolation:
+		details["error"] = "foreign_key_violation"
+		return withCause(exception.NewConflict(map[string]interface{}{
+			"message": "The record references a missing record, or is referenced by another one.",
+			"details": details,
+		}), err)
+	case SerializationFailure:
+		details["error"] = "serialization_failure"
+		return withCause(exception.NewConcurrencyConflict(map[string]interface{}{
+			"message": "The transaction conflicted with a concurrent one.",
+			"details": details,
+		}), err)
+	case QueryCanceled:
+		details["error"] = "query_canceled"
+		return withCause(exception.NewTimeout(map[string]interface{}{
+			"message": "The query was canceled.",
+			"details": details,
+		}), err)
+	default:
+		return err
+	}
+}
+
+// causable is implemented by the exceptions, through their embedded
+// `exception.CoreException`.
+type causable interface {
+	error
+	WithCause(cause error) error
+}
+
+// withCause sets the driver error as the cause of its translation.
+//
+// Parameters:
+//
+//	translated: The new, hence unsealed, exception.
+//	cause: The driver error.
+//
+// Returns:
+//
+//	The exception.
+func withCause(translated causable, cause error) error {
+	_ = translated.WithCause(cause)
+	return translated
+}
+
+// stringField returns the first non-empty string field of a struct, or of a
+// pointer to a struct, among the given names, e.g. `pgconn.PgError.ConstraintName`
+// or `pq.Error.Constraint`.
+func stringField(value interface{}, names ...string) string {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, name := range names {
+		if field := v.FieldByName(name); field.IsValid() && field.Kind() == reflect.String && field.String() != "" {
+			return field.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,209 @@
+// Package deprecation announces the deprecation of endpoints. A Registry maps
+// routes to their deprecation Policy, and its Middleware attaches the
+// Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers (pointing at the
+// successor version and the deprecation documentation) to their responses.
+// A registry can also emit a deprecation notice in the "meta" block of the
+// success envelopes built by the response package.
+package deprecation
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NoticeType is the type of the notices emitted in envelope meta blocks.
+const NoticeType = "deprecation"
+
+// Policy describes the deprecation of an endpoint.
+type Policy struct {
+	// Since is when the endpoint was deprecated. Zero sends the
+	// "Deprecation: true" form of the earlier drafts of RFC 9745.
+	Since time.Time
+
+	// Sunset, when set, is when the endpoint will stop responding.
+	Sunset time.Time
+
+	// Successor, when set, is the URL of the replacing endpoint, linked with
+	// the "successor-version" relation.
+	Successor string
+
+	// Documentation, when set, is the URL of the deprecation notes, linked
+	// with the "deprecation" relation.
+	Documentation string
+
+	// Message, when set, is a human readable explanation added to the notice.
+	Message string
+}
+
+// Notice is the deprecation notice emitted in envelope meta blocks.
+type Notice struct {
+	Type          string     `json:"type"`
+	Message       string     `json:"message,omitempty"`
+	DeprecatedAt  *time.Time `json:"deprecated_at,omitempty"`
+	SunsetAt      *time.Time `json:"sunset_at,omitempty"`
+	Successor     string     `json:"successor,omitempty"`
+	Documentation string     `json:"documentation,omitempty"`
+}
+
+// Notice returns the notice describing the policy.
+func (p Policy) Notice() Notice {
+	notice := Notice{Type: NoticeType, Message: p.Message, Successor: p.Successor, Documentation: p.Documentation}
+	if !p.Since.IsZero() {
+		since := p.Since.UTC()
+		notice.DeprecatedAt = &since
+	}
+	if !p.Sunset.IsZero() {
+		sunset := p.Sunset.UTC()
+		notice.SunsetAt = &sunset
+	}
+	return notice
+}
+
+// SetHeaders attaches the headers announcing the policy to a response.
+func (p Policy) SetHeaders(header http.Header) {
+	if p.Since.IsZero() {
+		header.Set("Deprecation", "true")
+	} else {
+		header.Set("Deprecation", "@"+strconv.FormatInt(p.Since.Unix(), 10))
+	}
+	if !p.Sunset.IsZero() {
+		header.Set("Sunset", p.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if p.Successor != "" {
+		header.Add("Link", "<"+p.Successor+`>; rel="successor-version"`)
+	}
+	if p.Documentation != "" {
+		header.Add("Link", "<"+p.Documentation+`>; rel="deprecation"`)
+	}
+}
+
+// Option customizes a Registry.
+type Option func(*Registry)
+
+// EmitMeta makes the registry emit the notice of the policy of a request
+// under the "deprecation" key of the meta block of its success envelope
+// (see Meta).
+func EmitMeta() Option {
+	return func(r *Registry) {
+		r.meta = true
+	}
+}
+
+// Registry maps routes to their deprecation policy. It is safe for
+// concurrent use.
+type Registry struct {
+	meta bool
+
+	mu       sync.RWMutex
+	policies map[string]Policy
+}
+
+// NewRegistry creates an empty Registry.
+//
+// Parameters:
+//
+//	opts: Options customizing the registry (e.g., EmitMeta).
+//
+// Returns:
+//
+//	A pointer to a new Registry.
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{policies: map[string]Policy{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Register marks a route as deprecated.
+//
+// Parameters:
+//
+//	route: The route, matched against the `http.ServeMux` pattern of the
+//	       request (e.g., "GET /v1/users/{id}"), then its method and path
+//	       ("GET /v1/users"), then its path ("/v1/users"). A route ending with
+//	       "/" also matches the paths below it ("/v1/" deprecates a version).
+//	       Patterns are only known to a Middleware wrapping the handlers
+//	       registered on the mux, not one wrapping the mux itself.
+//	policy: The deprecation policy of the route.
+func (r *Registry) Register(route string, policy Policy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies[route] = policy
+}
+
+// Lookup returns the policy of a request, the most specific route winning.
+func (r *Registry) Lookup(req *http.Request) (Policy, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, route := range []string{req.Pattern, req.Method + " " + req.URL.Path, req.URL.Path} {
+		if policy, ok := r.policies[route]; ok && route != "" {
+			return policy, true
+		}
+	}
+
+	var found Policy
+	longest := 0
+	for route, policy := range r.policies {
+		if strings.HasSuffix(route, "/") && strings.HasPrefix(req.URL.Path, route) && len(route) > longest {
+			found, longest = policy, len(route)
+		}
+	}
+	return found, longest > 0
+}
+
+// Middleware attaches the headers of the policy of each deprecated request
+// to its response, and places the policy in the request context (see
+// FromContext).
+//
+// Parameters:
+//
+//	registry: The registry of deprecated routes.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the deprecation headers.
+func Middleware(registry *Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policy, ok := registry.Lookup(r); ok {
+				policy.SetHeaders(w.Header())
+				r = r.WithContext(context.WithValue(r.Context(), contextKey{}, deprecated{policy: policy, meta: registry.meta}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// contextKey is the unexported type of the key defined by this package.
+type contextKey struct{}
+
+// deprecated is the value carried by the contexts of deprecated requests.
+type deprecated struct {
+	policy Policy
+	meta   bool
+}
+
+// FromContext returns the policy of the deprecated request of ctx, and false
+// when the request is not deprecated.
+func FromContext(ctx context.Context) (Policy, bool) {
+	value, ok := ctx.Value(contextKey{}).(deprecated)
+	return value.policy, ok
+}
+
+// Meta returns the meta block announcing the deprecation of the request of
+// ctx, e.g. {"deprecation": Notice}, or nil when the request is not
+// deprecated or its registry does not emit meta blocks. It is merged into
+// the envelopes built by `response.SuccessContext`.
+func Meta(ctx context.Context) map[string]interface{} {
+	value, ok := ctx.Value(contextKey{}).(deprecated)
+	if !ok || !value.meta {
+		return nil
+	}
+	return map[string]interface{}{NoticeType: value.policy.Notice()}
+}
@@ -0,0 +1,51 @@
+// Package domain provides the base types of domain models.
+// This file defines the AggregateRoot and its domain event buffer.
+package domain
+
+import (
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/id"
+)
+
+// AggregateRoot is the base of the entities guarding the consistency of an
+// aggregate. Its behavior records the domain events it raises with
+// RecordEvent; once the aggregate is stored, the events are pulled with
+// PullEvents and published. It is meant to be embedded, like Entity.
+type AggregateRoot[ID comparable] struct {
+	Entity[ID] // Embeds Entity for the identity, timestamps and version.
+
+	events []interface{} // The recorded events not pulled yet.
+}
+
+// NewAggregateRoot creates an AggregateRoot with a generated identity,
+// stamped with the current time of the clock.
+//
+// Parameters:
+//
+//	generator: The generator of the identity (e.g., `id.UUIDv7(c)`).
+//	c: The clock providing the creation time. Nil uses the system clock.
+//
+// Returns:
+//
+//	The new AggregateRoot, without recorded events.
+func NewAggregateRoot[ID comparable](generator id.Generator[ID], c clock.Clock) AggregateRoot[ID] {
+	return AggregateRoot[ID]{Entity: NewEntity(generator, c)}
+}
+
+// RecordEvent appends a domain event to the buffer of the aggregate.
+func (a *AggregateRoot[ID]) RecordEvent(event interface{}) {
+	a.events = append(a.events, event)
+}
+
+// Events returns a copy of the recorded events, leaving the buffer intact.
+func (a *AggregateRoot[ID]) Events() []interface{} {
+	return append([]interface{}(nil), a.events...)
+}
+
+// PullEvents returns the recorded events in recording order and empties the
+// buffer, so that each event is published once.
+func (a *AggregateRoot[ID]) PullEvents() []interface{} {
+	events := a.events
+	a.events = nil
+	return events
+}
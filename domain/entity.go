@@ -0,0 +1,93 @@
+// Package domain provides the base types of domain models: Entity, carrying
+// an identity, creation and modification timestamps and a version for
+// optimistic locking, and AggregateRoot, which additionally records the
+// domain events raised by its behavior until they are pulled for
+// publication (see the eventbus package).
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/id"
+)
+
+// Entity is the base of the domain types defined by their identity rather
+// than their attributes. It is meant to be embedded:
+//
+//	type User struct {
+//		domain.Entity[valueobject.UUID]
+//		Email valueobject.Email
+//	}
+//
+// Its fields are exported so that persistence adapters can restore stored
+// entities.
+type Entity[ID comparable] struct {
+	ID        ID        `json:"id"`         // The identity of the entity.
+	CreatedAt time.Time `json:"created_at"` // When the entity was created.
+	UpdatedAt time.Time `json:"updated_at"` // When the entity was last modified.
+	Version   int       `json:"version"`    // The stored version, used for optimistic locking.
+}
+
+// NewEntity creates an Entity with a generated identity, stamped with the
+// current time of the clock. Its version is 0 until it is first stored.
+//
+// Parameters:
+//
+//	generator: The generator of the identity (e.g., `id.UUIDv7(c)`).
+//	c: The clock providing the creation time. Nil uses the system clock.
+//
+// Returns:
+//
+//	The new Entity.
+func NewEntity[ID comparable](generator id.Generator[ID], c clock.Clock) Entity[ID] {
+	now := clock.OrSystem(c).Now()
+	return Entity[ID]{ID: generator.NewID(), CreatedAt: now, UpdatedAt: now}
+}
+
+// SameIdentity reports whether two entities have the same identity.
+func (e Entity[ID]) SameIdentity(other Entity[ID]) bool {
+	return e.ID == other.ID
+}
+
+// Touch records a modification of the entity at the current time of the
+// clock. Nil uses the system clock.
+func (e *Entity[ID]) Touch(c clock.Clock) {
+	e.UpdatedAt = clock.OrSystem(c).Now()
+}
+
+// CheckVersion implements optimistic locking: repositories call it before
+// writing an entity, with the version currently stored.
+//
+// Parameters:
+//
+//	stored: The version of the entity currently in storage.
+//
+// Returns:
+//
+//	Nil when the entity was loaded from the stored version, or an
+//	`exception.Conflict` with the "stale_version" reason when the stored
+//	entity was modified concurrently.
+func (e Entity[ID]) CheckVersion(stored int) error {
+	if e.Version == stored {
+		return nil
+	}
+
+	return exception.NewConflict(map[string]interface{}{
+		"message": fmt.Sprintf("The entity %v was modified concurrently.", e.ID),
+		"details": map[string]interface{}{
+			"id":               e.ID,
+			"expected_version": e.Version,
+			"stored_version":   stored,
+			"reason":           "stale_version",
+			"error":            "conflict",
+		},
+	})
+}
+
+// IncrementVersion advances the version after a successful write.
+func (e *Entity[ID]) IncrementVersion() {
+	e.Version++
+}
@@ -5,6 +5,11 @@
 // across applications.
 package status
 
+import (
+	"iter"
+	"slices"
+)
+
 // StatusCode is a custom integer type representing an HTTP-like status code.
 type StatusCode int
 
@@ -156,6 +161,29 @@ var statusDescriptions = map[StatusCode]string{
 	511: "Network Authentication Required",
 }
 
+// maxStatusCode bounds the codes of statusTable.
+const maxStatusCode = 600
+
+// statusTable holds the descriptions of statusDescriptions indexed by code,
+// so that the lookups on the hot path of every formatted error are a bounds
+// check and an index rather than a map hashing.
+var statusTable = func() (table [maxStatusCode]string) {
+	for code, description := range statusDescriptions {
+		table[code] = description
+	}
+	return table
+}()
+
+// lookupDescription returns the description of a code, and whether it is
+// known.
+func lookupDescription(c StatusCode) (string, bool) {
+	if c < 0 || c >= maxStatusCode {
+		return "", false
+	}
+	description := statusTable[c]
+	return description, description != ""
+}
+
 // GetValue returns the integer representation of the StatusCode.
 func (c StatusCode) GetValue() int {
 	return int(c)
@@ -164,7 +192,7 @@ func (c StatusCode) GetValue() int {
 // GetDescription returns the human-readable string description for the StatusCode.
 // If the StatusCode is not recognized, it returns "Unknown Status Code".
 func (c StatusCode) GetDescription() string {
-	if desc, exists := statusDescriptions[c]; exists {
+	if desc, exists := lookupDescription(c); exists {
 		return desc
 	}
 	return "Unknown Status Code"
@@ -184,16 +212,61 @@ func (c StatusCode) GetDescription() string {
 func NewStatusCode(value int) (StatusCode, bool) {
 	s := StatusCode(value)
 	// Check if the integer value corresponds to a known status code description.
-	if _, exists := statusDescriptions[s]; exists {
+	if _, exists := lookupDescription(s); exists {
 		return s, true
 	}
 	return 0, false // Return 0 and false if the status code is unknown.
 }
 
-// GetStatusTexts returns a copy of the internal map that maps each
-// StatusCode to its string description. This allows external access to
-// all defined status code descriptions without modifying the internal map.
-func GetStatusTexts() map[StatusCode]string {
+// sortedStatusCodes lists the codes of statusDescriptions in ascending
+// order, for the iteration of StatusTexts.
+var sortedStatusCodes = func() []StatusCode {
+	codes := make([]StatusCode, 0, len(statusDescriptions))
+	for code := range statusDescriptions {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}()
+
+// StatusTexts is a read-only view of the descriptions of the known status
+// codes. It is a zero-size value reading the package data, so obtaining and
+// querying it allocates nothing.
+type StatusTexts struct{}
+
+// Get returns the description of a status code, and whether it is known.
+func (StatusTexts) Get(code StatusCode) (string, bool) {
+	return lookupDescription(code)
+}
+
+// Len returns the number of known status codes.
+func (StatusTexts) Len() int {
+	return len(sortedStatusCodes)
+}
+
+// All iterates over the known status codes and their descriptions, in
+// ascending order of the codes.
+func (StatusTexts) All() iter.Seq2[StatusCode, string] {
+	return func(yield func(StatusCode, string) bool) {
+		for _, code := range sortedStatusCodes {
+			if !yield(code, statusTable[code]) {
+				return
+			}
+		}
+	}
+}
+
+// GetStatusTexts returns a read-only view of the descriptions of the known
+// status codes, without copying them; use CopyStatusTexts for a map the
+// caller may modify.
+func GetStatusTexts() StatusTexts {
+	return StatusTexts{}
+}
+
+// CopyStatusTexts returns a copy of the internal map that maps each
+// StatusCode to its string description. This allows callers to modify the
+// returned map without modifying the internal one.
+func CopyStatusTexts() map[StatusCode]string {
 	// Create a new map to ensure the original map is not modified externally.
 	copyMap := make(map[StatusCode]string, len(statusDescriptions))
 	for key, value := range statusDescriptions {
@@ -201,3 +274,25 @@ func GetStatusTexts() map[StatusCode]string {
 	}
 	return copyMap
 }
+
+// StatusClass is the class of a StatusCode, given by its first digit.
+type StatusClass int
+
+// Status code classes, as defined by RFC 9110.
+const (
+	UnknownClass       StatusClass = 0 // UnknownClass groups codes outside the 100-599 range.
+	InformationalClass StatusClass = 1 // InformationalClass groups the 1xx codes.
+	SuccessClass       StatusClass = 2 // SuccessClass groups the 2xx codes.
+	RedirectionClass   StatusClass = 3 // RedirectionClass groups the 3xx codes.
+	ClientErrorClass   StatusClass = 4 // ClientErrorClass groups the 4xx codes.
+	ServerErrorClass   StatusClass = 5 // ServerErrorClass groups the 5xx codes.
+)
+
+// GetClass returns the class of the StatusCode (e.g., `ClientErrorClass` for 404),
+// or `UnknownClass` when the code is outside the 100-599 range.
+func (c StatusCode) GetClass() StatusClass {
+	if c < 100 || c > 599 {
+		return UnknownClass
+	}
+	return StatusClass(c / 100)
+}
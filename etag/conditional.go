@@ -0,0 +1,141 @@
+// Package etag computes entity tags and evaluates conditional requests.
+// This file defines the evaluation of the conditional request headers.
+package etag
+
+import (
+	"net/http"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` constants of the decisions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Header names of conditional requests.
+const (
+	IfMatchHeader           = "If-Match"
+	IfNoneMatchHeader       = "If-None-Match"
+	IfModifiedSinceHeader   = "If-Modified-Since"
+	IfUnmodifiedSinceHeader = "If-Unmodified-Since"
+)
+
+// Evaluate evaluates the preconditions of a request against the current
+// state of the resource, in the order of RFC 9110 section 13.2.2. An unset
+// tag and a zero modification time mean that the resource does not exist,
+// so that "If-None-Match: *" lets a creation through and "If-Match: *"
+// does not.
+//
+// Parameters:
+//
+//	r: The request.
+//	current: The entity tag of the current representation.
+//	lastModified: The modification time of the resource, zero when unknown.
+//
+// Returns:
+//
+//	`status.OK` when the request must be processed normally,
+//	`status.NotModified` when a GET or HEAD can be answered with 304, or
+//	`status.PreconditionFailed` along with an `exception.PreconditionFailed`
+//	whose details name the failed "header".
+func Evaluate(r *http.Request, current ETag, lastModified time.Time) (status.StatusCode, error) {
+	exists := !current.IsZero() || !lastModified.IsZero()
+	safe := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	if header := r.Header.Get(IfMatchHeader); header != "" {
+		tags, wildcard := parseList(header)
+		if !(wildcard && exists) && !matchesAny(tags, current, ETag.StrongMatch) {
+			return status.PreconditionFailed, failure(IfMatchHeader, "The resource does not match the If-Match header.")
+		}
+	} else if since, ok := parseDate(r.Header.Get(IfUnmodifiedSinceHeader)); ok && !lastModified.IsZero() {
+		if lastModified.Truncate(time.Second).After(since) {
+			return status.PreconditionFailed, failure(IfUnmodifiedSinceHeader, "The resource was modified since the If-Unmodified-Since date.")
+		}
+	}
+
+	if header := r.Header.Get(IfNoneMatchHeader); header != "" {
+		tags, wildcard := parseList(header)
+		if (wildcard && exists) || matchesAny(tags, current, ETag.WeakMatch) {
+			if safe {
+				return status.NotModified, nil
+			}
+			return status.PreconditionFailed, failure(IfNoneMatchHeader, "The resource matches the If-None-Match header.")
+		}
+	} else if since, ok := parseDate(r.Header.Get(IfModifiedSinceHeader)); ok && safe && !lastModified.IsZero() {
+		if !lastModified.Truncate(time.Second).After(since) {
+			return status.NotModified, nil
+		}
+	}
+
+	return status.OK, nil
+}
+
+// Check sets the validators of the current representation (ETag and
+// Last-Modified headers) and evaluates the preconditions of the request.
+// When they call for it, the response is written: a bare 304 Not Modified,
+// or the 412 error envelope rendered by `response.WriteErrorContext`.
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	r: The request.
+//	current: The entity tag of the current representation; unset sets no
+//	         ETag header.
+//	lastModified: The modification time of the resource; zero sets no
+//	              Last-Modified header.
+//
+// Returns:
+//
+//	True when the response was written and the handler must stop, false
+//	when it must process the request.
+func Check(w http.ResponseWriter, r *http.Request, current ETag, lastModified time.Time) bool {
+	if !current.IsZero() {
+		w.Header().Set("ETag", current.String())
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	decision, err := Evaluate(r, current, lastModified)
+	switch decision {
+	case status.NotModified:
+		w.WriteHeader(decision.GetValue())
+		return true
+	case status.PreconditionFailed:
+		_ = response.WriteErrorContext(r.Context(), w, err)
+		return true
+	default:
+		return false
+	}
+}
+
+// matchesAny reports whether one of the tags matches the current one.
+func matchesAny(tags []ETag, current ETag, match func(ETag, ETag) bool) bool {
+	for _, tag := range tags {
+		if match(tag, current) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDate parses an HTTP date, ignoring invalid ones as RFC 9110 requires.
+func parseDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := http.ParseTime(value)
+	return t, err == nil
+}
+
+// failure builds the exception reporting a failed precondition.
+func failure(header string, message string) error {
+	return exception.NewPreconditionFailed(map[string]interface{}{
+		"message": message,
+		"details": map[string]interface{}{
+			"header": header,
+			"error":  "precondition_failed",
+		},
+	})
+}
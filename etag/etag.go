@@ -0,0 +1,123 @@
+// Package etag computes entity tags and evaluates the conditional request
+// headers of RFC 9110 (If-Match, If-None-Match, If-Modified-Since and
+// If-Unmodified-Since), so that handlers implement conditional GET (304 Not
+// Modified) and lost update prevention on writes (412 Precondition Failed)
+// correctly. This file defines entity tags.
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// ETag is an entity tag identifying a representation of a resource.
+type ETag struct {
+	Value string // The opaque tag, without quotes.
+	Weak  bool   // Whether the tag only denotes semantic equivalence.
+}
+
+// Strong computes the strong entity tag of a payload, changing whenever one
+// of its bytes changes.
+//
+// Parameters:
+//
+//	payload: The representation, exactly as sent.
+//
+// Returns:
+//
+//	The strong ETag.
+func Strong(payload []byte) ETag {
+	return ETag{Value: digest(payload)}
+}
+
+// Weak computes the weak entity tag of a payload, e.g. of a representation
+// whose encoding can change without its meaning changing (compression,
+// whitespace, field order).
+//
+// Parameters:
+//
+//	payload: The representation, or any canonical form of it.
+//
+// Returns:
+//
+//	The weak ETag.
+func Weak(payload []byte) ETag {
+	return ETag{Value: digest(payload), Weak: true}
+}
+
+// IsZero reports whether the tag is unset.
+func (e ETag) IsZero() bool {
+	return e.Value == ""
+}
+
+// String formats the tag as a header value, e.g. `"abc"` or `W/"abc"`.
+func (e ETag) String() string {
+	if e.Weak {
+		return `W/"` + e.Value + `"`
+	}
+	return `"` + e.Value + `"`
+}
+
+// StrongMatch reports whether two tags match with the strong comparison:
+// both are strong and have the same value.
+func (e ETag) StrongMatch(other ETag) bool {
+	return !e.Weak && !other.Weak && e.Value == other.Value && !e.IsZero()
+}
+
+// WeakMatch reports whether two tags match with the weak comparison: they
+// have the same value, whether they are weak or not.
+func (e ETag) WeakMatch(other ETag) bool {
+	return e.Value == other.Value && !e.IsZero()
+}
+
+// Parse parses an entity tag, e.g. `"abc"` or `W/"abc"`.
+//
+// Parameters:
+//
+//	value: The tag as sent in a header.
+//
+// Returns:
+//
+//	The ETag, and false when the value is not a valid entity tag.
+func Parse(value string) (ETag, bool) {
+	value = strings.TrimSpace(value)
+	weak := false
+	if rest, ok := strings.CutPrefix(value, "W/"); ok {
+		weak, value = true, rest
+	}
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return ETag{}, false
+	}
+	opaque := value[1 : len(value)-1]
+	if strings.ContainsAny(opaque, "\" ") {
+		return ETag{}, false
+	}
+	return ETag{Value: opaque, Weak: weak}, true
+}
+
+// parseList parses the value of an If-Match or If-None-Match header.
+//
+// Returns:
+//
+//	The listed tags, and true when the value is "*". Malformed tags are
+//	skipped.
+func parseList(header string) ([]ETag, bool) {
+	if strings.TrimSpace(header) == "*" {
+		return nil, true
+	}
+	var tags []ETag
+	for _, part := range strings.Split(header, ",") {
+		if tag, ok := Parse(part); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, false
+}
+
+// digest returns the opaque value of a payload tag: the first 16 bytes of its
+// SHA-256, in URL-safe base64.
+func digest(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
@@ -0,0 +1,248 @@
+// Package eventbus provides an in-process bus for domain events. Handlers
+// subscribe to an event type and are dispatched either synchronously or
+// asynchronously on publication. Handler failures, including panics, are
+// aggregated into an `exception.Aggregate` so that publishers can decide how
+// to react: roll back, log, or ignore.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Named is implemented by events that provide their own name, used when
+// reporting handler failures. Other events are named after their Go type.
+type Named interface {
+	// EventName returns the name of the event (e.g., "order.placed").
+	EventName() string
+}
+
+// Name returns the name of an event: its EventName when it implements
+// Named, its Go type (e.g., "orders.Placed") otherwise.
+func Name(event interface{}) string {
+	if named, ok := event.(Named); ok {
+		return named.EventName()
+	}
+	return fmt.Sprintf("%T", event)
+}
+
+// subscription is a handler registered on the bus.
+type subscription struct {
+	id      uint64
+	accepts func(event interface{}) bool
+	handle  func(ctx context.Context, event interface{}) error
+}
+
+// Bus dispatches published events to the subscribed handlers. The zero value
+// is not usable; create buses with New. It is safe for concurrent use.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	nextID        uint64
+
+	pending sync.WaitGroup // Asynchronous dispatches in flight.
+}
+
+// New creates an empty Bus.
+//
+// Returns:
+//
+//	A pointer to a new Bus.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for the events of type E. E may be a
+// concrete event type or an interface, in which case the handler receives
+// every event implementing it. Handlers are called in subscription order.
+//
+// Parameters:
+//
+//	bus: The bus to subscribe to.
+//	handler: The function handling the events.
+//
+// Returns:
+//
+//	A function removing the subscription. It is safe to call several times.
+func Subscribe[E any](bus *Bus, handler func(ctx context.Context, event E) error) (unsubscribe func()) {
+	return bus.subscribe(subscription{
+		accepts: func(event interface{}) bool {
+			_, ok := event.(E)
+			return ok
+		},
+		handle: func(ctx context.Context, event interface{}) error {
+			return handler(ctx, event.(E))
+		},
+	})
+}
+
+// SubscribeAll registers a handler receiving every published event, e.g.
+// for auditing or forwarding events to a message broker.
+//
+// Parameters:
+//
+//	handler: The function handling the events.
+//
+// Returns:
+//
+//	A function removing the subscription. It is safe to call several times.
+func (b *Bus) SubscribeAll(handler func(ctx context.Context, event interface{}) error) (unsubscribe func()) {
+	return b.subscribe(subscription{
+		accepts: func(interface{}) bool { return true },
+		handle:  handler,
+	})
+}
+
+// subscribe registers a subscription and returns its removal function.
+func (b *Bus) subscribe(sub subscription) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub.id = b.nextID
+	b.subscriptions = append(b.subscriptions, sub)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { b.unsubscribe(sub.id) })
+	}
+}
+
+// unsubscribe removes the subscription with the given id.
+func (b *Bus) unsubscribe(id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, sub := range b.subscriptions {
+		if sub.id == id {
+			// Copy instead of shifting in place: dispatches in flight may be
+			// iterating over the previous slice.
+			subscriptions := make([]subscription, 0, len(b.subscriptions)-1)
+			subscriptions = append(subscriptions, b.subscriptions[:i]...)
+			b.subscriptions = append(subscriptions, b.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish synchronously calls every handler subscribed to the event, in
+// subscription order. All handlers are called even if some of them fail.
+//
+// Parameters:
+//
+//	ctx: The context passed to the handlers.
+//	event: The event to publish.
+//
+// Returns:
+//
+//	Nil if every handler succeeded, or an `exception.Aggregate` grouping the
+//	handler failures otherwise. Panicking handlers are reported as
+//	`exception.Runtime` errors.
+func (b *Bus) Publish(ctx context.Context, event interface{}) error {
+	handlers := b.handlers(event)
+	errs := make([]error, 0, len(handlers))
+	for _, handle := range handlers {
+		errs = append(errs, call(ctx, handle, event))
+	}
+	return aggregate(event, errs)
+}
+
+// PublishAsync calls every handler subscribed to the event concurrently, each
+// in its own goroutine, and returns immediately.
+//
+// Parameters:
+//
+//	ctx: The context passed to the handlers. The handlers may outlive the
+//	     publisher, so a request-scoped context should usually be detached
+//	     with `context.WithoutCancel`.
+//	event: The event to publish.
+//
+// Returns:
+//
+//	A buffered channel receiving the outcome once every handler returned: nil
+//	or an `exception.Aggregate`, as for Publish. It may be ignored.
+func (b *Bus) PublishAsync(ctx context.Context, event interface{}) <-chan error {
+	handlers := b.handlers(event)
+	done := make(chan error, 1)
+	errs := make([]error, len(handlers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(handlers))
+	b.pending.Add(1)
+	for i, handle := range handlers {
+		go func() {
+			defer wg.Done()
+			errs[i] = call(ctx, handle, event)
+		}()
+	}
+	go func() {
+		defer b.pending.Done()
+		wg.Wait()
+		done <- aggregate(event, errs)
+	}()
+
+	return done
+}
+
+// Wait blocks until every asynchronous dispatch started so far completed,
+// e.g. during a graceful shutdown.
+func (b *Bus) Wait() {
+	b.pending.Wait()
+}
+
+// handlers returns the handlers accepting the event.
+func (b *Bus) handlers(event interface{}) []func(context.Context, interface{}) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var handlers []func(context.Context, interface{}) error
+	for _, sub := range b.subscriptions {
+		if sub.accepts(event) {
+			handlers = append(handlers, sub.handle)
+		}
+	}
+	return handlers
+}
+
+// call runs a handler, converting a panic into an `exception.Runtime`.
+func call(ctx context.Context, handle func(context.Context, interface{}) error, event interface{}) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = exception.NewRuntime(map[string]interface{}{
+				"message": "Event handler panicked.",
+				"details": map[string]interface{}{
+					"event": Name(event),
+					"panic": fmt.Sprint(recovered),
+					"error": "handler_panicked",
+				},
+			})
+		}
+	}()
+	return handle(ctx, event)
+}
+
+// aggregate groups the handler failures of an event, returning nil when
+// every handler succeeded.
+func aggregate(event interface{}, errs []error) error {
+	failed := 0
+	for _, err := range errs {
+		if !exception.IsNil(err) {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+
+	return exception.NewAggregate(map[string]interface{}{
+		"message": fmt.Sprintf("%d event handler(s) failed.", failed),
+		"details": map[string]interface{}{
+			"event":  Name(event),
+			"failed": failed,
+			"error":  "event_handlers_failed",
+		},
+	}, errs...)
+}
@@ -0,0 +1,168 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the typed accessors of the
+// entries of the Errors map of an exception.
+package exception
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// GetString returns the string entry of the `Errors` map under key.
+//
+// Parameters:
+//
+//	key: The key of the entry (e.g., "request_id").
+//
+// Returns:
+//
+//	The string and true, or "" and false when the entry is absent or is not
+//	a string.
+func (e *CoreException) GetString(key string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	return StringOf(e.Errors, key)
+}
+
+// GetInt returns the integer entry of the `Errors` map under key (see
+// IntOf for the accepted types).
+//
+// Parameters:
+//
+//	key: The key of the entry (e.g., "retry_after").
+//
+// Returns:
+//
+//	The integer and true, or 0 and false when the entry is absent or is not
+//	an integer.
+func (e *CoreException) GetInt(key string) (int, bool) {
+	if e == nil {
+		return 0, false
+	}
+	return IntOf(e.Errors, key)
+}
+
+// GetMap returns the `map[string]interface{}` entry of the `Errors` map
+// under key, such as "details".
+//
+// Parameters:
+//
+//	key: The key of the entry.
+//
+// Returns:
+//
+//	A copy of the map, like `GetDetails`, and true, or nil and false when the
+//	entry is absent or is not a `map[string]interface{}`.
+func (e *CoreException) GetMap(key string) (map[string]interface{}, bool) {
+	if e == nil {
+		return nil, false
+	}
+	return MapOf(e.Errors, key)
+}
+
+// GetSlice returns the slice entry of the `Errors` map under key (see
+// SliceOf for the accepted types).
+//
+// Parameters:
+//
+//	key: The key of the entry (e.g., "errors").
+//
+// Returns:
+//
+//	The items and true, or nil and false when the entry is absent or is not
+//	a slice.
+func (e *CoreException) GetSlice(key string) ([]interface{}, bool) {
+	if e == nil {
+		return nil, false
+	}
+	return SliceOf(e.Errors, key)
+}
+
+// StringOf returns the string entry of an errors map under key. It backs
+// `CoreException.GetString`, for the other implementations of
+// `CoreInterface`.
+func StringOf(errors map[string]interface{}, key string) (string, bool) {
+	value, ok := errors[key].(string)
+	return value, ok
+}
+
+// IntOf returns the integer entry of an errors map under key: any integer
+// type fitting an int, a float without fractional part (as decoded from
+// JSON) or a `json.Number` holding an integer. It backs
+// `CoreException.GetInt`.
+func IntOf(errors map[string]interface{}, key string) (int, bool) {
+	switch value := errors[key].(type) {
+	case int:
+		return value, true
+	case json.Number:
+		n, err := value.Int64()
+		if err != nil || int64(int(n)) != n {
+			return 0, false
+		}
+		return int(n), true
+	case float32:
+		return floatToInt(float64(value))
+	case float64:
+		return floatToInt(value)
+	case nil:
+		return 0, false
+	default:
+		v := reflect.ValueOf(value)
+		switch {
+		case v.CanInt():
+			if n := v.Int(); int64(int(n)) == n {
+				return int(n), true
+			}
+		case v.CanUint():
+			if n := v.Uint(); n <= math.MaxInt {
+				return int(n), true
+			}
+		}
+		return 0, false
+	}
+}
+
+// MapOf returns a copy of the `map[string]interface{}` entry of an errors
+// map under key, so that writing to it does not change the exception nor its
+// cached envelope. It backs `CoreException.GetMap`.
+func MapOf(errors map[string]interface{}, key string) (map[string]interface{}, bool) {
+	value, ok := errors[key].(map[string]interface{})
+	if !ok || value == nil {
+		return nil, false
+	}
+	return maps.Clone(value), true
+}
+
+// SliceOf returns a copy of the items of the slice entry of an errors map
+// under key, of any slice type (e.g., `[]interface{}`, `[]string`,
+// `[]map[string]interface{}`). It backs `CoreException.GetSlice`.
+func SliceOf(errors map[string]interface{}, key string) ([]interface{}, bool) {
+	switch value := errors[key].(type) {
+	case []interface{}:
+		return slices.Clone(value), value != nil
+	case nil:
+		return nil, false
+	default:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice || v.IsNil() {
+			return nil, false
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+		return items, true
+	}
+}
+
+// floatToInt converts a float without fractional part fitting an int.
+func floatToInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+		return 0, false
+	}
+	return int(f), true
+}
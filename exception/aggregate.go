@@ -0,0 +1,153 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines an exception type grouping several
+// errors that occurred together, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	"fmt"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and its constants.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Aggregate is a specific exception type that groups several errors that
+// occurred during a single operation (e.g., the failing handlers of a
+// published event), so that the caller can inspect each of them and decide
+// how to react.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting. The grouped errors are
+// exposed through `Unwrap() []error`, so `errors.Is` and `errors.As` see them.
+type Aggregate struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+
+	errs []error // The grouped errors, in the order they occurred.
+}
+
+// NewAggregate creates and returns a new `Aggregate` exception grouping the
+// given errors. Nil errors, and nil exceptions (see IsNil), are ignored.
+// The status code is the one shared by all the grouped exceptions, or
+// `status.BadRequest` when they are all client errors of different kinds, and
+// `status.InternalServerError` otherwise. The formatted grouped errors are
+// listed under the "errors" key of the `Errors` map.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing additional error
+//	        information. This map can include a "message" key which will be
+//	        used as the primary error message for the exception; it defaults
+//	        to "<n> errors occurred.".
+//	errs: The errors to group.
+//
+// Returns:
+//
+//	A pointer to a new `Aggregate` instance.
+func NewAggregate(errors map[string]interface{}, errs ...error) *Aggregate {
+	grouped := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if !IsNil(err) {
+			grouped = append(grouped, err)
+		}
+	}
+
+	if errors == nil {
+		errors = map[string]interface{}{}
+	}
+	if message, ok := errors["message"].(string); !ok || message == "" {
+		errors["message"] = fmt.Sprintf("%d errors occurred.", len(grouped))
+	}
+	formatted := make([]map[string]interface{}, 0, len(grouped))
+	for _, err := range grouped {
+		formatted = append(formatted, formatGrouped(err))
+	}
+	errors["errors"] = formatted
+
+	base := NewInstance(errors, aggregateStatus(grouped))
+	return notified(&Aggregate{CoreException: *base, errs: grouped})
+}
+
+// Unwrap returns the grouped errors, followed by the cause set by
+// `CoreException.WithCause`, if any, implementing the `Unwrap() []error`
+// contract of the multi-errors: `errors.Is` and `errors.As` inspect each of
+// them, at any depth, so that a caller can detect a specific failure inside
+// a batch (e.g., `errors.Is(err, ErrOrderNotFound)` for a sentinel raised by
+// one of the grouped operations).
+func (e *Aggregate) Unwrap() []error {
+	if e.cause == nil {
+		return e.errs
+	}
+	return append(e.errs[:len(e.errs):len(e.errs)], e.cause)
+}
+
+// Len returns the number of grouped errors.
+func (e *Aggregate) Len() int {
+	return len(e.errs)
+}
+
+// IsRetryable reports whether every grouped error is retryable, in which
+// case retrying the whole operation may succeed. See `IsRetryable`.
+func (e *Aggregate) IsRetryable() bool {
+	for _, err := range e.errs {
+		if !IsRetryable(err) {
+			return false
+		}
+	}
+	return len(e.errs) > 0
+}
+
+// GetErrorsForLog extends `CoreException.GetErrorsForLog` with the grouped
+// errors under CausesKey, rendered like the chain of a cause (see
+// `CoreException.WithCause`) and followed by it: the errors that are not
+// exceptions, hidden from `Format`, are logged with their raw message.
+func (e *Aggregate) GetErrorsForLog() map[string]interface{} {
+	entry := e.CoreException.GetErrorsForLog()
+	if causes := renderCauseList(e.Unwrap(), false, SnakeCase); causes != nil {
+		entry[CausesKey] = causes
+	}
+	return entry
+}
+
+// formatGrouped formats a grouped error for the "errors" entry. Errors that
+// are not exceptions are reported as internal errors, so that their message
+// is not leaked to clients.
+func formatGrouped(err error) map[string]interface{} {
+	coreErr, ok := exceptionOf(err)
+	if !ok {
+		return map[string]interface{}{
+			CurrentKeyNaming().Key("error_code"): status.InternalServerError.GetValue(),
+			"message":                            status.InternalServerError.GetDescription(),
+		}
+	}
+
+	formatted := coreErr.Format()
+	delete(formatted, "status")
+	return formatted
+}
+
+// aggregateStatus returns the status code of an aggregate of errors.
+func aggregateStatus(errs []error) status.StatusCode {
+	var shared status.StatusCode
+	allClient := len(errs) > 0
+	for i, err := range errs {
+		code := status.InternalServerError
+		if coreErr, ok := exceptionOf(err); ok {
+			code = status.StatusCode(coreErr.GetStatusCode())
+		}
+		if i == 0 {
+			shared = code
+		} else if code != shared {
+			shared = 0
+		}
+		allClient = allClient && code.GetClass() == status.ClientErrorClass
+	}
+
+	switch {
+	case shared != 0:
+		return shared
+	case allClient:
+		return status.BadRequest
+	default:
+		return status.InternalServerError
+	}
+}
@@ -0,0 +1,95 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the deployment context of the
+// application, added to the log output of every exception.
+package exception
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// AppInfo is the deployment context of the application: which service, in
+// which version, running where.
+type AppInfo struct {
+	Service     string // The name of the service (e.g., "orders").
+	Version     string // The version deployed (e.g., "1.4.2" or a commit hash).
+	Environment string // The environment (e.g., "production", "staging").
+	Hostname    string // The host or pod running the process.
+}
+
+// Fields returns the non-empty members of the context, under the "service",
+// "version", "environment" and "hostname" keys, or nil when they are all
+// empty.
+func (a AppInfo) Fields() map[string]interface{} {
+	var fields map[string]interface{}
+	for key, value := range map[string]string{
+		"service":     a.Service,
+		"version":     a.Version,
+		"environment": a.Environment,
+		"hostname":    a.Hostname,
+	} {
+		if value == "" {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]interface{}, 4)
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// appInfo holds the context installed by SetAppInfo, if any.
+var appInfo atomic.Pointer[AppInfo]
+
+// SetAppInfo sets the deployment context of the application, once at
+// startup, so that the `GetErrorsForLog()` output of every exception, and
+// therefore `logger.LogException` and the events of the report package,
+// carry it (see `AppInfo.Fields`), instead of the logging layer of each
+// service adding the same fields:
+//
+//	defer exception.SetAppInfo("orders", version, os.Getenv("APP_ENV"), "")()
+//
+// Parameters:
+//
+//	service: The name of the service.
+//	version: The version deployed.
+//	environment: The environment (e.g., "production").
+//	hostname: The host running the process; empty defaults to `os.Hostname`.
+//
+// Returns:
+//
+//	A function restoring the previous context.
+func SetAppInfo(service string, version string, environment string, hostname string) (restore func()) {
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	info := &AppInfo{Service: service, Version: version, Environment: environment, Hostname: hostname}
+	previous := appInfo.Swap(info)
+	return func() {
+		appInfo.Store(previous)
+	}
+}
+
+// CurrentAppInfo returns the context set by SetAppInfo, or a zero AppInfo.
+func CurrentAppInfo() AppInfo {
+	if info := appInfo.Load(); info != nil {
+		return *info
+	}
+	return AppInfo{}
+}
+
+// withAppInfo adds the members of the current context to a log entry,
+// without replacing its own entries.
+func withAppInfo(entry map[string]interface{}) map[string]interface{} {
+	info := appInfo.Load()
+	if info == nil {
+		return entry
+	}
+	for key, value := range info.Fields() {
+		if _, ok := entry[key]; !ok {
+			entry[key] = value
+		}
+	}
+	return entry
+}
@@ -37,5 +37,5 @@ func NewBadFunctionCall(errors map[string]interface{}) *BadFunctionCall {
 	// status of BadRequest, as this exception typically signifies a client-side
 	// issue with a function call.
 	base := NewInstance(errors, status.BadRequest)
-	return &BadFunctionCall{CoreException: *base}
+	return notified(&BadFunctionCall{CoreException: *base})
 }
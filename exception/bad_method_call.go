@@ -37,5 +37,5 @@ func NewBadMethodCall(errors map[string]interface{}) *BadMethodCall {
 	// status of BadRequest, as this exception typically signifies a client-side
 	// issue with a method call.
 	base := NewInstance(errors, status.BadRequest)
-	return &BadMethodCall{CoreException: *base}
+	return notified(&BadMethodCall{CoreException: *base})
 }
@@ -0,0 +1,41 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// cache misses, leveraging the core exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.NotFound` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// CacheMiss is a specific exception type that signifies that a cache holds
+// no entry for a key (never set, expired or evicted). Its own type lets
+// callers tell a miss, which is expected and usually answered by loading the
+// value, from an infrastructure failure of the cache.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type CacheMiss struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewCacheMiss creates and returns a new `CacheMiss` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.NotFound`, as the requested
+// entry does not exist.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the miss. This map can include a "message" key which will be
+//	        used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `CacheMiss` instance.
+func NewCacheMiss(errors map[string]interface{}) *CacheMiss {
+	// Initialize the base CoreException with the given errors and a default
+	// status of NotFound, as the cache holds no entry for the key.
+	base := NewInstance(errors, status.NotFound)
+	return notified(&CacheMiss{CoreException: *base})
+}
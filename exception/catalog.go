@@ -0,0 +1,275 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the catalog of the exception
+// types and its conformance self-test.
+package exception
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and its descriptions.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Constructor creates an exception from a map of error details, like the
+// `NewX` constructors of this package.
+type Constructor func(errors map[string]interface{}) CoreInterface
+
+// CatalogEntry is an exception type of the catalog.
+type CatalogEntry struct {
+	Name string      // The name of the type (e.g., "NotFound").
+	New  Constructor // The constructor of the type.
+}
+
+// catalog holds the exception types: those of this package, followed by the
+// ones added with RegisterType.
+var (
+	catalogMu sync.RWMutex
+	catalog   = []CatalogEntry{
+		{"BadFunctionCall", func(e map[string]interface{}) CoreInterface { return NewBadFunctionCall(e) }},
+		{"BadMethodCall", func(e map[string]interface{}) CoreInterface { return NewBadMethodCall(e) }},
+		{"CacheMiss", func(e map[string]interface{}) CoreInterface { return NewCacheMiss(e) }},
+		{"ConcurrencyConflict", func(e map[string]interface{}) CoreInterface { return NewConcurrencyConflict(e) }},
+		{"Configuration", func(e map[string]interface{}) CoreInterface { return NewConfiguration(e) }},
+		{"Conflict", func(e map[string]interface{}) CoreInterface { return NewConflict(e) }},
+		{"Domain", func(e map[string]interface{}) CoreInterface { return NewDomain(e) }},
+		{"Error", func(e map[string]interface{}) CoreInterface { return NewError(e) }},
+		{"FeatureDisabled", func(e map[string]interface{}) CoreInterface { return NewFeatureDisabled(e) }},
+		{"Forbidden", func(e map[string]interface{}) CoreInterface { return NewForbidden(e) }},
+		{"InvalidArgument", func(e map[string]interface{}) CoreInterface { return NewInvalidArgument(e) }},
+		{"Length", func(e map[string]interface{}) CoreInterface { return NewLength(e) }},
+		{"Logic", func(e map[string]interface{}) CoreInterface { return NewLogic(e) }},
+		{"NotAcceptable", func(e map[string]interface{}) CoreInterface { return NewNotAcceptable(e) }},
+		{"NotFound", func(e map[string]interface{}) CoreInterface { return NewNotFound(e) }},
+		{"OutOfBounds", func(e map[string]interface{}) CoreInterface { return NewOutOfBounds(e) }},
+		{"OutOfRange", func(e map[string]interface{}) CoreInterface { return NewOutOfRange(e) }},
+		{"Overflow", func(e map[string]interface{}) CoreInterface { return NewOverflow(e) }},
+		{"PreconditionFailed", func(e map[string]interface{}) CoreInterface { return NewPreconditionFailed(e) }},
+		{"Range", func(e map[string]interface{}) CoreInterface { return NewRange(e) }},
+		{"RangeNotSatisfiable", func(e map[string]interface{}) CoreInterface { return NewRangeNotSatisfiable(e) }},
+		{"RequestParseBody", func(e map[string]interface{}) CoreInterface { return NewRequestParseBody(e) }},
+		{"Runtime", func(e map[string]interface{}) CoreInterface { return NewRuntime(e) }},
+		{"ServiceUnavailable", func(e map[string]interface{}) CoreInterface { return NewServiceUnavailable(e) }},
+		{"Timeout", func(e map[string]interface{}) CoreInterface { return NewTimeout(e) }},
+		{"TooManyRequests", func(e map[string]interface{}) CoreInterface { return NewTooManyRequests(e) }},
+		{"Unauthorized", func(e map[string]interface{}) CoreInterface { return NewUnauthorized(e) }},
+		{"Underflow", func(e map[string]interface{}) CoreInterface { return NewUnderflow(e) }},
+		{"UnexpectedValue", func(e map[string]interface{}) CoreInterface { return NewUnexpectedValue(e) }},
+		{"UnsupportedMediaType", func(e map[string]interface{}) CoreInterface { return NewUnsupportedMediaType(e) }},
+		{"UnsupportedVersion", func(e map[string]interface{}) CoreInterface { return NewUnsupportedVersion(e) }},
+		{"UpstreamFailure", func(e map[string]interface{}) CoreInterface { return NewUpstreamFailure(e) }},
+		{"Validation", func(e map[string]interface{}) CoreInterface { return NewValidation(e) }},
+	}
+)
+
+// RegisterType adds an exception type to the catalog, so that VerifyCatalog
+// checks it along with those of this package, e.g. the domain exceptions of
+// an application.
+//
+// Parameters:
+//
+//	name: The name of the type (e.g., "InsufficientFunds"). It should be
+//	      unique, which VerifyCatalog checks.
+//	constructor: The constructor of the type.
+//
+// Returns:
+//
+//	A function removing the type from the catalog, e.g. at the end of a test.
+func RegisterType(name string, constructor Constructor) (unregister func()) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	catalog = append(catalog, CatalogEntry{Name: name, New: constructor})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			catalogMu.Lock()
+			defer catalogMu.Unlock()
+			// Remove the last entry of the name, the one added by this call
+			// unless the name was registered again since.
+			for i := len(catalog) - 1; i >= 0; i-- {
+				if catalog[i].Name == name {
+					catalog = append(catalog[:i:i], catalog[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// Catalog returns a copy of the catalog of the exception types, in the order
+// they were registered.
+func Catalog() []CatalogEntry {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	return append([]CatalogEntry{}, catalog...)
+}
+
+// TypeReport is the conformance report of an exception type.
+type TypeReport struct {
+	Name       string   // The name of the type in the catalog.
+	GoType     string   // The Go type created by its constructor (e.g., "*exception.NotFound").
+	StatusCode int      // Its default status code.
+	Problems   []string // Its conformance problems; empty when it conforms.
+}
+
+// CatalogReport is the conformance report of the catalog.
+type CatalogReport struct {
+	Types    []TypeReport // The report of each type, in the catalog order.
+	Problems []string     // The problems of the catalog itself (e.g., duplicate names).
+}
+
+// OK reports whether the catalog and all its types conform.
+func (r CatalogReport) OK() bool {
+	if len(r.Problems) > 0 {
+		return false
+	}
+	for _, t := range r.Types {
+		if len(t.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String lists the problems of the report, one per line, or returns "ok".
+func (r CatalogReport) String() string {
+	if r.OK() {
+		return "ok"
+	}
+	lines := append([]string{}, r.Problems...)
+	for _, t := range r.Types {
+		for _, problem := range t.Problems {
+			lines = append(lines, t.Name+": "+problem)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// VerifyCatalog checks that every exception type of the catalog conforms to
+// the contract of the package, so that test suites catch drift when
+// upgrading it:
+//
+//	if report := exception.VerifyCatalog(); !report.OK() {
+//		t.Errorf("exception catalog:\n%s", report)
+//	}
+//
+// Each type must be created as a non-nil pointer implementing
+// `CoreInterface`, under a unique name and Go type, with a known error
+// status (4xx or 5xx) as its default status. Its message must default to
+// the description of that status, a given "message" must replace it and be
+// removed from the errors, a stack trace must be captured, and `Format()`
+// must carry the status, error code and message. Error codes are shared by
+// design (e.g., 400 by every invalid input), so they are not required to be
+// unique; the factory of `FromStatus`, however, must cover every status code
+// it maps with a type keeping that code. The types are created by calling
+// their constructor, so the hook of `SetCreationHook` sees them.
+//
+// Returns:
+//
+//	The conformance report of the catalog.
+func VerifyCatalog() CatalogReport {
+	var report CatalogReport
+	coreType := reflect.TypeOf((*CoreInterface)(nil)).Elem()
+
+	names := map[string]bool{}
+	goTypes := map[string]string{}
+	for _, entry := range Catalog() {
+		t := verifyType(entry, coreType)
+		if names[entry.Name] {
+			report.Problems = append(report.Problems, fmt.Sprintf("the name %q is registered more than once", entry.Name))
+		}
+		names[entry.Name] = true
+		if other, ok := goTypes[t.GoType]; ok && t.GoType != "" {
+			report.Problems = append(report.Problems, fmt.Sprintf("%s and %s create the same type %s", other, entry.Name, t.GoType))
+		} else if t.GoType != "" {
+			goTypes[t.GoType] = entry.Name
+		}
+		report.Types = append(report.Types, t)
+	}
+
+	codes := make([]status.StatusCode, 0, len(statusFactories))
+	for code := range statusFactories {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	for _, code := range codes {
+		created := FromStatus(code, nil)
+		if created.GetStatusCode() != code.GetValue() {
+			report.Problems = append(report.Problems, fmt.Sprintf("FromStatus(%d) yields the status code %d", code, created.GetStatusCode()))
+		}
+		if goType := reflect.TypeOf(created).String(); goTypes[goType] == "" {
+			report.Problems = append(report.Problems, fmt.Sprintf("FromStatus(%d) yields %s, which is not in the catalog", code, goType))
+		}
+	}
+	return report
+}
+
+// verifyType checks an exception type of the catalog.
+func verifyType(entry CatalogEntry, coreType reflect.Type) (t TypeReport) {
+	t.Name = entry.Name
+	fail := func(format string, args ...interface{}) {
+		t.Problems = append(t.Problems, fmt.Sprintf(format, args...))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			fail("the constructor panics: %v", r)
+		}
+	}()
+
+	if entry.Name == "" {
+		fail("the name is empty")
+	}
+	if entry.New == nil {
+		fail("the constructor is nil")
+		return t
+	}
+
+	created := entry.New(map[string]interface{}{})
+	value := reflect.ValueOf(created)
+	if created == nil || value.Kind() != reflect.Pointer || value.IsNil() {
+		fail("the constructor does not return a non-nil pointer")
+		return t
+	}
+	t.GoType = value.Type().String()
+	if !value.Type().Implements(coreType) {
+		fail("%s does not implement CoreInterface", t.GoType)
+	}
+
+	t.StatusCode = created.GetStatusCode()
+	code, known := status.NewStatusCode(t.StatusCode)
+	switch {
+	case !known:
+		fail("the default status code %d is unknown", t.StatusCode)
+	case code.GetClass() != status.ClientErrorClass && code.GetClass() != status.ServerErrorClass:
+		fail("the default status code %d is not an error status", t.StatusCode)
+	case created.Error() != code.GetDescription():
+		fail("the default message %q is not the description of the status code %d", created.Error(), t.StatusCode)
+	}
+	if created.GetStackTrace() == "" {
+		fail("no stack trace is captured")
+	}
+
+	formatted := created.Format()
+	if formatted["status"] != status.ERROR {
+		fail("Format() has the status %v, want %v", formatted["status"], status.ERROR)
+	}
+	if code := formatted[CurrentKeyNaming().Key("error_code")]; code != t.StatusCode {
+		fail("Format() has the error code %v, want %d", code, t.StatusCode)
+	}
+	if formatted["message"] != created.Error() {
+		fail("Format() has the message %v, want %q", formatted["message"], created.Error())
+	}
+
+	withMessage := entry.New(map[string]interface{}{"message": "conformance"})
+	if withMessage.Error() != "conformance" {
+		fail("a given message is not used (got %q)", withMessage.Error())
+	}
+	if _, ok := withMessage.GetErrors()["message"]; ok {
+		fail("a given message is not removed from the errors")
+	}
+	return t
+}
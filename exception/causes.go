@@ -0,0 +1,170 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the causes wrapped by the
+// exceptions and their rendering in the envelopes and log entries.
+package exception
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// CausesKey is the key of the causes of an exception in its envelope and
+// log entry (see `CoreException.WithCause`).
+const CausesKey = "causes"
+
+// DefaultMaxCauseDepth is the number of causes rendered until
+// SetMaxCauseDepth changes it.
+const DefaultMaxCauseDepth = 5
+
+// maxCauseLinks bounds the errors visited in a chain of causes, whatever the
+// number of causes rendered, e.g. for chains of wrappers that are skipped.
+const maxCauseLinks = 64
+
+// maxCauseDepth holds the depth set by SetMaxCauseDepth, if any.
+var maxCauseDepth atomic.Pointer[int]
+
+// SetMaxCauseDepth sets the number of causes rendered under CausesKey; the
+// deeper causes are omitted.
+//
+// Parameters:
+//
+//	depth: The number of causes; zero or less renders none.
+//
+// Returns:
+//
+//	A function restoring the previous depth.
+func SetMaxCauseDepth(depth int) (restore func()) {
+	previous := maxCauseDepth.Swap(&depth)
+	return func() {
+		maxCauseDepth.Store(previous)
+	}
+}
+
+// WithCause sets the error that caused the exception, e.g. the failure of a
+// dependency translated into a domain exception. The exception unwraps to
+// it, so that `errors.Is` and `errors.As` reach it, and its chain is
+// rendered under CausesKey, one entry per error with its "type" (e.g.,
+// "*exception.NotFound"), its "message" and, for the exceptions, their
+// "error_code" and details "error" code:
+//
+//   - In the `GetErrorsForLog()` output, every error of the chain.
+//   - In the envelope of `Format()`, only the exceptions, as the messages of
+//     the other errors are not meant for the clients.
+//
+// The chain is walked through the `Unwrap() error` and `Unwrap() []error`
+// methods, up to the depth set by SetMaxCauseDepth. An errors map holding
+// CausesKey is rendered as is instead.
+//
+// Parameters:
+//
+//	cause: The cause; nil, or a nil exception (see IsNil), removes it.
+//
+// Returns:
+//
+//	Nil, or a `*Logic` exception leaving the exception unchanged when it is
+//	sealed (see Seal) or nil.
+func (e *CoreException) WithCause(cause error) error {
+	if err := e.checkMutable("WithCause"); err != nil {
+		return err
+	}
+	e.invalidateFormat()
+	if IsNil(cause) {
+		cause = nil
+	}
+	e.cause = cause
+	return nil
+}
+
+// Unwrap returns the cause set by WithCause, or nil.
+func (e *CoreException) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.cause
+}
+
+// renderCauses renders the chain of a cause (see WithCause).
+//
+// Parameters:
+//
+//	cause: The cause of an exception; it may be nil.
+//	exceptionsOnly: Whether the errors that are not exceptions are skipped,
+//	                for the envelope.
+//	naming: The naming of the built-in keys of the entries.
+//
+// Returns:
+//
+//	The entries, or nil when there is none.
+func renderCauses(cause error, exceptionsOnly bool, naming KeyNaming) []interface{} {
+	if cause == nil {
+		return nil
+	}
+	return renderCauseList([]error{cause}, exceptionsOnly, naming)
+}
+
+// renderCauseList renders the chains of several causes, one after the other,
+// e.g. the grouped errors of an Aggregate; the depth set by SetMaxCauseDepth
+// bounds the entries of all of them.
+func renderCauseList(list []error, exceptionsOnly bool, naming KeyNaming) []interface{} {
+	depth := DefaultMaxCauseDepth
+	if set := maxCauseDepth.Load(); set != nil {
+		depth = *set
+	}
+	if len(list) == 0 || depth <= 0 {
+		return nil
+	}
+
+	var causes []interface{}
+	visited := 0
+	var walk func(err error)
+	walk = func(err error) {
+		if IsNil(err) || len(causes) >= depth || visited >= maxCauseLinks {
+			return
+		}
+		visited++
+
+		if coreErr, ok := err.(CoreInterface); ok {
+			entry := map[string]interface{}{
+				"type":                   fmt.Sprintf("%T", err),
+				"message":                coreErr.Error(),
+				naming.Key("error_code"): coreErr.GetStatusCode(),
+			}
+			if code := coreErr.GetDetailsMessage(); code != "" {
+				entry["error"] = code
+			}
+			causes = append(causes, entry)
+		} else if !exceptionsOnly {
+			causes = append(causes, map[string]interface{}{
+				"type":    fmt.Sprintf("%T", err),
+				"message": messageOf(err),
+			})
+		}
+
+		switch wrapper := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range wrapper.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(err))
+		}
+	}
+	for _, cause := range list {
+		walk(cause)
+	}
+	return causes
+}
+
+// messageOf returns the message of an error that is not an exception. The
+// message of a wrapper holding a nil exception, e.g. `errors.Join(typedNil)`,
+// cannot be built, as the wrapper calls its Error method; "<nil>" is
+// returned instead.
+func messageOf(err error) (message string) {
+	defer func() {
+		if recover() != nil {
+			message = "<nil>"
+		}
+	}()
+	return err.Error()
+}
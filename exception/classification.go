@@ -0,0 +1,95 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the classification of failures
+// as transient or permanent, for the job runners and queue consumers
+// deciding between a retry and the dead-letter queue.
+package exception
+
+// Classification tells whether a failure is worth retrying.
+type Classification string
+
+const (
+	TransientFailure Classification = "transient" // TransientFailure means retrying may succeed (e.g., a timeout).
+	PermanentFailure Classification = "permanent" // PermanentFailure means retrying fails again (e.g., a validation error).
+)
+
+// classified overrides the classification of the error it wraps.
+type classified struct {
+	err       error
+	transient bool
+}
+
+// Error returns the message of the wrapped error.
+func (c *classified) Error() string {
+	return c.err.Error()
+}
+
+// Unwrap returns the wrapped error, so that the exception it carries is
+// still found by `errors.As` and written as usual.
+func (c *classified) Unwrap() error {
+	return c.err
+}
+
+// IsRetryable reports whether the wrapped error was marked transient.
+func (c *classified) IsRetryable() bool {
+	return c.transient
+}
+
+// Permanent marks an error as permanent, whatever its status code: the
+// consumer of a message failing with it dead-letters the message instead of
+// retrying it, e.g. for a 503 of a dependency that will never accept the
+// payload:
+//
+//	return exception.Permanent(err)
+//
+// Parameters:
+//
+//	err: The error to mark. A nil error yields nil.
+//
+// Returns:
+//
+//	An error wrapping err, whose classification is `PermanentFailure`.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{err: err}
+}
+
+// Transient marks an error as transient, whatever its status code, e.g. for
+// a 409 of an optimistic lock that a new attempt resolves.
+//
+// Parameters:
+//
+//	err: The error to mark. A nil error yields nil.
+//
+// Returns:
+//
+//	An error wrapping err, whose classification is `TransientFailure`.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{err: err, transient: true}
+}
+
+// Classify classifies a failure as transient or permanent. The first error
+// of the chain implementing `Retryable` decides: the outermost mark of
+// Permanent or Transient, or a type such as `ConcurrencyConflict`;
+// otherwise the status code of the exception is classified (see
+// `IsRetryableStatus`), and any other error is permanent. It agrees with
+// `IsRetryable`, which the retry loops use.
+//
+// Parameters:
+//
+//	err: The error to classify. A nil error is permanent: there is nothing
+//	     to retry.
+//
+// Returns:
+//
+//	`TransientFailure` or `PermanentFailure`.
+func Classify(err error) Classification {
+	if IsRetryable(err) {
+		return TransientFailure
+	}
+	return PermanentFailure
+}
@@ -0,0 +1,89 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the classifiers converting the
+// errors of third-party libraries into exceptions.
+package exception
+
+import (
+	"sync"
+)
+
+// Classifier converts an error that is not an exception, typically of a
+// third-party library (e.g., a cloud SDK), into the matching exception. It
+// returns nil for the errors it does not recognize.
+type Classifier func(err error) CoreInterface
+
+// classifiers holds the classifiers registered with RegisterClassifier.
+var (
+	classifiersMu sync.RWMutex
+	classifiers   []classifierEntry
+)
+
+// classifierEntry is a registered classifier, identified for its removal.
+type classifierEntry struct {
+	classify Classifier
+	id       *int
+}
+
+// RegisterClassifier adds a classifier to the pipeline of FromError, after
+// the ones already registered.
+//
+// Parameters:
+//
+//	classifier: The classifier to add.
+//
+// Returns:
+//
+//	A function removing the classifier, e.g. at the end of a test.
+func RegisterClassifier(classifier Classifier) (unregister func()) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	id := new(int)
+	classifiers = append(classifiers, classifierEntry{classify: classifier, id: id})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			classifiersMu.Lock()
+			defer classifiersMu.Unlock()
+			for i, entry := range classifiers {
+				if entry.id == id {
+					classifiers = append(classifiers[:i:i], classifiers[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// FromError converts an error into an exception: the exception of its chain
+// when it carries one, otherwise the exception returned by the first
+// registered classifier recognizing it (see RegisterClassifier), and
+// otherwise the internal server error exception of Normalize, which unwraps
+// to err.
+//
+// Parameters:
+//
+//	err: The error to convert.
+//
+// Returns:
+//
+//	The exception, or nil for a nil error or nil exception (see IsNil).
+func FromError(err error) CoreInterface {
+	if IsNil(err) {
+		return nil
+	}
+	if coreErr, ok := exceptionOf(err); ok {
+		return coreErr
+	}
+
+	classifiersMu.RLock()
+	registered := classifiers
+	classifiersMu.RUnlock()
+	for _, entry := range registered {
+		if classified := entry.classify(err); classified != nil {
+			return classified
+		}
+	}
+
+	coreErr, _ := exceptionOf(Normalize(err))
+	return coreErr
+}
@@ -0,0 +1,49 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// writes lost to a concurrent transaction, leveraging the core exception
+// handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Conflict` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// ConcurrencyConflict is a specific exception type that signifies that an
+// operation lost a race with a concurrent one (e.g., a serialization failure
+// of the database, or a stale optimistic lock). Unlike a `Conflict` with the
+// stored state, such as a duplicate key, the operation may succeed when
+// replayed, so it is retryable (see `IsRetryable`).
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type ConcurrencyConflict struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewConcurrencyConflict creates and returns a new `ConcurrencyConflict`
+// exception. It initializes the embedded `CoreException` with the provided
+// error details and sets the default status code to `status.Conflict`, as
+// the operation clashes with a concurrent modification of the resource.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the conflict. This map can include a "message" key which will
+//	        be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `ConcurrencyConflict` instance.
+func NewConcurrencyConflict(errors map[string]interface{}) *ConcurrencyConflict {
+	// Initialize the base CoreException with the given errors and a default
+	// status of Conflict, as a concurrent operation won the race.
+	base := NewInstance(errors, status.Conflict)
+	return notified(&ConcurrencyConflict{CoreException: *base})
+}
+
+// IsRetryable reports that the operation may succeed when replayed, for
+// `IsRetryable`.
+func (e *ConcurrencyConflict) IsRetryable() bool {
+	return true
+}
@@ -0,0 +1,45 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// invalid application configuration, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.InternalServerError` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Configuration is a specific exception type that signifies that the
+// configuration of the application (e.g., its environment variables or
+// configuration files) is missing or invalid. It is typically raised at
+// startup with an "errors" entry listing every offending setting, so that
+// operators can fix them all at once.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type Configuration struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewConfiguration creates and returns a new `Configuration` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.InternalServerError`. This
+// status code is appropriate as a misconfiguration is a fault of the
+// deployment, not of the caller.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the configuration problems, usually including a per-setting
+//	        "errors" map. This map can include a "message" key which will be
+//	        used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `Configuration` instance.
+func NewConfiguration(errors map[string]interface{}) *Configuration {
+	// Initialize the base CoreException with the given errors and a default
+	// status of InternalServerError, as the application cannot run correctly.
+	base := NewInstance(errors, status.InternalServerError)
+	return notified(&Configuration{CoreException: *base})
+}
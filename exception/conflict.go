@@ -0,0 +1,42 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// conflicts with the current state of a resource, leveraging the core
+// exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Conflict` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Conflict is a specific exception type that signifies that an operation
+// conflicts with the current state of a resource (e.g., a duplicate unique
+// key, or a concurrent modification detected through a version number).
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type Conflict struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewConflict creates and returns a new `Conflict` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.Conflict`. This status code
+// tells clients that the request may succeed once the conflict is resolved,
+// for instance after reloading the resource.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the conflict. This map can include a "message" key which will
+//	        be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `Conflict` instance.
+func NewConflict(errors map[string]interface{}) *Conflict {
+	// Initialize the base CoreException with the given errors and a default
+	// status of Conflict, as the operation clashes with the resource state.
+	base := NewInstance(errors, status.Conflict)
+	return notified(&Conflict{CoreException: *base})
+}
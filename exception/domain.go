@@ -37,5 +37,5 @@ func NewDomain(errors map[string]interface{}) *Domain {
 	// Initialize the base CoreException with the given errors and a default
 	// status of BadRequest, as domain errors often stem from invalid client input.
 	base := NewInstance(errors, status.BadRequest)
-	return &Domain{CoreException: *base}
+	return notified(&Domain{CoreException: *base})
 }
@@ -0,0 +1,73 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the v2 envelope of the
+// exceptions, nesting the exception under "error" next to a "meta" block.
+package exception
+
+import (
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.ERROR` constant of the envelope.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// metaKeys are the built-in keys of the envelope moved to the "meta" block
+// of the v2 envelope: the identifiers added by `ctxutil.Annotate`.
+var metaKeys = []string{"request_id", "correlation_id", "tenant_id", "trace_id", "span_id"}
+
+// FormatV2 returns the v2 envelope of an exception, which nests the entries
+// of its `Format()` output under "error", with the status code as "code",
+// and moves the identifiers of the request to a "meta" block stamped with
+// the time of the failure:
+//
+//	{
+//		"status": "error",
+//		"error": {"code": 404, "message": "Not Found", "details": {"error": "ORDER_NOT_FOUND"}},
+//		"meta": {"timestamp": "2024-05-01T12:00:00Z", "request_id": "req-1", "trace_id": "4bf9..."}
+//	}
+//
+// It backs the v2 envelopes of the response writers (see
+// `response.SetEnvelopeVersion`), and is described by EnvelopeSchemaV2. The
+// keys of the identifiers follow `SetKeyNaming`.
+//
+// Parameters:
+//
+//	e: The exception.
+//	timestamp: The time of the failure, rendered in UTC as RFC 3339.
+//
+// Returns:
+//
+//	The v2 envelope.
+func FormatV2(e CoreInterface, timestamp time.Time) map[string]interface{} {
+	naming := CurrentKeyNaming()
+	formatted := e.Format()
+	body := make(map[string]interface{}, len(formatted))
+	meta := map[string]interface{}{"timestamp": timestamp.UTC().Format(time.RFC3339Nano)}
+	for key, value := range formatted {
+		switch {
+		case key == "status":
+		case key == naming.Key("error_code"):
+			body["code"] = value
+		case isMetaKey(key, naming):
+			meta[key] = value
+		default:
+			body[key] = value
+		}
+	}
+	return map[string]interface{}{
+		"status": status.ERROR,
+		"error":  body,
+		"meta":   meta,
+	}
+}
+
+// isMetaKey reports whether a key of an envelope is one of metaKeys named
+// by naming.
+func isMetaKey(key string, naming KeyNaming) bool {
+	for _, metaKey := range metaKeys {
+		if key == naming.Key(metaKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -40,5 +40,5 @@ func NewError(errors map[string]interface{}) *Error {
 	// status of InternalServerError, as this is a generic error often indicating
 	// a server-side problem.
 	base := NewInstance(errors, status.InternalServerError)
-	return &Error{CoreException: *base}
+	return notified(&Error{CoreException: *base})
 }
@@ -0,0 +1,222 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the registry of the namespaced
+// application error codes, such as "USR-0042".
+package exception
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type of the error codes.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// ErrorCode is a namespaced application error code: the namespace of a
+// bounded context followed by a four-digit number (e.g., "USR-0042"). Its
+// string form is the "error" entry of the details of the exceptions raised
+// with it.
+type ErrorCode struct {
+	Namespace   string            // The prefix of the bounded context (e.g., "USR"): uppercase letters and digits, starting with a letter.
+	Number      int               // The numeric suffix, from 1 to 9999 (e.g., 42).
+	Description string            // What the code means, for the documentation and as the default message.
+	Status      status.StatusCode // The status code of the exceptions raised with the code.
+	DocsURL     string            // The page documenting the code; may be empty.
+}
+
+// String returns the code, e.g. "USR-0042".
+func (c ErrorCode) String() string {
+	return fmt.Sprintf("%s-%04d", c.Namespace, c.Number)
+}
+
+// New creates an exception raised with the code: the exception type
+// matching its status code (see `FromStatus`), with the code as the "error"
+// entry of its details and the DocsURL, when set, as the "docs_url" entry.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error
+//	        information. This map can include a "message" key which will be
+//	        used as the primary error message; it defaults to the
+//	        Description of the code.
+//
+// Returns:
+//
+//	The exception.
+func (c ErrorCode) New(errors map[string]interface{}) CoreInterface {
+	errors = withDetailsError(errors, c.String())
+	if message, ok := errors["message"].(string); (!ok || message == "") && c.Description != "" {
+		errors["message"] = c.Description
+	}
+	if c.DocsURL != "" {
+		errors["details"].(map[string]interface{})["docs_url"] = c.DocsURL
+	}
+	return FromStatus(c.Status, errors)
+}
+
+// validate checks the namespace, number and status of a code.
+func (c ErrorCode) validate() string {
+	if c.Namespace == "" {
+		return "the namespace is empty"
+	}
+	for i, r := range c.Namespace {
+		if !('A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return fmt.Sprintf("the namespace %q is not made of uppercase letters and digits", c.Namespace)
+		}
+	}
+	if c.Number < 1 || c.Number > 9999 {
+		return fmt.Sprintf("the number %d is not between 1 and 9999", c.Number)
+	}
+	if c.Status.GetClass() != status.ClientErrorClass && c.Status.GetClass() != status.ServerErrorClass {
+		return fmt.Sprintf("the status %d is not an error status", c.Status)
+	}
+	return ""
+}
+
+// codes holds the registered error codes by their string form.
+var (
+	codesMu sync.RWMutex
+	codes   = map[string]ErrorCode{}
+)
+
+// RegisterCode adds an error code to the registry. Registering a code twice
+// is a collision, typically two teams picking the same number in a
+// namespace, and is rejected.
+//
+// Parameters:
+//
+//	code: The code to register.
+//
+// Returns:
+//
+//	A function removing the code from the registry, e.g. at the end of a
+//	test, and a nil error; or a nil function and a `*Logic` exception when
+//	the code is invalid or already registered.
+func RegisterCode(code ErrorCode) (unregister func(), err error) {
+	if problem := code.validate(); problem != "" {
+		return nil, NewLogic(map[string]interface{}{
+			"message": fmt.Sprintf("The error code %s is invalid: %s.", code, problem),
+			"details": map[string]interface{}{"code": code.String(), "error": "invalid_error_code"},
+		})
+	}
+
+	key := code.String()
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	if existing, ok := codes[key]; ok {
+		return nil, NewLogic(map[string]interface{}{
+			"message": fmt.Sprintf("The error code %s is already registered (%s).", key, existing.Description),
+			"details": map[string]interface{}{"code": key, "error": "error_code_collision"},
+		})
+	}
+	codes[key] = code
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			codesMu.Lock()
+			defer codesMu.Unlock()
+			delete(codes, key)
+		})
+	}, nil
+}
+
+// MustRegisterCode registers an error code like RegisterCode, panicking on
+// an invalid code or a collision, so that the codes declared at package
+// level fail at startup:
+//
+//	var ErrUserNotFound = exception.MustRegisterCode(exception.ErrorCode{
+//		Namespace: "USR", Number: 42, Status: status.NotFound,
+//		Description: "The user was not found.",
+//	})
+//
+//	return ErrUserNotFound.New(map[string]interface{}{"user_id": id})
+//
+// Parameters:
+//
+//	code: The code to register.
+//
+// Returns:
+//
+//	The registered code.
+func MustRegisterCode(code ErrorCode) ErrorCode {
+	if _, err := RegisterCode(code); err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// LookupCode returns a registered error code.
+//
+// Parameters:
+//
+//	code: The string form of the code (e.g., "USR-0042").
+//
+// Returns:
+//
+//	The code and true, or a zero ErrorCode and false when it is not
+//	registered.
+func LookupCode(code string) (ErrorCode, bool) {
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+	registered, ok := codes[code]
+	return registered, ok
+}
+
+// Codes returns the registered error codes, sorted by namespace and number,
+// e.g. to generate their documentation.
+func Codes() []ErrorCode {
+	codesMu.RLock()
+	registered := make([]ErrorCode, 0, len(codes))
+	for _, code := range codes {
+		registered = append(registered, code)
+	}
+	codesMu.RUnlock()
+
+	sort.Slice(registered, func(i, j int) bool {
+		if registered[i].Namespace != registered[j].Namespace {
+			return registered[i].Namespace < registered[j].Namespace
+		}
+		return registered[i].Number < registered[j].Number
+	})
+	return registered
+}
+
+// FromCode creates the exception of a registered error code (see
+// `ErrorCode.New`), e.g. to rebuild the exception named by the error
+// response of another service.
+//
+// Parameters:
+//
+//	code: The string form of the code (e.g., "USR-0042").
+//	errors: A map of string to interface{} containing detailed error
+//	        information, as for `ErrorCode.New`.
+//
+// Returns:
+//
+//	The exception of the code, or a generic `Error` carrying the code as the
+//	"error" entry of its details when it is not registered.
+func FromCode(code string, errors map[string]interface{}) CoreInterface {
+	if registered, ok := LookupCode(code); ok {
+		return registered.New(errors)
+	}
+	return NewError(withDetailsError(errors, code))
+}
+
+// withDetailsError returns errors, or a new map when it is nil, with a copy
+// of its details holding code as the "error" entry.
+func withDetailsError(errors map[string]interface{}, code string) map[string]interface{} {
+	if errors == nil {
+		errors = map[string]interface{}{}
+	}
+	details, _ := errors["details"].(map[string]interface{})
+	details = maps.Clone(details)
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["error"] = code
+	errors["details"] = details
+	return errors
+}
@@ -5,10 +5,11 @@
 package exception
 
 import (
+	"maps"
+
 	// "github.com/osirisgate/golang-core/status" is expected to provide
 	// the 'status.StatusCode' type and the 'status.ERROR' constant.
 	"github.com/osirisgate/golang-core/enum"
-	"runtime/debug" // Used for capturing stack trace information.
 )
 
 // CoreInterface defines the contract that any core exception type must satisfy.
@@ -27,9 +28,10 @@ type CoreInterface interface {
 	// providing context about the error.
 	GetErrors() map[string]interface{}
 
-	// GetDetails extracts and returns a specific "details" map from the main
-	// Errors map, if it exists and is of the correct type. This is useful for
-	// retrieving nested error information. Returns an empty map if not found.
+	// GetDetails extracts and returns a copy of the specific "details" map
+	// from the main Errors map, if it exists and is of the correct type. This
+	// is useful for retrieving nested error information; modifying the copy
+	// does not alter the exception. Returns an empty map if not found.
 	GetDetails() map[string]interface{}
 
 	// GetDetailsMessage attempts to extract a string value under the "error" key
@@ -38,13 +40,27 @@ type CoreInterface interface {
 	// if the key is not found or is not a string.
 	GetDetailsMessage() string
 
+	// GetString, GetInt, GetMap and GetSlice return the entry of the errors
+	// map under a key with its expected type, and false when it is absent or
+	// of another type, sparing the callers the type assertions.
+	GetString(key string) (string, bool)
+	GetInt(key string) (int, bool)
+	GetMap(key string) (map[string]interface{}, bool)
+	GetSlice(key string) ([]interface{}, bool)
+
 	// GetStatusCode returns the integer value of the HTTP-like status code
 	// associated with this exception.
 	GetStatusCode() int
 
+	// Headers returns the response headers implied by the exception (e.g.,
+	// Retry-After for a 429), which the response writers set along with its
+	// status code. Returns nil when there is none.
+	Headers() map[string]string
+
 	// GetErrorsForLog returns a map containing comprehensive error information
 	// specifically formatted for logging purposes. This includes the message,
-	// status code, full errors map, and the stack trace.
+	// status code, full errors map, the stack trace, and the deployment
+	// context set by SetAppInfo.
 	GetErrorsForLog() map[string]interface{}
 
 	// GetStackTrace returns the full stack trace captured at the moment
@@ -55,12 +71,23 @@ type CoreInterface interface {
 // CoreException is the concrete implementation of the CoreInterface.
 // It encapsulates all relevant information about an error, including its
 // primary message, a status code, a flexible map for additional error details,
-// and the execution stack trace.
+// and the execution stack trace. Its methods are safe on a nil
+// `*CoreException`, returning empty values, and its setters a `*Logic`
+// exception; see IsNil for the nil pointers of the types embedding it.
 type CoreException struct {
 	Message    string                 // The primary human-readable message describing the exception.
 	StatusCode status.StatusCode      // The HTTP-like status code associated with the exception (e.g., 400, 500).
 	Errors     map[string]interface{} // A flexible map to hold additional, granular error information.
-	StackTrace string                 // The stack trace captured when this exception was initialized.
+	StackTrace string                 // A stack trace set explicitly, replacing the captured one (see GetStackTrace).
+	StatusText string                 // A reason phrase replacing the description of StatusCode (see GetStatusText); may be empty.
+
+	stack  *stack       // The stack captured when this exception was initialized, formatted on demand.
+	cache  *formatCache // The envelope cached by CacheFormat, if any.
+	pooled bool         // Whether the exception was acquired from the pool and not yet released.
+	sealed bool         // Whether the exception was sealed, rejecting the mutations (see Seal).
+
+	request *RequestSnapshot // The request attached by WithRequest, if any.
+	cause   error            // The cause set by WithCause, if any.
 }
 
 // NewInstance creates and returns a new CoreException.
@@ -69,74 +96,194 @@ type CoreException struct {
 //
 // Parameters:
 //
-//	errors: A map that can contain various error details. If this map includes
-//	        a key "message" with a string value, that value will be used as
-//	        the CoreException's main Message, and the "message" key will be
-//	        removed from the `errors` map itself.
+//	errors: A map that can contain various error details; nil is treated as
+//	        an empty map. If this map includes a key "message" with a string
+//	        value, that value will be used as the CoreException's main
+//	        Message, and the "message" key will be removed from the `errors`
+//	        map itself. Likewise, a "status_text" key (see `StatusTextKey`)
+//	        is moved to `StatusText`. When interning is enabled (see
+//	        `SetInterning`), its strings are interned in place.
 //	defaultStatusCode: The default `status.StatusCode` to use if no explicit
 //	                   message is provided within the `errors` map. Its
-//	                   description will be used as the message in such cases.
+//	                   description, or the status text, will be used as the
+//	                   message in such cases.
 //
 // Returns:
 //
-//	A pointer to a newly created CoreException instance.
+//	A pointer to a newly created CoreException instance. It is not passed to
+//	the hook installed by `SetCreationHook`: the constructors embedding it
+//	call `NotifyCreation` with the exception they return.
 func NewInstance(errors map[string]interface{}, defaultStatusCode status.StatusCode) *CoreException {
+	if errors == nil {
+		errors = map[string]interface{}{}
+	}
+	statusText := takeStatusText(errors)
 	message, ok := errors["message"].(string)
 	if !ok || message == "" {
 		// If no message is provided in the errors map, or it's empty,
-		// use the description of the default status code as the message.
-		message = defaultStatusCode.GetDescription()
+		// use the reason phrase of the default status code as the message.
+		message = statusText
+		if message == "" {
+			message = defaultStatusCode.GetDescription()
+		}
 	} else {
 		// If a message was provided in the errors map, remove it to avoid redundancy
 		// in the `Errors` field, as it's now the main `Message`.
 		delete(errors, "message")
 	}
+	if interning.Load() {
+		// Share a single copy of the strings repeated by many exceptions (see
+		// SetInterning).
+		message = Intern(message)
+		internErrors(errors)
+	}
 
-	return &CoreException{
+	e := &CoreException{
 		Message:    message,
 		StatusCode: defaultStatusCode,
+		StatusText: statusText,
 		Errors:     errors,
-		// Capture the current goroutine's stack trace at the point of exception creation.
-		StackTrace: string(debug.Stack()),
 	}
+	if sampled(defaultStatusCode, message) {
+		// Capture the program counters of the current goroutine at the point
+		// of exception creation, starting at the caller of NewInstance; they
+		// are only formatted when the stack trace is read.
+		e.stack = captureStack(1)
+	}
+	return e
 }
 
 // Error implements the `error` interface for CoreException.
 // It returns the primary message of the exception.
-func (e CoreException) Error() string {
+func (e *CoreException) Error() string {
+	if e == nil {
+		return ""
+	}
 	return e.Message
 }
 
 // GetStatusCode returns the integer representation of the exception's
 // `StatusCode`.
-func (e CoreException) GetStatusCode() int {
+func (e *CoreException) GetStatusCode() int {
+	if e == nil {
+		return 0
+	}
 	return e.StatusCode.GetValue()
 }
 
 // GetErrors returns the map containing additional error details associated
 // with the exception.
-func (e CoreException) GetErrors() map[string]interface{} {
+func (e *CoreException) GetErrors() map[string]interface{} {
+	if e == nil {
+		return nil
+	}
 	return e.Errors
 }
 
+// SetError adds or replaces an entry of the `Errors` map, creating the map if
+// needed. It is intended for enriching an exception with contextual
+// information (request identifiers, tenant, etc.) after it has been created.
+//
+// Parameters:
+//
+//	key: The key of the entry to set.
+//	value: The value to store under the key.
+//
+// Returns:
+//
+//	Nil, or a `*Logic` exception leaving the exception unchanged when it is
+//	sealed (see Seal) or nil.
+func (e *CoreException) SetError(key string, value interface{}) error {
+	if err := e.checkMutable("SetError"); err != nil {
+		return err
+	}
+	e.invalidateFormat()
+	if e.Errors == nil {
+		e.Errors = map[string]interface{}{}
+	}
+	e.Errors[key] = value
+	return nil
+}
+
+// SetMessage replaces the primary message of the exception. It is intended
+// for rendering the message for a specific audience, such as translating it
+// into the locale of the client, after the exception has been created.
+//
+// Parameters:
+//
+//	message: The new primary message.
+//
+// Returns:
+//
+//	Nil, or a `*Logic` exception leaving the exception unchanged when it is
+//	sealed (see Seal) or nil.
+func (e *CoreException) SetMessage(message string) error {
+	if err := e.checkMutable("SetMessage"); err != nil {
+		return err
+	}
+	e.invalidateFormat()
+	e.Message = message
+	return nil
+}
+
 // GetDetails attempts to retrieve a sub-map named "details" from the `Errors` map.
 // This is commonly used for more granular, structured error information.
+// It returns a shallow copy, so that the callers modifying it do not alter
+// the `Format()` output; use `SetDetail` to modify the details.
 // Returns an empty map if "details" is not present or is not a map[string]interface{}.
-func (e CoreException) GetDetails() map[string]interface{} {
-	if details, ok := e.Errors["details"].(map[string]interface{}); ok {
-		return details
+func (e *CoreException) GetDetails() map[string]interface{} {
+	if details := e.details(); details != nil {
+		return maps.Clone(details)
 	}
 	return map[string]interface{}{} // Return an empty map if details are not found or not of the expected type.
 }
 
+// details returns the "details" map of the `Errors` map, not a copy, or nil.
+func (e *CoreException) details() map[string]interface{} {
+	if e == nil {
+		return nil
+	}
+	details, _ := e.Errors["details"].(map[string]interface{})
+	return details
+}
+
+// SetDetail adds or replaces an entry of the "details" map of the `Errors`
+// map, creating the map if needed, e.g. to add the identifier of the
+// missing resource to an exception raised deeper in the call chain.
+//
+// Parameters:
+//
+//	key: The key of the entry to set (e.g., "order_id").
+//	value: The value to store under the key.
+//
+// Returns:
+//
+//	Nil, or a `*Logic` exception leaving the exception unchanged when it is
+//	sealed (see Seal) or nil.
+func (e *CoreException) SetDetail(key string, value interface{}) error {
+	if err := e.checkMutable("SetDetail"); err != nil {
+		return err
+	}
+	e.invalidateFormat()
+	details := e.details()
+	if details == nil {
+		if e.Errors == nil {
+			e.Errors = map[string]interface{}{}
+		}
+		details = map[string]interface{}{}
+		e.Errors["details"] = details
+	}
+	details[key] = value
+	return nil
+}
+
 // GetDetailsMessage attempts to extract a string message from the "error" key
 // within the "details" map. This provides a way to get a specific error message
 // that might be nested within the error details.
 // Returns an empty string if the "details" map or the "error" key within it
 // is not found or not a string.
-func (e CoreException) GetDetailsMessage() string {
-	details := e.GetDetails()
-	if msg, ok := details["error"].(string); ok {
+func (e *CoreException) GetDetailsMessage() string {
+	if msg, ok := e.details()["error"].(string); ok {
 		return msg
 	}
 	return "" // Return an empty string if the specific error message is not found.
@@ -144,21 +291,56 @@ func (e CoreException) GetDetailsMessage() string {
 
 // GetErrorsForLog returns a map specifically formatted for logging purposes.
 // This map includes the main message, the status code, the full `Errors` map,
-// and the `StackTrace`, providing a complete context for logging systems.
-func (e CoreException) GetErrorsForLog() map[string]interface{} {
-	return map[string]interface{}{
+// the stack trace (see `GetStackTrace`), the request attached by
+// `WithRequest` under "request", the chain of the cause set by `WithCause`
+// under "causes", and the deployment context set by
+// `SetAppInfo`, providing a complete context for logging systems.
+func (e *CoreException) GetErrorsForLog() map[string]interface{} {
+	if e == nil {
+		return map[string]interface{}{}
+	}
+	entry := map[string]interface{}{
 		"message":     e.Message,
 		"status_code": e.StatusCode.GetValue(),
 		"errors":      e.Errors,
-		"stack_trace": e.StackTrace,
+		"stack_trace": e.GetStackTrace(),
+	}
+	if e.request != nil {
+		entry[RequestLogKey] = e.request.Fields()
+	}
+	if causes := renderCauses(e.cause, false, SnakeCase); causes != nil {
+		entry[CausesKey] = causes
 	}
+	return withAppInfo(entry)
 }
 
 // GetStackTrace returns the complete stack trace string associated with
 // the exception. This is invaluable for debugging and pinpointing the
-// origin of the error.
-func (e CoreException) GetStackTrace() string {
-	return e.StackTrace
+// origin of the error. It is the `StackTrace` field when set explicitly,
+// otherwise the stack captured by `NewInstance`, formatted on the first call
+// as one "function\n\tfile:line" entry per frame, from the caller of
+// `NewInstance` outwards.
+func (e *CoreException) GetStackTrace() string {
+	if e == nil {
+		return ""
+	}
+	if e.StackTrace != "" {
+		return e.StackTrace
+	}
+	return e.stack.String()
+}
+
+// GetStackFrames returns the frames of the stack captured by `NewInstance`,
+// from the caller of `NewInstance` outwards, e.g. for the error trackers
+// expecting structured frames. They are resolved on the first call of
+// GetStackFrames, `GetStackTrace` or `GetErrorsForLog`, and memoized, so the
+// exceptions that are never logged do not pay for the symbolization. It is
+// nil when no stack was captured, and ignores the `StackTrace` field.
+func (e *CoreException) GetStackFrames() []StackFrame {
+	if e == nil {
+		return nil
+	}
+	return e.stack.Frames()
 }
 
 // Format returns a map representation of the exception, designed for
@@ -166,21 +348,47 @@ func (e CoreException) GetStackTrace() string {
 // (assumed to be a constant like `status.ERROR`), an "error_code"
 // corresponding to the status code, and the primary "message". Any additional
 // key-value pairs from the `Errors` map are flattened directly into this
-// formatted output.
-func (e CoreException) Format() map[string]interface{} {
+// formatted output, along with the "causes" of the cause set by `WithCause`
+// that are exceptions. The built-in keys are named by the strategy set by
+// `SetKeyNaming` (e.g., "errorCode" in camelCase). After `CacheFormat`, it
+// returns a copy of the cached envelope. In strict mode, it panics when the
+// envelope violates its schema (see `SetStrictEnvelopes`).
+func (e *CoreException) Format() map[string]interface{} {
+	if e == nil {
+		return map[string]interface{}{}
+	}
+	formatted, ok := e.cachedFormat()
+	if !ok {
+		formatted = e.format(CurrentKeyNaming())
+	}
+	if strictEnvelopes.Load() {
+		// In strict mode, an envelope violating its schema is a programming
+		// error (see SetStrictEnvelopes).
+		if err := validateFormat(formatted); err != nil {
+			panic(err)
+		}
+	}
+	return formatted
+}
+
+// format builds the envelope returned by Format, with the built-in keys
+// named by naming (see SetKeyNaming).
+func (e *CoreException) format(naming KeyNaming) map[string]interface{} {
 	formatted := map[string]interface{}{
-		"status":     status.ERROR, // Assumed to be a constant indicating a general error status.
-		"error_code": e.StatusCode.GetValue(),
-		"message":    e.Message,
+		"status":                 status.ERROR, // Assumed to be a constant indicating a general error status.
+		naming.Key("error_code"): e.StatusCode.GetValue(),
+		"message":                e.Message,
+	}
+
+	// The causes of the exception are rendered, unless the `Errors` map
+	// holds its own (see WithCause).
+	if causes := renderCauses(e.cause, true, naming); causes != nil {
+		formatted[CausesKey] = causes
 	}
 
 	// If there are additional errors in the `Errors` map, merge them
 	// into the top level of the formatted output.
-	if e.Errors != nil {
-		for key, value := range e.Errors {
-			formatted[key] = value
-		}
-	}
+	naming.copyKeys(formatted, e.Errors)
 
 	return formatted
 }
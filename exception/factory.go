@@ -0,0 +1,71 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the factory building the exception
+// matching a status code.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and its constants.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// statusFactories maps the status codes having a dedicated exception type to
+// the wrapping of a CoreException of that status code into that type.
+var statusFactories = map[status.StatusCode]func(base *CoreException) CoreInterface{
+	status.BadRequest:           func(b *CoreException) CoreInterface { return &InvalidArgument{CoreException: *b} },
+	status.Unauthorized:         func(b *CoreException) CoreInterface { return &Unauthorized{CoreException: *b} },
+	status.Forbidden:            func(b *CoreException) CoreInterface { return &Forbidden{CoreException: *b} },
+	status.NotFound:             func(b *CoreException) CoreInterface { return &NotFound{CoreException: *b} },
+	status.NotAcceptable:        func(b *CoreException) CoreInterface { return &NotAcceptable{CoreException: *b} },
+	status.RequestTimeout:       func(b *CoreException) CoreInterface { return &Timeout{CoreException: *b} },
+	status.Conflict:             func(b *CoreException) CoreInterface { return &Conflict{CoreException: *b} },
+	status.PreconditionFailed:   func(b *CoreException) CoreInterface { return &PreconditionFailed{CoreException: *b} },
+	status.UnsupportedMediaType: func(b *CoreException) CoreInterface { return &UnsupportedMediaType{CoreException: *b} },
+	status.RangeNotSatisfiable:  func(b *CoreException) CoreInterface { return &RangeNotSatisfiable{CoreException: *b} },
+	status.UnprocessableContent: func(b *CoreException) CoreInterface { return &Validation{CoreException: *b} },
+	status.TooManyRequests:      func(b *CoreException) CoreInterface { return &TooManyRequests{CoreException: *b} },
+	status.ServiceUnavailable:   func(b *CoreException) CoreInterface { return &ServiceUnavailable{CoreException: *b} },
+	status.GatewayTimeout:       func(b *CoreException) CoreInterface { return &Timeout{CoreException: *b} },
+}
+
+// FromStatus creates the exception matching a status code, e.g. to convert
+// the error response of an upstream service. Status codes with a dedicated
+// exception type (400 `InvalidArgument`, 401 `Unauthorized`, 403 `Forbidden`,
+// 404 `NotFound`, 406 `NotAcceptable`, 408 and 504 `Timeout`, 409
+// `Conflict`, 412 `PreconditionFailed`, 415 `UnsupportedMediaType`, 416
+// `RangeNotSatisfiable`, 422 `Validation`, 429 `TooManyRequests`, 503
+// `ServiceUnavailable`) yield that type; any other error status yields a
+// generic `Error`. In every case the exception keeps the given status code.
+// Codes that are not error statuses (below 400) yield an `Error` with
+// `status.InternalServerError`.
+//
+// Parameters:
+//
+//	code: The status code to convert.
+//	errors: A map of string to interface{} containing detailed error
+//	        information. This map can include a "message" key which will be
+//	        used as the primary error message; it defaults to the description
+//	        of the status code.
+//
+// Returns:
+//
+//	The exception matching the status code.
+func FromStatus(code status.StatusCode, errors map[string]interface{}) CoreInterface {
+	if code < status.BadRequest {
+		code = status.InternalServerError
+	}
+	if errors == nil {
+		errors = map[string]interface{}{}
+	}
+	if message, ok := errors["message"].(string); !ok || message == "" {
+		errors["message"] = code.GetDescription()
+	}
+
+	// Created with its final status code, so that the creation hook sees it.
+	base := NewInstance(errors, code)
+	wrap, ok := statusFactories[code]
+	if !ok {
+		return notified(&Error{CoreException: *base})
+	}
+	return notified(wrap(base))
+}
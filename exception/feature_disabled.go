@@ -0,0 +1,43 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// calls to disabled features, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Forbidden` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// FeatureDisabled is a specific exception type that signifies that an
+// operation is gated by a feature flag that is disabled for the caller
+// (e.g., a feature being rolled out gradually).
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type FeatureDisabled struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewFeatureDisabled creates and returns a new `FeatureDisabled` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.Forbidden`. This status code
+// tells clients that the operation exists but is not available to them, and
+// that retrying the same request will not help until the flag changes.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the disabled feature, usually including the flag name. This map
+//	        can include a "message" key which will be used as the primary error
+//	        message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `FeatureDisabled` instance.
+func NewFeatureDisabled(errors map[string]interface{}) *FeatureDisabled {
+	// Initialize the base CoreException with the given errors and a default
+	// status of Forbidden, as the caller may not use the feature.
+	base := NewInstance(errors, status.Forbidden)
+	return notified(&FeatureDisabled{CoreException: *base})
+}
@@ -0,0 +1,40 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// authenticated requests that are not permitted, leveraging the core
+// exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Forbidden` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Forbidden is a specific exception type that signifies that the authenticated
+// caller is not permitted to perform the requested operation.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type Forbidden struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewForbidden creates and returns a new `Forbidden` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.Forbidden`. This status
+// code tells clients that authenticating again will not help.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the denied permission. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `Forbidden` instance.
+func NewForbidden(errors map[string]interface{}) *Forbidden {
+	// Initialize the base CoreException with the given errors and a default
+	// status of Forbidden, as the caller lacks the required permission.
+	base := NewInstance(errors, status.Forbidden)
+	return notified(&Forbidden{CoreException: *base})
+}
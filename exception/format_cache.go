@@ -0,0 +1,69 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the caching of the envelope of
+// an exception formatted several times.
+package exception
+
+import (
+	"sync"
+)
+
+// formatCache holds the envelope of an exception, computed on first use.
+type formatCache struct {
+	naming KeyNaming // The naming of the keys of the cached envelope (see SetKeyNaming).
+
+	formatOnce sync.Once
+	formatted  map[string]interface{} // The output of Format.
+
+	encodeOnce sync.Once
+	encoded    []byte // The output of AppendJSON.
+	encodeErr  error  // The error of AppendJSON.
+}
+
+// CacheFormat makes Format and AppendJSON compute the envelope of the
+// exception once, on their first call, and reuse it when the same exception
+// is written to the response, logged and reported. It is meant to be called
+// once the exception is complete, as `response.WriteErrorContext` does after
+// annotating and translating it. The mutation APIs (`SetError`,
+// `SetMessage`) invalidate the cache; direct modifications of the fields or
+// of the maps of `Errors` do not, and must not follow CacheFormat.
+func (e *CoreException) CacheFormat() {
+	if e == nil {
+		return
+	}
+	if e.cache == nil {
+		e.cache = &formatCache{naming: CurrentKeyNaming()}
+	}
+}
+
+// invalidateFormat drops the cached envelope, before a mutation.
+func (e *CoreException) invalidateFormat() {
+	e.cache = nil
+}
+
+// cachedFormat returns a deep copy of the cached output of Format, so that
+// editing the result does not alter the later ones, and whether the envelope
+// is cached under the current naming of the keys.
+func (e *CoreException) cachedFormat() (map[string]interface{}, bool) {
+	if e.cache == nil || e.cache.naming != CurrentKeyNaming() {
+		return nil, false
+	}
+	e.cache.formatOnce.Do(func() {
+		e.cache.formatted = e.format(e.cache.naming)
+	})
+	return deepCopyMap(e.cache.formatted), true
+}
+
+// cachedJSON appends the cached output of AppendJSON to dst, and reports
+// whether the envelope is cached under the current naming of the keys.
+func (e *CoreException) cachedJSON(dst []byte) ([]byte, bool, error) {
+	if e.cache == nil || e.cache.naming != CurrentKeyNaming() {
+		return dst, false, nil
+	}
+	e.cache.encodeOnce.Do(func() {
+		e.cache.encoded, e.cache.encodeErr = e.appendJSON(nil, e.cache.naming)
+	})
+	if e.cache.encodeErr != nil {
+		return dst, true, e.cache.encodeErr
+	}
+	return append(dst, e.cache.encoded...), true, nil
+}
@@ -0,0 +1,186 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a group of goroutines collecting
+// the exceptions of all of them, for the fan-out of service calls.
+package exception
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// TaskGroup runs goroutines working on parts of a task, like
+// `golang.org/x/sync/errgroup.Group`, but collects the error of every
+// goroutine instead of the first one only: Wait returns them grouped in an
+// `Aggregate`, so the caller can report every failed call. The panics of the
+// goroutines are converted into `Runtime` exceptions.
+//
+// It is created by Group; a TaskGroup must not be reused after Wait.
+type TaskGroup struct {
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	failFast bool
+	sem      chan struct{}
+
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	errs   []error // The errors, by order of the Go calls; nil for the successes.
+	failed bool    // Whether a goroutine failed, for the fail-fast mode.
+}
+
+// GroupOption configures a TaskGroup.
+type GroupOption func(*TaskGroup)
+
+// FailFast cancels the context of the group as soon as a goroutine fails,
+// with the error as the cause (see `context.Cause`), and skips the
+// goroutines started afterwards. The `context.Canceled` errors returned by
+// the goroutines interrupted by this cancellation are not collected: the
+// aggregate holds the failures, not their consequences.
+func FailFast() GroupOption {
+	return func(g *TaskGroup) {
+		g.failFast = true
+	}
+}
+
+// WithGroupLimit limits the number of goroutines running at once; Go blocks
+// until one of them returns. A limit lower than 1 means no limit.
+//
+// Parameters:
+//
+//	limit: The maximum number of active goroutines.
+func WithGroupLimit(limit int) GroupOption {
+	return func(g *TaskGroup) {
+		if limit > 0 {
+			g.sem = make(chan struct{}, limit)
+		}
+	}
+}
+
+// Group returns a new TaskGroup and its context, derived from ctx, which is
+// canceled when Wait returns (or at the first failure with FailFast):
+//
+//	group, ctx := exception.Group(ctx)
+//	group.Go(func() error { return users.Fetch(ctx, id) })
+//	group.Go(func() error { return orders.Fetch(ctx, id) })
+//	if err := group.Wait(); err != nil {
+//		return err // an *Aggregate of every failed call.
+//	}
+//
+// Parameters:
+//
+//	ctx: The parent context.
+//	opts: Options such as FailFast and WithGroupLimit.
+//
+// Returns:
+//
+//	The group and the context to pass to its goroutines.
+func Group(ctx context.Context, opts ...GroupOption) (*TaskGroup, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &TaskGroup{ctx: ctx, cancel: cancel}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, ctx
+}
+
+// Go runs fn in a new goroutine. With FailFast, fn is not run once a
+// goroutine has failed.
+//
+// Parameters:
+//
+//	fn: The function to run; its error is collected by Wait.
+func (g *TaskGroup) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.mu.Lock()
+	index := len(g.errs)
+	g.errs = append(g.errs, nil)
+	skip := g.failFast && g.failed
+	g.mu.Unlock()
+	if skip {
+		g.release()
+		return
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer g.release()
+		g.record(index, runGrouped(fn))
+	}()
+}
+
+// Wait waits for the goroutines to return, then cancels the context of the
+// group.
+//
+// Returns:
+//
+//	Nil if every goroutine succeeded, otherwise an `*Aggregate` of their
+//	errors, in the order of the Go calls (see `NewAggregate` for its status
+//	code).
+func (g *TaskGroup) Wait() error {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var errs []error
+	for _, err := range g.errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewAggregate(map[string]interface{}{}, errs...)
+}
+
+// record stores the error of the goroutine started by the index-th Go call;
+// a typed nil, e.g. a nil `*NotFound` returned as an error, is a success (see
+// IsNil).
+func (g *TaskGroup) record(index int, err error) {
+	if IsNil(err) {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.failFast {
+		if g.failed && errors.Is(err, context.Canceled) {
+			return
+		}
+		if !g.failed {
+			g.failed = true
+			g.cancel(err)
+		}
+	}
+	g.errs[index] = err
+}
+
+// release frees the slot of a goroutine when the group is limited.
+func (g *TaskGroup) release() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
+
+// runGrouped runs a function of a group, converting its panic into an
+// exception.
+func runGrouped(fn func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = NewRuntime(map[string]interface{}{
+				"message": "A goroutine of the group panicked.",
+				"details": map[string]interface{}{
+					"panic": fmt.Sprint(recovered),
+					"error": "goroutine_panicked",
+				},
+			})
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,84 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the response headers implied by
+// the exceptions, such as Retry-After.
+package exception
+
+import (
+	"strconv"
+	"strings"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the status code constants implying response headers.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Keys of the details implying response headers (see HeadersOf).
+const (
+	RetryAfterKey = "retry_after" // The seconds to wait, for the Retry-After header of 429 and 503.
+	AllowKey      = "allow"       // The allowed methods, for the Allow header of 405.
+	ChallengeKey  = "challenge"   // The challenge, for the WWW-Authenticate header of 401.
+)
+
+// DefaultChallenge is the WWW-Authenticate challenge of the 401 exceptions
+// whose details hold none, as the header is required in 401 responses.
+const DefaultChallenge = "Bearer"
+
+// Headers returns the response headers implied by the exception (see
+// HeadersOf), which the response writers and the adapters set unless the
+// response already has them.
+func (e *CoreException) Headers() map[string]string {
+	return HeadersOf(e.GetStatusCode(), e.details())
+}
+
+// HeadersOf returns the response headers implied by a status code and the
+// details of an exception. It backs `CoreException.Headers`, for the other
+// implementations of `CoreInterface`:
+//
+//   - 429 and 503: Retry-After, from a positive RetryAfterKey integer.
+//   - 405: Allow, from an AllowKey string or list of strings (e.g.,
+//     []string{"GET", "HEAD"} yields "GET, HEAD").
+//   - 401: WWW-Authenticate, from a ChallengeKey string, defaulting to
+//     DefaultChallenge.
+//
+// Parameters:
+//
+//	statusCode: The status code of the exception.
+//	details: The details of the exception; may be nil.
+//
+// Returns:
+//
+//	The headers by canonical name, or nil when there is none.
+func HeadersOf(statusCode int, details map[string]interface{}) map[string]string {
+	switch statusCode {
+	case status.TooManyRequests.GetValue(), status.ServiceUnavailable.GetValue():
+		if seconds, ok := IntOf(details, RetryAfterKey); ok && seconds > 0 {
+			return map[string]string{"Retry-After": strconv.Itoa(seconds)}
+		}
+	case status.MethodNotAllowed.GetValue():
+		if allow := allowedMethods(details); allow != "" {
+			return map[string]string{"Allow": allow}
+		}
+	case status.Unauthorized.GetValue():
+		challenge, _ := StringOf(details, ChallengeKey)
+		if challenge == "" {
+			challenge = DefaultChallenge
+		}
+		return map[string]string{"WWW-Authenticate": challenge}
+	}
+	return nil
+}
+
+// allowedMethods returns the Allow header of the AllowKey entry of details.
+func allowedMethods(details map[string]interface{}) string {
+	if allow, ok := StringOf(details, AllowKey); ok {
+		return allow
+	}
+	items, _ := SliceOf(details, AllowKey)
+	methods := make([]string, 0, len(items))
+	for _, item := range items {
+		if method, ok := item.(string); ok && method != "" {
+			methods = append(methods, method)
+		}
+	}
+	return strings.Join(methods, ", ")
+}
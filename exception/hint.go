@@ -0,0 +1,53 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the remediation hint of the
+// exceptions.
+package exception
+
+// HintKey is the key of the remediation hint of an exception in its errors
+// map, and therefore in its envelope and problem details: an actionable
+// guidance that the clients and the support tooling can surface to the user,
+// beside the message describing what went wrong:
+//
+//	exception.NewInvalidArgument(map[string]interface{}{
+//		"message":         "The amount is invalid.",
+//		exception.HintKey: "Check that the amount is positive.",
+//	})
+const HintKey = "hint"
+
+// WithHint sets the remediation hint of the exception (see HintKey), e.g.
+// where the exception raised by a lower layer is handled with more context.
+//
+// Parameters:
+//
+//	text: The hint (e.g., "Check that the amount is positive."); empty
+//	      removes it.
+//
+// Returns:
+//
+//	Nil, or a `*Logic` exception leaving the exception unchanged when it is
+//	sealed (see Seal) or nil.
+func (e *CoreException) WithHint(text string) error {
+	if err := e.checkMutable("WithHint"); err != nil {
+		return err
+	}
+	if text == "" {
+		e.invalidateFormat()
+		delete(e.Errors, HintKey)
+		return nil
+	}
+	return e.SetError(HintKey, text)
+}
+
+// Hint returns the remediation hint of an exception (see HintKey).
+//
+// Parameters:
+//
+//	e: The exception.
+//
+// Returns:
+//
+//	The hint, or an empty string when it has none.
+func Hint(e CoreInterface) string {
+	hint, _ := e.GetString(HintKey)
+	return hint
+}
@@ -0,0 +1,145 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the global hook notified of the
+// creation of every exception.
+package exception
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// creationHook holds the hook installed by SetCreationHook, if any.
+var creationHook atomic.Pointer[func(CoreInterface)]
+
+// SetCreationHook installs a hook notified of every exception created by the
+// constructors of this package (see NotifyCreation), e.g. to forward server
+// errors to an error tracker (see the report package).
+// The hook receives the typed exception as its constructor returns it (e.g.,
+// a `*NotFound`). It runs synchronously on the goroutine creating the
+// exception, so it must be fast, safe for concurrent use, and must not create
+// exceptions itself. The exception is only valid during the call, as it goes
+// on being adjusted by `SetError` or `SetMessage` afterwards: a hook keeping
+// it, e.g. to report it from another goroutine, keeps its Snapshot instead,
+// taken only for the exceptions it keeps.
+//
+// Parameters:
+//
+//	hook: The hook to install, replacing the previous one; nil removes it.
+//
+// Returns:
+//
+//	A function restoring the previous hook.
+func SetCreationHook(hook func(CoreInterface)) (restore func()) {
+	var previous *func(CoreInterface)
+	if hook == nil {
+		previous = creationHook.Swap(nil)
+	} else {
+		previous = creationHook.Swap(&hook)
+	}
+	return func() {
+		creationHook.Store(previous)
+	}
+}
+
+// NotifyCreation passes a newly created exception to the hook of
+// SetCreationHook, if any. The constructors of this package call it with
+// the typed exception they return; the constructors of the exception types
+// defined elsewhere, embedding the `CoreException` of `NewInstance`, call it
+// likewise, as `NewInstance` does not.
+//
+// Parameters:
+//
+//	e: The exception, as returned by its constructor. A nil exception (see
+//	   IsNil) is ignored.
+func NotifyCreation(e CoreInterface) {
+	if hook := creationHook.Load(); hook != nil && !IsNil(e) {
+		(*hook)(e)
+	}
+}
+
+// notified calls NotifyCreation for a typed exception and returns it, for
+// the constructors of this package.
+func notified[T CoreInterface](e T) T {
+	NotifyCreation(e)
+	return e
+}
+
+// Snapshot returns a sealed copy of an exception of the same type (e.g., a
+// `*NotFound`), with a deep copy of its `Errors`, which shares no mutable
+// state with it: it may be retained and read from another goroutine while
+// the exception goes on being adjusted, and does not see these changes.
+//
+// Parameters:
+//
+//	e: The exception, typically received by the hook of SetCreationHook.
+//
+// Returns:
+//
+//	The snapshot, or e itself when it is not a pointer to a struct.
+func Snapshot(e CoreInterface) CoreInterface {
+	value := reflect.ValueOf(e)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return e
+	}
+	copied := reflect.New(value.Type().Elem())
+	copied.Elem().Set(value.Elem())
+	snap, ok := copied.Interface().(CoreInterface)
+	if !ok {
+		return e
+	}
+	if core, ok := snap.(interface{ core() *CoreException }); ok {
+		c := core.core()
+		c.Errors = deepCopyMap(c.Errors)
+		c.cache = nil
+		c.pooled = false
+		c.sealed = true
+	}
+	return snap
+}
+
+// core returns the CoreException, promoted to the types embedding it.
+func (e *CoreException) core() *CoreException {
+	return e
+}
+
+// deepCopyMap copies an errors map and the maps and slices it nests.
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		copied[key] = deepCopyValue(value)
+	}
+	return copied
+}
+
+// deepCopyValue copies the maps and slices of the shapes found in the errors
+// maps; the other values are returned as they are.
+func deepCopyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopyValue(item)
+		}
+		return copied
+	case []map[string]interface{}:
+		copied := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopyMap(item)
+		}
+		return copied
+	case map[string]string:
+		copied := make(map[string]string, len(v))
+		for key, item := range v {
+			copied[key] = item
+		}
+		return copied
+	case []string:
+		return append([]string(nil), v...)
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the interning of the messages
+// and error details of the exceptions.
+package exception
+
+import (
+	"sync/atomic"
+	"unique"
+)
+
+// maxInternedLength is the length beyond which strings are not interned, as
+// long strings are rarely repeated (e.g., a serialized payload).
+const maxInternedLength = 256
+
+// interning reports whether NewInstance interns the messages and details.
+var interning atomic.Bool
+
+// SetInterning enables or disables the interning of the messages and error
+// details of the exceptions created by `NewInstance`: the message, and the
+// keys and string values of `Errors` at any depth (the field names and rule
+// names of a validation error, for instance), are replaced by canonical
+// copies (see Intern), so that an error storm repeating the same strings,
+// built dynamically, keeps a single copy of each in the heap. It costs a
+// hash of each string per exception, hence is disabled by default.
+//
+// Parameters:
+//
+//	enabled: Whether to intern.
+//
+// Returns:
+//
+//	A function restoring the previous setting.
+func SetInterning(enabled bool) (restore func()) {
+	previous := interning.Swap(enabled)
+	return func() {
+		interning.Store(previous)
+	}
+}
+
+// Intern returns the canonical copy of a string, shared by all the equal
+// strings interned, using the `unique` package: it is freed once no longer
+// referenced. Strings longer than 256 bytes are returned unchanged.
+func Intern(s string) string {
+	if len(s) > maxInternedLength {
+		return s
+	}
+	return unique.Make(s).Value()
+}
+
+// internErrors interns the keys and string values of an errors map, in
+// place, at any depth.
+func internErrors(errors map[string]interface{}) {
+	for key, value := range errors {
+		// Assigning to an existing string key also replaces the stored key.
+		errors[Intern(key)] = internValue(value)
+	}
+}
+
+// internValue interns a string, or the strings of a collection in place.
+func internValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return Intern(v)
+	case map[string]interface{}:
+		internErrors(v)
+	case map[string]string:
+		for key, item := range v {
+			v[Intern(key)] = Intern(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = internValue(item)
+		}
+	case []string:
+		for i, item := range v {
+			v[i] = Intern(item)
+		}
+	}
+	return value
+}
@@ -38,5 +38,5 @@ func NewInvalidArgument(errors map[string]interface{}) *InvalidArgument {
 	// Initialize the base CoreException with the given errors and a default
 	// status of BadRequest, as invalid arguments are typically client-side input errors.
 	base := NewInstance(errors, status.BadRequest)
-	return &InvalidArgument{CoreException: *base}
+	return notified(&InvalidArgument{CoreException: *base})
 }
@@ -0,0 +1,330 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the direct JSON encoding of the
+// envelope of an exception, without building the map of Format.
+package exception
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.ERROR` constant of the envelope.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// hex holds the digits of the \u escapes.
+const hex = "0123456789abcdef"
+
+// AppendJSON appends the JSON encoding of the envelope of the exception to
+// dst, without building the intermediate map of `Format`. The result is the
+// same as `json.Marshal(e.Format())`: keys are sorted, named by the
+// strategy set by `SetKeyNaming`, and HTML characters escaped. Strings,
+// booleans, numbers, nil, `map[string]interface{}`, `map[string]string`,
+// `[]interface{}` and `[]string` values of `Errors` are encoded directly;
+// any other value falls back to `json.Marshal`. The
+// response writers prefer it to Format, so a type embedding `CoreException`
+// and overriding Format must override AppendJSON accordingly. After
+// `CacheFormat`, it appends the cached encoding.
+//
+// Parameters:
+//
+//	dst: The buffer to append to; it may be nil.
+//
+// Returns:
+//
+//	The extended buffer, or dst unchanged and the encoding error of a value
+//	of `Errors` that JSON does not support (e.g., NaN, a channel), or the
+//	validation error of the envelope in strict mode (see
+//	`SetStrictEnvelopes`).
+func (e *CoreException) AppendJSON(dst []byte) ([]byte, error) {
+	if e == nil {
+		return append(dst, "{}"...), nil
+	}
+	encoded, ok, err := e.cachedJSON(dst)
+	if !ok {
+		encoded, err = e.appendJSON(dst, CurrentKeyNaming())
+	}
+	if err == nil && strictEnvelopes.Load() {
+		// In strict mode, the envelope is validated against its schema (see
+		// SetStrictEnvelopes).
+		if err := ValidateEnvelope(encoded[len(dst):]); err != nil {
+			return dst, err
+		}
+	}
+	return encoded, err
+}
+
+// appendJSON encodes the envelope returned by AppendJSON, with the built-in
+// keys named by naming (see SetKeyNaming).
+func (e *CoreException) appendJSON(dst []byte, naming KeyNaming) ([]byte, error) {
+	// Sort the builtin keys with those of Errors, which override them like
+	// in Format. A small array keeps the keys of common exceptions off the
+	// heap.
+	var array [16]string
+	keys := append(array[:0], naming.Key("error_code"), "message", "status")
+	causes := renderCauses(e.cause, true, naming)
+	if _, ok := e.Errors[CausesKey]; !ok && causes != nil {
+		keys = append(keys, CausesKey)
+	}
+	for key := range e.Errors {
+		keys = append(keys, naming.Key(key))
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	start := len(dst)
+	dst = append(dst, '{')
+	for i, key := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, key)
+		dst = append(dst, ':')
+
+		if value, ok := e.errorsEntry(key, naming); ok {
+			var err error
+			if dst, err = appendJSONValue(dst, value); err != nil {
+				return dst[:start], err
+			}
+			continue
+		}
+		switch key {
+		case naming.Key("error_code"):
+			dst = strconv.AppendInt(dst, int64(e.StatusCode.GetValue()), 10)
+		case "message":
+			dst = appendJSONString(dst, e.Message)
+		case "status":
+			dst = appendJSONString(dst, status.ERROR)
+		case CausesKey:
+			dst, _ = appendJSONValue(dst, causes)
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// errorsEntry returns the entry of `Errors` encoded under a key of the
+// envelope, preferring a renamed built-in key like `KeyNaming.copyKeys`.
+func (e *CoreException) errorsEntry(key string, naming KeyNaming) (interface{}, bool) {
+	if naming == CamelCase {
+		if snake, ok := snakeKeys[key]; ok {
+			if value, ok := e.Errors[snake]; ok {
+				return value, true
+			}
+		}
+	}
+	value, ok := e.Errors[key]
+	return value, ok
+}
+
+// FormatTo writes the JSON encoding of the envelope of the exception to buf
+// (see AppendJSON), reusing its spare capacity.
+//
+// Parameters:
+//
+//	buf: The buffer to write to.
+//
+// Returns:
+//
+//	Nil, or the encoding error of a value of `Errors`; nothing is written then.
+func (e *CoreException) FormatTo(buf *bytes.Buffer) error {
+	encoded, err := e.AppendJSON(buf.AvailableBuffer())
+	if err != nil {
+		return err
+	}
+	_, _ = buf.Write(encoded)
+	return nil
+}
+
+// appendJSONValue appends the JSON encoding of a value.
+func appendJSONValue(dst []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return appendJSONString(dst, v), nil
+	case bool:
+		return strconv.AppendBool(dst, v), nil
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, v, 10), nil
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(dst, v, 10), nil
+	case float32:
+		return appendJSONFloat(dst, float64(v), 32)
+	case float64:
+		return appendJSONFloat(dst, v, 64)
+	case map[string]interface{}:
+		if v == nil {
+			return append(dst, "null"...), nil
+		}
+		var array [16]string
+		keys := array[:0]
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		dst = append(dst, '{')
+		for i, key := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, key)
+			dst = append(dst, ':')
+			var err error
+			if dst, err = appendJSONValue(dst, v[key]); err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, '}'), nil
+	case map[string]string:
+		if v == nil {
+			return append(dst, "null"...), nil
+		}
+		var array [16]string
+		keys := array[:0]
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		dst = append(dst, '{')
+		for i, key := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, key)
+			dst = append(dst, ':')
+			dst = appendJSONString(dst, v[key])
+		}
+		return append(dst, '}'), nil
+	case []interface{}:
+		if v == nil {
+			return append(dst, "null"...), nil
+		}
+		dst = append(dst, '[')
+		for i, item := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			var err error
+			if dst, err = appendJSONValue(dst, item); err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, ']'), nil
+	case []string:
+		if v == nil {
+			return append(dst, "null"...), nil
+		}
+		dst = append(dst, '[')
+		for i, item := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, item)
+		}
+		return append(dst, ']'), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, encoded...), nil
+	}
+}
+
+// appendJSONFloat appends a float like `encoding/json` does.
+func appendJSONFloat(dst []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, bits))
+	}
+
+	// Use the exponent format for very small and very large numbers.
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
+
+// appendJSONString appends a quoted string like `encoding/json` does,
+// escaping the HTML characters, U+2028, U+2029 and invalid UTF-8.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,90 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the formatting of an exception
+// as a JSON-RPC 2.0 error object.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` constants mapped to the JSON-RPC error codes.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// JSON-RPC 2.0 error codes. The codes from -32000 to -32099 are reserved for
+// the errors defined by the server.
+const (
+	JSONRPCParseError     = -32700 // The request is not valid JSON.
+	JSONRPCInvalidRequest = -32600 // The request is not a valid request object.
+	JSONRPCMethodNotFound = -32601 // The method does not exist or is not available.
+	JSONRPCInvalidParams  = -32602 // The parameters of the method are invalid.
+	JSONRPCInternalError  = -32603 // An internal error of the server.
+	JSONRPCServerError    = -32000 // Any other error of the server.
+)
+
+// JSONRPCCode returns the JSON-RPC error code matching a status code:
+//
+//	400 Bad Request, 422 Unprocessable Content   -32602 (invalid params)
+//	405 Method Not Allowed, 501 Not Implemented  -32601 (method not found)
+//	500 Internal Server Error                    -32603 (internal error)
+//	any other status code                        -32000 (server error)
+//
+// The status code itself is kept in the "data" of the error object (see
+// `CoreException.FormatJSONRPC`), so the clients can tell the server errors
+// apart.
+//
+// Parameters:
+//
+//	code: The status code to map.
+//
+// Returns:
+//
+//	The JSON-RPC error code.
+func JSONRPCCode(code status.StatusCode) int {
+	switch code {
+	case status.BadRequest, status.UnprocessableContent:
+		return JSONRPCInvalidParams
+	case status.MethodNotAllowed, status.NotImplemented:
+		return JSONRPCMethodNotFound
+	case status.InternalServerError:
+		return JSONRPCInternalError
+	default:
+		return JSONRPCServerError
+	}
+}
+
+// FormatJSONRPC returns the exception as a JSON-RPC 2.0 error object, the
+// "error" member of a response: its "code" is the JSON-RPC error code of its
+// status code (see `JSONRPCCode`), its "message" the message of the
+// exception, and its "data" the entries of `Errors` with the status code
+// under "error_code", like in `Format`, keys named by `SetKeyNaming`.
+func (e *CoreException) FormatJSONRPC() map[string]interface{} {
+	if e == nil {
+		return map[string]interface{}{}
+	}
+	return formatJSONRPC(JSONRPCCode(e.StatusCode), e.Message, e.StatusCode, e.Errors)
+}
+
+// FormatJSONRPC returns the exception as a JSON-RPC 2.0 error object (see
+// `CoreException.FormatJSONRPC`), with the -32700 (parse error) code, as the
+// request body could not be parsed.
+func (e *RequestParseBody) FormatJSONRPC() map[string]interface{} {
+	return formatJSONRPC(JSONRPCParseError, e.Message, e.StatusCode, e.Errors)
+}
+
+// FormatJSONRPC returns the sentinel as a JSON-RPC 2.0 error object (see
+// `CoreException.FormatJSONRPC`).
+func (s *Sentinel) FormatJSONRPC() map[string]interface{} {
+	return formatJSONRPC(JSONRPCCode(s.statusCode), s.message, s.statusCode, s.GetErrors())
+}
+
+// formatJSONRPC builds a JSON-RPC 2.0 error object.
+func formatJSONRPC(rpcCode int, message string, code status.StatusCode, errors map[string]interface{}) map[string]interface{} {
+	naming := CurrentKeyNaming()
+	data := make(map[string]interface{}, len(errors)+1)
+	data[naming.Key("error_code")] = code.GetValue()
+	naming.copyKeys(data, errors)
+	return map[string]interface{}{
+		"code":    rpcCode,
+		"message": message,
+		"data":    data,
+	}
+}
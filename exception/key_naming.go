@@ -0,0 +1,157 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the naming strategy of the
+// built-in keys of the envelopes.
+package exception
+
+import (
+	"sync/atomic"
+)
+
+// KeyNaming is the naming strategy of the built-in keys of the envelopes
+// returned by `Format()` and encoded by `AppendJSON` (see SetKeyNaming).
+type KeyNaming int32
+
+// Naming strategies of the built-in keys.
+const (
+	SnakeCase KeyNaming = iota // "error_code", "request_id"; the default.
+	CamelCase                  // "errorCode", "requestId".
+)
+
+// camelKeys maps the built-in keys of several words to their camelCase form:
+// those of the error envelopes, and those of the "meta" blocks and items of
+// the success envelopes (see `response.SuccessContext`, `response.Batch` and
+// the pagination metadata). The other built-in keys ("status", "message",
+// "details", "hint", "causes", "errors", "total", ...) are single words,
+// identical in both strategies.
+var camelKeys = map[string]string{
+	"error_code":     "errorCode",
+	"request_id":     "requestId",
+	"correlation_id": "correlationId",
+	"tenant_id":      "tenantId",
+	"trace_id":       "traceId",
+	"span_id":        "spanId",
+	"status_code":    "statusCode",
+	"per_page":       "perPage",
+	"total_pages":    "totalPages",
+	"has_more":       "hasMore",
+	"next_cursor":    "nextCursor",
+}
+
+// snakeKeys maps the camelCase form of the built-in keys back to their
+// snake_case form.
+var snakeKeys = map[string]string{
+	"errorCode":     "error_code",
+	"requestId":     "request_id",
+	"correlationId": "correlation_id",
+	"tenantId":      "tenant_id",
+	"traceId":       "trace_id",
+	"spanId":        "span_id",
+	"statusCode":    "status_code",
+	"perPage":       "per_page",
+	"totalPages":    "total_pages",
+	"hasMore":       "has_more",
+	"nextCursor":    "next_cursor",
+}
+
+// keyNaming holds the strategy set by SetKeyNaming.
+var keyNaming atomic.Int32
+
+// SetKeyNaming sets the naming strategy of the built-in keys of the
+// envelopes, once at startup, e.g. for the frontends expecting camelCase:
+//
+//	defer exception.SetKeyNaming(exception.CamelCase)()
+//
+// It renames the top-level "error_code" of the envelopes, the identifiers
+// added by `ctxutil.Annotate` (e.g., "request_id") and the "error_code" of
+// their causes (see WithCause), in `Format`, `AppendJSON`, `FormatJSONRPC`
+// and the responses built from them, as well as the identifiers of the
+// "meta" block of the success envelopes, their pagination metadata (e.g.,
+// "per_page") and the "status_code" of the batch items. The other keys of `Errors`, and the
+// keys of the details, are user-provided and kept as is, and the
+// `GetErrorsForLog()` output is not affected.
+//
+// Parameters:
+//
+//	naming: The strategy; SnakeCase is the default, and replaces the values
+//	        that are not strategies.
+//
+// Returns:
+//
+//	A function restoring the previous strategy.
+func SetKeyNaming(naming KeyNaming) (restore func()) {
+	if naming != CamelCase {
+		naming = SnakeCase
+	}
+	previous := keyNaming.Swap(int32(naming))
+	return func() {
+		keyNaming.Store(previous)
+	}
+}
+
+// CurrentKeyNaming returns the strategy set by SetKeyNaming, SnakeCase by
+// default.
+func CurrentKeyNaming() KeyNaming {
+	return KeyNaming(keyNaming.Load())
+}
+
+// Key returns the name of a built-in key under the strategy, e.g.
+// "errorCode" for "error_code" in CamelCase. The keys that are not built-in
+// are returned unchanged.
+//
+// Parameters:
+//
+//	key: The snake_case name of the key.
+//
+// Returns:
+//
+//	The name of the key in the envelopes.
+func (n KeyNaming) Key(key string) string {
+	if n == CamelCase {
+		if camel, ok := camelKeys[key]; ok {
+			return camel
+		}
+	}
+	return key
+}
+
+// BuiltinKey returns the snake_case name of a built-in key of an envelope
+// formatted under any strategy, e.g. "error_code" for "errorCode", for the
+// consumers decoding envelopes (see SetKeyNaming). The keys that are not
+// built-in are returned unchanged.
+//
+// Parameters:
+//
+//	key: The key found in an envelope.
+//
+// Returns:
+//
+//	The snake_case name of the key.
+func BuiltinKey(key string) string {
+	if snake, ok := snakeKeys[key]; ok {
+		return snake
+	}
+	return key
+}
+
+// renamed reports whether a key of `Errors` is renamed by the strategy, so
+// that it takes precedence over a user-provided key of the same name.
+func (n KeyNaming) renamed(key string) bool {
+	_, ok := camelKeys[key]
+	return ok && n == CamelCase
+}
+
+// copyKeys copies the entries of an errors map into an envelope, renaming
+// the built-in keys; they win over the user-provided keys they collide
+// with (e.g., "request_id" over "requestId" in CamelCase).
+func (n KeyNaming) copyKeys(dst map[string]interface{}, errors map[string]interface{}) {
+	for key, value := range errors {
+		if !n.renamed(key) {
+			dst[key] = value
+		}
+	}
+	for key, value := range errors {
+		if n.renamed(key) {
+			dst[n.Key(key)] = value
+		}
+	}
+}
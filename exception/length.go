@@ -40,5 +40,5 @@ func NewLength(errors map[string]interface{}) *Length {
 	// Initialize the base CoreException with the given errors and a default
 	// status of BadRequest, as length errors are typically client-side input validation issues.
 	base := NewInstance(errors, status.BadRequest)
-	return &Length{CoreException: *base}
+	return notified(&Length{CoreException: *base})
 }
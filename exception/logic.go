@@ -39,5 +39,5 @@ func NewLogic(errors map[string]interface{}) *Logic {
 	// status of BadRequest, as logic errors often manifest due to invalid input
 	// that breaches business rules.
 	base := NewInstance(errors, status.BadRequest)
-	return &Logic{CoreException: *base}
+	return notified(&Logic{CoreException: *base})
 }
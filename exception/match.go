@@ -0,0 +1,68 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the matching of the errors by
+// the status code of their exception, whatever its type.
+package exception
+
+import (
+	"errors"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// HasStatus reports whether the exception of err has a status code, so
+// that a generic middleware can act on the errors without knowing their
+// concrete types:
+//
+//	if exception.HasStatus(err, status.TooManyRequests) { ... }
+//
+// The exception is the first `CoreInterface` of the chain of err, as for
+// the response writers, found through any wrapping (`fmt.Errorf` with %w,
+// `errors.Join`, ...).
+//
+// Parameters:
+//
+//	err: The error to match; a nil error, or nil exception (see IsNil), has
+//	     no status code.
+//	code: The status code to match.
+//
+// Returns:
+//
+//	True if the chain of err holds an exception of that status code.
+func HasStatus(err error, code status.StatusCode) bool {
+	coreErr, ok := exceptionOf(err)
+	return ok && coreErr.GetStatusCode() == code.GetValue()
+}
+
+// CodeOf returns the error code of the exception of err, i.e. the status
+// code of its envelope under "error_code", whatever its concrete type, e.g.
+// for the metrics of a generic middleware. The exception is the first
+// `CoreInterface` of the chain of err, like for HasStatus.
+//
+// Parameters:
+//
+//	err: The error; it may be nil.
+//
+// Returns:
+//
+//	The error code, or 0 when err carries no exception.
+func CodeOf(err error) int {
+	if coreErr, ok := exceptionOf(err); ok {
+		return coreErr.GetStatusCode()
+	}
+	return 0
+}
+
+// exceptionOf returns the first `CoreInterface` of the chain of err, and
+// false when there is none, or when it is a nil exception.
+func exceptionOf(err error) (CoreInterface, bool) {
+	if IsNil(err) {
+		return nil, false
+	}
+	var coreErr CoreInterface
+	if !errors.As(err, &coreErr) || IsNil(coreErr) {
+		return nil, false
+	}
+	return coreErr, true
+}
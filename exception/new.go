@@ -0,0 +1,51 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the constructor taking the error
+// details as alternating keys and values.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type of the created exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// BadKey is the key of the values passed to New without a string key, like
+// in `log/slog`.
+const BadKey = "!BADKEY"
+
+// New creates the exception matching a status code (see FromStatus) from
+// alternating keys and values, sparing the callers the map literal of the
+// other constructors:
+//
+//	exception.New(status.UnprocessableContent,
+//		"message", "The email is invalid.",
+//		"field", "email",
+//		"details", map[string]interface{}{"error": "invalid_email"},
+//	)
+//
+// Pairs are read like the arguments of `slog.Logger.Info`: a "message" key
+// sets the primary message, a key that is not a string and a final value
+// without a key are stored under BadKey, and a repeated key keeps its last
+// value. The error map is allocated once, with the size of the pairs.
+//
+// Parameters:
+//
+//	code: The status code of the exception.
+//	keyValues: The alternating keys and values of the error details.
+//
+// Returns:
+//
+//	The exception matching the status code.
+func New(code status.StatusCode, keyValues ...interface{}) CoreInterface {
+	errors := make(map[string]interface{}, (len(keyValues)+1)/2+1)
+	for i := 0; i < len(keyValues); i++ {
+		key, ok := keyValues[i].(string)
+		if !ok || i+1 == len(keyValues) {
+			errors[BadKey] = keyValues[i]
+			continue
+		}
+		errors[key] = keyValues[i+1]
+		i++
+	}
+	return FromStatus(code, errors)
+}
@@ -0,0 +1,39 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the detection of the nil
+// exceptions hidden in non-nil errors.
+package exception
+
+import (
+	"reflect"
+)
+
+// IsNil reports whether err is nil or holds a nil pointer, typically the
+// nil `*NotFound` of a function declared to return one, returned as an
+// error: the error is then not nil, but calling its methods would panic in
+// the types embedding `CoreException`. `Normalize`, the loggers and the
+// response writers treat such errors as nil.
+//
+// Parameters:
+//
+//	err: The error to check.
+//
+// Returns:
+//
+//	True if err is nil or a nil pointer, map, slice, function or channel.
+func IsNil(err error) bool {
+	return isNilValue(err)
+}
+
+// isNilValue reports whether value is nil or holds a nil pointer, map,
+// slice, function or channel, e.g. the target of an `errors.As` on an
+// interface such as Retryable.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
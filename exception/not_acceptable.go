@@ -0,0 +1,44 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// requests whose content negotiation fails, leveraging the core exception
+// handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.NotAcceptable` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// NotAcceptable is a specific exception type that signifies that none of the
+// representations the server can produce is acceptable according to the
+// Accept, Accept-Language or Accept-Encoding header of the request. It
+// usually lists the supported values, so that clients can adjust their
+// headers.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type NotAcceptable struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewNotAcceptable creates and returns a new `NotAcceptable` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.NotAcceptable`. This status
+// code tells clients that the server cannot produce a representation they
+// accept.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the failed negotiation. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `NotAcceptable` instance.
+func NewNotAcceptable(errors map[string]interface{}) *NotAcceptable {
+	// Initialize the base CoreException with the given errors and a default
+	// status of NotAcceptable, as no supported representation is accepted.
+	base := NewInstance(errors, status.NotAcceptable)
+	return notified(&NotAcceptable{CoreException: *base})
+}
@@ -0,0 +1,40 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// missing resources, leveraging the core exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.NotFound` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// NotFound is a specific exception type that signifies that a requested
+// resource or entity does not exist (e.g., a repository lookup by identifier
+// that matched nothing).
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type NotFound struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewNotFound creates and returns a new `NotFound` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.NotFound`. This status code
+// tells clients that the resource they addressed does not exist.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the missing resource. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `NotFound` instance.
+func NewNotFound(errors map[string]interface{}) *NotFound {
+	// Initialize the base CoreException with the given errors and a default
+	// status of NotFound, as the addressed resource does not exist.
+	base := NewInstance(errors, status.NotFound)
+	return notified(&NotFound{CoreException: *base})
+}
@@ -42,5 +42,5 @@ func NewOutOfBounds(errors map[string]interface{}) *OutOfBounds {
 	// status of UnprocessableContent, as out-of-bounds issues often relate
 	// to semantically incorrect data.
 	base := NewInstance(errors, status.UnprocessableContent)
-	return &OutOfBounds{CoreException: *base}
+	return notified(&OutOfBounds{CoreException: *base})
 }
@@ -39,5 +39,5 @@ func NewOutOfRange(errors map[string]interface{}) *OutOfRange {
 	// Initialize the base CoreException with the given errors and a default
 	// status of BadRequest, as out-of-range errors are typically client-side input validation issues.
 	base := NewInstance(errors, status.BadRequest)
-	return &OutOfRange{CoreException: *base}
+	return notified(&OutOfRange{CoreException: *base})
 }
@@ -42,5 +42,5 @@ func NewOverflow(errors map[string]interface{}) *Overflow {
 	// status of UnprocessableContent, as overflow issues often relate to
 	// semantically incorrect or excessively large data.
 	base := NewInstance(errors, status.UnprocessableContent)
-	return &Overflow{CoreException: *base}
+	return notified(&Overflow{CoreException: *base})
 }
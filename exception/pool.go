@@ -0,0 +1,92 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the opt-in pool of exceptions
+// for the paths formatting and forgetting them at a high rate.
+package exception
+
+import (
+	"sync"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type of the pooled exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// maxPooledErrors is the size beyond which the Errors map of a released
+// exception is dropped rather than kept for the next one, so that a single
+// large exception does not pin its map in the pool.
+const maxPooledErrors = 32
+
+// exceptionPool holds the released exceptions.
+var exceptionPool = sync.Pool{
+	New: func() interface{} {
+		return &CoreException{Errors: map[string]interface{}{}, stack: &stack{}}
+	},
+}
+
+// Acquire takes an exception from the pool, for the code writing an error
+// response and forgetting the exception right away, e.g. a middleware
+// rejecting tens of thousands of requests per second:
+//
+//	e := exception.Acquire(status.TooManyRequests, "")
+//	e.SetError("details", map[string]interface{}{"error": "rate_limited"})
+//	_ = response.WriteError(w, e)
+//	exception.Release(e)
+//
+// The ownership rules are strict: the caller owns the exception until it
+// calls Release, after which neither the exception nor its Errors map may be
+// used, so it must not be returned, wrapped, stored or logged asynchronously.
+// For the same reason, pooled exceptions are not passed to the hook of
+// `SetCreationHook`, which could retain them. When in doubt, use the
+// regular constructors.
+//
+// Parameters:
+//
+//	statusCode: The status code of the exception.
+//	message: The primary message; it defaults to the description of the
+//	         status code.
+//
+// Returns:
+//
+//	A pointer to a pooled CoreException with an empty Errors map and the
+//	stack of the caller, unless not sampled (see `SetStackSampling`).
+func Acquire(statusCode status.StatusCode, message string) *CoreException {
+	e := exceptionPool.Get().(*CoreException)
+	if message == "" {
+		message = statusCode.GetDescription()
+	}
+	e.Message = message
+	e.StatusCode = statusCode
+	if sampled(statusCode, message) {
+		e.stack.recapture(1)
+	} else {
+		*e.stack = stack{}
+	}
+	e.pooled = true
+	return e
+}
+
+// Release returns an exception taken by Acquire to the pool. It does nothing
+// for the exceptions that were not acquired or are already released.
+//
+// Parameters:
+//
+//	e: The exception to release. It must not be used afterwards.
+func Release(e *CoreException) {
+	if e == nil || !e.pooled {
+		return
+	}
+	e.pooled = false
+	e.sealed = false
+	e.request = nil
+	e.cause = nil
+	e.cache = nil
+	e.Message = ""
+	e.StackTrace = ""
+	e.StatusText = ""
+	if e.Errors == nil || len(e.Errors) > maxPooledErrors {
+		e.Errors = map[string]interface{}{}
+	} else {
+		clear(e.Errors)
+	}
+	exceptionPool.Put(e)
+}
@@ -0,0 +1,43 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// conditional requests whose preconditions do not hold, leveraging the core
+// exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.PreconditionFailed` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// PreconditionFailed is a specific exception type that signifies that a
+// precondition of a conditional request (If-Match, If-None-Match,
+// If-Unmodified-Since) does not hold, typically because the resource was
+// modified since the client last read it (lost update prevention).
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type PreconditionFailed struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewPreconditionFailed creates and returns a new `PreconditionFailed` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.PreconditionFailed`. This status
+// code tells clients to read the current state of the resource before
+// retrying.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the failed precondition. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `PreconditionFailed` instance.
+func NewPreconditionFailed(errors map[string]interface{}) *PreconditionFailed {
+	// Initialize the base CoreException with the given errors and a default
+	// status of PreconditionFailed, as the request precondition does not hold.
+	base := NewInstance(errors, status.PreconditionFailed)
+	return notified(&PreconditionFailed{CoreException: *base})
+}
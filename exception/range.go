@@ -40,5 +40,5 @@ func NewRange(errors map[string]interface{}) *Range {
 	// status of UnprocessableContent, as range issues often relate to
 	// semantically incorrect input data.
 	base := NewInstance(errors, status.UnprocessableContent)
-	return &Range{CoreException: *base}
+	return notified(&Range{CoreException: *base})
 }
@@ -0,0 +1,43 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// byte ranges that cannot be served, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.RangeNotSatisfiable` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// RangeNotSatisfiable is a specific exception type that signifies that none
+// of the byte ranges requested by the Range header of a request overlaps the
+// resource (e.g., a range starting beyond the end of a file). It usually
+// carries the size of the resource, so that clients can adjust their ranges.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type RangeNotSatisfiable struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewRangeNotSatisfiable creates and returns a new `RangeNotSatisfiable` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.RangeNotSatisfiable`. This
+// status code tells clients that the requested ranges lie outside the
+// resource.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the requested ranges. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `RangeNotSatisfiable` instance.
+func NewRangeNotSatisfiable(errors map[string]interface{}) *RangeNotSatisfiable {
+	// Initialize the base CoreException with the given errors and a default
+	// status of RangeNotSatisfiable, as no requested range can be served.
+	base := NewInstance(errors, status.RangeNotSatisfiable)
+	return notified(&RangeNotSatisfiable{CoreException: *base})
+}
@@ -41,5 +41,5 @@ func NewRequestParseBody(errors map[string]interface{}) *RequestParseBody {
 	// status of BadRequest, as body parsing errors are typically due to
 	// malformed client requests.
 	base := NewInstance(errors, status.BadRequest)
-	return &RequestParseBody{CoreException: *base}
+	return notified(&RequestParseBody{CoreException: *base})
 }
@@ -0,0 +1,204 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the snapshots of the HTTP
+// requests attached to the log output of the exceptions.
+package exception
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+// RequestLogKey is the key of the request snapshot in the `GetErrorsForLog()`
+// output of an exception.
+const RequestLogKey = "request"
+
+// redactedHeaderValue replaces the values of the credential headers.
+const redactedHeaderValue = "[REDACTED]"
+
+// DefaultSnapshotHeaders are the request headers captured by WithRequest
+// until SetSnapshotHeaders selects others.
+var DefaultSnapshotHeaders = []string{
+	"Accept",
+	"Authorization",
+	"Content-Length",
+	"Content-Type",
+	"Cookie",
+	"Referer",
+	"User-Agent",
+	"X-Forwarded-For",
+}
+
+// redactedHeaders are the headers whose values are never captured, as they
+// hold credentials, in canonical form.
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+}
+
+// snapshotHeaders holds the headers selected by SetSnapshotHeaders, if any.
+var snapshotHeaders atomic.Pointer[[]string]
+
+// SetSnapshotHeaders selects the request headers captured by WithRequest,
+// replacing DefaultSnapshotHeaders. The values of Authorization,
+// Proxy-Authorization, Cookie and Set-Cookie are always redacted.
+//
+// Parameters:
+//
+//	names: The names of the headers, in any case; none captures no header.
+//
+// Returns:
+//
+//	A function restoring the previous selection.
+func SetSnapshotHeaders(names ...string) (restore func()) {
+	selected := make([]string, len(names))
+	for i, name := range names {
+		selected[i] = http.CanonicalHeaderKey(name)
+	}
+	previous := snapshotHeaders.Swap(&selected)
+	return func() {
+		snapshotHeaders.Store(previous)
+	}
+}
+
+// RequestSnapshot is what an exception retains of the HTTP request that
+// failed, for the investigation of server errors without correlating the
+// access logs.
+type RequestSnapshot struct {
+	Method   string            // The request method (e.g., "POST").
+	Path     string            // The path of the request URL.
+	Query    string            // The raw query of the request URL, without "?"; may be empty.
+	Headers  map[string]string // The selected headers present in the request, credentials redacted.
+	RemoteIP string            // The host part of `http.Request.RemoteAddr`.
+}
+
+// SnapshotRequest captures the method, path, query, selected headers (see
+// SetSnapshotHeaders) and remote IP of a request. Forwarding headers such as
+// X-Forwarded-For are captured as headers, not trusted as the remote IP.
+//
+// Parameters:
+//
+//	r: The request; nil yields a zero RequestSnapshot.
+//
+// Returns:
+//
+//	The snapshot.
+func SnapshotRequest(r *http.Request) RequestSnapshot {
+	if r == nil {
+		return RequestSnapshot{}
+	}
+
+	snapshot := RequestSnapshot{Method: r.Method}
+	if r.URL != nil {
+		snapshot.Path = r.URL.Path
+		snapshot.Query = r.URL.RawQuery
+	}
+	snapshot.RemoteIP = r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		snapshot.RemoteIP = host
+	}
+
+	names := DefaultSnapshotHeaders
+	if selected := snapshotHeaders.Load(); selected != nil {
+		names = *selected
+	}
+	for _, name := range names {
+		name = http.CanonicalHeaderKey(name)
+		value := r.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		if redactedHeaders[name] {
+			value = redactedHeaderValue
+		}
+		if snapshot.Headers == nil {
+			snapshot.Headers = map[string]string{}
+		}
+		snapshot.Headers[name] = value
+	}
+	return snapshot
+}
+
+// Fields returns the snapshot as a log entry: "method", "path", "query",
+// "headers" and "remote_ip", omitting the empty members.
+func (s RequestSnapshot) Fields() map[string]interface{} {
+	fields := map[string]interface{}{"method": s.Method, "path": s.Path}
+	if s.Query != "" {
+		fields["query"] = s.Query
+	}
+	if len(s.Headers) > 0 {
+		headers := make(map[string]interface{}, len(s.Headers))
+		for name, value := range s.Headers {
+			headers[name] = value
+		}
+		fields["headers"] = headers
+	}
+	if s.RemoteIP != "" {
+		fields["remote_ip"] = s.RemoteIP
+	}
+	return fields
+}
+
+// WithRequest attaches a snapshot of the HTTP request that failed to the
+// exception (see SnapshotRequest). It appears under RequestLogKey in the
+// `GetErrorsForLog()` output, and never in the envelope sent to the client.
+//
+// Parameters:
+//
+//	r: The request; nil detaches the snapshot.
+//
+// Returns:
+//
+//	Nil, or a `*Logic` exception leaving the exception unchanged when it is
+//	sealed (see Seal) or nil.
+func (e *CoreException) WithRequest(r *http.Request) error {
+	if err := e.checkMutable("WithRequest"); err != nil {
+		return err
+	}
+	if r == nil {
+		e.request = nil
+		return nil
+	}
+	snapshot := SnapshotRequest(r)
+	e.request = &snapshot
+	return nil
+}
+
+// Request returns the snapshot attached by WithRequest, and false when there
+// is none.
+func (e *CoreException) Request() (RequestSnapshot, bool) {
+	if e == nil {
+		return RequestSnapshot{}, false
+	}
+	if e.request == nil {
+		return RequestSnapshot{}, false
+	}
+	return *e.request, true
+}
+
+// AttachRequest attaches a snapshot of a request to the first exception of
+// the chain of err supporting it (see `CoreException.WithRequest`), e.g. in
+// the middleware logging the server errors of the handlers. Errors without
+// such an exception, and sealed exceptions, are left unchanged.
+//
+// Parameters:
+//
+//	err: The error to enrich; nil is returned as is.
+//	r: The request that failed.
+//
+// Returns:
+//
+//	err.
+func AttachRequest(err error, r *http.Request) error {
+	var target interface {
+		error
+		WithRequest(*http.Request) error
+	}
+	if !IsNil(err) && errors.As(err, &target) && !IsNil(target) {
+		_ = target.WithRequest(r)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines how an error reports whether the
+// operation that produced it may be retried.
+package exception
+
+import (
+	"errors"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and its constants.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Retryable is implemented by errors that know whether the operation that
+// produced them may be retried. It takes precedence over the status code
+// based classification of `IsRetryable`.
+type Retryable interface {
+	// IsRetryable reports whether retrying the failed operation may succeed.
+	IsRetryable() bool
+}
+
+// retryableStatusCodes lists the status codes denoting transient failures.
+var retryableStatusCodes = map[status.StatusCode]bool{
+	status.RequestTimeout:     true,
+	status.TooEarly:           true,
+	status.TooManyRequests:    true,
+	status.BadGateway:         true,
+	status.ServiceUnavailable: true,
+	status.GatewayTimeout:     true,
+}
+
+// IsRetryableStatus reports whether a status code denotes a transient failure
+// (408, 425, 429, 502, 503 and 504), for which retrying may succeed.
+func IsRetryableStatus(code status.StatusCode) bool {
+	return retryableStatusCodes[code]
+}
+
+// IsRetryable reports whether the operation that produced err may be retried.
+// The first error of the chain implementing `Retryable` decides; otherwise
+// the status code of the first `CoreInterface` is classified with
+// `IsRetryableStatus`. Any other error is considered permanent.
+//
+// Parameters:
+//
+//	err: The error to classify. A nil error, or nil exception (see IsNil), is
+//	     not retryable.
+//
+// Returns:
+//
+//	True if retrying may succeed, false otherwise.
+func IsRetryable(err error) bool {
+	if IsNil(err) {
+		return false
+	}
+
+	// The chain cannot be walked past a nil exception (see IsNil), whose
+	// promoted Unwrap method panics.
+	coreErr, ok := exceptionOf(err)
+	var first CoreInterface
+	if !ok && errors.As(err, &first) {
+		return false
+	}
+
+	var retryable Retryable
+	if errors.As(err, &retryable) && !isNilValue(retryable) {
+		return retryable.IsRetryable()
+	}
+
+	if ok {
+		return IsRetryableStatus(status.StatusCode(coreErr.GetStatusCode()))
+	}
+
+	return false
+}
@@ -38,5 +38,5 @@ func NewRuntime(errors map[string]interface{}) *Runtime {
 	// Initialize the base CoreException with the given errors and a default
 	// status of InternalServerError, as runtime errors are typically server-side issues.
 	base := NewInstance(errors, status.InternalServerError)
-	return &Runtime{CoreException: *base}
+	return notified(&Runtime{CoreException: *base})
 }
@@ -0,0 +1,174 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the sampling of the stack traces
+// captured by the exceptions.
+package exception
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and its classes.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// maxSampledFingerprints bounds the fingerprints tracked by SamplePerSecond;
+// they are forgotten all at once beyond it.
+const maxSampledFingerprints = 10000
+
+// SamplingPolicy decides whether an exception captures its stack trace,
+// from its status code and message at creation. It must be fast and safe
+// for concurrent use.
+type SamplingPolicy func(code status.StatusCode, message string) bool
+
+// samplingPolicy holds the policy installed by SetStackSampling, if any.
+var samplingPolicy atomic.Pointer[SamplingPolicy]
+
+// SetStackSampling installs the policy deciding which exceptions capture
+// their stack trace, e.g. to keep the stacks of every server error but only
+// a sample of the client errors during an error storm:
+//
+//	exception.SetStackSampling(exception.SampleAny(
+//		exception.SampleByClass(1, 0.01),
+//		exception.SamplePerSecond(5, nil),
+//	))
+//
+// The exceptions that are not sampled have an empty stack trace and no
+// stack frames. By default, every exception captures its stack; note that
+// `VerifyCatalog` expects them to.
+//
+// Parameters:
+//
+//	policy: The policy to install, replacing the previous one; nil captures
+//	        every stack.
+//
+// Returns:
+//
+//	A function restoring the previous policy.
+func SetStackSampling(policy SamplingPolicy) (restore func()) {
+	var previous *SamplingPolicy
+	if policy == nil {
+		previous = samplingPolicy.Swap(nil)
+	} else {
+		previous = samplingPolicy.Swap(&policy)
+	}
+	return func() {
+		samplingPolicy.Store(previous)
+	}
+}
+
+// sampled reports whether an exception captures its stack trace.
+func sampled(code status.StatusCode, message string) bool {
+	if policy := samplingPolicy.Load(); policy != nil {
+		return (*policy)(code, message)
+	}
+	return true
+}
+
+// SampleByClass samples the server errors (5xx) and the other exceptions at
+// fixed rates. The sampling is deterministic, evenly spread rather than
+// random: a rate of 0.01 captures the 100th exception of its class, then the
+// 200th, etc.
+//
+// Parameters:
+//
+//	serverRate: The rate of the server errors, between 0 and 1.
+//	otherRate: The rate of the other exceptions, between 0 and 1.
+//
+// Returns:
+//
+//	The sampling policy.
+func SampleByClass(serverRate float64, otherRate float64) SamplingPolicy {
+	server, other := newRateSampler(serverRate), newRateSampler(otherRate)
+	return func(code status.StatusCode, _ string) bool {
+		if code.GetClass() == status.ServerErrorClass {
+			return server()
+		}
+		return other()
+	}
+}
+
+// newRateSampler returns a function reporting true at a rate.
+func newRateSampler(rate float64) func() bool {
+	var count atomic.Uint64
+	return func() bool {
+		switch {
+		case rate >= 1:
+			return true
+		case rate <= 0:
+			return false
+		}
+		n := count.Add(1)
+		return math.Floor(float64(n)*rate) > math.Floor(float64(n-1)*rate)
+	}
+}
+
+// SamplePerSecond samples at most n exceptions per second for each
+// fingerprint, the fingerprint of an exception being its status code and
+// message at creation.
+//
+// Parameters:
+//
+//	n: The number of stacks captured per second and fingerprint.
+//	now: Returns the current time. Defaults to `time.Now`; overridable in
+//	     tests.
+//
+// Returns:
+//
+//	The sampling policy.
+func SamplePerSecond(n int, now func() time.Time) SamplingPolicy {
+	if now == nil {
+		now = time.Now
+	}
+	type window struct {
+		second int64
+		count  int
+	}
+	type fingerprint struct {
+		code    status.StatusCode
+		message string
+	}
+	var mu sync.Mutex
+	windows := map[fingerprint]*window{}
+
+	return func(code status.StatusCode, message string) bool {
+		second := now().Unix()
+		key := fingerprint{code: code, message: message}
+
+		mu.Lock()
+		defer mu.Unlock()
+		w, ok := windows[key]
+		if !ok {
+			if len(windows) >= maxSampledFingerprints {
+				clear(windows)
+			}
+			w = &window{second: second}
+			windows[key] = w
+		}
+		if w.second != second {
+			w.second, w.count = second, 0
+		}
+		if w.count >= n {
+			return false
+		}
+		w.count++
+		return true
+	}
+}
+
+// SampleAny combines policies, capturing the stack of an exception when any
+// of them samples it. Each policy is consulted, so that they all count the
+// exception.
+func SampleAny(policies ...SamplingPolicy) SamplingPolicy {
+	return func(code status.StatusCode, message string) bool {
+		result := false
+		for _, policy := range policies {
+			if policy(code, message) {
+				result = true
+			}
+		}
+		return result
+	}
+}
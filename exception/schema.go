@@ -0,0 +1,373 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the JSON Schema of the envelope
+// of the exceptions and the validation of envelopes against it.
+package exception
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// EnvelopeSchema is the JSON Schema (draft 2020-12) of the envelope of the
+// exceptions, as returned by `Format()` and written by the response writers,
+// to publish alongside an API specification or load into the validators of
+// other languages. Its keys are in snake_case (see SetKeyNaming). It must not
+// be modified.
+//
+//go:embed envelope.schema.json
+var EnvelopeSchema []byte
+
+// EnvelopeSchemaV2 is the JSON Schema (draft 2020-12) of the v2 envelope of
+// the exceptions, as returned by FormatV2. Its keys are in snake_case (see
+// SetKeyNaming). It must not be modified.
+//
+//go:embed envelope.v2.schema.json
+var EnvelopeSchemaV2 []byte
+
+// strictEnvelopes reports whether Format and AppendJSON validate the
+// envelopes they return.
+var strictEnvelopes atomic.Bool
+
+// SetStrictEnvelopes enables or disables the strict mode, in which the
+// envelopes returned by `CoreException.Format` and `CoreException.AppendJSON`
+// are validated against EnvelopeSchema (see ValidateEnvelope): Format panics
+// with the validation error, and AppendJSON returns it. It is meant for the
+// test suites proving that every exception of a service is spec-compliant,
+// as it costs an encoding and a validation per envelope:
+//
+//	func TestMain(m *testing.M) {
+//		exception.SetStrictEnvelopes(true)
+//		os.Exit(m.Run())
+//	}
+//
+// Parameters:
+//
+//	enabled: Whether to validate.
+//
+// Returns:
+//
+//	A function restoring the previous setting.
+func SetStrictEnvelopes(enabled bool) (restore func()) {
+	previous := strictEnvelopes.Swap(enabled)
+	return func() {
+		strictEnvelopes.Store(previous)
+	}
+}
+
+// ValidateEnvelope validates a JSON error envelope against EnvelopeSchema,
+// e.g. the body of an error response in a contract test. The built-in keys
+// of the schema are renamed by the strategy set by `SetKeyNaming` (e.g.,
+// "errorCode" is required in camelCase).
+//
+// Parameters:
+//
+//	data: The JSON envelope.
+//
+// Returns:
+//
+//	Nil if the envelope is valid, otherwise an `*InvalidArgument` whose
+//	details list the "violations", each prefixed with the JSON Pointer of
+//	the offending value (e.g., "/error_code: 200 is less than 400").
+func ValidateEnvelope(data []byte) error {
+	schemaOnce.Do(loadSchema)
+	return validateEnvelope(envelopeSchemas[CurrentKeyNaming()], data)
+}
+
+// ValidateEnvelopeV2 validates a JSON v2 error envelope against
+// EnvelopeSchemaV2, like ValidateEnvelope.
+//
+// Parameters:
+//
+//	data: The JSON envelope.
+//
+// Returns:
+//
+//	Nil if the envelope is valid, otherwise an `*InvalidArgument` whose
+//	details list the "violations" (e.g., "/error/code: 200 is less than
+//	400").
+func ValidateEnvelopeV2(data []byte) error {
+	schemaOnce.Do(loadSchema)
+	return validateEnvelope(envelopeSchemasV2[CurrentKeyNaming()], data)
+}
+
+// validateEnvelope validates a JSON envelope against a decoded schema.
+func validateEnvelope(schema map[string]interface{}, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var envelope interface{}
+	if err := decoder.Decode(&envelope); err != nil {
+		return invalidEnvelope([]string{"the envelope is not valid JSON: " + err.Error()})
+	}
+	if decoder.More() {
+		return invalidEnvelope([]string{"the envelope is followed by other JSON values"})
+	}
+
+	var violations []string
+	validateSchema(schema, envelope, "", &violations)
+	if len(violations) > 0 {
+		return invalidEnvelope(violations)
+	}
+	return nil
+}
+
+// invalidEnvelope builds the error of an invalid envelope.
+func invalidEnvelope(violations []string) error {
+	return NewInvalidArgument(map[string]interface{}{
+		"message": fmt.Sprintf("The envelope does not match its JSON Schema: %s.", violations[0]),
+		"details": map[string]interface{}{"error": "invalid_envelope", "violations": violations},
+	})
+}
+
+// validateFormat validates the envelope returned by Format in strict mode.
+func validateFormat(formatted map[string]interface{}) error {
+	encoded, err := json.Marshal(formatted)
+	if err != nil {
+		return invalidEnvelope([]string{"the envelope cannot be encoded as JSON: " + err.Error()})
+	}
+	return ValidateEnvelope(encoded)
+}
+
+// envelopeSchemas and envelopeSchemasV2 are EnvelopeSchema and
+// EnvelopeSchemaV2, decoded once, by KeyNaming.
+var (
+	schemaOnce        sync.Once
+	envelopeSchemas   [2]map[string]interface{}
+	envelopeSchemasV2 [2]map[string]interface{}
+)
+
+// loadSchema decodes EnvelopeSchema and EnvelopeSchemaV2.
+func loadSchema() {
+	envelopeSchemas = decodeSchema(EnvelopeSchema)
+	envelopeSchemasV2 = decodeSchema(EnvelopeSchemaV2)
+}
+
+// decodeSchema decodes a schema, valid JSON by construction, for each
+// KeyNaming.
+func decodeSchema(data []byte) [2]map[string]interface{} {
+	var schemas [2]map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	_ = decoder.Decode(&schemas[SnakeCase])
+	schemas[CamelCase], _ = renameSchema(schemas[SnakeCase], CamelCase).(map[string]interface{})
+	return schemas
+}
+
+// renameSchema returns a copy of a schema whose properties and required
+// members are renamed by naming.
+func renameSchema(schema interface{}, naming KeyNaming) interface{} {
+	object, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+	renamed := make(map[string]interface{}, len(object))
+	for keyword, value := range object {
+		switch keyword {
+		case "properties":
+			properties, _ := value.(map[string]interface{})
+			renamedProperties := make(map[string]interface{}, len(properties))
+			for name, property := range properties {
+				renamedProperties[naming.Key(name)] = renameSchema(property, naming)
+			}
+			renamed[keyword] = renamedProperties
+		case "required":
+			names, _ := value.([]interface{})
+			renamedNames := make([]interface{}, len(names))
+			for i, name := range names {
+				if key, ok := name.(string); ok {
+					renamedNames[i] = naming.Key(key)
+				} else {
+					renamedNames[i] = name
+				}
+			}
+			renamed[keyword] = renamedNames
+		default:
+			renamed[keyword] = renameSchema(value, naming)
+		}
+	}
+	return renamed
+}
+
+// validateSchema appends the violations of a value to a schema. It supports
+// the keywords used by EnvelopeSchema: type, const, enum, required,
+// properties, additionalProperties, items, minimum, maximum and minLength.
+func validateSchema(schema map[string]interface{}, value interface{}, pointer string, violations *[]string) {
+	fail := func(format string, args ...interface{}) {
+		location := pointer
+		if location == "" {
+			location = "/"
+		}
+		*violations = append(*violations, location+": "+fmt.Sprintf(format, args...))
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		fail("%s is not of type %s", describe(value), describeTypes(types))
+		return
+	}
+	if expected, ok := schema["const"]; ok && !equalJSON(expected, value) {
+		fail("%s is not %s", describe(value), describe(expected))
+	}
+	if allowed, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range allowed {
+			found = found || equalJSON(candidate, value)
+		}
+		if !found {
+			fail("%s is not one of the allowed values", describe(value))
+		}
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		if minimum, ok := schema["minimum"].(json.Number); ok && compareNumbers(v, minimum) < 0 {
+			fail("%s is less than %s", v, minimum)
+		}
+		if maximum, ok := schema["maximum"].(json.Number); ok && compareNumbers(v, maximum) > 0 {
+			fail("%s is greater than %s", v, maximum)
+		}
+	case string:
+		if minLength, ok := schema["minLength"].(json.Number); ok {
+			if n, err := minLength.Int64(); err == nil && int64(utf8.RuneCountInString(v)) < n {
+				fail("the string is shorter than %d characters", n)
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if key, ok := name.(string); ok {
+					if _, present := v[key]; !present {
+						fail("the member %q is missing", key)
+					}
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := pointer + "/" + escapePointer(key)
+			if property, ok := properties[key].(map[string]interface{}); ok {
+				validateSchema(property, v[key], child, violations)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					fail("the member %q is not allowed", key)
+				}
+			case map[string]interface{}:
+				validateSchema(additional, v[key], child, violations)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateSchema(items, item, fmt.Sprintf("%s/%d", pointer, i), violations)
+			}
+		}
+	}
+}
+
+// matchesType reports whether a value has one of the types of the "type"
+// keyword, a name or a list of names.
+func matchesType(types interface{}, value interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return hasType(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if s, ok := name.(string); ok && hasType(s, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasType reports whether a decoded JSON value has a JSON Schema type.
+func hasType(name string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case json.Number:
+		if name == "number" {
+			return true
+		}
+		if name != "integer" {
+			return false
+		}
+		// An integer is a number without fractional part, whatever its
+		// notation (e.g., 1.0 or 1e3).
+		r, ok := new(big.Rat).SetString(v.String())
+		return ok && r.IsInt()
+	case []interface{}:
+		return name == "array"
+	case map[string]interface{}:
+		return name == "object"
+	}
+	return false
+}
+
+// compareNumbers compares two JSON numbers exactly.
+func compareNumbers(a, b json.Number) int {
+	x, okX := new(big.Rat).SetString(a.String())
+	y, okY := new(big.Rat).SetString(b.String())
+	if !okX || !okY {
+		return 0
+	}
+	return x.Cmp(y)
+}
+
+// equalJSON reports whether two decoded JSON values are equal.
+func equalJSON(a, b interface{}) bool {
+	if x, ok := a.(json.Number); ok {
+		y, ok := b.(json.Number)
+		return ok && compareNumbers(x, y) == 0
+	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// describe describes a decoded JSON value in a violation.
+func describe(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if len(encoded) > 40 {
+		return string(encoded[:37]) + "..."
+	}
+	return string(encoded)
+}
+
+// describeTypes describes the types of the "type" keyword in a violation.
+func describeTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, name := range list {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+// escapePointer escapes a member name in a JSON Pointer (RFC 6901).
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
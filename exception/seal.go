@@ -0,0 +1,50 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the sealing of the exceptions
+// that were sent to the client.
+package exception
+
+import (
+	"fmt"
+)
+
+// Seal marks the exception immutable, typically once it has been formatted
+// for the response or logged, so that a middleware cannot modify an error
+// the client has already received: `SetError` and `SetMessage` then return
+// a `*Logic` exception instead of modifying it. It caches the envelope (see
+// CacheFormat), which can no longer change. The response writers seal the
+// exceptions they write with a context (e.g., `response.WriteErrorContext`).
+// As with CacheFormat, direct modifications of the fields or of the maps of
+// `Errors` are not prevented, and must not follow Seal.
+func (e *CoreException) Seal() {
+	if e == nil {
+		return
+	}
+	e.CacheFormat()
+	e.sealed = true
+}
+
+// IsSealed reports whether the exception was sealed (see Seal).
+func (e *CoreException) IsSealed() bool {
+	if e == nil {
+		return false
+	}
+	return e.sealed
+}
+
+// checkMutable returns the exception rejecting a mutation of a sealed or nil
+// exception, or nil.
+func (e *CoreException) checkMutable(operation string) error {
+	if e == nil {
+		return NewLogic(map[string]interface{}{
+			"message": fmt.Sprintf("The exception is nil: %s cannot modify it.", operation),
+			"details": map[string]interface{}{"operation": operation, "error": "exception_nil"},
+		})
+	}
+	if !e.sealed {
+		return nil
+	}
+	return NewLogic(map[string]interface{}{
+		"message": fmt.Sprintf("The exception is sealed: %s cannot modify it.", operation),
+		"details": map[string]interface{}{"operation": operation, "error": "exception_sealed"},
+	})
+}
@@ -0,0 +1,200 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the sentinel exceptions declared
+// at package level with Define, and the instances raised from them.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type of the sentinels.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Sentinel is a predefined exception, declared once at package level and
+// compared with `errors.Is`:
+//
+//	var ErrOrderNotFound = exception.Define("ORDER_NOT_FOUND", status.NotFound, "The order was not found.")
+//
+//	return ErrOrderNotFound.Raise(map[string]interface{}{"order_id": id})
+//	...
+//	if errors.Is(err, ErrOrderNotFound) { ... }
+//
+// Its code is the "error" entry of its details. Its `Format()` output and
+// JSON encoding are computed once by Define, for each strategy of
+// SetKeyNaming. A Sentinel is immutable: it has
+// no `SetError` or `SetMessage`, so the request identifiers and translations
+// applied by the response writers only reach the raised instances (see
+// Raise). It is safe for concurrent use.
+type Sentinel struct {
+	code       string
+	statusCode status.StatusCode
+	message    string
+
+	formatted [2]map[string]interface{} // The cached outputs of Format, by KeyNaming.
+	encoded   [2][]byte                 // The cached outputs of AppendJSON, by KeyNaming.
+}
+
+// Define declares a sentinel exception.
+//
+// Parameters:
+//
+//	code: The code of the sentinel (e.g., "ORDER_NOT_FOUND"), stored as the
+//	      "error" entry of its details.
+//	statusCode: The status code of the sentinel and of its instances.
+//	message: The message of the sentinel; it defaults to the description of
+//	         the status code.
+//
+// Returns:
+//
+//	A pointer to a new Sentinel.
+func Define(code string, statusCode status.StatusCode, message string) *Sentinel {
+	if message == "" {
+		message = statusCode.GetDescription()
+	}
+	s := &Sentinel{code: code, statusCode: statusCode, message: message}
+
+	base := CoreException{Message: message, StatusCode: statusCode, Errors: s.GetErrors()}
+	for _, naming := range []KeyNaming{SnakeCase, CamelCase} {
+		s.formatted[naming] = base.format(naming)
+		// The errors of a sentinel are strings only, so the encoding cannot
+		// fail.
+		s.encoded[naming], _ = base.appendJSON(nil, naming)
+	}
+	return s
+}
+
+// Code returns the code of the sentinel.
+func (s *Sentinel) Code() string {
+	return s.code
+}
+
+// Error returns the message of the sentinel.
+func (s *Sentinel) Error() string {
+	return s.message
+}
+
+// GetStatusCode returns the status code of the sentinel.
+func (s *Sentinel) GetStatusCode() int {
+	return s.statusCode.GetValue()
+}
+
+// GetErrors returns a new map holding the details of the sentinel.
+func (s *Sentinel) GetErrors() map[string]interface{} {
+	return map[string]interface{}{"details": s.GetDetails()}
+}
+
+// GetDetails returns a new map holding the code of the sentinel under the
+// "error" key.
+func (s *Sentinel) GetDetails() map[string]interface{} {
+	return map[string]interface{}{"error": s.code}
+}
+
+// GetDetailsMessage returns the code of the sentinel.
+func (s *Sentinel) GetDetailsMessage() string {
+	return s.code
+}
+
+// GetString returns the string entry of the errors of the sentinel under
+// key (see `CoreException.GetString`).
+func (s *Sentinel) GetString(key string) (string, bool) {
+	return StringOf(s.GetErrors(), key)
+}
+
+// GetInt returns the integer entry of the errors of the sentinel under key;
+// they hold none.
+func (s *Sentinel) GetInt(key string) (int, bool) {
+	return IntOf(s.GetErrors(), key)
+}
+
+// GetMap returns the map entry of the errors of the sentinel under key, a
+// new map for "details".
+func (s *Sentinel) GetMap(key string) (map[string]interface{}, bool) {
+	return MapOf(s.GetErrors(), key)
+}
+
+// GetSlice returns the slice entry of the errors of the sentinel under key;
+// they hold none.
+func (s *Sentinel) GetSlice(key string) ([]interface{}, bool) {
+	return SliceOf(s.GetErrors(), key)
+}
+
+// Headers returns the response headers implied by the status code of the
+// sentinel (see `HeadersOf`), e.g. the default WWW-Authenticate challenge of
+// a 401.
+func (s *Sentinel) Headers() map[string]string {
+	return HeadersOf(s.GetStatusCode(), s.GetDetails())
+}
+
+// GetErrorsForLog returns the message, status code and errors of the
+// sentinel, and the deployment context set by `SetAppInfo`. Its stack trace
+// is empty, as it is not raised anywhere in particular.
+func (s *Sentinel) GetErrorsForLog() map[string]interface{} {
+	return withAppInfo(map[string]interface{}{
+		"message":     s.message,
+		"status_code": s.statusCode.GetValue(),
+		"errors":      s.GetErrors(),
+		"stack_trace": "",
+	})
+}
+
+// GetStackTrace returns an empty string: use Raise to capture one.
+func (s *Sentinel) GetStackTrace() string {
+	return ""
+}
+
+// Format returns a deep copy of the cached envelope of the sentinel, so
+// that a caller modifying it, or its "details", leaves the sentinel intact.
+func (s *Sentinel) Format() map[string]interface{} {
+	return deepCopyMap(s.formatted[CurrentKeyNaming()])
+}
+
+// AppendJSON appends the cached JSON encoding of the envelope of the
+// sentinel to dst (see `CoreException.AppendJSON`).
+func (s *Sentinel) AppendJSON(dst []byte) ([]byte, error) {
+	return append(dst, s.encoded[CurrentKeyNaming()]...), nil
+}
+
+// Raise creates an instance of the sentinel, capturing the stack of its
+// caller, with its status code, its message and a copy of its details
+// overlaid with the given ones. The instance matches the sentinel with
+// `errors.Is`.
+//
+// Parameters:
+//
+//	details: The entries merged into the details of the instance (e.g., the
+//	         identifier of the missing order); it may be nil. An "error"
+//	         entry replaces the code of the sentinel.
+//
+// Returns:
+//
+//	A pointer to a new Raised exception.
+func (s *Sentinel) Raise(details map[string]interface{}) *Raised {
+	merged := make(map[string]interface{}, len(details)+1)
+	merged["error"] = s.code
+	for key, value := range details {
+		merged[key] = value
+	}
+
+	base := NewInstance(map[string]interface{}{"message": s.message, "details": merged}, s.statusCode)
+	return notified(&Raised{CoreException: *base, sentinel: s})
+}
+
+// Raised is an exception raised from a Sentinel. It embeds `CoreException`
+// to inherit all its properties and methods, ensuring consistent error
+// reporting and formatting.
+type Raised struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+
+	sentinel *Sentinel // The sentinel the exception was raised from.
+}
+
+// Sentinel returns the sentinel the exception was raised from.
+func (e *Raised) Sentinel() *Sentinel {
+	return e.sentinel
+}
+
+// Is reports whether target is the sentinel the exception was raised from,
+// for `errors.Is`.
+func (e *Raised) Is(target error) bool {
+	s, ok := target.(*Sentinel)
+	return ok && s == e.sentinel
+}
@@ -0,0 +1,43 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// temporarily unavailable services, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.ServiceUnavailable` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// ServiceUnavailable is a specific exception type that signifies that a
+// service, or one of its dependencies, is temporarily unable to handle the
+// request (e.g., overload, maintenance, open circuit breaker). Retrying later
+// is expected to succeed.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type ServiceUnavailable struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewServiceUnavailable creates and returns a new `ServiceUnavailable` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.ServiceUnavailable`. This status
+// code tells clients that the failure is temporary and that the request may
+// be retried later.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the unavailability. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `ServiceUnavailable` instance.
+func NewServiceUnavailable(errors map[string]interface{}) *ServiceUnavailable {
+	// Initialize the base CoreException with the given errors and a default
+	// status of ServiceUnavailable, as the failure is expected to be temporary.
+	base := NewInstance(errors, status.ServiceUnavailable)
+	return notified(&ServiceUnavailable{CoreException: *base})
+}
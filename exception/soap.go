@@ -0,0 +1,93 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the formatting of an exception
+// as a SOAP Fault element.
+package exception
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type whose class gives the fault code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// SOAPVersion is a version of the SOAP protocol.
+type SOAPVersion int
+
+// Supported SOAP versions.
+const (
+	SOAP11 SOAPVersion = iota // SOAP 1.1, namespace "http://schemas.xmlsoap.org/soap/envelope/".
+	SOAP12                    // SOAP 1.2, namespace "http://www.w3.org/2003/05/soap-envelope".
+)
+
+// Namespaces of the SOAP envelopes.
+const (
+	SOAP11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	SOAP12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// FormatSOAPFault returns the exception as a SOAP Fault element, to be
+// placed in the Body of a SOAP envelope. The fault code is given by the
+// class of its status code: "Client" (1.1) or "Sender" (1.2) for the client
+// errors, "Server" (1.1) or "Receiver" (1.2) otherwise. The fault string (1.1)
+// or reason (1.2) is the message of the exception, and the detail holds its
+// status code under "error_code" and the entries of its `Errors`, as
+// elements, like in `FormatXML`.
+//
+// Example of SOAP 1.1 fault, indented for readability:
+//
+//	<soap:Fault xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+//	  <faultcode>soap:Client</faultcode>
+//	  <faultstring>The order was not found.</faultstring>
+//	  <detail><details><error>ORDER_NOT_FOUND</error></details><error_code>404</error_code></detail>
+//	</soap:Fault>
+//
+// Parameters:
+//
+//	e: The exception to format.
+//	version: The SOAP version of the fault, `SOAP11` or `SOAP12`.
+//
+// Returns:
+//
+//	The Fault element, or an error if `Errors` holds a value that JSON does
+//	not support (e.g., NaN, a channel), which is how the values are
+//	normalized.
+func FormatSOAPFault(e CoreInterface, version SOAPVersion) ([]byte, error) {
+	fields, err := xmlFields(e.GetErrors())
+	if err != nil {
+		return nil, err
+	}
+	fields["error_code"] = json.Number(strconv.Itoa(e.GetStatusCode()))
+
+	client := status.StatusCode(e.GetStatusCode()).GetClass() == status.ClientErrorClass
+	var buf bytes.Buffer
+	if version == SOAP12 {
+		code := "env:Receiver"
+		if client {
+			code = "env:Sender"
+		}
+		buf.WriteString(`<env:Fault xmlns:env="` + SOAP12Namespace + `">`)
+		buf.WriteString("<env:Code><env:Value>" + code + "</env:Value></env:Code>")
+		buf.WriteString(`<env:Reason><env:Text xml:lang="en">`)
+		_ = xml.EscapeText(&buf, []byte(e.Error()))
+		buf.WriteString("</env:Text></env:Reason><env:Detail>")
+		writeXMLFields(&buf, fields)
+		buf.WriteString("</env:Detail></env:Fault>")
+		return buf.Bytes(), nil
+	}
+
+	code := "soap:Server"
+	if client {
+		code = "soap:Client"
+	}
+	buf.WriteString(`<soap:Fault xmlns:soap="` + SOAP11Namespace + `">`)
+	buf.WriteString("<faultcode>" + code + "</faultcode><faultstring>")
+	_ = xml.EscapeText(&buf, []byte(e.Error()))
+	buf.WriteString("</faultstring><detail>")
+	writeXMLFields(&buf, fields)
+	buf.WriteString("</detail></soap:Fault>")
+	return buf.Bytes(), nil
+}
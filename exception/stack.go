@@ -0,0 +1,105 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the lazy capture of the stack
+// traces of the exceptions.
+package exception
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxStackDepth is the maximum number of frames of a captured stack trace.
+const maxStackDepth = 64
+
+// StackFrame is a frame of the stack trace of an exception.
+type StackFrame struct {
+	Function string `json:"function"` // The fully qualified name of the function (e.g., "main.handle").
+	File     string `json:"file"`     // The path of the source file.
+	Line     int    `json:"line"`     // The line in the source file.
+}
+
+// stack is a captured stack trace. Only the program counters are recorded
+// when an exception is created; they are resolved into frames, and those
+// formatted, on the first call of Frames or String, which is what makes the
+// creation of an exception cheap on the error paths that never log it.
+type stack struct {
+	pcs [maxStackDepth]uintptr
+	n   int
+
+	once      sync.Once
+	frames    []StackFrame
+	formatted string
+}
+
+// captureStack records the stack of the calling goroutine.
+//
+// Parameters:
+//
+//	skip: The number of frames to skip above the caller of captureStack
+//	      (0 starts the trace at the caller itself).
+//
+// Returns:
+//
+//	A pointer to the captured stack.
+func captureStack(skip int) *stack {
+	s := &stack{}
+	// Skip runtime.Callers and captureStack.
+	s.n = runtime.Callers(skip+2, s.pcs[:])
+	return s
+}
+
+// recapture records the stack of the calling goroutine into a stack that is
+// no longer used, such as the one of a released pooled exception.
+//
+// Parameters:
+//
+//	skip: The number of frames to skip above the caller of recapture.
+func (s *stack) recapture(skip int) {
+	*s = stack{}
+	// Skip runtime.Callers and recapture.
+	s.n = runtime.Callers(skip+2, s.pcs[:])
+}
+
+// resolve resolves the frames of the stack and formats them, once.
+func (s *stack) resolve() {
+	s.once.Do(func() {
+		if s.n == 0 {
+			return
+		}
+		s.frames = make([]StackFrame, 0, s.n)
+		var b strings.Builder
+		frames := runtime.CallersFrames(s.pcs[:s.n])
+		for {
+			frame, more := frames.Next()
+			s.frames = append(s.frames, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			if !more {
+				break
+			}
+		}
+		s.formatted = b.String()
+	})
+}
+
+// Frames returns a copy of the frames of the stack, from the innermost
+// frame, resolved once. It is nil for a nil stack.
+func (s *stack) Frames() []StackFrame {
+	if s == nil {
+		return nil
+	}
+	s.resolve()
+	return append([]StackFrame(nil), s.frames...)
+}
+
+// String returns the formatted stack trace, one "function\n\tfile:line"
+// entry per frame, from the innermost frame. It is formatted once, and is
+// empty for a nil stack.
+func (s *stack) String() string {
+	if s == nil {
+		return ""
+	}
+	s.resolve()
+	return s.formatted
+}
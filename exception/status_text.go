@@ -0,0 +1,63 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the per-instance override of the
+// reason phrase of the status code of an exception.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and its descriptions.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// StatusTextKey is the key of the errors map given to a constructor that
+// overrides the reason phrase of the status code of the exception, without
+// altering the global status table:
+//
+//	exception.NewValidation(map[string]interface{}{
+//		exception.StatusTextKey: "Validation Failed", // Instead of "Unprocessable Content".
+//	})
+//
+// The phrase becomes the default message of the exception, and the title of
+// its HTML error page and problem details.
+const StatusTextKey = "status_text"
+
+// GetStatusText returns the reason phrase of the status code of the
+// exception: its StatusText, or the description of its status code.
+func (e *CoreException) GetStatusText() string {
+	if e == nil {
+		return ""
+	}
+	if e.StatusText != "" {
+		return e.StatusText
+	}
+	return e.StatusCode.GetDescription()
+}
+
+// StatusText returns the reason phrase of the status code of an exception:
+// the one returned by its GetStatusText method, when it has one (e.g., the
+// types embedding `CoreException`), or the description of its status code.
+//
+// Parameters:
+//
+//	e: The exception.
+//
+// Returns:
+//
+//	The reason phrase (e.g., "Not Found").
+func StatusText(e CoreInterface) string {
+	if texter, ok := e.(interface{ GetStatusText() string }); ok {
+		return texter.GetStatusText()
+	}
+	return status.StatusCode(e.GetStatusCode()).GetDescription()
+}
+
+// takeStatusText removes the `StatusTextKey` entry of the errors map given to
+// a constructor, returning it.
+func takeStatusText(errors map[string]interface{}) string {
+	text, ok := errors[StatusTextKey].(string)
+	if !ok {
+		return ""
+	}
+	delete(errors, StatusTextKey)
+	return text
+}
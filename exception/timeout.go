@@ -0,0 +1,41 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// operations that did not complete in time, leveraging the core exception
+// handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.GatewayTimeout` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Timeout is a specific exception type that signifies that an operation did
+// not complete within its deadline (e.g., an outgoing HTTP call or a database
+// query that timed out). Retrying later may succeed.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type Timeout struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewTimeout creates and returns a new `Timeout` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.GatewayTimeout`. This status
+// code tells clients that an upstream dependency did not answer in time.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the operation that timed out. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `Timeout` instance.
+func NewTimeout(errors map[string]interface{}) *Timeout {
+	// Initialize the base CoreException with the given errors and a default
+	// status of GatewayTimeout, as the operation waited too long for a dependency.
+	base := NewInstance(errors, status.GatewayTimeout)
+	return notified(&Timeout{CoreException: *base})
+}
@@ -0,0 +1,42 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// requests rejected by rate limiting, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.TooManyRequests` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// TooManyRequests is a specific exception type that signifies that the client
+// has sent too many requests in a given amount of time and has been rate
+// limited. It usually carries the applicable limit, the remaining quota and
+// the time at which the quota resets, so that clients can back off.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type TooManyRequests struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewTooManyRequests creates and returns a new `TooManyRequests` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.TooManyRequests`. This status
+// code tells clients to slow down and retry after the quota resets.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the rate limit. This map can include a "message" key which
+//	        will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `TooManyRequests` instance.
+func NewTooManyRequests(errors map[string]interface{}) *TooManyRequests {
+	// Initialize the base CoreException with the given errors and a default
+	// status of TooManyRequests, as the client exceeded its request quota.
+	base := NewInstance(errors, status.TooManyRequests)
+	return notified(&TooManyRequests{CoreException: *base})
+}
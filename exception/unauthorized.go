@@ -0,0 +1,41 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// missing or invalid authentication, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Unauthorized` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Unauthorized is a specific exception type that signifies that a request lacks
+// valid authentication credentials (e.g., a missing or expired token, or an
+// invalid signature).
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type Unauthorized struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewUnauthorized creates and returns a new `Unauthorized` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.Unauthorized`. This status
+// code tells clients to authenticate, or to authenticate again.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the authentication failure. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `Unauthorized` instance.
+func NewUnauthorized(errors map[string]interface{}) *Unauthorized {
+	// Initialize the base CoreException with the given errors and a default
+	// status of Unauthorized, as the request is not authenticated.
+	base := NewInstance(errors, status.Unauthorized)
+	return notified(&Unauthorized{CoreException: *base})
+}
@@ -41,5 +41,5 @@ func NewUnderflow(errors map[string]interface{}) *Underflow {
 	// status of InternalServerError, as underflow issues typically represent
 	// internal computational errors.
 	base := NewInstance(errors, status.InternalServerError)
-	return &Underflow{CoreException: *base}
+	return notified(&Underflow{CoreException: *base})
 }
@@ -0,0 +1,57 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines how errors that are not
+// exceptions are normalized into exceptions.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.InternalServerError` status of unexpected errors.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// unexpected is an internal server error exception wrapping an error that is
+// not an exception. Its message is the generic internal error description,
+// so that the cause is not leaked to clients; the cause is logged and
+// unwrapped.
+type unexpected struct {
+	CoreException       // Embeds CoreException to inherit its fields and methods.
+	cause         error // The original error.
+}
+
+// Unwrap returns the original error.
+func (e *unexpected) Unwrap() error {
+	return e.cause
+}
+
+// GetErrorsForLog extends `CoreException.GetErrorsForLog` with the message
+// of the original error under the "cause" key.
+func (e *unexpected) GetErrorsForLog() map[string]interface{} {
+	entry := e.CoreException.GetErrorsForLog()
+	entry["cause"] = messageOf(e.cause)
+	return entry
+}
+
+// Normalize guarantees that an error carries a `CoreInterface`. It returns
+// nil when err is nil or holds a nil pointer (see IsNil), err unchanged when
+// its chain already contains a `CoreInterface`, and wraps it into an internal
+// server error exception otherwise. The wrapping exception unwraps to err, so that `errors.Is` still
+// matches sentinel errors such as `context.Canceled`.
+//
+// Parameters:
+//
+//	err: The error to normalize.
+//
+// Returns:
+//
+//	Nil, or an error whose chain contains a `CoreInterface`.
+func Normalize(err error) error {
+	if IsNil(err) {
+		return nil
+	}
+	if _, ok := exceptionOf(err); ok {
+		return err
+	}
+
+	base := NewInstance(map[string]interface{}{}, status.InternalServerError)
+	return notified(&unexpected{CoreException: *base, cause: err})
+}
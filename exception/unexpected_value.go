@@ -41,5 +41,5 @@ func NewUnexpectedValue(errors map[string]interface{}) *UnexpectedValue {
 	// status of UnprocessableContent, as unexpected values often relate to
 	// semantically incorrect input data that cannot be processed.
 	base := NewInstance(errors, status.UnprocessableContent)
-	return &UnexpectedValue{CoreException: *base}
+	return notified(&UnexpectedValue{CoreException: *base})
 }
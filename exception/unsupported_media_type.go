@@ -0,0 +1,44 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// requests whose body is in a media type the server does not support,
+// leveraging the core exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.UnsupportedMediaType` constant for setting the default
+	// status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// UnsupportedMediaType is a specific exception type that signifies that the
+// body of a request is in a media type, given by its Content-Type header,
+// that the server does not accept for the target resource. It usually lists
+// the supported media types, so that clients can convert their payload.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type UnsupportedMediaType struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewUnsupportedMediaType creates and returns a new `UnsupportedMediaType`
+// exception. It initializes the embedded `CoreException` with the provided
+// error details and sets the default status code to
+// `status.UnsupportedMediaType`. This status code tells clients that the
+// server refuses the format of the payload, not its content.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the rejected media type. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `UnsupportedMediaType` instance.
+func NewUnsupportedMediaType(errors map[string]interface{}) *UnsupportedMediaType {
+	// Initialize the base CoreException with the given errors and a default
+	// status of UnsupportedMediaType, as the payload format is refused.
+	base := NewInstance(errors, status.UnsupportedMediaType)
+	return notified(&UnsupportedMediaType{CoreException: *base})
+}
@@ -0,0 +1,44 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// requests targeting an API version that is not supported, leveraging the
+// core exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.BadRequest` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// UnsupportedVersion is a specific exception type that signifies that a
+// request targets an API version the server does not support (anymore). It
+// usually lists the supported versions, so that clients can migrate. Its
+// status code is 400 Bad Request, or 406 Not Acceptable when the version was
+// negotiated through the media type of the Accept header.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type UnsupportedVersion struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewUnsupportedVersion creates and returns a new `UnsupportedVersion`
+// exception. It initializes the embedded `CoreException` with the provided
+// error details and sets the default status code to `status.BadRequest`.
+// Callers negotiating the version through the Accept header override it
+// with `status.NotAcceptable`.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the requested version. This map can include a "message" key
+//	        which will be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `UnsupportedVersion` instance.
+func NewUnsupportedVersion(errors map[string]interface{}) *UnsupportedVersion {
+	// Initialize the base CoreException with the given errors and a default
+	// status of BadRequest, as the request targets an unknown version.
+	base := NewInstance(errors, status.BadRequest)
+	return notified(&UnsupportedVersion{CoreException: *base})
+}
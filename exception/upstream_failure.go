@@ -0,0 +1,42 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// failures of the upstream services, leveraging the core exception handling
+// mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.BadGateway` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// UpstreamFailure is a specific exception type that signifies that a service
+// the application depends on (e.g., a cloud API, a partner service) failed
+// to serve a request on its side. The name of the service is usually given
+// under the "service" key of its details.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type UpstreamFailure struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewUpstreamFailure creates and returns a new `UpstreamFailure` exception.
+// It initializes the embedded `CoreException` with the provided error
+// details and sets the default status code to `status.BadGateway`, as the
+// application could not get a valid response from the upstream service.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the failure. This map can include a "message" key which will
+//	        be used as the primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `UpstreamFailure` instance.
+func NewUpstreamFailure(errors map[string]interface{}) *UpstreamFailure {
+	// Initialize the base CoreException with the given errors and a default
+	// status of BadGateway, as the failure lies in the upstream service.
+	base := NewInstance(errors, status.BadGateway)
+	return notified(&UpstreamFailure{CoreException: *base})
+}
@@ -0,0 +1,44 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines a specific exception type for
+// input validation failures, leveraging the core exception handling mechanisms.
+package exception
+
+import (
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.UnprocessableContent` constant for setting the default status code.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// Validation is a specific exception type that signifies that the input of an
+// operation failed one or more validation rules. It is typically created with
+// an "errors" entry mapping each offending field to the rules it violated,
+// so that clients can highlight every invalid field at once.
+// It embeds `CoreException` to inherit all its properties and methods,
+// ensuring consistent error reporting and formatting.
+type Validation struct {
+	CoreException // Embeds CoreException to inherit its fields and methods.
+}
+
+// NewValidation creates and returns a new `Validation` exception.
+// It initializes the embedded `CoreException` with the provided error details
+// and sets the default status code to `status.UnprocessableContent`. This
+// status code is appropriate when the request is well-formed but its content
+// does not satisfy the validation rules of the server.
+//
+// Parameters:
+//
+//	errors: A map of string to interface{} containing detailed error information
+//	        about the validation failure, usually including a per-field "errors"
+//	        map. This map can include a "message" key which will be used as the
+//	        primary error message for the exception.
+//
+// Returns:
+//
+//	A pointer to a new `Validation` instance.
+func NewValidation(errors map[string]interface{}) *Validation {
+	// Initialize the base CoreException with the given errors and a default
+	// status of UnprocessableContent, as validation errors concern well-formed
+	// but semantically invalid input.
+	base := NewInstance(errors, status.UnprocessableContent)
+	return notified(&Validation{CoreException: *base})
+}
@@ -0,0 +1,119 @@
+// Package exception provides a structured and standardized approach to error handling
+// within the application. This file defines the formatting of an exception
+// as an XML document.
+package exception
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FormatXML returns the `Format()` envelope of an exception as an XML
+// document whose root is an "error" element, for the clients of XML APIs.
+// The entries of the envelope are elements: the keys, sorted, are the
+// element names (the characters not allowed in XML names are replaced with
+// "_"), maps become nested elements, and each item of a list becomes an
+// "item" element:
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<error><error_code>404</error_code><message>Not Found</message><status>error</status></error>
+//
+// Parameters:
+//
+//	e: The exception to format.
+//
+// Returns:
+//
+//	The document, or an error if the envelope holds a value that JSON does
+//	not support (e.g., NaN, a channel), which is how the values are
+//	normalized.
+func FormatXML(e CoreInterface) ([]byte, error) {
+	fields, err := xmlFields(e.Format())
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	writeXMLElement(&buf, "error", fields)
+	return buf.Bytes(), nil
+}
+
+// xmlFields normalizes a map through JSON, so that structs and typed maps
+// and slices are encoded like in the JSON envelope. Numbers are kept as
+// written by JSON.
+func xmlFields(m map[string]interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	return fields, nil
+}
+
+// writeXMLFields writes the entries of a map as elements, sorted by key.
+func writeXMLFields(buf *bytes.Buffer, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeXMLElement(buf, xmlName(key), fields[key])
+	}
+}
+
+// writeXMLElement writes a value as an element; nil values are empty
+// elements.
+func writeXMLElement(buf *bytes.Buffer, name string, value interface{}) {
+	buf.WriteString("<" + name + ">")
+	switch v := value.(type) {
+	case map[string]interface{}:
+		writeXMLFields(buf, v)
+	case []interface{}:
+		for _, item := range v {
+			writeXMLElement(buf, "item", item)
+		}
+	case string:
+		_ = xml.EscapeText(buf, []byte(v))
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		buf.WriteString(v.String())
+	}
+	buf.WriteString("</" + name + ">")
+}
+
+// xmlName turns a key into an XML element name, replacing the characters
+// that names do not allow with "_" and prefixing the names that do not start
+// with a letter or "_".
+func xmlName(key string) string {
+	if key == "" {
+		return "_"
+	}
+	name := make([]rune, 0, utf8.RuneCountInString(key)+1)
+	for i, r := range key {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		case i == 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+			name = append(name, '_')
+		default:
+			r = '_'
+		}
+		name = append(name, r)
+	}
+	return string(name)
+}
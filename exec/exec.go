@@ -0,0 +1,156 @@
+// Package exec bounds the duration of operations. WithTimeout and
+// WithDeadline run a function with a derived context, return as soon as the
+// context expires, and convert the expiry into an `exception.Timeout`
+// carrying the name of the operation and the elapsed time, replacing the
+// select blocks written around each slow call.
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Func is an operation bounded by its context. It must return promptly once
+// the context is done.
+type Func func(ctx context.Context) error
+
+// Option customizes a bounded execution.
+type Option func(*options)
+
+// options holds the settings of a bounded execution.
+type options struct {
+	operation string
+	grace     time.Duration
+}
+
+// Operation names the operation in the exception reporting its expiry.
+func Operation(name string) Option {
+	return func(o *options) {
+		o.operation = name
+	}
+}
+
+// Grace makes an expired execution wait up to d for the function to return
+// after its context is canceled, so that it does not outlive the call (e.g.,
+// to release a connection before the caller retries). By default the call
+// returns immediately and the function completes in the background.
+func Grace(d time.Duration) Option {
+	return func(o *options) {
+		o.grace = d
+	}
+}
+
+// WithTimeout runs fn with a context canceled after d.
+//
+// Parameters:
+//
+//	ctx: The parent context.
+//	d: The maximum duration of the operation.
+//	fn: The operation.
+//	opts: Options customizing the execution (e.g., Operation, Grace).
+//
+// Returns:
+//
+//	The error of fn, or an `exception.Timeout` whose details hold the
+//	"operation", the "timeout_ms" and the "elapsed_ms", with the
+//	"operation_timeout" error code, when the context expires first. When
+//	the parent context is canceled, its normalized error is returned.
+func WithTimeout(ctx context.Context, d time.Duration, fn Func, opts ...Option) error {
+	start := time.Now()
+	return run(ctx, start, start.Add(d), fn, opts)
+}
+
+// WithDeadline runs fn with a context canceled at deadline. It behaves like
+// WithTimeout, the parent deadline applying when it is earlier. A panic of
+// fn is propagated to the caller goroutine.
+//
+// Parameters:
+//
+//	ctx: The parent context.
+//	deadline: The time by which the operation must complete.
+//	fn: The operation.
+//	opts: Options customizing the execution (e.g., Operation, Grace).
+//
+// Returns:
+//
+//	The error of fn, or an `exception.Timeout` when the context expires
+//	first.
+func WithDeadline(ctx context.Context, deadline time.Time, fn Func, opts ...Option) error {
+	return run(ctx, time.Now(), deadline, fn, opts)
+}
+
+// run runs fn with a context canceled at deadline, start being the time the
+// execution began.
+func run(ctx context.Context, start time.Time, deadline time.Time, fn Func, opts []Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	// Both channels are buffered, so that fn never blocks on them once the
+	// call returned.
+	done := make(chan error, 1)
+	panicked := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				panicked <- recovered
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case recovered := <-panicked:
+		panic(recovered)
+
+	case err := <-done:
+		if err != nil && errors.Is(err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return expired(o, deadline.Sub(start), time.Since(start))
+		}
+		return err
+
+	case <-ctx.Done():
+		elapsed := time.Since(start)
+		cancel()
+		if o.grace > 0 {
+			timer := time.NewTimer(o.grace)
+			defer timer.Stop()
+			select {
+			case recovered := <-panicked:
+				panic(recovered)
+			case <-done:
+			case <-timer.C:
+			}
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return exception.Normalize(ctx.Err())
+		}
+		return expired(o, deadline.Sub(start), elapsed)
+	}
+}
+
+// expired builds the exception reporting an operation that timed out.
+func expired(o options, timeout time.Duration, elapsed time.Duration) error {
+	message := fmt.Sprintf("The operation did not complete within %s.", timeout.Round(time.Millisecond))
+	details := map[string]interface{}{
+		"timeout_ms": timeout.Milliseconds(),
+		"elapsed_ms": elapsed.Milliseconds(),
+		"error":      "operation_timeout",
+	}
+	if o.operation != "" {
+		message = fmt.Sprintf("The operation %q did not complete within %s.", o.operation, timeout.Round(time.Millisecond))
+		details["operation"] = o.operation
+	}
+	return exception.NewTimeout(map[string]interface{}{
+		"message": message,
+		"details": details,
+	})
+}
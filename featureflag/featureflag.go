@@ -0,0 +1,202 @@
+// Package featureflag provides feature flags: a Provider supplies the raw
+// flag values (from memory, environment variables or a remote service) and a
+// Client evaluates them as typed values, falling back to a default when a
+// flag is unset, malformed or its provider fails. Require and Middleware gate
+// operations behind a flag with an `exception.FeatureDisabled`.
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/osirisgate/golang-core/logger"
+)
+
+// Provider supplies the raw values of flags.
+type Provider interface {
+	// Lookup returns the value of a flag and whether it is set. The value is
+	// typically a bool, a string or a number; strings are parsed by the typed
+	// evaluations of Client. The context carries what targeting providers
+	// need (e.g., the authenticated user).
+	Lookup(ctx context.Context, key string) (value interface{}, ok bool, err error)
+}
+
+// ProviderFunc adapts a function into a Provider.
+type ProviderFunc func(ctx context.Context, key string) (interface{}, bool, error)
+
+// Lookup calls the function.
+func (f ProviderFunc) Lookup(ctx context.Context, key string) (interface{}, bool, error) {
+	return f(ctx, key)
+}
+
+// Client evaluates flags. It is safe for concurrent use when its Provider is.
+type Client struct {
+	provider Provider
+	logger   logger.Logger
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithLogger logs, as warnings, the provider failures and the values that
+// cannot be converted to the requested type. Defaults to `logger.Nop()`.
+func WithLogger(l logger.Logger) Option {
+	return func(c *Client) {
+		if l != nil {
+			c.logger = l
+		}
+	}
+}
+
+// New creates a Client.
+//
+// Parameters:
+//
+//	provider: The provider supplying the flag values.
+//	opts: Options such as WithLogger.
+//
+// Returns:
+//
+//	A pointer to a new Client.
+func New(provider Provider, opts ...Option) *Client {
+	c := &Client{provider: provider, logger: logger.Nop()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Enabled reports whether a boolean flag is set to true. Unset flags are
+// disabled.
+func (c *Client) Enabled(ctx context.Context, key string) bool {
+	return c.Bool(ctx, key, false)
+}
+
+// Bool evaluates a boolean flag. Strings are parsed with `strconv.ParseBool`
+// (e.g., "true", "1" or "false").
+//
+// Parameters:
+//
+//	ctx: The context passed to the provider.
+//	key: The name of the flag.
+//	fallback: The value returned when the flag is unset or invalid.
+//
+// Returns:
+//
+//	The value of the flag, or fallback.
+func (c *Client) Bool(ctx context.Context, key string, fallback bool) bool {
+	return evaluate(ctx, c, key, fallback, "bool", func(value interface{}) (bool, bool) {
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			return parsed, err == nil
+		default:
+			return false, false
+		}
+	})
+}
+
+// String evaluates a string flag, e.g. the variant of an experiment.
+// Non-string values are formatted with `fmt.Sprint`.
+//
+// Parameters:
+//
+//	ctx: The context passed to the provider.
+//	key: The name of the flag.
+//	fallback: The value returned when the flag is unset.
+//
+// Returns:
+//
+//	The value of the flag, or fallback.
+func (c *Client) String(ctx context.Context, key string, fallback string) string {
+	return evaluate(ctx, c, key, fallback, "string", func(value interface{}) (string, bool) {
+		if v, ok := value.(string); ok {
+			return v, true
+		}
+		return fmt.Sprint(value), true
+	})
+}
+
+// Int evaluates an integer flag, e.g. a limit. Strings are parsed, and
+// floats are accepted when they hold a whole number.
+//
+// Parameters:
+//
+//	ctx: The context passed to the provider.
+//	key: The name of the flag.
+//	fallback: The value returned when the flag is unset or invalid.
+//
+// Returns:
+//
+//	The value of the flag, or fallback.
+func (c *Client) Int(ctx context.Context, key string, fallback int) int {
+	return evaluate(ctx, c, key, fallback, "int", func(value interface{}) (int, bool) {
+		switch v := value.(type) {
+		case int:
+			return v, true
+		case int64:
+			return int(v), int64(int(v)) == v
+		case float64:
+			return int(v), v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64
+		case string:
+			parsed, err := strconv.Atoi(strings.TrimSpace(v))
+			return parsed, err == nil
+		default:
+			return 0, false
+		}
+	})
+}
+
+// Float evaluates a floating point flag, e.g. a rollout percentage.
+// Integers and strings are converted.
+//
+// Parameters:
+//
+//	ctx: The context passed to the provider.
+//	key: The name of the flag.
+//	fallback: The value returned when the flag is unset or invalid.
+//
+// Returns:
+//
+//	The value of the flag, or fallback.
+func (c *Client) Float(ctx context.Context, key string, fallback float64) float64 {
+	return evaluate(ctx, c, key, fallback, "float", func(value interface{}) (float64, bool) {
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			return parsed, err == nil
+		default:
+			return 0, false
+		}
+	})
+}
+
+// evaluate looks a flag up and converts its value, falling back on failure.
+func evaluate[T any](ctx context.Context, c *Client, key string, fallback T, kind string, convert func(interface{}) (T, bool)) T {
+	value, ok, err := c.provider.Lookup(ctx, key)
+	if err != nil {
+		c.logger.Warn("Feature flag lookup failed.", logger.Any("flag", key), logger.Any("error", err.Error()))
+		return fallback
+	}
+	if !ok || value == nil {
+		return fallback
+	}
+
+	converted, ok := convert(value)
+	if !ok {
+		c.logger.Warn("Feature flag value has the wrong type.", logger.Any("flag", key), logger.Any("expected", kind))
+		return fallback
+	}
+	return converted
+}
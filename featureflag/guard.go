@@ -0,0 +1,63 @@
+// Package featureflag provides feature flags evaluated as typed values.
+// This file defines the guards gating operations behind a flag.
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Require checks that a boolean flag is enabled, e.g. at the start of a use
+// case being rolled out.
+//
+// Parameters:
+//
+//	ctx: The context passed to the provider.
+//	client: The client evaluating the flag.
+//	key: The name of the flag.
+//
+// Returns:
+//
+//	Nil when the flag is enabled, or an `exception.FeatureDisabled` whose
+//	details hold the "flag" and the "feature_disabled" error code.
+func Require(ctx context.Context, client *Client, key string) error {
+	if client.Enabled(ctx, key) {
+		return nil
+	}
+	return exception.NewFeatureDisabled(map[string]interface{}{
+		"message": fmt.Sprintf("The feature %q is not enabled.", key),
+		"details": map[string]interface{}{
+			"flag":  key,
+			"error": "feature_disabled",
+		},
+	})
+}
+
+// Middleware gates an endpoint behind a boolean flag. Requests received
+// while the flag is disabled receive the 403 response of an
+// `exception.FeatureDisabled` rendered by `response.WriteError`, and next is
+// not called. The flag is evaluated with the request context.
+//
+// Parameters:
+//
+//	client: The client evaluating the flag.
+//	key: The name of the flag.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the flag check.
+func Middleware(client *Client, key string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := Require(r.Context(), client, key); err != nil {
+				_ = response.WriteErrorContext(r.Context(), w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package featureflag provides feature flags evaluated as typed values.
+// This file defines the built-in providers.
+package featureflag
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Memory is a Provider holding flags in memory, e.g. for tests or for flags
+// toggled at runtime by an admin endpoint. It is safe for concurrent use.
+type Memory struct {
+	mu    sync.RWMutex
+	flags map[string]interface{}
+}
+
+// NewMemory creates a Memory provider.
+//
+// Parameters:
+//
+//	flags: The initial flag values, copied. May be nil.
+//
+// Returns:
+//
+//	A pointer to a new Memory provider.
+func NewMemory(flags map[string]interface{}) *Memory {
+	m := &Memory{flags: make(map[string]interface{}, len(flags))}
+	for key, value := range flags {
+		m.flags[key] = value
+	}
+	return m
+}
+
+// Lookup returns the value of a flag.
+func (m *Memory) Lookup(_ context.Context, key string) (interface{}, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.flags[key]
+	return value, ok, nil
+}
+
+// Set sets the value of a flag.
+func (m *Memory) Set(key string, value interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.flags[key] = value
+}
+
+// Delete unsets a flag.
+func (m *Memory) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.flags, key)
+}
+
+// Env is a Provider reading flags from environment variables. The variable
+// of a flag is its name upper-cased, with every character that is not a
+// letter or a digit replaced by "_", after a prefix: with the "FEATURE_"
+// prefix, the "new-checkout" flag is read from FEATURE_NEW_CHECKOUT.
+type Env struct {
+	prefix string
+	lookup func(key string) (string, bool)
+}
+
+// NewEnv creates an Env provider.
+//
+// Parameters:
+//
+//	prefix: The prefix of the variable names (e.g., "FEATURE_").
+//	lookup: The function reading a variable. Nil uses `os.LookupEnv`.
+//
+// Returns:
+//
+//	A pointer to a new Env provider.
+func NewEnv(prefix string, lookup func(key string) (string, bool)) *Env {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	return &Env{prefix: prefix, lookup: lookup}
+}
+
+// Lookup returns the value of the variable of a flag, as a string.
+func (e *Env) Lookup(_ context.Context, key string) (interface{}, bool, error) {
+	value, ok := e.lookup(e.Variable(key))
+	if !ok {
+		return nil, false, nil
+	}
+	return value, true, nil
+}
+
+// Variable returns the name of the environment variable of a flag.
+func (e *Env) Variable(key string) string {
+	return e.prefix + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, key)
+}
+
+// Chain combines providers: a flag takes the value of the first provider
+// setting it, e.g. environment overrides over remotely managed defaults.
+// A provider failure stops the lookup and is returned.
+//
+// Parameters:
+//
+//	providers: The providers, by decreasing precedence.
+//
+// Returns:
+//
+//	A Provider querying the providers in order.
+func Chain(providers ...Provider) Provider {
+	return ProviderFunc(func(ctx context.Context, key string) (interface{}, bool, error) {
+		for _, provider := range providers {
+			value, ok, err := provider.Lookup(ctx, key)
+			if err != nil || ok {
+				return value, ok, err
+			}
+		}
+		return nil, false, nil
+	})
+}
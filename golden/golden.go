@@ -0,0 +1,225 @@
+// Package golden provides snapshot testing helpers for the wire format of
+// exceptions and envelopes. Values are serialized deterministically (sorted
+// keys, indented JSON, volatile keys scrubbed) and compared against golden
+// files, failures showing a line diff. Running the tests with the -update
+// flag, or with the GOLDEN_UPDATE environment variable set, rewrites the
+// golden files:
+//
+//	go test ./... -run TestErrors -update
+//
+// so that downstream tests stop comparing `Format()` maps with
+// `reflect.DeepEqual`. The package registers the -update flag, so the test
+// binaries importing it must not define their own.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// UpdateEnv is the environment variable that, when set, rewrites the golden
+// files like the -update flag.
+const UpdateEnv = "GOLDEN_UPDATE"
+
+// Scrubbed replaces the values of the scrubbed keys.
+const Scrubbed = "<scrubbed>"
+
+// update is the -update flag of the test binaries importing the package.
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// Option customizes a snapshot.
+type Option func(*options)
+
+// options holds the settings of a snapshot.
+type options struct {
+	dir   string
+	scrub map[string]bool
+}
+
+// Dir sets the directory of the golden files. Defaults to "testdata".
+func Dir(dir string) Option {
+	return func(o *options) {
+		o.dir = dir
+	}
+}
+
+// Scrub replaces the values of keys, at any depth, with Scrubbed, e.g. the
+// request identifiers and timestamps that change on every run.
+func Scrub(keys ...string) Option {
+	return func(o *options) {
+		for _, key := range keys {
+			o.scrub[key] = true
+		}
+	}
+}
+
+// Marshal serializes a value deterministically: errors are replaced by the
+// `Format()` output of their exception (see `exception.Normalize`), and the
+// result is encoded as JSON with sorted keys, an indentation of two spaces
+// and a final newline.
+//
+// Parameters:
+//
+//	value: The value to serialize, typically an exception or an envelope.
+//	opts: Options customizing the serialization (e.g., Scrub).
+//
+// Returns:
+//
+//	The serialized value, or the error of the JSON encoding.
+func Marshal(value interface{}, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+
+	if err, ok := value.(error); ok {
+		var coreErr exception.CoreInterface
+		if errors.As(exception.Normalize(err), &coreErr) {
+			value = coreErr.Format()
+		}
+	}
+
+	// A round trip through JSON turns structs into maps, so that their keys
+	// can be scrubbed and are sorted by the final encoding.
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(o.scrubValue(generic)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Assert compares the serialization of a value (see Marshal) with the golden
+// file <dir>/<name>.golden, writing the file instead when updating. A
+// mismatch fails the test with a line diff.
+//
+// Parameters:
+//
+//	t: The test.
+//	name: The name of the golden file, without extension (e.g.,
+//	      "not_found"); it may contain slashes.
+//	value: The value to serialize.
+//	opts: Options customizing the snapshot (e.g., Dir, Scrub).
+func Assert(t testing.TB, name string, value interface{}, opts ...Option) {
+	t.Helper()
+	o := newOptions(opts)
+
+	got, err := Marshal(value, opts...)
+	if err != nil {
+		t.Fatalf("golden: cannot serialize %s: %v", name, err)
+		return
+	}
+
+	path := filepath.Join(o.dir, filepath.FromSlash(name)+".golden")
+	if *update || os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden: cannot create %s: %v", filepath.Dir(path), err)
+			return
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("golden: cannot write %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden: cannot read %s (run the test with -update to create it): %v", path, err)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("golden: %s does not match (run the test with -update to accept the changes):\n%s", path, Diff(string(want), string(got)))
+	}
+}
+
+// Diff returns a line diff of two texts, the lines only in want prefixed
+// with "-", those only in got with "+" and the common ones with a space.
+func Diff(want string, got string) string {
+	a, b := splitLines(want), splitLines(got)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&out, "  %s\n", a[i])
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&out, "- %s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(&out, "+ %s\n", b[j])
+			j++
+		}
+	}
+	return out.String()
+}
+
+// newOptions applies the options over the defaults.
+func newOptions(opts []Option) options {
+	o := options{dir: "testdata", scrub: map[string]bool{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// scrubValue replaces the values of the scrubbed keys of a decoded value.
+func (o options) scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if o.scrub[key] {
+				v[key] = Scrubbed
+			} else {
+				v[key] = o.scrubValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = o.scrubValue(item)
+		}
+	}
+	return value
+}
+
+// splitLines splits a text into lines, ignoring the final newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,136 @@
+// Package gqlerr converts exceptions into GraphQL errors, so that the
+// GraphQL services built on gqlgen share the exception catalog. It provides
+// the error presenter and the recover function of a gqlgen server without
+// depending on gqlgen: Present returns the message and the extensions of the
+// `gqlerror.Error` to return, and RecoverFunc has the signature of
+// `graphql.RecoverFunc`. The setup of a server is:
+//
+//	srv.SetRecoverFunc(gqlerr.RecoverFunc(log))
+//	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+//		var gqlErr *gqlerror.Error
+//		if errors.As(err, &gqlErr) && gqlErr.Err == nil {
+//			return gqlErr // A parsing or validation error of the query.
+//		}
+//		e := gqlerr.Present(ctx, err)
+//		return &gqlerror.Error{Err: err, Message: e.Message, Path: graphql.GetPath(ctx), Extensions: e.Extensions}
+//	})
+package gqlerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/tracing"
+)
+
+// Error is a GraphQL error, without its locations and path, which gqlgen
+// knows from the context of the presenter.
+type Error struct {
+	Message    string                 `json:"message"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Present converts an error into a GraphQL error. Like
+// `response.WriteErrorContext`, it annotates the exception with the
+// identifiers of the context, localizes its message and records it on the
+// current span first. The message is that of the exception, and the
+// extensions are its `Format()` envelope without the message, with a "code"
+// entry, as expected by the GraphQL clients, when the envelope has none: the
+// "error" entry of its details, or the reason phrase of its status code
+// (see `exception.StatusText`) in upper snake case (e.g., "NOT_FOUND").
+// Errors that are not exceptions are presented as internal server errors,
+// without their message, which may contain implementation details.
+//
+// Parameters:
+//
+//	ctx: The context of the resolver.
+//	err: The error to present. A nil error yields nil.
+//
+// Returns:
+//
+//	The GraphQL error.
+func Present(ctx context.Context, err error) *Error {
+	if exception.IsNil(err) {
+		return nil
+	}
+
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || exception.IsNil(coreErr) {
+		coreErr = exception.NewError(map[string]interface{}{})
+	}
+	_ = ctxutil.Annotate(ctx, coreErr)
+	_ = i18n.Localize(ctx, coreErr)
+	_ = tracing.Record(ctx, coreErr)
+
+	extensions := coreErr.Format()
+	delete(extensions, "message")
+	if _, ok := extensions["code"]; !ok {
+		code := coreErr.GetDetailsMessage()
+		if code == "" {
+			code = upperSnake(exception.StatusText(coreErr))
+		}
+		extensions["code"] = code
+	}
+	return &Error{Message: coreErr.Error(), Extensions: extensions}
+}
+
+// RecoverFunc returns the recover function of a gqlgen server, called with
+// the value of a panicking resolver. It logs the panic and returns a
+// `exception.Runtime` describing it, which gqlgen then passes to the error
+// presenter.
+//
+// Parameters:
+//
+//	l: The Logger receiving the panics. Nil uses `logger.Nop()`.
+//
+// Returns:
+//
+//	The recover function.
+func RecoverFunc(l logger.Logger) func(ctx context.Context, recovered interface{}) error {
+	if l == nil {
+		l = logger.Nop()
+	}
+
+	return func(ctx context.Context, recovered interface{}) error {
+		err := exception.NewRuntime(map[string]interface{}{
+			"message": "The resolver panicked.",
+			"details": map[string]interface{}{
+				"panic": fmt.Sprint(recovered),
+				"error": "resolver_panicked",
+			},
+		})
+		logger.LogExceptionContext(ctx, l, err)
+		return err
+	}
+}
+
+// upperSnake converts a description into a code, e.g. "Not Found" into
+// "NOT_FOUND".
+func upperSnake(description string) string {
+	var b strings.Builder
+	separate := false
+	for _, r := range description {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = b.Len() > 0
+			continue
+		}
+		if separate {
+			b.WriteByte('_')
+			separate = false
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
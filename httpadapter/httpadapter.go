@@ -0,0 +1,170 @@
+// Package httpadapter adapts the `net/http` handlers returning errors, so
+// that they return exceptions on their error paths instead of writing the
+// error responses themselves. Writing the exceptions, recovering the panics
+// and logging them are centralized by Wrap:
+//
+//	func getOrder(w http.ResponseWriter, r *http.Request) error {
+//		order, err := orders.Find(r.Context(), r.PathValue("id"))
+//		if err != nil {
+//			return err // e.g. an *exception.NotFound.
+//		}
+//		return response.WriteSuccessContext(r.Context(), w, status.OK, order)
+//	}
+//
+//	mux.Handle("GET /orders/{id}", httpadapter.Wrap(getOrder, log))
+package httpadapter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Handler is a `net/http` handler returning the error of its request, if
+// any; it writes the response only on success.
+type Handler func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorWriter writes the response of an error, such as `response.WriteNegotiated`.
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, err error) error
+
+// Option configures Wrap.
+type Option func(*adapter)
+
+// WithErrorWriter replaces the writer of the error responses, by default
+// `response.WriteErrorContext` with the context of the request.
+//
+// Parameters:
+//
+//	writer: The writer of the error responses (e.g., `response.WriteNegotiated`).
+//
+// Returns:
+//
+//	The Option.
+func WithErrorWriter(writer ErrorWriter) Option {
+	return func(a *adapter) {
+		if writer != nil {
+			a.write = writer
+		}
+	}
+}
+
+// adapter holds the settings of a wrapped handler.
+type adapter struct {
+	handler Handler
+	log     logger.Logger
+	write   ErrorWriter
+}
+
+// Wrap converts a Handler into an `http.Handler`. The errors it returns are
+// normalized (see `exception.Normalize`), logged with
+// `logger.LogExceptionContext`, along with a snapshot of the request for the
+// server errors (see `exception.AttachRequest`), and written, unless the
+// handler had already started the response. Its panics are converted into
+// an `exception.Runtime` whose details hold the panic value and the
+// "handler_panicked" error code, and handled likewise; panics with
+// `http.ErrAbortHandler`, used to abort a response deliberately, are
+// propagated.
+//
+// Parameters:
+//
+//	handler: The handler to wrap.
+//	l: The Logger receiving the errors and the panics. Nil uses `logger.Nop()`.
+//	opts: Options such as WithErrorWriter.
+//
+// Returns:
+//
+//	The `http.Handler`.
+func Wrap(handler Handler, l logger.Logger, opts ...Option) http.Handler {
+	if l == nil {
+		l = logger.Nop()
+	}
+	a := &adapter{handler: handler, log: l, write: writeErrorContext}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// ServeHTTP calls the handler and handles its error or panic.
+func (a *adapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tracked := &tracker{ResponseWriter: w}
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+			panic(recovered)
+		}
+		a.fail(tracked, r, exception.NewRuntime(map[string]interface{}{
+			"message": "The request handler panicked.",
+			"details": map[string]interface{}{
+				"panic": fmt.Sprint(recovered),
+				"error": "handler_panicked",
+			},
+		}))
+	}()
+
+	if err := a.handler(tracked, r); !exception.IsNil(err) {
+		a.fail(tracked, r, err)
+	}
+}
+
+// fail logs the error of a request and writes it when the response has not
+// started.
+func (a *adapter) fail(w *tracker, r *http.Request, err error) {
+	err = exception.Normalize(err)
+	var coreErr exception.CoreInterface
+	if errors.As(err, &coreErr) && coreErr.GetStatusCode() >= http.StatusInternalServerError {
+		exception.AttachRequest(err, r)
+	}
+	logger.LogExceptionContext(r.Context(), a.log, err)
+	if !w.started {
+		_ = a.write(w, r, err)
+	}
+}
+
+// writeErrorContext is the default ErrorWriter.
+func writeErrorContext(w http.ResponseWriter, r *http.Request, err error) error {
+	return response.WriteErrorContext(r.Context(), w, err)
+}
+
+// tracker wraps an `http.ResponseWriter`, recording whether the response has
+// started.
+type tracker struct {
+	http.ResponseWriter
+	started bool
+}
+
+// WriteHeader records the start of the response, unless the status code is
+// informational (e.g., 103 Early Hints), and sends the status code.
+func (t *tracker) WriteHeader(code int) {
+	if code >= http.StatusOK {
+		t.started = true
+	}
+	t.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the start of the response and writes the body.
+func (t *tracker) Write(body []byte) (int, error) {
+	t.started = true
+	return t.ResponseWriter.Write(body)
+}
+
+// Flush records the start of the response and flushes the wrapped writer
+// when it supports it.
+func (t *tracker) Flush() {
+	t.started = true
+	if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer, for `http.ResponseController`.
+func (t *tracker) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
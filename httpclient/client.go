@@ -0,0 +1,209 @@
+// Package httpclient wraps `*http.Client` so that outgoing calls fail with
+// typed exceptions: error responses are converted with `exception.FromStatus`
+// (a 404 becomes an `exception.NotFound`, a 429 an `exception.TooManyRequests`,
+// ...), their problem+json or error envelope bodies are parsed into the
+// exception details, timeouts become `exception.Timeout` errors and other
+// transport failures 502 errors. Retryable failures of idempotent requests
+// can be retried with the retry package.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.BadGateway` status of invalid upstream responses.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/retry"
+)
+
+// DefaultMaxErrorBody is the number of bytes of an error response body read
+// to build its exception (64 KiB).
+const DefaultMaxErrorBody int64 = 64 << 10
+
+// Client sends HTTP requests and converts failures into exceptions. It is
+// safe for concurrent use.
+type Client struct {
+	http         *http.Client
+	retry        []retry.Option // Nil when retries are disabled.
+	maxErrorBody int64
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithRetry retries the retryable failures (see `exception.IsRetryable`) of
+// idempotent requests: GET, HEAD, OPTIONS, TRACE, PUT and DELETE requests, and
+// requests carrying an Idempotency-Key header. Requests with a body are only
+// retried when their body can be replayed (`http.Request.GetBody`, set by
+// `http.NewRequest` for in-memory bodies).
+//
+// Parameters:
+//
+//	opts: The options of the retry loop (e.g., `retry.MaxAttempts(5)`).
+func WithRetry(opts ...retry.Option) Option {
+	return func(c *Client) { c.retry = append([]retry.Option{}, opts...) }
+}
+
+// WithMaxErrorBody sets the number of bytes of an error response body read
+// to build its exception.
+func WithMaxErrorBody(n int64) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.maxErrorBody = n
+		}
+	}
+}
+
+// New creates a Client.
+//
+// Parameters:
+//
+//	httpClient: The client sending the requests. Nil uses `http.DefaultClient`;
+//	            configure its Timeout to bound every call.
+//	opts: Options such as WithRetry.
+//
+// Returns:
+//
+//	A pointer to a new Client.
+func New(httpClient *http.Client, opts ...Option) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c := &Client{http: httpClient, maxErrorBody: DefaultMaxErrorBody}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Do sends a request. Unlike `http.Client.Do`, an error response (4xx or
+// 5xx) is returned as an error, its body being read and closed.
+//
+// Parameters:
+//
+//	req: The request to send.
+//
+// Returns:
+//
+//	The response, with a status code below 400, or an error whose chain
+//	contains an `exception.CoreInterface`: the exception matching the status
+//	code of an error response, an `exception.Timeout`, a 502 exception for
+//	other transport failures, or a `*retry.Exhausted` when retries ran out.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.retry == nil || !replayable(req) {
+		return c.send(req)
+	}
+
+	first := true
+	return retry.DoValue(req.Context(), func(ctx context.Context) (*http.Response, error) {
+		attempt := req
+		if !first && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, exception.Normalize(err)
+			}
+			attempt = req.Clone(ctx)
+			attempt.Body = body
+		}
+		first = false
+		return c.send(attempt)
+	}, c.retry...)
+}
+
+// DoJSON sends a JSON request and decodes the JSON response.
+//
+// Parameters:
+//
+//	ctx: The context of the request.
+//	method: The HTTP method (e.g., http.MethodPost).
+//	url: The URL of the request.
+//	body: The value encoded as the request body. Nil sends no body.
+//	dst: A pointer receiving the decoded response body. Nil discards it.
+//
+// Returns:
+//
+//	Nil on success, or an error as for Do. A response body that cannot be
+//	decoded into dst yields a 502 exception.
+func (c *Client) DoJSON(ctx context.Context, method string, url string, body interface{}, dst interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return exception.NewInvalidArgument(map[string]interface{}{
+				"message": "The request body cannot be encoded as JSON.",
+				"details": map[string]interface{}{"error": "unencodable_body"},
+			})
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return exception.NewInvalidArgument(map[string]interface{}{
+			"message": "The request cannot be built.",
+			"details": map[string]interface{}{"error": "invalid_request"},
+		})
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if dst == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return exception.FromStatus(status.BadGateway, map[string]interface{}{
+			"message": "The upstream response could not be decoded.",
+			"details": map[string]interface{}{
+				"upstream": req.URL.Host,
+				"error":    "invalid_upstream_response",
+			},
+		})
+	}
+	return nil
+}
+
+// send sends a request once and converts its failures.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, transportError(req, err)
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	return nil, responseError(req, resp, c.maxErrorBody)
+}
+
+// idempotentMethods lists the methods whose requests may be retried.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// replayable reports whether a request may be sent several times.
+func replayable(req *http.Request) bool {
+	if !idempotentMethods[req.Method] && req.Header.Get("Idempotency-Key") == "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
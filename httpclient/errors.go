@@ -0,0 +1,177 @@
+// Package httpclient wraps `*http.Client` so that outgoing calls fail with
+// typed exceptions. This file defines the conversion of failed calls into
+// exceptions.
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and the `status.BadGateway` constant.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// problemMediaType is the media type of RFC 9457 problem details.
+const problemMediaType = "application/problem+json"
+
+// problemMembers lists the standard members of RFC 9457 problem details.
+var problemMembers = []string{"type", "title", "detail", "instance"}
+
+// envelopeMembers lists the members of the error envelope of the core (see
+// `exception.CoreException.Format`) that are not copied into the exception.
+var envelopeMembers = map[string]bool{"status": true, "error_code": true, "message": true, "details": true}
+
+// transportError converts the failure of a request that got no response.
+func transportError(req *http.Request, err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exception.Normalize(err)
+
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return exception.NewTimeout(map[string]interface{}{
+			"message": fmt.Sprintf("The request to %s timed out.", req.URL.Host),
+			"details": map[string]interface{}{
+				"upstream": req.URL.Host,
+				"error":    "upstream_timeout",
+			},
+		})
+
+	default:
+		return exception.FromStatus(status.BadGateway, map[string]interface{}{
+			"message": fmt.Sprintf("The request to %s failed.", req.URL.Host),
+			"details": map[string]interface{}{
+				"upstream": req.URL.Host,
+				"error":    "upstream_unreachable",
+			},
+		})
+	}
+}
+
+// responseError converts an error response into the exception matching its
+// status code, with the content of its body when it holds problem details or
+// an error envelope.
+func responseError(req *http.Request, resp *http.Response, maxBody int64) error {
+	errorsMap := map[string]interface{}{}
+	details := map[string]interface{}{}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	var decoded map[string]interface{}
+	if isJSON(resp.Header.Get("Content-Type")) && json.Unmarshal(body, &decoded) == nil {
+		if isProblem(resp.Header.Get("Content-Type"), decoded) {
+			parseProblem(decoded, errorsMap, details)
+		} else {
+			parseEnvelope(decoded, errorsMap, details)
+		}
+	}
+
+	details["upstream"] = req.URL.Host
+	details["upstream_status"] = resp.StatusCode
+	if _, ok := details["error"]; !ok {
+		details["error"] = "upstream_error"
+	}
+	if seconds, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		details["retry_after"] = seconds
+	}
+	errorsMap["details"] = details
+
+	return exception.FromStatus(status.StatusCode(resp.StatusCode), errorsMap)
+}
+
+// parseProblem copies RFC 9457 problem details: the detail (or the title)
+// becomes the message, and the other members, including extensions, are
+// added to the details.
+func parseProblem(problem map[string]interface{}, errorsMap map[string]interface{}, details map[string]interface{}) {
+	for key, value := range problem {
+		if key != "status" {
+			details[key] = value
+		}
+	}
+	for _, key := range []string{"detail", "title"} {
+		if message, ok := problem[key].(string); ok && message != "" {
+			errorsMap["message"] = message
+			return
+		}
+	}
+}
+
+// envelopeCode returns the error code of the error envelope of a service
+// built with the core, under any naming of its keys (see
+// `exception.SetKeyNaming`), and false when body is not an envelope.
+func envelopeCode(body map[string]interface{}) (interface{}, bool) {
+	for key, value := range body {
+		if exception.BuiltinKey(key) == "error_code" && value != nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// parseEnvelope copies the error envelope of a service built with the core:
+// its message, its details and its other members (e.g., the per-field
+// "errors" of a validation failure), with the snake_case names of its
+// built-in keys.
+func parseEnvelope(envelope map[string]interface{}, errorsMap map[string]interface{}, details map[string]interface{}) {
+	if message, ok := envelope["message"].(string); ok && message != "" {
+		errorsMap["message"] = message
+	}
+	if upstreamDetails, ok := envelope["details"].(map[string]interface{}); ok {
+		for key, value := range upstreamDetails {
+			details[key] = value
+		}
+	}
+	for key, value := range envelope {
+		if key = exception.BuiltinKey(key); !envelopeMembers[key] {
+			errorsMap[key] = value
+		}
+	}
+}
+
+// isJSON reports whether a Content-Type header denotes a JSON body.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// isProblem reports whether a JSON body holds problem details, either by its
+// media type or, for servers sending them as plain JSON, by its members.
+func isProblem(contentType string, body map[string]interface{}) bool {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == problemMediaType {
+		return true
+	}
+	if _, isEnvelope := envelopeCode(body); isEnvelope {
+		return false
+	}
+	for _, member := range problemMembers {
+		if _, ok := body[member]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, given either as a number of
+// seconds or as an HTTP date, into a number of seconds.
+func parseRetryAfter(value string, now time.Time) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return seconds, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(int(date.Sub(now).Round(time.Second).Seconds()), 0), true
+	}
+	return 0, false
+}
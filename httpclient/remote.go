@@ -0,0 +1,92 @@
+// Package httpclient wraps `*http.Client` so that outgoing calls fail with
+// typed exceptions. This file defines the parsing of the error responses
+// received without a Client.
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type of the rebuilt exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// FromHTTPResponse rebuilds the exception of an error response, e.g. one
+// received through another HTTP client or a generated SDK. The body is
+// parsed when it holds RFC 9457 problem details or the error envelope of a
+// service built with the core: the exception is then the type matching the
+// remote status (see `exception.FromStatus`), which is the "status" member of
+// the problem or the "error_code" (or "errorCode", see
+// `exception.SetKeyNaming`) of the envelope, and the HTTP status of the
+// response otherwise, with the remote message and details. Any other body
+// yields an `exception.UpstreamFailure`. In both cases the details hold the
+// host of the request under "upstream", the HTTP status of the response
+// under "upstream_status" and its Retry-After, if any, under "retry_after".
+// At most DefaultMaxErrorBody bytes of the body are read; it is not closed.
+//
+// Parameters:
+//
+//	resp: The response to parse.
+//
+// Returns:
+//
+//	The exception, or nil when resp is nil or not an error response.
+func FromHTTPResponse(resp *http.Response) exception.CoreInterface {
+	if resp == nil || resp.StatusCode < int(status.BadRequest) {
+		return nil
+	}
+
+	errorsMap := map[string]interface{}{}
+	details := map[string]interface{}{}
+	code := status.StatusCode(resp.StatusCode)
+	recognized := false
+
+	contentType := resp.Header.Get("Content-Type")
+	var decoded map[string]interface{}
+	if resp.Body != nil && isJSON(contentType) {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, DefaultMaxErrorBody))
+		if json.Unmarshal(body, &decoded) == nil {
+			var remote interface{}
+			errorCode, isEnvelope := envelopeCode(decoded)
+			switch {
+			case isProblem(contentType, decoded):
+				parseProblem(decoded, errorsMap, details)
+				remote, recognized = decoded["status"], true
+			case isEnvelope:
+				parseEnvelope(decoded, errorsMap, details)
+				remote, recognized = errorCode, true
+			}
+			if number, ok := remote.(float64); ok && number >= 400 && number < 600 && number == float64(int(number)) {
+				code = status.StatusCode(int(number))
+			}
+		}
+	}
+
+	upstream := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		upstream = resp.Request.URL.Host
+		details["upstream"] = upstream
+	}
+	details["upstream_status"] = resp.StatusCode
+	if _, ok := details["error"]; !ok {
+		details["error"] = "upstream_error"
+	}
+	if seconds, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		details["retry_after"] = seconds
+	}
+	errorsMap["details"] = details
+
+	if !recognized {
+		message := "The upstream service failed."
+		if upstream != "" {
+			message = fmt.Sprintf("The request to %s failed.", upstream)
+		}
+		return exception.NewUpstreamFailure(map[string]interface{}{"message": message, "details": details})
+	}
+	return exception.FromStatus(code, errorsMap)
+}
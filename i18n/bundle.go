@@ -0,0 +1,222 @@
+// Package i18n provides message translation: a Bundle holds message catalogs
+// keyed by locale, messages are templates with "{name}" parameters, and
+// lookups fall back from a regional locale to its language and then to the
+// default locale of the bundle. The locale of each request is resolved from
+// its Accept-Language header by Middleware and carried by the context, where
+// T translates application messages and Localize translates exceptions (the
+// response package calls it when writing error envelopes).
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Bundle holds the message catalogs of an application. It is safe for
+// concurrent use.
+type Bundle struct {
+	defaultLocale string
+
+	mu       sync.RWMutex
+	catalogs map[string]map[string]string // Messages by key, by normalized locale.
+}
+
+// NewBundle creates an empty Bundle.
+//
+// Parameters:
+//
+//	defaultLocale: The locale used when no catalog of the requested locale
+//	               holds a message (e.g., "en").
+//
+// Returns:
+//
+//	A pointer to a new Bundle.
+func NewBundle(defaultLocale string) *Bundle {
+	return &Bundle{
+		defaultLocale: Normalize(defaultLocale),
+		catalogs:      map[string]map[string]string{},
+	}
+}
+
+// DefaultLocale returns the default locale of the bundle.
+func (b *Bundle) DefaultLocale() string {
+	return b.defaultLocale
+}
+
+// Add adds messages to the catalog of a locale, replacing the messages
+// previously added under the same keys.
+//
+// Parameters:
+//
+//	locale: The locale of the messages (e.g., "fr" or "pt-BR").
+//	messages: The message templates by key (e.g., "error.not_found").
+func (b *Bundle) Add(locale string, messages map[string]string) {
+	locale = Normalize(locale)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	catalog, ok := b.catalogs[locale]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		b.catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// LoadFS adds the catalogs stored as JSON files in a directory of a file
+// system, typically an `embed.FS`. Each file is named after its locale
+// (e.g., "fr.json", "pt-BR.json") and holds an object of messages, nested
+// objects being flattened into dotted keys:
+//
+//	{"error": {"not_found": "{entity} introuvable."}}
+//
+// defines the "error.not_found" message.
+//
+// Parameters:
+//
+//	fsys: The file system holding the catalogs.
+//	dir: The directory of the catalogs ("." for the root).
+//
+// Returns:
+//
+//	Nil on success, or an `exception.Configuration` listing under its
+//	"errors" key every file that cannot be read or decoded.
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return catalogError(map[string]interface{}{dir: err.Error()})
+	}
+
+	problems := map[string]interface{}{}
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			problems[file] = "The catalog cannot be read."
+			continue
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(content, &decoded); err != nil {
+			problems[file] = "The catalog is not a valid JSON object."
+			continue
+		}
+
+		messages := map[string]string{}
+		if reason := flatten(messages, "", decoded); reason != "" {
+			problems[file] = reason
+			continue
+		}
+		b.Add(strings.TrimSuffix(path.Base(file), ".json"), messages)
+	}
+
+	if len(problems) > 0 {
+		return catalogError(problems)
+	}
+	return nil
+}
+
+// Locales returns the locales having a catalog, sorted.
+func (b *Bundle) Locales() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	locales := make([]string, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Translate renders the message of a key in a locale. The catalogs are
+// searched along the fallback chain of the locale (see Fallbacks).
+//
+// Parameters:
+//
+//	locale: The requested locale.
+//	key: The key of the message.
+//	params: The values of the "{name}" placeholders of the template. May be nil.
+//
+// Returns:
+//
+//	The rendered message and true, or an empty string and false when no
+//	catalog of the chain holds the key.
+func (b *Bundle) Translate(locale string, key string, params map[string]interface{}) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, candidate := range Fallbacks(locale, b.defaultLocale) {
+		if template, ok := b.catalogs[candidate][key]; ok {
+			return render(template, params), true
+		}
+	}
+	return "", false
+}
+
+// render replaces the "{name}" placeholders of a template. Placeholders
+// without a parameter are left as they are.
+func render(template string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	var builder strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		builder.WriteString(template[:start])
+		if value, ok := params[template[start+1:end]]; ok {
+			builder.WriteString(fmt.Sprint(value))
+		} else {
+			builder.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	builder.WriteString(template)
+	return builder.String()
+}
+
+// flatten adds the string members of a decoded catalog to messages, nested
+// objects yielding dotted keys. It returns the reason of the failure or an
+// empty string.
+func flatten(messages map[string]string, prefix string, node map[string]interface{}) string {
+	for key, value := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[key] = v
+		case map[string]interface{}:
+			if reason := flatten(messages, key, v); reason != "" {
+				return reason
+			}
+		default:
+			return fmt.Sprintf("The message %q must be a string or an object.", key)
+		}
+	}
+	return ""
+}
+
+// catalogError builds the exception reporting unloadable catalogs.
+func catalogError(problems map[string]interface{}) error {
+	return exception.NewConfiguration(map[string]interface{}{
+		"message": "The message catalogs cannot be loaded.",
+		"errors":  problems,
+		"details": map[string]interface{}{"error": "invalid_catalog"},
+	})
+}
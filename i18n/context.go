@@ -0,0 +1,154 @@
+// Package i18n provides message translation with locale fallbacks. This file
+// defines the per-request locale carried by contexts, and the translation of
+// messages and exceptions in that locale.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type describing the status of exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Key prefixes of the messages translating exceptions.
+const (
+	ErrorKeyPrefix  = "error."  // ErrorKeyPrefix prefixes the details "error" code of an exception (e.g., "error.not_found").
+	StatusKeyPrefix = "status." // StatusKeyPrefix prefixes the status code of an exception (e.g., "status.404").
+)
+
+// contextKey is the unexported type of the keys defined by this package.
+type contextKey int
+
+// localizerKey is the key of the Localizer carried by a context.
+const localizerKey contextKey = iota
+
+// Localizer is the bundle and the locale used to translate the messages of a
+// request.
+type Localizer struct {
+	Bundle *Bundle
+	Locale string
+}
+
+// WithLocale returns a copy of ctx translating messages with a bundle in a
+// locale.
+func WithLocale(ctx context.Context, bundle *Bundle, locale string) context.Context {
+	return context.WithValue(ctx, localizerKey, Localizer{Bundle: bundle, Locale: Normalize(locale)})
+}
+
+// FromContext returns the Localizer carried by ctx, and false when there is
+// none.
+func FromContext(ctx context.Context) (Localizer, bool) {
+	if ctx == nil {
+		return Localizer{}, false
+	}
+	localizer, ok := ctx.Value(localizerKey).(Localizer)
+	return localizer, ok && localizer.Bundle != nil
+}
+
+// Locale returns the locale carried by ctx, or an empty string.
+func Locale(ctx context.Context) string {
+	localizer, _ := FromContext(ctx)
+	return localizer.Locale
+}
+
+// T translates a message in the locale carried by ctx, e.g. for a message of
+// a success envelope.
+//
+// Parameters:
+//
+//	ctx: The context carrying the Localizer.
+//	key: The key of the message.
+//	params: The values of the "{name}" placeholders of the template. May be nil.
+//
+// Returns:
+//
+//	The translated message, or the key itself when ctx carries no Localizer
+//	or no catalog holds the key, so that missing translations are visible.
+func T(ctx context.Context, key string, params map[string]interface{}) string {
+	localizer, ok := FromContext(ctx)
+	if !ok {
+		return key
+	}
+	if message, found := localizer.Bundle.Translate(localizer.Locale, key, params); found {
+		return message
+	}
+	return key
+}
+
+// messageSetter is implemented by exceptions whose message can be replaced,
+// such as every type embedding `exception.CoreException`.
+type messageSetter interface {
+	error
+	SetMessage(message string) error
+}
+
+// Localize translates the message of the exception found in the chain of err
+// into the locale carried by ctx. The message is looked up under
+// ErrorKeyPrefix followed by the details "error" code of the exception, the
+// details providing the template parameters (e.g., "{entity} introuvable.").
+// Exceptions without error code whose message is the description of their
+// status are looked up under StatusKeyPrefix followed by the status code.
+// Exceptions without a translation, and sealed exceptions (see
+// `exception.CoreException.Seal`), keep their message.
+//
+// Parameters:
+//
+//	ctx: The context carrying the Localizer.
+//	err: The error to localize. It may be nil.
+//
+// Returns:
+//
+//	The same error, for convenient use in return statements.
+func Localize(ctx context.Context, err error) error {
+	localizer, ok := FromContext(ctx)
+	if !ok || err == nil {
+		return err
+	}
+	var coreErr exception.CoreInterface
+	var setter messageSetter
+	if !errors.As(err, &coreErr) || !errors.As(err, &setter) || exception.IsNil(coreErr) || exception.IsNil(setter) {
+		return err
+	}
+
+	key := ""
+	if code := coreErr.GetDetailsMessage(); code != "" {
+		key = ErrorKeyPrefix + code
+	} else if coreErr.Error() == status.StatusCode(coreErr.GetStatusCode()).GetDescription() {
+		key = StatusKeyPrefix + strconv.Itoa(coreErr.GetStatusCode())
+	}
+	if key == "" {
+		return err
+	}
+
+	if message, found := localizer.Bundle.Translate(localizer.Locale, key, coreErr.GetDetails()); found {
+		_ = setter.SetMessage(message)
+	}
+	return err
+}
+
+// Middleware resolves the locale of each request from its Accept-Language
+// header among the locales of the bundle, and stores it in the request
+// context. The selected locale is reported in the Content-Language header.
+//
+// Parameters:
+//
+//	bundle: The bundle translating the messages.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the locale resolution.
+func Middleware(bundle *Bundle) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := Negotiate(r.Header.Get("Accept-Language"), bundle.Locales(), bundle.DefaultLocale())
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), bundle, locale)))
+		})
+	}
+}
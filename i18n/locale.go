@@ -0,0 +1,120 @@
+// Package i18n provides message translation with locale fallbacks. This file
+// defines the normalization and negotiation of locales.
+package i18n
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/osirisgate/golang-core/negotiation"
+)
+
+// Normalize normalizes a BCP 47 language tag: the language is lower-cased,
+// a two letter region upper-cased, a four letter script title-cased, and
+// "_" separators are replaced by "-" (e.g., "pt_br" becomes "pt-BR").
+func Normalize(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Fallbacks returns the chain of locales searched for a message: the locale,
+// its parents obtained by removing its last subtag ("zh-Hant-TW", "zh-Hant",
+// "zh"), and then the chain of the default locale.
+//
+// Parameters:
+//
+//	locale: The requested locale.
+//	defaultLocale: The locale searched last.
+//
+// Returns:
+//
+//	The normalized locales to search, in order, without duplicates.
+func Fallbacks(locale string, defaultLocale string) []string {
+	var chain []string
+	seen := map[string]bool{}
+	for _, tag := range []string{locale, defaultLocale} {
+		for tag = Normalize(tag); tag != ""; {
+			if !seen[tag] {
+				seen[tag] = true
+				chain = append(chain, tag)
+			}
+			cut := strings.LastIndexByte(tag, '-')
+			if cut < 0 {
+				break
+			}
+			tag = tag[:cut]
+		}
+	}
+	return chain
+}
+
+// Negotiate selects the locale of a response from an Accept-Language header.
+// Languages are considered by decreasing quality; each matches an available
+// locale equal to it, one of its parents (a request for "fr-CH" is served in
+// "fr"), or a regional variant of it (a request for "fr" is served in
+// "fr-FR").
+//
+// Parameters:
+//
+//	header: The value of the Accept-Language header (e.g., "fr-CH, fr;q=0.9, en;q=0.8").
+//	available: The locales the application can serve.
+//	defaultLocale: The locale returned when no language matches.
+//
+// Returns:
+//
+//	The selected locale, normalized.
+func Negotiate(header string, available []string, defaultLocale string) string {
+	normalized := make([]string, 0, len(available))
+	for _, locale := range available {
+		normalized = append(normalized, Normalize(locale))
+	}
+	sort.Strings(normalized)
+
+	for _, tag := range parseAcceptLanguage(header) {
+		if tag == "*" {
+			break
+		}
+		for candidate := tag; candidate != ""; {
+			for _, locale := range normalized {
+				if locale == candidate {
+					return locale
+				}
+			}
+			cut := strings.LastIndexByte(candidate, '-')
+			if cut < 0 {
+				break
+			}
+			candidate = candidate[:cut]
+		}
+		for _, locale := range normalized {
+			if strings.HasPrefix(locale, tag+"-") {
+				return locale
+			}
+		}
+	}
+	return Normalize(defaultLocale)
+}
+
+// parseAcceptLanguage returns the normalized languages of an Accept-Language
+// header by decreasing quality, omitting those of quality 0.
+func parseAcceptLanguage(header string) []string {
+	var tags []string
+	for _, preference := range negotiation.Parse(header) {
+		if preference.Quality > 0 {
+			tags = append(tags, Normalize(preference.Value))
+		}
+	}
+	return tags
+}
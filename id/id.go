@@ -0,0 +1,79 @@
+// Package id provides identifier generators. Components generate identifiers
+// through a Generator rather than directly, so that tests can use
+// predictable identifiers.
+package id
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+// Generator generates identifiers of type ID.
+type Generator[ID any] interface {
+	// NewID returns a new identifier.
+	NewID() ID
+}
+
+// Func adapts a function to the Generator interface.
+type Func[ID any] func() ID
+
+// NewID calls f.
+func (f Func[ID]) NewID() ID {
+	return f()
+}
+
+// UUID returns a Generator of random (version 4) UUIDs.
+func UUID() Generator[valueobject.UUID] {
+	return Func[valueobject.UUID](valueobject.NewUUID)
+}
+
+// UUIDv7 returns a Generator of time-ordered (version 7) UUIDs.
+//
+// Parameters:
+//
+//	c: The clock providing the generation time. Nil uses the system clock.
+//
+// Returns:
+//
+//	The UUIDv7 Generator.
+func UUIDv7(c clock.Clock) Generator[valueobject.UUID] {
+	c = clock.OrSystem(c)
+	return Func[valueobject.UUID](func() valueobject.UUID {
+		return valueobject.NewUUIDv7(c.Now())
+	})
+}
+
+// Sequence is a Generator of predictable string identifiers ("<prefix>1",
+// "<prefix>2", ...), intended for tests. It is safe for concurrent use.
+type Sequence struct {
+	prefix string
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewSequence creates a Sequence.
+//
+// Parameters:
+//
+//	prefix: The prefix of the identifiers (e.g., "user-").
+//
+// Returns:
+//
+//	A pointer to a new Sequence starting at 1.
+func NewSequence(prefix string) *Sequence {
+	return &Sequence{prefix: prefix, next: 1}
+}
+
+// NewID returns the next identifier of the sequence.
+func (s *Sequence) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.prefix + strconv.Itoa(s.next)
+	s.next++
+	return id
+}
@@ -0,0 +1,68 @@
+// Package job describes asynchronous operations. This file defines the HTTP
+// helpers of the 202 Accepted and status polling endpoints.
+package job
+
+import (
+	"context"
+	"net/http"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Accepted` and `status.OK` constants of the responses.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/repository"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Store is the repository of jobs, e.g.
+// `repository.NewMemory("job", func(j Job) string { return j.ID })`.
+type Store = repository.Repository[Job, string]
+
+// WriteAccepted answers the request starting a job: it writes a 202 Accepted
+// success envelope carrying the job, with a Location header pointing at its
+// status polling endpoint.
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	w: The `http.ResponseWriter` to write to.
+//	job: The accepted job.
+//	location: The URL of the status endpoint of the job (e.g., "/jobs/42").
+//	          An empty location sets no header.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteAccepted(ctx context.Context, w http.ResponseWriter, job *Job, location string) error {
+	if location != "" {
+		w.Header().Set("Location", location)
+	}
+	return response.WriteSuccessContext(ctx, w, status.Accepted, job)
+}
+
+// Handler serves the status polling endpoint of jobs: it writes a 200 OK
+// success envelope carrying the job, whatever its status, or the error
+// envelope of the store (an `exception.NotFound` for an unknown job).
+//
+// Parameters:
+//
+//	store: The repository of jobs.
+//	idOf: The function extracting the job identifier from the request. Nil
+//	      reads the "id" path value (e.g., "GET /jobs/{id}").
+//
+// Returns:
+//
+//	The handler.
+func Handler(store Store, idOf func(r *http.Request) string) http.Handler {
+	if idOf == nil {
+		idOf = func(r *http.Request) string { return r.PathValue("id") }
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		job, err := store.Find(r.Context(), idOf(r))
+		if err != nil {
+			_ = response.WriteErrorContext(r.Context(), w, err)
+			return
+		}
+		_ = response.WriteSuccessContext(r.Context(), w, status.OK, job)
+	})
+}
@@ -0,0 +1,235 @@
+// Package job describes asynchronous operations: a Job carries the status,
+// progress, result or exception and timestamps of work accepted now and
+// completed later, serializes to JSON for status polling endpoints, and the
+// HTTP helpers answer the initial request with 202 Accepted and the polling
+// requests with the current state of the job.
+package job
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type used to restore the exception of a job.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Status is the lifecycle status of a Job.
+type Status string
+
+const (
+	Pending   Status = "pending"   // Pending means the job is accepted but not started.
+	Running   Status = "running"   // Running means the job is in progress.
+	Succeeded Status = "succeeded" // Succeeded means the job completed with a result.
+	Failed    Status = "failed"    // Failed means the job completed with an exception.
+	Canceled  Status = "canceled"  // Canceled means the job was stopped before completing.
+)
+
+// IsTerminal reports whether the status is final: succeeded, failed or
+// canceled.
+func (s Status) IsTerminal() bool {
+	return s == Succeeded || s == Failed || s == Canceled
+}
+
+// Job describes an asynchronous operation. Its fields are exported so that
+// stores can restore persisted jobs; use the transition methods to change
+// them, as they keep the status, progress and timestamps consistent.
+type Job struct {
+	ID         string
+	Status     Status
+	Progress   int         // The completion percentage, from 0 to 100.
+	Result     interface{} // The payload of a succeeded job; a `json.RawMessage` once decoded.
+	Err        error       // The failure of a failed job.
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	StartedAt  time.Time // Zero until the job starts.
+	FinishedAt time.Time // Zero until the job reaches a terminal status.
+}
+
+// New creates a pending Job stamped with the current time of the clock.
+//
+// Parameters:
+//
+//	id: The identifier of the job (e.g., generated by `id.UUIDv7(c)`).
+//	c: The clock providing the creation time. Nil uses the system clock.
+//
+// Returns:
+//
+//	A pointer to the new Job.
+func New(id string, c clock.Clock) *Job {
+	now := clock.OrSystem(c).Now()
+	return &Job{ID: id, Status: Pending, CreatedAt: now, UpdatedAt: now}
+}
+
+// Start moves a pending job to running.
+func (j *Job) Start(c clock.Clock) error {
+	if err := j.checkOpen("start"); err != nil {
+		return err
+	}
+	now := clock.OrSystem(c).Now()
+	j.Status, j.StartedAt, j.UpdatedAt = Running, now, now
+	return nil
+}
+
+// SetProgress records the completion percentage of a job, clamped between 0
+// and 100. A pending job is moved to running.
+func (j *Job) SetProgress(percent int, c clock.Clock) error {
+	if err := j.checkOpen("progress"); err != nil {
+		return err
+	}
+	if j.Status == Pending {
+		_ = j.Start(c)
+	}
+	j.Progress = min(max(percent, 0), 100)
+	j.UpdatedAt = clock.OrSystem(c).Now()
+	return nil
+}
+
+// Succeed completes a job with its result.
+func (j *Job) Succeed(result interface{}, c clock.Clock) error {
+	if err := j.finish(Succeeded, "succeed", c); err != nil {
+		return err
+	}
+	j.Progress, j.Result = 100, result
+	return nil
+}
+
+// Fail completes a job with the error that stopped it.
+func (j *Job) Fail(err error, c clock.Clock) error {
+	if finishErr := j.finish(Failed, "fail", c); finishErr != nil {
+		return finishErr
+	}
+	j.Err = exception.Normalize(err)
+	return nil
+}
+
+// Cancel stops a job before it completes.
+func (j *Job) Cancel(c clock.Clock) error {
+	return j.finish(Canceled, "cancel", c)
+}
+
+// finish moves a job to a terminal status.
+func (j *Job) finish(to Status, action string, c clock.Clock) error {
+	if err := j.checkOpen(action); err != nil {
+		return err
+	}
+	now := clock.OrSystem(c).Now()
+	if j.StartedAt.IsZero() {
+		j.StartedAt = now
+	}
+	j.Status, j.FinishedAt, j.UpdatedAt = to, now, now
+	return nil
+}
+
+// checkOpen returns an `exception.Conflict` when the job already reached a
+// terminal status and can no longer change.
+func (j *Job) checkOpen(action string) error {
+	if !j.Status.IsTerminal() {
+		return nil
+	}
+	return exception.NewConflict(map[string]interface{}{
+		"message": fmt.Sprintf("The job %s is already %s.", j.ID, j.Status),
+		"details": map[string]interface{}{
+			"job":    j.ID,
+			"status": string(j.Status),
+			"action": action,
+			"error":  "job_finished",
+		},
+	})
+}
+
+// jobJSON is the wire representation of a Job.
+type jobJSON struct {
+	ID         string                 `json:"id"`
+	Status     Status                 `json:"status"`
+	Progress   int                    `json:"progress"`
+	Result     interface{}            `json:"result,omitempty"`
+	Error      map[string]interface{} `json:"error,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+	StartedAt  *time.Time             `json:"started_at,omitempty"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+}
+
+// MarshalJSON encodes the job, its exception under "error" in the shape of
+// the error envelope and its unset timestamps omitted.
+func (j Job) MarshalJSON() ([]byte, error) {
+	wire := jobJSON{
+		ID:         j.ID,
+		Status:     j.Status,
+		Progress:   j.Progress,
+		Result:     j.Result,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+		StartedAt:  optionalTime(j.StartedAt),
+		FinishedAt: optionalTime(j.FinishedAt),
+	}
+	var coreErr exception.CoreInterface
+	if errors.As(exception.Normalize(j.Err), &coreErr) {
+		wire.Error = coreErr.Format()
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON decodes a job encoded by MarshalJSON. Its result is kept as a
+// `json.RawMessage` and its exception is rebuilt from its status code (see
+// `exception.FromStatus`).
+func (j *Job) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		jobJSON
+		Result json.RawMessage `json:"result,omitempty"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	*j = Job{
+		ID:        wire.ID,
+		Status:    wire.Status,
+		Progress:  wire.Progress,
+		CreatedAt: wire.CreatedAt,
+		UpdatedAt: wire.UpdatedAt,
+	}
+	if len(wire.Result) > 0 {
+		j.Result = wire.Result
+	}
+	if wire.StartedAt != nil {
+		j.StartedAt = *wire.StartedAt
+	}
+	if wire.FinishedAt != nil {
+		j.FinishedAt = *wire.FinishedAt
+	}
+	if wire.Error != nil {
+		j.Err = restoreException(wire.Error)
+	}
+	return nil
+}
+
+// restoreException rebuilds an exception from its formatted envelope, under
+// any naming of its keys (see `exception.SetKeyNaming`).
+func restoreException(formatted map[string]interface{}) exception.CoreInterface {
+	var code float64
+	fields := make(map[string]interface{}, len(formatted))
+	for key, value := range formatted {
+		switch key = exception.BuiltinKey(key); key {
+		case "status":
+		case "error_code":
+			code, _ = value.(float64)
+		default:
+			fields[key] = value
+		}
+	}
+	return exception.FromStatus(status.StatusCode(code), fields)
+}
+
+// optionalTime returns nil for the zero time, so that it is omitted.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
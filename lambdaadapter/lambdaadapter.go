@@ -0,0 +1,155 @@
+// Package lambdaadapter converts exceptions into the responses of the AWS
+// Lambda functions behind API Gateway, so that the serverless functions
+// answer with the same envelopes as the HTTP services. It does not depend on
+// aws-lambda-go: Response has the fields and the JSON encoding of
+// `events.APIGatewayProxyResponse`, so it can be returned by a handler
+// started with `lambda.Start` as is, or converted to it:
+//
+//	lambda.Start(lambdaadapter.Wrap(handler, log))
+//
+//	return events.APIGatewayProxyResponse(lambdaadapter.FromExceptionContext(ctx, err)), nil
+package lambdaadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/response"
+	"github.com/osirisgate/golang-core/tracing"
+)
+
+// Response is an API Gateway proxy response, with the fields of
+// `events.APIGatewayProxyResponse`.
+type Response struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded,omitempty"`
+}
+
+// Handler is a Lambda handler of API Gateway events, e.g. of
+// `events.APIGatewayProxyRequest`.
+type Handler[Request any] func(ctx context.Context, request Request) (Response, error)
+
+// FromException converts an error into the response of its exception: its
+// status code, the headers it implies (see `exception.CoreInterface.Headers`)
+// and its envelope as JSON body, in the version set by
+// `response.SetEnvelopeVersion`. Errors that are not exceptions are
+// converted into internal server errors, without their message, like
+// `response.WriteError` does.
+//
+// Parameters:
+//
+//	err: The error to convert; it must not be nil.
+//
+// Returns:
+//
+//	The response, or an error if the envelope could not be encoded.
+func FromException(err error) (Response, error) {
+	return fromException(context.Background(), toException(err))
+}
+
+// FromExceptionContext behaves like FromException, additionally annotating
+// the exception with the identifiers of ctx, localizing its message and
+// recording it on the current span, like `response.WriteErrorContext`, and
+// writing the envelope in the version of ctx (see
+// `response.EnvelopeVersionOf`). It falls back to the envelope of an internal server error when the envelope
+// of the exception cannot be encoded, so that it always yields a response.
+//
+// Parameters:
+//
+//	ctx: The context of the invocation.
+//	err: The error to convert; it must not be nil.
+//
+// Returns:
+//
+//	The response.
+func FromExceptionContext(ctx context.Context, err error) Response {
+	coreErr := toException(err)
+	_ = ctxutil.Annotate(ctx, coreErr)
+	_ = i18n.Localize(ctx, coreErr)
+	_ = tracing.Record(ctx, coreErr)
+
+	resp, encodeErr := fromException(ctx, coreErr)
+	if encodeErr != nil {
+		// The envelope of a bare internal server error always encodes.
+		resp, _ = fromException(ctx, exception.NewError(map[string]interface{}{}))
+	}
+	return resp
+}
+
+// Wrap wraps a handler so that it answers with the response of the
+// exceptions it returns (see FromExceptionContext), and of its panics,
+// converted into an `exception.Runtime`, instead of failing the invocation,
+// which API Gateway would turn into a bare 502. The errors and the panics
+// are logged.
+//
+// Parameters:
+//
+//	handler: The handler to wrap.
+//	l: The Logger receiving the errors and the panics. Nil uses `logger.Nop()`.
+//
+// Returns:
+//
+//	The wrapped handler, which never returns an error.
+func Wrap[Request any](handler Handler[Request], l logger.Logger) Handler[Request] {
+	if l == nil {
+		l = logger.Nop()
+	}
+
+	return func(ctx context.Context, request Request) (resp Response, err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			panicErr := exception.NewRuntime(map[string]interface{}{
+				"message": "The Lambda handler panicked.",
+				"details": map[string]interface{}{
+					"panic": fmt.Sprint(recovered),
+					"error": "handler_panicked",
+				},
+			})
+			logger.LogExceptionContext(ctx, l, panicErr)
+			resp, err = FromExceptionContext(ctx, panicErr), nil
+		}()
+
+		resp, err = handler(ctx, request)
+		if exception.IsNil(err) {
+			return resp, nil
+		}
+		logger.LogExceptionContext(ctx, l, err)
+		return FromExceptionContext(ctx, err), nil
+	}
+}
+
+// fromException builds the response of an exception, in the envelope
+// version of ctx.
+func fromException(ctx context.Context, coreErr exception.CoreInterface) (Response, error) {
+	body, err := response.AppendEnvelope(ctx, make([]byte, 0, 512), coreErr)
+	if err != nil {
+		return Response{}, err
+	}
+
+	headers := map[string]string{"Content-Type": response.ContentTypeJSON}
+	for key, value := range coreErr.Headers() {
+		headers[key] = value
+	}
+	return Response{StatusCode: coreErr.GetStatusCode(), Headers: headers, Body: string(body)}, nil
+}
+
+// toException returns the exception of the chain of err, or an internal
+// server error whose message does not disclose err.
+func toException(err error) exception.CoreInterface {
+	var coreErr exception.CoreInterface
+	if errors.As(err, &coreErr) && !exception.IsNil(coreErr) {
+		return coreErr
+	}
+	return exception.NewError(map[string]interface{}{})
+}
@@ -0,0 +1,77 @@
+// Package logger defines the minimal structured logging contract of the core.
+// This file defines the helpers logging exceptions through a Logger.
+package logger
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// LogException logs an error with the fields of its `GetErrorsForLog()`
+// output. Server errors (5xx) are logged at the Error level and every other
+// exception at the Warn level. Errors that are not exceptions are logged at
+// the Error level with their message only.
+//
+// Parameters:
+//
+//	l: The Logger to write to.
+//	err: The error to log. A nil error, or nil exception (see
+//	`exception.IsNil`), logs nothing.
+func LogException(l Logger, err error) {
+	LogExceptionContext(context.Background(), l, err)
+}
+
+// LogExceptionContext behaves like `LogException`, additionally adding the
+// request, correlation and tenant IDs carried by ctx to the entry.
+//
+// Parameters:
+//
+//	ctx: The context carrying the identifiers (see `ctxutil`).
+//	l: The Logger to write to.
+//	err: The error to log. A nil error, or nil exception (see
+//	`exception.IsNil`), logs nothing.
+func LogExceptionContext(ctx context.Context, l Logger, err error) {
+	if exception.IsNil(err) || l == nil {
+		return
+	}
+
+	ids := ctxutil.Fields(ctx)
+	fields := make([]Field, 0, len(ids)+4)
+	for _, key := range sortedKeys(ids) {
+		fields = append(fields, Any(key, ids[key]))
+	}
+
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || exception.IsNil(coreErr) {
+		l.Error(err.Error(), fields...)
+		return
+	}
+
+	entry := coreErr.GetErrorsForLog()
+	for _, key := range sortedKeys(entry) {
+		if key != "message" {
+			fields = append(fields, Any(key, entry[key]))
+		}
+	}
+
+	if coreErr.GetStatusCode() >= 500 {
+		l.Error(coreErr.Error(), fields...)
+	} else {
+		l.Warn(coreErr.Error(), fields...)
+	}
+}
+
+// sortedKeys returns the keys of a map in lexical order, so that log entries
+// have a stable field order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+// Package logger defines the minimal structured logging contract shared by
+// the core packages and their consumers, so that downstream code depends on
+// one interface instead of a specific logging library. A `log/slog` backed
+// implementation and a no-op implementation are provided.
+package logger
+
+// Field is a structured key/value pair attached to a log entry.
+type Field struct {
+	Key   string      // The name of the field.
+	Value interface{} // The value of the field.
+}
+
+// Any creates a Field from a key and a value of any type.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger is the structured logging contract of the core. Implementations must
+// be safe for concurrent use.
+type Logger interface {
+	// Debug logs a message useful when diagnosing issues.
+	Debug(msg string, fields ...Field)
+
+	// Info logs a message describing the normal operation of the application.
+	Info(msg string, fields ...Field)
+
+	// Warn logs a message describing an unexpected but handled situation.
+	Warn(msg string, fields ...Field)
+
+	// Error logs a message describing a failure.
+	Error(msg string, fields ...Field)
+
+	// With returns a Logger adding the given fields to every entry.
+	With(fields ...Field) Logger
+}
+
+// nop is a Logger discarding every entry.
+type nop struct{}
+
+// Nop returns a Logger discarding every entry, for tests and optional logging.
+func Nop() Logger {
+	return nop{}
+}
+
+func (nop) Debug(string, ...Field) {}
+func (nop) Info(string, ...Field)  {}
+func (nop) Warn(string, ...Field)  {}
+func (nop) Error(string, ...Field) {}
+func (n nop) With(...Field) Logger { return n }
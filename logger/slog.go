@@ -0,0 +1,71 @@
+// Package logger defines the minimal structured logging contract of the core.
+// This file defines the `log/slog` backed implementation.
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogLogger adapts a `*slog.Logger` to the Logger interface.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlog creates a Logger writing through the given `*slog.Logger`.
+//
+// Parameters:
+//
+//	l: The slog logger to write to. A nil logger falls back to `slog.Default()`.
+//
+// Returns:
+//
+//	A Logger backed by slog.
+func NewSlog(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{logger: l}
+}
+
+// Default returns a Logger writing through `slog.Default()`.
+func Default() Logger {
+	return NewSlog(nil)
+}
+
+func (l slogLogger) Debug(msg string, fields ...Field) { l.log(slog.LevelDebug, msg, fields) }
+func (l slogLogger) Info(msg string, fields ...Field)  { l.log(slog.LevelInfo, msg, fields) }
+func (l slogLogger) Warn(msg string, fields ...Field)  { l.log(slog.LevelWarn, msg, fields) }
+func (l slogLogger) Error(msg string, fields ...Field) { l.log(slog.LevelError, msg, fields) }
+
+// With returns a Logger adding the given fields to every entry.
+func (l slogLogger) With(fields ...Field) Logger {
+	return slogLogger{logger: l.logger.With(toArgs(fields)...)}
+}
+
+// log writes an entry at the given level.
+func (l slogLogger) log(level slog.Level, msg string, fields []Field) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.LogAttrs(ctx, level, msg, toAttrs(fields)...)
+}
+
+// toAttrs converts fields into slog attributes.
+func toAttrs(fields []Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, field := range fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+	return attrs
+}
+
+// toArgs converts fields into the variadic arguments expected by `slog.Logger.With`.
+func toArgs(fields []Field) []interface{} {
+	args := make([]interface{}, 0, len(fields))
+	for _, attr := range toAttrs(fields) {
+		args = append(args, attr)
+	}
+	return args
+}
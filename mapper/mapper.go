@@ -0,0 +1,110 @@
+// Package mapper provides a small mapping layer between representations of
+// the same data, such as DTOs and entities. Mappings are either written by
+// hand as a Mapper, or derived by reflection with MapInto, which copies the
+// fields matching by name or tag. Mapping failures are reported as
+// `exception.UnexpectedValue` errors listing the offending fields.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Mapper converts values of type S into values of type D.
+type Mapper[S any, D any] interface {
+	// Map converts a source value.
+	Map(src S) (D, error)
+}
+
+// Func adapts a function to the Mapper interface.
+type Func[S any, D any] func(src S) (D, error)
+
+// Map calls f.
+func (f Func[S, D]) Map(src S) (D, error) {
+	return f(src)
+}
+
+// Auto returns a Mapper deriving the mapping by reflection with MapInto.
+func Auto[S any, D any]() Mapper[S, D] {
+	return Func[S, D](func(src S) (D, error) {
+		var dst D
+		err := MapInto(src, &dst)
+		return dst, err
+	})
+}
+
+// MapSlice converts every element of a slice.
+//
+// Parameters:
+//
+//	m: The Mapper converting each element.
+//	src: The values to convert. A nil slice yields a nil slice.
+//
+// Returns:
+//
+//	The converted values, or an `exception.UnexpectedValue` listing the
+//	failing elements by index (e.g., "[2].email") when any element fails.
+func MapSlice[S any, D any](m Mapper[S, D], src []S) ([]D, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := make([]D, len(src))
+	fields := map[string]interface{}{}
+	for i, value := range src {
+		mapped, err := m.Map(value)
+		if err != nil {
+			addFailures(fields, fmt.Sprintf("[%d]", i), err)
+			continue
+		}
+		dst[i] = mapped
+	}
+
+	if len(fields) > 0 {
+		return nil, failure(fmt.Sprintf("%T", src), fmt.Sprintf("%T", dst), fields)
+	}
+	return dst, nil
+}
+
+// failure builds the exception reporting the offending fields of a mapping.
+func failure(source string, target string, fields map[string]interface{}) error {
+	return exception.NewUnexpectedValue(map[string]interface{}{
+		"message": fmt.Sprintf("Cannot map %s to %s.", source, target),
+		"details": map[string]interface{}{
+			"source": source,
+			"target": target,
+			"fields": fields,
+			"error":  "mapping_failed",
+		},
+	})
+}
+
+// addFailures records the failure of a nested mapping under a path prefix.
+// The offending fields of nested mapping failures are merged with the prefix;
+// any other error is recorded under the prefix itself.
+func addFailures(fields map[string]interface{}, prefix string, err error) {
+	var unexpected *exception.UnexpectedValue
+	if errors.As(err, &unexpected) && unexpected.GetDetailsMessage() == "mapping_failed" {
+		if nested, ok := unexpected.GetDetails()["fields"].(map[string]interface{}); ok {
+			for path, reason := range nested {
+				fields[joinPath(prefix, path)] = reason
+			}
+			return
+		}
+	}
+	fields[prefix] = err.Error()
+}
+
+// joinPath joins a field path prefix and a nested path.
+func joinPath(prefix string, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "" || path[0] == '[':
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
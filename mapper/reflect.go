@@ -0,0 +1,316 @@
+// Package mapper provides a small mapping layer between representations of
+// the same data. This file defines the reflection based MapInto.
+package mapper
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// TagName is the struct tag naming a field for MapInto, taking precedence
+// over the `json` tag, e.g.
+//
+//	Email string `mapper:"email_address"`
+//
+// A "-" value excludes the field from the mapping.
+const TagName = "mapper"
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// MapInto copies the fields of a source struct into the matching fields of a
+// destination struct. Fields match by their `mapper` tag name, their `json`
+// tag name or their Go name, case-insensitively; the fields of embedded
+// structs are matched as if they were declared in the outer struct.
+// Destination fields without a matching source field are left untouched.
+//
+// Values are converted when their types differ:
+//   - between numeric types, when the value fits in the destination type;
+//   - between types sharing the same underlying string or bool type;
+//   - from an `encoding.TextMarshaler` to a string, and from a string to an
+//     `encoding.TextUnmarshaler` (e.g., the value objects of the core);
+//   - between pointers and their element types, a nil pointer mapping to the
+//     zero value;
+//   - recursively between structs and between slices.
+//
+// Parameters:
+//
+//	src: The source struct, or a pointer to it. A nil pointer maps nothing.
+//	dst: A non-nil pointer to the destination struct.
+//
+// Returns:
+//
+//	Nil on success, an `exception.UnexpectedValue` whose details list under
+//	"fields" the reason of every field that could not be mapped (e.g.,
+//	"address.zip" or "items[1].price"), or an `exception.Logic` when src or
+//	dst is not of the expected kind.
+func MapInto(src interface{}, dst interface{}) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return invalidOperand("destination", dst)
+	}
+
+	source := reflect.ValueOf(src)
+	for source.Kind() == reflect.Pointer {
+		if source.IsNil() {
+			return nil
+		}
+		source = source.Elem()
+	}
+	if source.Kind() != reflect.Struct {
+		return invalidOperand("source", src)
+	}
+
+	fields := map[string]interface{}{}
+	mapStruct(fields, "", source, target.Elem())
+	if len(fields) > 0 {
+		return failure(source.Type().String(), target.Elem().Type().String(), fields)
+	}
+	return nil
+}
+
+// invalidOperand reports a MapInto operand of the wrong kind.
+func invalidOperand(operand string, value interface{}) error {
+	return exception.NewLogic(map[string]interface{}{
+		"message": fmt.Sprintf("The %s of a mapping must be a struct, or a pointer to one.", operand),
+		"details": map[string]interface{}{
+			"operand": operand,
+			"type":    fmt.Sprintf("%T", value),
+			"error":   "invalid_mapping_operand",
+		},
+	})
+}
+
+// field is a mappable struct field.
+type field struct {
+	name  string // The name of the field in error paths.
+	index []int  // The index sequence of the field, for `reflect.Value.FieldByIndex`.
+}
+
+// structFields returns the mappable fields of a struct type indexed by their
+// lower-cased name. Fields declared in the struct shadow those of embedded
+// structs, as in Go.
+func structFields(typ reflect.Type) map[string]field {
+	fields := map[string]field{}
+	collectFields(fields, typ, nil)
+	return fields
+}
+
+// collectFields adds the fields of a struct type to fields, the declared
+// fields first and then those of the embedded structs.
+func collectFields(fields map[string]field, typ reflect.Type, parent []int) {
+	var embedded []reflect.StructField
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, structField)
+			continue
+		}
+
+		name, ok := fieldName(structField)
+		key := strings.ToLower(name)
+		if _, shadowed := fields[key]; !ok || shadowed {
+			continue
+		}
+		fields[key] = field{name: name, index: append(append([]int(nil), parent...), i)}
+	}
+
+	for _, structField := range embedded {
+		collectFields(fields, structField.Type, append(append([]int(nil), parent...), structField.Index...))
+	}
+}
+
+// fieldName returns the mapping name of a field, and false when the field is
+// excluded from the mapping.
+func fieldName(structField reflect.StructField) (string, bool) {
+	for _, tag := range []string{TagName, "json"} {
+		if value, ok := structField.Tag.Lookup(tag); ok {
+			name, _, _ := strings.Cut(value, ",")
+			if name == "-" {
+				return "", false
+			}
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+	return structField.Name, true
+}
+
+// mapStruct maps the matching fields of two struct values.
+func mapStruct(failures map[string]interface{}, path string, src reflect.Value, dst reflect.Value) {
+	sourceFields := structFields(src.Type())
+	for key, target := range structFields(dst.Type()) {
+		source, ok := sourceFields[key]
+		if !ok {
+			continue
+		}
+		assign(failures, joinPath(path, target.name), src.FieldByIndex(source.index), dst.FieldByIndex(target.index))
+	}
+}
+
+// assign maps a source value into a settable destination value, recording
+// the failures under path.
+func assign(failures map[string]interface{}, path string, src reflect.Value, dst reflect.Value) {
+	srcType, dstType := src.Type(), dst.Type()
+
+	switch {
+	case srcType.AssignableTo(dstType):
+		dst.Set(src)
+
+	case dstType.Kind() == reflect.Pointer:
+		if src.Kind() == reflect.Pointer && src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		elem := reflect.New(dstType.Elem())
+		before := len(failures)
+		assign(failures, path, src, elem.Elem())
+		if len(failures) == before {
+			dst.Set(elem)
+		}
+
+	case srcType.Kind() == reflect.Pointer:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		assign(failures, path, src.Elem(), dst)
+
+	case dstType.Kind() == reflect.String && srcType.Implements(textMarshalerType):
+		text, err := src.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			failures[path] = err.Error()
+			return
+		}
+		dst.SetString(string(text))
+
+	case srcType.Kind() == reflect.String && reflect.PointerTo(dstType).Implements(textUnmarshalerType):
+		if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(src.String())); err != nil {
+			failures[path] = err.Error()
+		}
+
+	case srcType.Kind() == reflect.Struct && dstType.Kind() == reflect.Struct:
+		mapStruct(failures, path, src, dst)
+
+	case srcType.Kind() == reflect.Slice && dstType.Kind() == reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		elems := reflect.MakeSlice(dstType, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			assign(failures, fmt.Sprintf("%s[%d]", path, i), src.Index(i), elems.Index(i))
+		}
+		dst.Set(elems)
+
+	default:
+		if reason := convert(src, dst); reason != "" {
+			failures[path] = reason
+		}
+	}
+}
+
+// convert converts a scalar value into the destination, returning the reason
+// of the failure or an empty string.
+func convert(src reflect.Value, dst reflect.Value) string {
+	srcKind, dstKind := kindOf(src.Kind()), kindOf(dst.Kind())
+	if srcKind == 0 || srcKind != dstKind {
+		return fmt.Sprintf("cannot map %s to %s", src.Type(), dst.Type())
+	}
+
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, ok := toInt(src)
+		if !ok || dst.OverflowInt(value) {
+			return fmt.Sprintf("%v does not fit in %s", src, dst.Type())
+		}
+		dst.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, ok := toUint(src)
+		if !ok || dst.OverflowUint(value) {
+			return fmt.Sprintf("%v does not fit in %s", src, dst.Type())
+		}
+		dst.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value := toFloat(src)
+		if dst.OverflowFloat(value) {
+			return fmt.Sprintf("%v does not fit in %s", src, dst.Type())
+		}
+		dst.SetFloat(value)
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+	return ""
+}
+
+// Kind families converted into each other.
+const (
+	numericFamily = iota + 1
+	stringFamily
+	boolFamily
+)
+
+// kindOf returns the conversion family of a kind, or 0 when it has none.
+func kindOf(kind reflect.Kind) int {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return numericFamily
+	case reflect.String:
+		return stringFamily
+	case reflect.Bool:
+		return boolFamily
+	default:
+		return 0
+	}
+}
+
+// toInt reads a numeric value as an int64, reporting whether it is exact.
+func toInt(v reflect.Value) (int64, bool) {
+	switch {
+	case v.CanInt():
+		return v.Int(), true
+	case v.CanUint():
+		return int64(v.Uint()), v.Uint() <= math.MaxInt64
+	default:
+		f := v.Float()
+		return int64(f), f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+	}
+}
+
+// toUint reads a numeric value as a uint64, reporting whether it is exact.
+func toUint(v reflect.Value) (uint64, bool) {
+	switch {
+	case v.CanUint():
+		return v.Uint(), true
+	case v.CanInt():
+		return uint64(v.Int()), v.Int() >= 0
+	default:
+		f := v.Float()
+		return uint64(f), f == math.Trunc(f) && f >= 0 && f < math.MaxUint64
+	}
+}
+
+// toFloat reads a numeric value as a float64.
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
@@ -0,0 +1,182 @@
+// Package messaging provides the envelope wrapping the messages exchanged
+// through queues. This file defines the flattening of exceptions into the
+// native headers of the brokers, such as Kafka record headers and AMQP
+// header tables.
+package messaging
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// brokerHeaders are the headers of ErrorHeaders kept by BrokerHeaders, in
+// the order of the Kafka headers.
+var brokerHeaders = []string{ErrorCodeHeader, ErrorFingerprintHeader, ErrorMessageHeader, ErrorStatusHeader}
+
+// KafkaHeader is a Kafka record header. It has the fields of the `Header`
+// types of segmentio/kafka-go and confluent-kafka-go, so the headers convert
+// with a loop:
+//
+//	for _, h := range messaging.KafkaHeaders(err) {
+//		msg.Headers = append(msg.Headers, kafka.Header{Key: h.Key, Value: h.Value})
+//	}
+type KafkaHeader struct {
+	Key   string
+	Value []byte
+}
+
+// BrokerHeaders flattens an exception into the broker headers: the
+// ErrorStatusHeader, ErrorCodeHeader, ErrorMessageHeader and
+// ErrorFingerprintHeader of ErrorHeaders. Unlike ErrorHeaders, the errors
+// map is not included: the headers stay small enough for every broker, and
+// the fingerprint lets the dead-letter and replay tools group the failures
+// without it. Errors that are not exceptions are flattened as the generic
+// 500 exception of `exception.Normalize`, without their message. The
+// headers are decoded by ErrorFromHeaders.
+//
+// Parameters:
+//
+//	err: The error to flatten.
+//
+// Returns:
+//
+//	The headers, or nil for a nil error.
+func BrokerHeaders(err error) map[string]string {
+	headers := ErrorHeaders(err)
+	if headers == nil {
+		return nil
+	}
+	flat := make(map[string]string, len(brokerHeaders))
+	for _, key := range brokerHeaders {
+		if value, ok := headers[key]; ok {
+			flat[key] = value
+		}
+	}
+	return flat
+}
+
+// KafkaHeaders flattens an exception into Kafka record headers (see
+// BrokerHeaders), sorted by key.
+//
+// Parameters:
+//
+//	err: The error to flatten.
+//
+// Returns:
+//
+//	The headers, or nil for a nil error.
+func KafkaHeaders(err error) []KafkaHeader {
+	headers := BrokerHeaders(err)
+	if headers == nil {
+		return nil
+	}
+
+	kafkaHeaders := make([]KafkaHeader, 0, len(headers))
+	for _, key := range brokerHeaders {
+		if value, ok := headers[key]; ok {
+			kafkaHeaders = append(kafkaHeaders, KafkaHeader{Key: key, Value: []byte(value)})
+		}
+	}
+	return kafkaHeaders
+}
+
+// ErrorFromKafkaHeaders rebuilds the exception flattened by KafkaHeaders.
+// The other headers of the record are ignored; when a header is repeated,
+// the last one wins, as with the Kafka clients.
+//
+// Parameters:
+//
+//	headers: The headers of a failed record.
+//
+// Returns:
+//
+//	The exception, or nil when the headers hold no valid ErrorStatusHeader.
+func ErrorFromKafkaHeaders(headers []KafkaHeader) exception.CoreInterface {
+	flat := map[string]string{}
+	for _, header := range headers {
+		if slices.Contains(brokerHeaders, header.Key) {
+			flat[header.Key] = string(header.Value)
+		}
+	}
+	return ErrorFromHeaders(flat)
+}
+
+// AMQPHeaders flattens an exception into an AMQP header table (see
+// BrokerHeaders), assignable to the `amqp.Table` of rabbitmq/amqp091-go.
+// The status code is an int32, a type every AMQP client decodes; the other
+// headers are strings.
+//
+// Parameters:
+//
+//	err: The error to flatten.
+//
+// Returns:
+//
+//	The table, or nil for a nil error.
+func AMQPHeaders(err error) map[string]interface{} {
+	headers := BrokerHeaders(err)
+	if headers == nil {
+		return nil
+	}
+
+	table := make(map[string]interface{}, len(headers))
+	for key, value := range headers {
+		table[key] = value
+	}
+	code, _ := strconv.Atoi(headers[ErrorStatusHeader])
+	table[ErrorStatusHeader] = int32(code)
+	return table
+}
+
+// ErrorFromAMQPHeaders rebuilds the exception flattened by AMQPHeaders. The
+// values may be strings or byte slices (long strings and byte arrays of
+// the other clients), and the status code any integer type or a string.
+//
+// Parameters:
+//
+//	table: The header table of a failed delivery.
+//
+// Returns:
+//
+//	The exception, or nil when the table holds no valid ErrorStatusHeader.
+func ErrorFromAMQPHeaders(table map[string]interface{}) exception.CoreInterface {
+	flat := map[string]string{}
+	for _, key := range brokerHeaders {
+		switch value := table[key].(type) {
+		case string:
+			flat[key] = value
+		case []byte:
+			flat[key] = string(value)
+		case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64:
+			flat[key] = strconv.FormatInt(toInt64(value), 10)
+		}
+	}
+	return ErrorFromHeaders(flat)
+}
+
+// toInt64 converts the integer types of the AMQP tables.
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,140 @@
+// Package messaging provides the envelope wrapping the messages exchanged
+// through queues. This file defines the serialization of exceptions into
+// dead-letter headers.
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type used to rebuild exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/report"
+)
+
+// Headers describing the exception that made a message fail, in the
+// envelopes and in the native headers of the brokers (see BrokerHeaders).
+// They are lowercase, as the header names of Kafka and AMQP are
+// case-sensitive.
+const (
+	ErrorStatusHeader      = "x-error-status"      // ErrorStatusHeader carries the status code of the exception.
+	ErrorCodeHeader        = "x-error-code"        // ErrorCodeHeader carries the details "error" code of the exception.
+	ErrorMessageHeader     = "x-error-message"     // ErrorMessageHeader carries the message of the exception.
+	ErrorFingerprintHeader = "x-error-fingerprint" // ErrorFingerprintHeader carries the fingerprint of the exception (see `report.DefaultFingerprint`).
+	ErrorTypeHeader        = "x-error-type"        // ErrorTypeHeader carries the Go type of the exception (e.g., "*exception.NotFound").
+	ErrorErrorsHeader      = "x-error-errors"      // ErrorErrorsHeader carries the errors map of the exception, encoded as JSON.
+	ErrorAttemptHeader     = "x-error-attempt"     // ErrorAttemptHeader carries the attempt at which the message failed.
+	ErrorAtHeader          = "x-error-at"          // ErrorAtHeader carries the RFC 3339 time of the failure.
+)
+
+// ErrorHeaders serializes an exception into headers. The stack trace is not
+// included; the errors map is encoded as JSON, so `valueobject.Redacted`
+// values stay redacted. Errors that are not exceptions are serialized as the
+// generic 500 exception of `exception.Normalize`, without their message.
+//
+// Parameters:
+//
+//	err: The error to serialize.
+//
+// Returns:
+//
+//	The headers, or nil for a nil error.
+func ErrorHeaders(err error) map[string]string {
+	if err == nil {
+		return nil
+	}
+	var coreErr exception.CoreInterface
+	if !errors.As(exception.Normalize(err), &coreErr) {
+		return nil
+	}
+
+	headers := map[string]string{
+		ErrorStatusHeader:      strconv.Itoa(coreErr.GetStatusCode()),
+		ErrorMessageHeader:     coreErr.Error(),
+		ErrorFingerprintHeader: report.DefaultFingerprint(coreErr),
+		ErrorTypeHeader:        fmt.Sprintf("%T", coreErr),
+		ErrorAtHeader:          time.Now().UTC().Format(time.RFC3339),
+	}
+	if code := coreErr.GetDetailsMessage(); code != "" {
+		headers[ErrorCodeHeader] = code
+	}
+	if len(coreErr.GetErrors()) > 0 {
+		if encoded, err := json.Marshal(coreErr.GetErrors()); err == nil {
+			headers[ErrorErrorsHeader] = string(encoded)
+		}
+	}
+	return headers
+}
+
+// ErrorFromHeaders rebuilds the exception serialized by ErrorHeaders, or
+// flattened by BrokerHeaders, as the exception type matching its status code
+// (see `exception.FromStatus`). The details hold the ErrorCodeHeader as their
+// "error" entry, unless the errors map of ErrorErrorsHeader sets it, and the
+// ErrorFingerprintHeader as their "fingerprint" entry, so that the
+// dead-letter and replay tools group the failures as the reporters did.
+//
+// Parameters:
+//
+//	headers: The headers of a failed message.
+//
+// Returns:
+//
+//	The exception, or nil when the headers hold no valid ErrorStatusHeader.
+func ErrorFromHeaders(headers map[string]string) exception.CoreInterface {
+	code, err := strconv.Atoi(headers[ErrorStatusHeader])
+	if err != nil {
+		return nil
+	}
+
+	errorsMap := map[string]interface{}{}
+	if encoded := headers[ErrorErrorsHeader]; encoded != "" {
+		_ = json.Unmarshal([]byte(encoded), &errorsMap)
+	}
+	if message := headers[ErrorMessageHeader]; message != "" {
+		errorsMap["message"] = message
+	}
+	details, _ := errorsMap["details"].(map[string]interface{})
+	for key, header := range map[string]string{"error": ErrorCodeHeader, "fingerprint": ErrorFingerprintHeader} {
+		if value := headers[header]; value != "" {
+			if details == nil {
+				details = map[string]interface{}{}
+			}
+			if _, ok := details[key]; !ok {
+				details[key] = value
+			}
+		}
+	}
+	if details != nil {
+		errorsMap["details"] = details
+	}
+	return exception.FromStatus(status.StatusCode(code), errorsMap)
+}
+
+// DeadLetter returns a copy of the envelope carrying the headers of the
+// exception that made it fail, to publish on a dead-letter queue.
+//
+// Parameters:
+//
+//	err: The error that made the message fail.
+//
+// Returns:
+//
+//	The annotated copy of the envelope. Its original headers are kept.
+func (e Envelope) DeadLetter(err error) Envelope {
+	failed := e.WithHeader(ErrorAttemptHeader, strconv.Itoa(e.Attempt))
+	for name, value := range ErrorHeaders(err) {
+		failed.Headers[name] = value
+	}
+	return failed
+}
+
+// Err returns the exception carried by the headers of a dead-lettered
+// envelope, or nil.
+func (e Envelope) Err() exception.CoreInterface {
+	return ErrorFromHeaders(e.Headers)
+}
@@ -0,0 +1,127 @@
+// Package messaging provides the envelope wrapping the messages exchanged
+// through queues and brokers: a payload with its headers, correlation ID and
+// delivery attempt count. Failed messages carry the exception that made them
+// fail in their headers (see DeadLetter), so that dead-letter consumers can
+// rebuild and report it with the same contract as HTTP errors.
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+// Envelope is a message with its metadata. Its JSON form can be published
+// as is on brokers without native headers.
+type Envelope struct {
+	ID            string            `json:"id"`
+	Type          string            `json:"type"`
+	Payload       json.RawMessage   `json:"payload"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Attempt       int               `json:"attempt"` // The number of deliveries so far, starting at 1.
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// New creates the envelope of a message. The correlation ID and the tenant
+// ID carried by ctx (see ctxutil) are propagated, the latter as the
+// ctxutil.TenantIDHeader header.
+//
+// Parameters:
+//
+//	ctx: The context of the producer.
+//	messageType: The type of the message (e.g., "order.paid").
+//	payload: The message, encoded as JSON.
+//
+// Returns:
+//
+//	The Envelope, with a random UUID and an attempt count of 1, or an
+//	`exception.InvalidArgument` when the payload cannot be encoded.
+func New(ctx context.Context, messageType string, payload interface{}) (Envelope, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, exception.NewInvalidArgument(map[string]interface{}{
+			"message": "The message payload cannot be encoded as JSON.",
+			"details": map[string]interface{}{"type": messageType, "error": "unencodable_payload"},
+		})
+	}
+
+	envelope := Envelope{
+		ID:            valueobject.NewUUID().String(),
+		Type:          messageType,
+		Payload:       encoded,
+		Headers:       map[string]string{},
+		CorrelationID: ctxutil.CorrelationID(ctx),
+		Attempt:       1,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if tenantID := ctxutil.TenantID(ctx); tenantID != "" {
+		envelope.Headers[ctxutil.TenantIDHeader] = tenantID
+	}
+	return envelope, nil
+}
+
+// Decode decodes the payload of an envelope.
+//
+// Parameters:
+//
+//	envelope: The envelope to decode.
+//
+// Returns:
+//
+//	The payload, or an `exception.UnexpectedValue` with the
+//	"undecodable_payload" error code when it does not match T.
+func Decode[T any](envelope Envelope) (T, error) {
+	var payload T
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return payload, exception.NewUnexpectedValue(map[string]interface{}{
+			"message": "The message payload cannot be decoded.",
+			"details": map[string]interface{}{
+				"id":    envelope.ID,
+				"type":  envelope.Type,
+				"error": "undecodable_payload",
+			},
+		})
+	}
+	return payload, nil
+}
+
+// Context returns a copy of ctx carrying the correlation ID and the tenant
+// ID of the envelope, for the consumer handling it.
+func (e Envelope) Context(ctx context.Context) context.Context {
+	if e.CorrelationID != "" {
+		ctx = ctxutil.WithCorrelationID(ctx, e.CorrelationID)
+	}
+	if tenantID := e.Headers[ctxutil.TenantIDHeader]; tenantID != "" {
+		ctx = ctxutil.WithTenantID(ctx, tenantID)
+	}
+	return ctx
+}
+
+// Header returns the value of a header, or an empty string.
+func (e Envelope) Header(name string) string {
+	return e.Headers[name]
+}
+
+// WithHeader returns a copy of the envelope with a header set. The headers
+// of the receiver are not modified.
+func (e Envelope) WithHeader(name string, value string) Envelope {
+	headers := make(map[string]string, len(e.Headers)+1)
+	for key, existing := range e.Headers {
+		headers[key] = existing
+	}
+	headers[name] = value
+	e.Headers = headers
+	return e
+}
+
+// Redelivered returns a copy of the envelope for its next delivery, with an
+// incremented attempt count.
+func (e Envelope) Redelivered() Envelope {
+	e.Attempt++
+	return e
+}
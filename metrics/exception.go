@@ -0,0 +1,44 @@
+// Package metrics defines the minimal instrumentation contract of the core.
+// This file defines the counting of exceptions.
+package metrics
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// ExceptionsMetric is the name of the counter recorded by Exceptions.
+const ExceptionsMetric = "exceptions_total"
+
+// Exceptions returns a hook counting errors by status code and details
+// "error" code, under the "status_code" and "error_code" labels. Errors that
+// are not exceptions are counted with the 500 status code and no error code.
+// It suits the error hooks of the core, e.g.
+//
+//	count := metrics.Exceptions(meter)
+//	workerpool.Config{OnError: func(_ context.Context, err error) { count(err) }}
+//
+// Parameters:
+//
+//	m: The Meter creating the counter.
+//
+// Returns:
+//
+//	A function counting an error; nil errors are ignored.
+func Exceptions(m Meter) func(err error) {
+	counter := OrNop(m).Counter(ExceptionsMetric, "Number of exceptions by status and error code.")
+	return func(err error) {
+		if exception.IsNil(err) {
+			return
+		}
+		labels := Labels{"status_code": "500", "error_code": ""}
+		var coreErr exception.CoreInterface
+		if errors.As(err, &coreErr) && !exception.IsNil(coreErr) {
+			labels["status_code"] = strconv.Itoa(coreErr.GetStatusCode())
+			labels["error_code"] = coreErr.GetDetailsMessage()
+		}
+		counter.Add(1, labels)
+	}
+}
@@ -0,0 +1,79 @@
+// Package metrics defines the minimal instrumentation contract of the core:
+// a Meter creating counters, histograms and gauges. Core components (retry,
+// circuitbreaker, the HTTP middlewares, exception hooks) emit through it, so
+// that instrumentation is uniform and the backend swappable: Nop discards
+// everything and Registry exposes the metrics in the Prometheus text format.
+package metrics
+
+// Labels are the dimensions of a measurement (e.g., {"method": "GET"}).
+// Keep their values to small, bounded sets: every distinct combination is a
+// separate time series.
+type Labels map[string]string
+
+// Counter is a cumulative value that only increases (e.g., a number of
+// requests).
+type Counter interface {
+	// Add increases the counter. Negative values are ignored.
+	Add(value float64, labels Labels)
+}
+
+// Histogram samples observations into buckets (e.g., request durations).
+type Histogram interface {
+	// Observe records an observation.
+	Observe(value float64, labels Labels)
+}
+
+// Gauge is a value that can go up and down (e.g., a number of items in a
+// queue).
+type Gauge interface {
+	// Set sets the gauge.
+	Set(value float64, labels Labels)
+}
+
+// Meter creates instruments. Creating an instrument twice with the same name
+// returns the same instrument; implementations must be safe for concurrent
+// use.
+type Meter interface {
+	// Counter returns the counter with the given name.
+	Counter(name string, help string) Counter
+
+	// Histogram returns the histogram with the given name. Nil buckets use
+	// DefaultBuckets.
+	Histogram(name string, help string, buckets []float64) Histogram
+
+	// Gauge returns the gauge with the given name.
+	Gauge(name string, help string) Gauge
+}
+
+// DefaultBuckets are the default histogram buckets, suited to durations in
+// seconds from 5ms to 10s.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Inc increases a counter by one.
+func Inc(counter Counter, labels Labels) {
+	counter.Add(1, labels)
+}
+
+// nop is the Meter returned by Nop.
+type nop struct{}
+
+// Nop returns a Meter whose instruments discard every measurement, the
+// default of the core components.
+func Nop() Meter {
+	return nop{}
+}
+
+func (nop) Counter(string, string) Counter                { return nop{} }
+func (nop) Histogram(string, string, []float64) Histogram { return nop{} }
+func (nop) Gauge(string, string) Gauge                    { return nop{} }
+func (nop) Add(float64, Labels)                           {}
+func (nop) Observe(float64, Labels)                       {}
+func (nop) Set(float64, Labels)                           {}
+
+// OrNop returns m, or Nop when m is nil.
+func OrNop(m Meter) Meter {
+	if m == nil {
+		return Nop()
+	}
+	return m
+}
@@ -0,0 +1,262 @@
+// Package metrics defines the minimal instrumentation contract of the core.
+// This file defines the Registry exposing metrics in the Prometheus text
+// format.
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// PrometheusContentType is the Content-Type of the Prometheus text format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Kinds of metric families.
+const (
+	counterKind   = "counter"
+	histogramKind = "histogram"
+	gaugeKind     = "gauge"
+)
+
+// Registry is a Meter keeping its measurements in memory and serving them
+// in the Prometheus text exposition format, so that a Prometheus server can
+// scrape it without an additional dependency:
+//
+//	registry := metrics.NewRegistry()
+//	mux.Handle("/metrics", registry)
+//
+// It is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]*family{}}
+}
+
+// family is a named metric and its series.
+type family struct {
+	registry *Registry
+	name     string
+	help     string
+	kind     string
+	buckets  []float64          // The upper bounds of a histogram, sorted.
+	series   map[string]*series // The series by encoded labels.
+}
+
+// series is the state of a metric for one combination of labels.
+type series struct {
+	labels string   // The encoded labels, e.g. `method="GET",status="200"`.
+	value  float64  // The value of a counter or a gauge, the sum of a histogram.
+	count  uint64   // The number of observations of a histogram.
+	counts []uint64 // The observations per bucket of a histogram (not cumulated).
+}
+
+// Counter returns the counter with the given name.
+func (r *Registry) Counter(name string, help string) Counter {
+	return r.family(name, help, counterKind, nil)
+}
+
+// Histogram returns the histogram with the given name.
+func (r *Registry) Histogram(name string, help string, buckets []float64) Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return r.family(name, help, histogramKind, sorted)
+}
+
+// Gauge returns the gauge with the given name.
+func (r *Registry) Gauge(name string, help string) Gauge {
+	return r.family(name, help, gaugeKind, nil)
+}
+
+// family returns the family of a name, creating it when needed. Reusing a
+// name for another kind of metric is a programming error and panics with an
+// `exception.Logic`.
+func (r *Registry) family(name string, help string, kind string, buckets []float64) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.families[name]; ok {
+		if existing.kind != kind {
+			panic(exception.NewLogic(map[string]interface{}{
+				"message": fmt.Sprintf("The metric %q is already registered as a %s.", name, existing.kind),
+				"details": map[string]interface{}{"metric": name, "kind": existing.kind, "error": "metric_kind_conflict"},
+			}))
+		}
+		return existing
+	}
+
+	f := &family{registry: r, name: name, help: help, kind: kind, buckets: buckets, series: map[string]*series{}}
+	r.families[name] = f
+	return f
+}
+
+// Add increases a counter.
+func (f *family) Add(value float64, labels Labels) {
+	if value < 0 {
+		return
+	}
+	f.registry.mu.Lock()
+	defer f.registry.mu.Unlock()
+	f.get(labels).value += value
+}
+
+// Set sets a gauge.
+func (f *family) Set(value float64, labels Labels) {
+	f.registry.mu.Lock()
+	defer f.registry.mu.Unlock()
+	f.get(labels).value = value
+}
+
+// Observe records an observation of a histogram.
+func (f *family) Observe(value float64, labels Labels) {
+	f.registry.mu.Lock()
+	defer f.registry.mu.Unlock()
+	s := f.get(labels)
+	s.value += value
+	s.count++
+	for i, bound := range f.buckets {
+		if value <= bound {
+			s.counts[i]++
+			break
+		}
+	}
+}
+
+// get returns the series of a combination of labels. The registry lock must
+// be held.
+func (f *family) get(labels Labels) *series {
+	key := encodeLabels(labels)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: key}
+		if f.kind == histogramKind {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", PrometheusContentType)
+	_, _ = w.Write([]byte(r.Text()))
+}
+
+// Text returns the metrics in the Prometheus text format, families and
+// series being sorted for a stable output.
+func (r *Registry) Text() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		f := r.families[name]
+		if f.help != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, escapeHelp(f.help))
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, f.kind)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			f.write(&b, f.series[key])
+		}
+	}
+	return b.String()
+}
+
+// write writes the samples of a series.
+func (f *family) write(b *strings.Builder, s *series) {
+	if f.kind != histogramKind {
+		fmt.Fprintf(b, "%s%s %s\n", f.name, braces(s.labels), formatFloat(s.value))
+		return
+	}
+
+	var cumulated uint64
+	for i, bound := range f.buckets {
+		cumulated += s.counts[i]
+		fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, braces(joinLabels(s.labels, `le="`+formatFloat(bound)+`"`)), cumulated)
+	}
+	fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, braces(joinLabels(s.labels, `le="+Inf"`)), s.count)
+	fmt.Fprintf(b, "%s_sum%s %s\n", f.name, braces(s.labels), formatFloat(s.value))
+	fmt.Fprintf(b, "%s_count%s %d\n", f.name, braces(s.labels), s.count)
+}
+
+// encodeLabels encodes labels sorted by name, e.g. `method="GET",status="200"`.
+func encodeLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + `="` + escapeLabel(labels[name]) + `"`
+	}
+	return strings.Join(parts, ",")
+}
+
+// joinLabels appends a label to encoded labels.
+func joinLabels(labels string, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+// braces wraps encoded labels in braces, or returns an empty string.
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+// escapeLabel escapes a label value.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// escapeHelp escapes a help text.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// formatFloat formats a sample value.
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,86 @@
+// Package middleware composes `net/http` middlewares and provides the core
+// ones: Recovery converts panics into `exception.Runtime` error envelopes,
+// RequestID propagates the request identifiers of ctxutil, Logging logs each
+// request, and Timeout bounds the duration of handlers. The middlewares of
+// other packages (ratelimit, webhook, featureflag, i18n, ...) have the same
+// shape and compose with them, so that services built on plain `net/http`
+// behave consistently without a framework.
+package middleware
+
+import "net/http"
+
+// Middleware wraps an `http.Handler` with additional behavior.
+type Middleware func(next http.Handler) http.Handler
+
+// Chain is an immutable list of middlewares. The zero value is an empty
+// chain, ready to use.
+//
+//	base := middleware.New(middleware.Recovery(log), middleware.RequestID(), middleware.Logging(log))
+//	mux.Handle("/orders", base.Then(orders))
+//	mux.Handle("/webhooks", base.Use(webhook.Middleware(verifier)).Then(hooks))
+type Chain struct {
+	middlewares []Middleware
+}
+
+// New creates a Chain.
+//
+// Parameters:
+//
+//	middlewares: The middlewares, from the outermost to the innermost: the
+//	             first one sees the request first and the response last.
+//
+// Returns:
+//
+//	The Chain.
+func New(middlewares ...Middleware) Chain {
+	return Chain{middlewares: append([]Middleware(nil), middlewares...)}
+}
+
+// Use returns a new Chain running the middlewares of c and then the given
+// ones. The receiver is not modified, so a base chain can be shared by
+// routes adding their own middlewares.
+func (c Chain) Use(middlewares ...Middleware) Chain {
+	combined := make([]Middleware, 0, len(c.middlewares)+len(middlewares))
+	combined = append(combined, c.middlewares...)
+	return Chain{middlewares: append(combined, middlewares...)}
+}
+
+// Extend returns a new Chain running the middlewares of c and then those of
+// other.
+func (c Chain) Extend(other Chain) Chain {
+	return c.Use(other.middlewares...)
+}
+
+// Len returns the number of middlewares of the chain.
+func (c Chain) Len() int {
+	return len(c.middlewares)
+}
+
+// Then wraps a handler with the middlewares of the chain.
+//
+// Parameters:
+//
+//	handler: The final handler. Nil uses `http.DefaultServeMux`.
+//
+// Returns:
+//
+//	The wrapped handler.
+func (c Chain) Then(handler http.Handler) http.Handler {
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		if c.middlewares[i] != nil {
+			handler = c.middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+// ThenFunc wraps a handler function with the middlewares of the chain.
+func (c Chain) ThenFunc(handler http.HandlerFunc) http.Handler {
+	if handler == nil {
+		return c.Then(nil)
+	}
+	return c.Then(handler)
+}
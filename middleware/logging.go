@@ -0,0 +1,69 @@
+// Package middleware composes `net/http` middlewares and provides the core
+// ones. This file defines the Logging middleware.
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/logger"
+)
+
+// Logging logs one entry per request once it has been served, with the
+// request method and path, the response status and size, the duration in
+// milliseconds and the identifiers carried by the request context (see
+// RequestID, which must run first for them to be present). Server errors
+// (5xx) are logged at the Error level, client errors (4xx) at the Warn level
+// and other responses at the Info level.
+//
+// Parameters:
+//
+//	l: The Logger to write to. Nil uses `logger.Nop()`.
+//
+// Returns:
+//
+//	The Middleware.
+func Logging(l logger.Logger) Middleware {
+	if l == nil {
+		l = logger.Nop()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := newRecorder(w)
+			defer func() {
+				ids := ctxutil.Fields(r.Context())
+				fields := make([]logger.Field, 0, len(ids)+5)
+				fields = append(fields,
+					logger.Any("method", r.Method),
+					logger.Any("path", r.URL.Path),
+					logger.Any("status", rec.statusCode()),
+					logger.Any("bytes", rec.bytes),
+					logger.Any("duration_ms", time.Since(start).Milliseconds()),
+				)
+				keys := make([]string, 0, len(ids))
+				for key := range ids {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					fields = append(fields, logger.Any(key, ids[key]))
+				}
+
+				switch status := rec.statusCode(); {
+				case status >= 500:
+					l.Error("HTTP request served.", fields...)
+				case status >= 400:
+					l.Warn("HTTP request served.", fields...)
+				default:
+					l.Info("HTTP request served.", fields...)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
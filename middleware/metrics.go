@@ -0,0 +1,44 @@
+// Package middleware composes `net/http` middlewares and provides the core
+// ones. This file defines the Metrics middleware.
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/osirisgate/golang-core/metrics"
+)
+
+// Metrics records every served request through a Meter:
+// http_requests_total{method,status} counts them and
+// http_request_duration_seconds{method,status} samples their duration. The
+// path is deliberately left out of the labels, as raw paths carry identifiers
+// and would make the number of series unbounded.
+//
+// Parameters:
+//
+//	m: The Meter to emit through. Nil uses `metrics.Nop()`.
+//
+// Returns:
+//
+//	The Middleware.
+func Metrics(m metrics.Meter) Middleware {
+	m = metrics.OrNop(m)
+	requests := m.Counter("http_requests_total", "Number of HTTP requests served.")
+	durations := m.Histogram("http_request_duration_seconds", "Duration of the HTTP requests served, in seconds.", nil)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := newRecorder(w)
+			defer func() {
+				labels := metrics.Labels{"method": r.Method, "status": strconv.Itoa(rec.statusCode())}
+				metrics.Inc(requests, labels)
+				durations.Observe(time.Since(start).Seconds(), labels)
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
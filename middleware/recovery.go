@@ -0,0 +1,74 @@
+// Package middleware composes `net/http` middlewares and provides the core
+// ones. This file defines the Recovery and RequestID middlewares.
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Recovery recovers the panics of the next handlers. A panic is converted
+// into an `exception.Runtime` whose details hold the request method and path,
+// the panic value and the "handler_panicked" error code; it is logged with
+// `logger.LogExceptionContext`, with a snapshot of the request (see
+// `exception.CoreException.WithRequest`), and, when the response has not started yet,
+// written as a 500 error envelope. Panics with `http.ErrAbortHandler`, used
+// to abort a response deliberately, are propagated.
+//
+// Parameters:
+//
+//	l: The Logger receiving the panics. Nil uses `logger.Nop()`.
+//
+// Returns:
+//
+//	The Middleware.
+func Recovery(l logger.Logger) Middleware {
+	if l == nil {
+		l = logger.Nop()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newRecorder(w)
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(recovered)
+				}
+
+				err := exception.NewRuntime(map[string]interface{}{
+					"message": "The request handler panicked.",
+					"details": map[string]interface{}{
+						"method": r.Method,
+						"path":   r.URL.Path,
+						"panic":  fmt.Sprint(recovered),
+						"error":  "handler_panicked",
+					},
+				})
+				_ = err.WithRequest(r)
+				logger.LogExceptionContext(r.Context(), l, err)
+				if !rec.written() {
+					_ = response.WriteErrorContext(r.Context(), rec, err)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// RequestID stores the request and correlation IDs in the request context
+// and echoes them in the response headers; see `ctxutil.Middleware`, and
+// `ctxutil.MiddlewareWithTenant` to resolve the tenant ID.
+func RequestID() Middleware {
+	return ctxutil.Middleware
+}
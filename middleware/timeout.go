@@ -0,0 +1,125 @@
+// Package middleware composes `net/http` middlewares and provides the core
+// ones. This file defines the Timeout middleware.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Timeout bounds the duration of the next handlers. The request context is
+// canceled after the duration, and the response of a handler that did not
+// complete in time is replaced by the error envelope of an
+// `exception.Timeout` holding the "timeout_ms" and the "request_timeout"
+// error code; the later writes of the handler fail with
+// `http.ErrHandlerTimeout`. Like `http.TimeoutHandler`, the response is
+// buffered until the handler returns, so Timeout is not suited to streaming
+// endpoints. A panic of the handler is propagated to the caller goroutine,
+// where Recovery can handle it.
+//
+// Parameters:
+//
+//	duration: The maximum duration of a request.
+//
+// Returns:
+//
+//	The Middleware.
+func Timeout(duration time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), duration)
+			defer cancel()
+
+			tw := &timeoutWriter{header: http.Header{}}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if recovered := recover(); recovered != nil {
+						panicked <- recovered
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case recovered := <-panicked:
+				panic(recovered)
+
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				for key, values := range tw.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(tw.statusCode())
+				_, _ = w.Write(tw.body.Bytes())
+
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				tw.timedOut = true
+				_ = response.WriteErrorContext(r.Context(), w, exception.NewTimeout(map[string]interface{}{
+					"message": fmt.Sprintf("The request did not complete within %s.", duration),
+					"details": map[string]interface{}{
+						"timeout_ms": duration.Milliseconds(),
+						"error":      "request_timeout",
+					},
+				}))
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers the response of a handler run by Timeout.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+// Header returns the buffered headers.
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader records the status code.
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.status == 0 && !tw.timedOut {
+		tw.status = status
+	}
+}
+
+// Write buffers the body, failing once the request timed out.
+func (tw *timeoutWriter) Write(body []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.body.Write(body)
+}
+
+// statusCode returns the recorded status code, 200 when the handler wrote
+// nothing.
+func (tw *timeoutWriter) statusCode() int {
+	if tw.status == 0 {
+		return http.StatusOK
+	}
+	return tw.status
+}
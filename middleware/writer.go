@@ -0,0 +1,70 @@
+// Package middleware composes `net/http` middlewares and provides the core
+// ones. This file defines the response writer recording what a handler
+// wrote.
+package middleware
+
+import "net/http"
+
+// recorder wraps an `http.ResponseWriter`, recording the status code and the
+// number of bytes written.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+// newRecorder wraps a response writer, reusing it when it already is a
+// recorder.
+func newRecorder(w http.ResponseWriter) *recorder {
+	if rec, ok := w.(*recorder); ok {
+		return rec
+	}
+	return &recorder{ResponseWriter: w}
+}
+
+// WriteHeader records and sends the status code.
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records an implicit 200 status and the written bytes.
+func (r *recorder) Write(body []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(body)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap returns the wrapped writer, for `http.ResponseController`.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush flushes the wrapped writer when it supports it.
+func (r *recorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// written reports whether the response has started.
+func (r *recorder) written() bool {
+	return r.status != 0
+}
+
+// statusCode returns the recorded status code, 200 when the handler wrote
+// nothing.
+func (r *recorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
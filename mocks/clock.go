@@ -0,0 +1,54 @@
+// Package mocks provides hand-maintained test doubles of the core
+// interfaces. This file defines the Clock mock of `clock.Clock`; use
+// `clock.Fake` for a clock moved by the test instead.
+package mocks
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a mock of `clock.Clock` returning a scripted sequence of times
+// and counting the calls, e.g. to simulate the time elapsing between the
+// two readings of a duration.
+type Clock struct {
+	mu    sync.Mutex
+	times []time.Time
+	calls int
+}
+
+// NewClock creates a Clock.
+//
+// Parameters:
+//
+//	times: The times returned by the successive calls of Now, the last one
+//	       being repeated. The zero time is returned when empty.
+//
+// Returns:
+//
+//	A pointer to a new Clock.
+func NewClock(times ...time.Time) *Clock {
+	return &Clock{times: times}
+}
+
+// Now returns the next time of the sequence.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	switch {
+	case len(c.times) == 0:
+		return time.Time{}
+	case c.calls <= len(c.times):
+		return c.times[c.calls-1]
+	default:
+		return c.times[len(c.times)-1]
+	}
+}
+
+// Calls returns the number of calls of Now so far.
+func (c *Clock) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
@@ -0,0 +1,144 @@
+// Package mocks provides hand-maintained test doubles of the core
+// interfaces, so that consumers can unit test their error-handling paths
+// without constructing real exceptions, loggers or clocks. The doubles are
+// plain structs: set the fields to stub the results, and read the recorded
+// calls through the accessors. They are safe for concurrent use.
+//
+// This file defines the Exception stub of `exception.CoreInterface`.
+package mocks
+
+import (
+	"maps"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.ERROR` constant of the formatted exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Exception is a stub of `exception.CoreInterface` that captures no stack
+// trace. Its methods derive their results from the fields like
+// `exception.CoreException` does, FormatFunc overriding Format:
+//
+//	err := &mocks.Exception{Message: "The order was not found.", StatusCode: 404}
+type Exception struct {
+	Message    string                 // The result of Error.
+	StatusCode int                    // The result of GetStatusCode.
+	Errors     map[string]interface{} // The result of GetErrors, and the source of GetDetails.
+	StackTrace string                 // The result of GetStackTrace; empty by default.
+
+	// FormatFunc, when set, replaces the result of Format.
+	FormatFunc func() map[string]interface{}
+}
+
+// Error returns Message.
+func (e *Exception) Error() string {
+	return e.Message
+}
+
+// GetStatusCode returns StatusCode.
+func (e *Exception) GetStatusCode() int {
+	return e.StatusCode
+}
+
+// GetErrors returns Errors.
+func (e *Exception) GetErrors() map[string]interface{} {
+	return e.Errors
+}
+
+// SetError adds or replaces an entry of Errors, like
+// `exception.CoreException.SetError`, so that the stub is annotated by
+// `ctxutil.Annotate`.
+func (e *Exception) SetError(key string, value interface{}) error {
+	if e.Errors == nil {
+		e.Errors = map[string]interface{}{}
+	}
+	e.Errors[key] = value
+	return nil
+}
+
+// SetMessage replaces Message, so that the stub is translated by
+// `i18n.Localize`.
+func (e *Exception) SetMessage(message string) error {
+	e.Message = message
+	return nil
+}
+
+// GetDetails returns a copy of the "details" map of Errors, or an empty map.
+func (e *Exception) GetDetails() map[string]interface{} {
+	if details, ok := e.Errors["details"].(map[string]interface{}); ok {
+		return maps.Clone(details)
+	}
+	return map[string]interface{}{}
+}
+
+// GetDetailsMessage returns the "error" entry of the details, or an empty
+// string.
+func (e *Exception) GetDetailsMessage() string {
+	message, _ := e.GetDetails()["error"].(string)
+	return message
+}
+
+// GetString returns the string entry of Errors under key, like
+// `exception.CoreException.GetString`.
+func (e *Exception) GetString(key string) (string, bool) {
+	return exception.StringOf(e.Errors, key)
+}
+
+// GetInt returns the integer entry of Errors under key, like
+// `exception.CoreException.GetInt`.
+func (e *Exception) GetInt(key string) (int, bool) {
+	return exception.IntOf(e.Errors, key)
+}
+
+// GetMap returns the map entry of Errors under key, like
+// `exception.CoreException.GetMap`.
+func (e *Exception) GetMap(key string) (map[string]interface{}, bool) {
+	return exception.MapOf(e.Errors, key)
+}
+
+// GetSlice returns the slice entry of Errors under key, like
+// `exception.CoreException.GetSlice`.
+func (e *Exception) GetSlice(key string) ([]interface{}, bool) {
+	return exception.SliceOf(e.Errors, key)
+}
+
+// Headers returns the response headers implied by StatusCode and the
+// details, like `exception.CoreException.Headers`.
+func (e *Exception) Headers() map[string]string {
+	return exception.HeadersOf(e.StatusCode, e.GetDetails())
+}
+
+// GetErrorsForLog returns the message, status code, errors and stack trace,
+// like `exception.CoreException.GetErrorsForLog`.
+func (e *Exception) GetErrorsForLog() map[string]interface{} {
+	return map[string]interface{}{
+		"message":     e.Message,
+		"status_code": e.StatusCode,
+		"errors":      e.Errors,
+		"stack_trace": e.StackTrace,
+	}
+}
+
+// GetStackTrace returns StackTrace.
+func (e *Exception) GetStackTrace() string {
+	return e.StackTrace
+}
+
+// Format returns the result of FormatFunc when set, otherwise the status,
+// error code and message merged with Errors, like
+// `exception.CoreException.Format`.
+func (e *Exception) Format() map[string]interface{} {
+	if e.FormatFunc != nil {
+		return e.FormatFunc()
+	}
+	formatted := map[string]interface{}{
+		"status":  status.ERROR,
+		"message": e.Message,
+	}
+	formatted[exception.CurrentKeyNaming().Key("error_code")] = e.StatusCode
+	for key, value := range e.Errors {
+		formatted[key] = value
+	}
+	return formatted
+}
@@ -0,0 +1,112 @@
+// Package mocks provides hand-maintained test doubles of the core
+// interfaces. This file defines the Logger mock of `logger.Logger`.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/osirisgate/golang-core/logger"
+)
+
+// Log levels of the recorded entries.
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Entry is a log entry recorded by a Logger.
+type Entry struct {
+	Level   string
+	Message string
+	Fields  []logger.Field // The fields of the entry, after those added by With.
+}
+
+// Field returns the value of the last field of the entry with the key, and
+// whether it was found.
+func (e Entry) Field(key string) (interface{}, bool) {
+	for i := len(e.Fields) - 1; i >= 0; i-- {
+		if e.Fields[i].Key == key {
+			return e.Fields[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Logger is a mock of `logger.Logger` recording the entries. The loggers
+// returned by With record into the same entries.
+type Logger struct {
+	record *record
+	fields []logger.Field
+}
+
+// record holds the entries shared by a Logger and its children.
+type record struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewLogger creates a Logger.
+//
+// Returns:
+//
+//	A pointer to a new Logger without entries.
+func NewLogger() *Logger {
+	return &Logger{record: &record{}}
+}
+
+// Debug records a debug entry.
+func (l *Logger) Debug(msg string, fields ...logger.Field) {
+	l.log(LevelDebug, msg, fields)
+}
+
+// Info records an info entry.
+func (l *Logger) Info(msg string, fields ...logger.Field) {
+	l.log(LevelInfo, msg, fields)
+}
+
+// Warn records a warn entry.
+func (l *Logger) Warn(msg string, fields ...logger.Field) {
+	l.log(LevelWarn, msg, fields)
+}
+
+// Error records an error entry.
+func (l *Logger) Error(msg string, fields ...logger.Field) {
+	l.log(LevelError, msg, fields)
+}
+
+// With returns a Logger adding the fields to its entries.
+func (l *Logger) With(fields ...logger.Field) logger.Logger {
+	return &Logger{record: l.record, fields: append(append([]logger.Field{}, l.fields...), fields...)}
+}
+
+// Entries returns the entries recorded so far by the logger and its
+// children, in order.
+func (l *Logger) Entries() []Entry {
+	l.record.mu.Lock()
+	defer l.record.mu.Unlock()
+	return append([]Entry{}, l.record.entries...)
+}
+
+// EntriesAt returns the recorded entries of a level (e.g., LevelError).
+func (l *Logger) EntriesAt(level string) []Entry {
+	var entries []Entry
+	for _, entry := range l.Entries() {
+		if entry.Level == level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// log records an entry.
+func (l *Logger) log(level string, msg string, fields []logger.Field) {
+	l.record.mu.Lock()
+	defer l.record.mu.Unlock()
+	l.record.entries = append(l.record.entries, Entry{
+		Level:   level,
+		Message: msg,
+		Fields:  append(append([]logger.Field{}, l.fields...), fields...),
+	})
+}
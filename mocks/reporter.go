@@ -0,0 +1,38 @@
+// Package mocks provides hand-maintained test doubles of the core
+// interfaces. This file defines the Reporter mock of `report.Reporter`.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Reporter is a mock of `report.Reporter` recording the reported
+// exceptions.
+type Reporter struct {
+	mu       sync.Mutex
+	reported []exception.CoreInterface
+}
+
+// Report records the exception.
+func (r *Reporter) Report(_ context.Context, err exception.CoreInterface) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reported = append(r.reported, err)
+}
+
+// Reported returns the exceptions reported so far, in order.
+func (r *Reporter) Reported() []exception.CoreInterface {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]exception.CoreInterface{}, r.reported...)
+}
+
+// Reset forgets the reported exceptions.
+func (r *Reporter) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reported = nil
+}
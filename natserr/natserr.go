@@ -0,0 +1,169 @@
+// Package natserr carries exceptions through the request-reply of NATS, so
+// that a requester gets back the exception of the responder, of the same
+// concrete type, like the HTTP clients do with `httpclient`. It does not
+// depend on nats.go: Header has the type of `nats.Header`, and the headers
+// follow the conventions of the NATS micro services, so that the other
+// micro clients see a service error:
+//
+//	// Responder.
+//	header, data, _ := natserr.Encode(err)
+//	_ = msg.RespondMsg(&nats.Msg{Header: nats.Header(header), Data: data})
+//
+//	// Requester.
+//	reply, err := nc.Request(subject, payload, time.Second)
+//	...
+//	if e := natserr.Decode(natserr.Header(reply.Header), reply.Data); e != nil {
+//		return e
+//	}
+package natserr
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"reflect"
+	"strconv"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type used to rebuild exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Headers describing the exception of a reply.
+const (
+	ErrorHeader     = "Nats-Service-Error"      // ErrorHeader carries the message of the exception, as in NATS micro.
+	ErrorCodeHeader = "Nats-Service-Error-Code" // ErrorCodeHeader carries the status code of the exception, as in NATS micro.
+	ErrorTypeHeader = "Nats-Service-Error-Type" // ErrorTypeHeader carries the catalog name of the exception type (e.g., "NotFound").
+)
+
+// Header holds the headers of a NATS message, like `nats.Header`.
+type Header map[string][]string
+
+// Get returns the first value of a header, or an empty string.
+func (h Header) Get(key string) string {
+	if values := h[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// jsonAppender is implemented by the exceptions encoding their envelope
+// directly (see `exception.CoreException.AppendJSON`).
+type jsonAppender interface {
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+// Encode encodes an error into the headers and the payload of a reply: the
+// headers carry its message, its status code and the name of its type, and
+// the payload its `Format()` envelope as JSON. The stack trace is not
+// included. Errors that are not exceptions are encoded as the generic 500
+// exception of `exception.Normalize`, without their message.
+//
+// Parameters:
+//
+//	err: The error to encode.
+//
+// Returns:
+//
+//	The headers and the payload, nil for a nil error, or an error if the
+//	envelope could not be encoded.
+func Encode(err error) (Header, []byte, error) {
+	if err == nil {
+		return nil, nil, nil
+	}
+	var coreErr exception.CoreInterface
+	if !errors.As(exception.Normalize(err), &coreErr) {
+		return nil, nil, nil
+	}
+
+	var data []byte
+	var encodeErr error
+	if appender, ok := coreErr.(jsonAppender); ok {
+		data, encodeErr = appender.AppendJSON(nil)
+	} else {
+		data, encodeErr = json.Marshal(coreErr.Format())
+	}
+	if encodeErr != nil {
+		return nil, nil, encodeErr
+	}
+
+	header := Header{
+		ErrorHeader:     {coreErr.Error()},
+		ErrorCodeHeader: {strconv.Itoa(coreErr.GetStatusCode())},
+	}
+	if name := typeName(coreErr); name != "" {
+		header[ErrorTypeHeader] = []string{name}
+	}
+	return header, data, nil
+}
+
+// Decode rebuilds the exception encoded by Encode in a reply. The type of
+// the exception is the catalog type named by ErrorTypeHeader (see
+// `exception.Catalog`), when its default status code is the encoded one;
+// otherwise it is the type matching the status code (see
+// `exception.FromStatus`). The errors map is restored from the envelope of
+// the payload. The service errors of other NATS micro services, without an
+// envelope, are rebuilt from their headers only; their codes that are not
+// status codes are kept as the "error" entry of the details of a 500
+// exception.
+//
+// Parameters:
+//
+//	header: The headers of the reply.
+//	data: The payload of the reply.
+//
+// Returns:
+//
+//	The exception, or nil when the reply is not a service error.
+func Decode(header Header, data []byte) exception.CoreInterface {
+	message, code := header.Get(ErrorHeader), header.Get(ErrorCodeHeader)
+	if message == "" && code == "" {
+		return nil
+	}
+
+	errorsMap := map[string]interface{}{}
+	var envelope map[string]interface{}
+	if json.Unmarshal(data, &envelope) == nil {
+		for key, value := range envelope {
+			if key = exception.BuiltinKey(key); key != "status" && key != "error_code" && key != "message" {
+				errorsMap[key] = value
+			}
+		}
+	}
+	if message != "" {
+		errorsMap["message"] = message
+	}
+
+	statusCode, err := strconv.Atoi(code)
+	if err != nil || statusCode < int(status.BadRequest) {
+		if _, ok := errorsMap["details"]; !ok && code != "" {
+			errorsMap["details"] = map[string]interface{}{"error": code}
+		}
+		statusCode = int(status.InternalServerError)
+	}
+
+	if name := header.Get(ErrorTypeHeader); name != "" {
+		for _, entry := range exception.Catalog() {
+			if entry.Name != name {
+				continue
+			}
+			// The constructors consume the "message" entry, so they get a copy.
+			if created := entry.New(maps.Clone(errorsMap)); created != nil && created.GetStatusCode() == statusCode {
+				return created
+			}
+			break
+		}
+	}
+	return exception.FromStatus(status.StatusCode(statusCode), errorsMap)
+}
+
+// typeName returns the name of the type of an exception, which is its name
+// in the catalog for the types of the exception package.
+func typeName(coreErr exception.CoreInterface) string {
+	t := reflect.TypeOf(coreErr)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,159 @@
+// Package negotiation implements proactive content negotiation (RFC 9110
+// section 12): it parses the Accept, Accept-Language and Accept-Encoding
+// headers with their quality values and picks, among the options the server
+// supports, the one the client prefers. This file defines the media types
+// (RFC 9110 section 8.3.1) and the check of the Content-Type of requests,
+// reported as an `exception.UnsupportedMediaType`.
+package negotiation
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Common media types, in their canonical form.
+const (
+	ApplicationJSON        = "application/json"                  // JSON documents, such as the envelopes.
+	ApplicationProblemJSON = "application/problem+json"          // RFC 9457 problem details.
+	ApplicationXML         = "application/xml"                   // XML documents.
+	ApplicationForm        = "application/x-www-form-urlencoded" // URL-encoded HTML forms.
+	ApplicationOctetStream = "application/octet-stream"          // Arbitrary binary data.
+	MultipartFormData      = "multipart/form-data"               // Multipart HTML forms, e.g. file uploads.
+	TextPlain              = "text/plain"                        // Plain text.
+	TextHTML               = "text/html"                         // HTML documents.
+	TextCSV                = "text/csv"                          // Comma-separated values.
+	TextEventStream        = "text/event-stream"                 // Server-sent events.
+)
+
+// MediaType is a parsed media type, e.g. of a Content-Type header, or a
+// media range of an Accept header when its type or subtype is "*".
+type MediaType struct {
+	Type    string            // The top-level type, lowercased (e.g., "application"), or "*".
+	Subtype string            // The subtype, lowercased (e.g., "problem+json"), or "*".
+	Params  map[string]string // The parameters, names lowercased (e.g., {"charset": "utf-8"}), nil when there is none.
+}
+
+// ParseMediaType parses a media type and its parameters, e.g.
+// "multipart/form-data; boundary=xyz". Types and parameter names are case
+// insensitive, and returned lowercased.
+//
+// Parameters:
+//
+//	value: The media type (e.g., "text/html; charset=UTF-8", "text/*").
+//
+// Returns:
+//
+//	The MediaType, or an `exception.InvalidArgument` when the value is not a
+//	"type/subtype" media type, or a media range ("type/*" or "*/*").
+func ParseMediaType(value string) (MediaType, error) {
+	essence, params, err := mime.ParseMediaType(value)
+	mediaType, subtype, ok := strings.Cut(essence, "/")
+	if err != nil || !ok || (mediaType == "*" && subtype != "*") {
+		return MediaType{}, exception.NewInvalidArgument(map[string]interface{}{
+			"message": fmt.Sprintf("Invalid media type %q.", value),
+			"details": map[string]interface{}{
+				"value": value,
+				"error": "invalid_media_type",
+			},
+		})
+	}
+	if len(params) == 0 {
+		params = nil
+	}
+	return MediaType{Type: mediaType, Subtype: subtype, Params: params}, nil
+}
+
+// Essence returns the media type without its parameters, e.g.
+// "application/json".
+func (m MediaType) Essence() string {
+	return m.Type + "/" + m.Subtype
+}
+
+// String returns the media type with its parameters, sorted by name, e.g.
+// "text/html; charset=utf-8".
+func (m MediaType) String() string {
+	if len(m.Params) == 0 {
+		return m.Essence()
+	}
+	return mime.FormatMediaType(m.Essence(), m.Params)
+}
+
+// Charset returns the "charset" parameter of the media type, e.g. "utf-8",
+// or an empty string when it has none.
+func (m MediaType) Charset() string {
+	return m.Params["charset"]
+}
+
+// Boundary returns the "boundary" parameter of a multipart media type, or
+// an empty string when it has none.
+func (m MediaType) Boundary() string {
+	return m.Params["boundary"]
+}
+
+// Matches reports whether the media type, taken as a media range, matches
+// other: their types and subtypes are equal, or the one of the range is "*"
+// ("text/*" matches "text/csv", "*/*" matches anything). Parameters are
+// ignored, and the wildcards of other match nothing but themselves.
+//
+// Parameters:
+//
+//	other: The media type to match (e.g., the Content-Type of a request).
+//
+// Returns:
+//
+//	True if other belongs to the range.
+func (m MediaType) Matches(other MediaType) bool {
+	return m.specificity(other) >= 0
+}
+
+// specificity returns how specifically the media type, taken as a media
+// range, matches other: 2 for the same type, 1 for "type/*", 0 for "*/*",
+// and -1 when it does not match.
+func (m MediaType) specificity(other MediaType) int {
+	switch {
+	case m.Type == other.Type && m.Subtype == other.Subtype:
+		return 2
+	case m.Type == other.Type && m.Subtype == "*":
+		return 1
+	case m.Type == "*" && m.Subtype == "*":
+		return 0
+	}
+	return -1
+}
+
+// RequireContentType checks the Content-Type header of a request against
+// the media types the server accepts for its body, e.g. before decoding it.
+//
+// Parameters:
+//
+//	header: The value of the Content-Type header.
+//	supported: The accepted media types or ranges (e.g., ApplicationJSON,
+//	           "text/*"). Invalid values are ignored.
+//
+// Returns:
+//
+//	The parsed Content-Type, or an `exception.UnsupportedMediaType` listing
+//	the supported media types when the header is missing, invalid or
+//	matches none of them.
+func RequireContentType(header string, supported []string) (MediaType, error) {
+	contentType, err := ParseMediaType(header)
+	if err == nil {
+		for _, value := range supported {
+			if supportedType, err := ParseMediaType(value); err == nil && supportedType.Matches(contentType) {
+				return contentType, nil
+			}
+		}
+	}
+
+	return MediaType{}, exception.NewUnsupportedMediaType(map[string]interface{}{
+		"message": "The media type of the request body is not supported.",
+		"details": map[string]interface{}{
+			"header":    "Content-Type",
+			"supported": append([]string{}, supported...),
+			"error":     "unsupported_media_type",
+		},
+	})
+}
@@ -0,0 +1,209 @@
+// Package negotiation implements proactive content negotiation (RFC 9110
+// section 12): it parses the Accept, Accept-Language and Accept-Encoding
+// headers with their quality values and picks, among the options the server
+// supports, the one the client prefers. When the client accepts none of
+// them, an `exception.NotAcceptable` lists the supported options.
+package negotiation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Preference is an element of an Accept-* header.
+type Preference struct {
+	Value   string            // The media range, language range or coding (e.g., "text/*", "fr-CH", "gzip").
+	Quality float64           // The "q" parameter, from 0 to 1; 1 when absent.
+	Params  map[string]string // The other parameters (e.g., {"charset": "utf-8"}), nil when there is none.
+}
+
+// Parse parses an Accept-* header. Elements with an invalid quality are
+// skipped; elements of quality 0, which exclude a value, are kept.
+//
+// Parameters:
+//
+//	header: The value of the header (e.g., "text/html, application/json;q=0.9").
+//
+// Returns:
+//
+//	The preferences by decreasing quality, elements of equal quality keeping
+//	the order of the header.
+func Parse(header string) []Preference {
+	var preferences []Preference
+	for _, element := range strings.Split(header, ",") {
+		parts := strings.Split(element, ";")
+		value := strings.TrimSpace(parts[0])
+		if value == "" {
+			continue
+		}
+
+		preference := Preference{Value: value, Quality: 1}
+		valid := true
+		for _, param := range parts[1:] {
+			name, arg, _ := strings.Cut(param, "=")
+			name, arg = strings.ToLower(strings.TrimSpace(name)), strings.Trim(strings.TrimSpace(arg), `"`)
+			if name == "q" {
+				quality, err := strconv.ParseFloat(arg, 64)
+				if err != nil || quality < 0 || quality > 1 {
+					valid = false
+					break
+				}
+				preference.Quality = quality
+				continue
+			}
+			if preference.Params == nil {
+				preference.Params = map[string]string{}
+			}
+			preference.Params[name] = arg
+		}
+		if valid {
+			preferences = append(preferences, preference)
+		}
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].Quality > preferences[j].Quality })
+	return preferences
+}
+
+// ContentType selects the media type of a response from an Accept header.
+// The quality of an offer is the one of the most specific range matching it
+// ("text/html" before "text/*" before "*/*", see `MediaType.Matches`).
+//
+// Parameters:
+//
+//	header: The value of the Accept header. An empty header accepts anything.
+//	offers: The media types the server can produce, by preference (e.g.,
+//	        ApplicationJSON, TextCSV). Their parameters are ignored, and
+//	        invalid media types match no range.
+//
+// Returns:
+//
+//	The accepted offer of highest quality, ties going to the earlier offer,
+//	or an `exception.NotAcceptable` when none is accepted.
+func ContentType(header string, offers []string) (string, error) {
+	return choose("Accept", header, offers, func(preferences []Preference, offer string) float64 {
+		offerType, err := ParseMediaType(offer)
+		if err != nil {
+			return 0
+		}
+		best, quality := -1, 0.0
+		for _, p := range preferences {
+			mediaRange, err := ParseMediaType(p.Value)
+			if err != nil {
+				continue
+			}
+			if specificity := mediaRange.specificity(offerType); specificity > best {
+				best, quality = specificity, p.Quality
+			}
+		}
+		return quality
+	})
+}
+
+// Language selects the language of a response from an Accept-Language
+// header. A range matches an offer equal to it, a regional variant of it
+// (a request for "fr" accepts "fr-FR") or one of its parents (a request for
+// "fr-CH" accepts "fr"), the first match being the most specific.
+//
+// Parameters:
+//
+//	header: The value of the Accept-Language header. An empty header accepts
+//	        anything.
+//	offers: The languages the server can produce, by preference.
+//
+// Returns:
+//
+//	The accepted offer of highest quality, ties going to the earlier offer,
+//	or an `exception.NotAcceptable` when none is accepted.
+func Language(header string, offers []string) (string, error) {
+	return choose("Accept-Language", header, offers, func(preferences []Preference, offer string) float64 {
+		tag := strings.ToLower(offer)
+		best, quality := -1, 0.0
+		for _, p := range preferences {
+			rangeTag := strings.ToLower(p.Value)
+			specificity := -1
+			switch {
+			case rangeTag == tag:
+				specificity = 3
+			case strings.HasPrefix(tag, rangeTag+"-"):
+				specificity = 2
+			case strings.HasPrefix(rangeTag, tag+"-"):
+				specificity = 1
+			case rangeTag == "*":
+				specificity = 0
+			}
+			if specificity > best {
+				best, quality = specificity, p.Quality
+			}
+		}
+		return quality
+	})
+}
+
+// Encoding selects the content coding of a response from an Accept-Encoding
+// header. The "identity" coding is acceptable unless the header excludes it,
+// explicitly or through "*;q=0".
+//
+// Parameters:
+//
+//	header: The value of the Accept-Encoding header. An empty header accepts
+//	        anything.
+//	offers: The codings the server can apply, by preference (e.g., "br",
+//	        "gzip", "identity").
+//
+// Returns:
+//
+//	The accepted offer of highest quality, ties going to the earlier offer,
+//	or an `exception.NotAcceptable` when none is accepted.
+func Encoding(header string, offers []string) (string, error) {
+	return choose("Accept-Encoding", header, offers, func(preferences []Preference, offer string) float64 {
+		coding := strings.ToLower(offer)
+		wildcard := -1.0
+		for _, p := range preferences {
+			switch strings.ToLower(p.Value) {
+			case coding:
+				return p.Quality
+			case "*":
+				wildcard = p.Quality
+			}
+		}
+		if wildcard >= 0 {
+			return wildcard
+		}
+		if coding == "identity" {
+			return 0.001 // Acceptable, but only when nothing else is.
+		}
+		return 0
+	})
+}
+
+// choose returns the offer of highest quality according to a header.
+func choose(name string, header string, offers []string, quality func([]Preference, string) float64) (string, error) {
+	if strings.TrimSpace(header) == "" && len(offers) > 0 {
+		return offers[0], nil
+	}
+
+	preferences := Parse(header)
+	chosen, best := "", 0.0
+	for _, offer := range offers {
+		if q := quality(preferences, offer); q > best {
+			chosen, best = offer, q
+		}
+	}
+	if best > 0 {
+		return chosen, nil
+	}
+
+	return "", exception.NewNotAcceptable(map[string]interface{}{
+		"message": fmt.Sprintf("None of the supported values is acceptable according to the %s header.", name),
+		"details": map[string]interface{}{
+			"header":    name,
+			"supported": append([]string{}, offers...),
+			"error":     "not_acceptable",
+		},
+	})
+}
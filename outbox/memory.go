@@ -0,0 +1,80 @@
+// Package outbox implements the transactional outbox pattern. This file
+// defines the in-memory Store.
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/osirisgate/golang-core/repository"
+)
+
+// Memory is an in-memory Store, for tests and single process applications.
+// It is safe for concurrent use.
+type Memory struct {
+	mu       sync.RWMutex
+	messages map[string]Message
+}
+
+// NewMemory creates an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{messages: map[string]Message{}}
+}
+
+// Add adds messages to the store, failing with an `exception.Conflict` when
+// one of them already exists.
+func (m *Memory) Add(_ context.Context, messages ...Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, message := range messages {
+		if _, ok := m.messages[message.ID()]; ok {
+			return repository.NewConflict("outbox_message", message.ID(), "duplicate")
+		}
+	}
+	for _, message := range messages {
+		m.messages[message.ID()] = message
+	}
+	return nil
+}
+
+// Due returns the pending messages due at now, the oldest first.
+func (m *Memory) Due(_ context.Context, now time.Time, limit int) ([]Message, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var due []Message
+	for _, message := range m.messages {
+		if message.Status == Pending && !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].Envelope.CreatedAt.Before(due[j].Envelope.CreatedAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// Update saves the new state of a message.
+func (m *Memory) Update(_ context.Context, message Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.messages[message.ID()]; !ok {
+		return repository.NewNotFound("outbox_message", message.ID())
+	}
+	m.messages[message.ID()] = message
+	return nil
+}
+
+// Find returns the message identified by id, or an `exception.NotFound`.
+func (m *Memory) Find(_ context.Context, id string) (Message, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	message, ok := m.messages[id]
+	if !ok {
+		return Message{}, repository.NewNotFound("outbox_message", id)
+	}
+	return message, nil
+}
@@ -0,0 +1,93 @@
+// Package outbox implements the transactional outbox pattern: the messages
+// announcing a change are added to a Store within the transaction of the
+// change, then a Relay publishes them to the broker, retrying the failures
+// that `exception.IsRetryable` classifies as transient. This file defines the
+// messages and the Store contract.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.Status` type and the `status.SUCCESS` and `status.ERROR`
+	// constants of the delivery statuses.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/messaging"
+)
+
+// Delivery statuses of the messages.
+const (
+	Pending   status.Status = "pending"      // The message waits for its (next) publication.
+	Published status.Status = status.SUCCESS // The message was published.
+	Failed    status.Status = status.ERROR   // The message failed permanently, or exhausted its attempts.
+)
+
+// Message is a message of the outbox.
+type Message struct {
+	// Envelope is the message to publish. Its ID identifies the message in
+	// the store, and its Type is the event type (e.g., "order.paid").
+	Envelope messaging.Envelope `json:"envelope"`
+
+	// AggregateID identifies the aggregate whose change the message
+	// announces, e.g. to publish the messages of an aggregate in order.
+	AggregateID string `json:"aggregate_id"`
+
+	Status        status.Status          `json:"status"`
+	Attempts      int                    `json:"attempts"`               // The number of failed publications.
+	LastError     map[string]interface{} `json:"last_error,omitempty"`   // The formatted exception of the last failure.
+	NextAttemptAt time.Time              `json:"next_attempt_at"`        // The time from which the message may be published.
+	PublishedAt   *time.Time             `json:"published_at,omitempty"` // The time of the publication.
+}
+
+// NewMessage creates a pending message.
+//
+// Parameters:
+//
+//	ctx: The context of the change, whose correlation and tenant IDs are
+//	     propagated (see `messaging.New`).
+//	aggregateID: The identifier of the changed aggregate.
+//	eventType: The type of the event (e.g., "order.paid").
+//	payload: The event, encoded as JSON.
+//	c: The clock of the creation time; nil uses the system clock.
+//
+// Returns:
+//
+//	The Message, or an `exception.InvalidArgument` when the payload cannot
+//	be encoded.
+func NewMessage(ctx context.Context, aggregateID string, eventType string, payload interface{}, c clock.Clock) (Message, error) {
+	envelope, err := messaging.New(ctx, eventType, payload)
+	if err != nil {
+		return Message{}, err
+	}
+	now := clock.OrSystem(c).Now()
+	envelope.CreatedAt = now.UTC()
+	return Message{
+		Envelope:      envelope,
+		AggregateID:   aggregateID,
+		Status:        Pending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// ID returns the identifier of the message.
+func (m Message) ID() string {
+	return m.Envelope.ID
+}
+
+// Store persists the messages of the outbox. Implementations must join the
+// transaction carried by the context (see `uow.UnitOfWork`), so that the
+// messages are added atomically with the change they announce.
+type Store interface {
+	// Add adds messages to the outbox.
+	Add(ctx context.Context, messages ...Message) error
+
+	// Due returns up to limit pending messages whose NextAttemptAt is not
+	// after now, the oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]Message, error)
+
+	// Update saves the new state of a message, or fails with an
+	// `exception.NotFound` when it does not exist.
+	Update(ctx context.Context, message Message) error
+}
@@ -0,0 +1,210 @@
+// Package outbox implements the transactional outbox pattern. This file
+// defines the Relay publishing the messages of a Store.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/messaging"
+)
+
+// Default settings of a Relay.
+const (
+	DefaultBatchSize   = 100
+	DefaultInterval    = time.Second
+	DefaultMaxAttempts = 10
+)
+
+// Publisher publishes messages to a broker.
+type Publisher interface {
+	Publish(ctx context.Context, envelope messaging.Envelope) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface.
+type PublisherFunc func(ctx context.Context, envelope messaging.Envelope) error
+
+// Publish calls f.
+func (f PublisherFunc) Publish(ctx context.Context, envelope messaging.Envelope) error {
+	return f(ctx, envelope)
+}
+
+// RelayConfig holds the settings of a Relay. Zero values fall back to the
+// defaults.
+type RelayConfig struct {
+	// Store holds the messages. It is required.
+	Store Store
+
+	// Publisher publishes the messages. It is required.
+	Publisher Publisher
+
+	// BatchSize is the number of messages read from the store at once.
+	// Defaults to DefaultBatchSize.
+	BatchSize int
+
+	// Interval is the delay between two polls of the store by Run. Defaults
+	// to DefaultInterval.
+	Interval time.Duration
+
+	// MaxAttempts is the number of publications after which a message
+	// failing with retryable errors is marked Failed. Defaults to
+	// DefaultMaxAttempts.
+	MaxAttempts int
+
+	// Backoff returns the delay before the next publication of a message
+	// that failed attempts times. Defaults to DefaultBackoff.
+	Backoff func(attempts int) time.Duration
+
+	// Retryable classifies the publication errors. Defaults to
+	// `exception.IsRetryable`; the messages failing with other errors are
+	// marked Failed at once.
+	Retryable func(error) bool
+
+	// OnError, when set, receives the errors of the store and the
+	// publication errors, normalized with `exception.Normalize`.
+	OnError func(ctx context.Context, err error)
+
+	// Clock provides the current time. Defaults to the system clock.
+	Clock clock.Clock
+}
+
+// Relay publishes the due messages of a store. It is safe for concurrent
+// use, but concurrent relays on a store must be prevented by the store (e.g.,
+// with row locks) to avoid duplicate publications.
+type Relay struct {
+	config RelayConfig
+}
+
+// DefaultBackoff doubles the delay from one second after each failure, up to
+// five minutes.
+func DefaultBackoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < 5*time.Minute; i++ {
+		delay *= 2
+	}
+	return min(delay, 5*time.Minute)
+}
+
+// NewRelay creates a Relay.
+//
+// Parameters:
+//
+//	config: The relay settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Relay.
+func NewRelay(config RelayConfig) *Relay {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Backoff == nil {
+		config.Backoff = DefaultBackoff
+	}
+	if config.Retryable == nil {
+		config.Retryable = exception.IsRetryable
+	}
+	config.Clock = clock.OrSystem(config.Clock)
+	return &Relay{config: config}
+}
+
+// RelayOnce publishes a batch of due messages. Published messages are marked
+// Published; failed ones are rescheduled with the backoff delay when their
+// error is retryable and attempts remain, and marked Failed otherwise.
+//
+// Parameters:
+//
+//	ctx: The context passed to the store and the publisher.
+//
+// Returns:
+//
+//	The number of published messages, and the normalized error of the store
+//	when it failed. Publication errors are reported to OnError only.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	messages, err := r.config.Store.Due(ctx, r.config.Clock.Now(), r.config.BatchSize)
+	if err != nil {
+		return 0, exception.Normalize(err)
+	}
+
+	published := 0
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			return published, exception.Normalize(ctx.Err())
+		}
+
+		if err := r.config.Publisher.Publish(ctx, message.Envelope); !exception.IsNil(err) {
+			r.fail(ctx, &message, err)
+		} else {
+			now := r.config.Clock.Now()
+			message.Status, message.PublishedAt, message.LastError = Published, &now, nil
+			published++
+		}
+
+		if err := r.config.Store.Update(ctx, message); err != nil {
+			return published, exception.Normalize(err)
+		}
+	}
+	return published, nil
+}
+
+// Run relays the due messages every Interval until ctx is done. The errors
+// of the store are reported to OnError, and the next poll retries.
+//
+// Returns:
+//
+//	The normalized error of ctx.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		for {
+			published, err := r.RelayOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.report(ctx, err)
+			}
+			// A full batch suggests more due messages: relay them at once.
+			if err != nil || published < r.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return exception.Normalize(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// fail records a failed publication on a message.
+func (r *Relay) fail(ctx context.Context, message *Message, err error) {
+	err = exception.Normalize(err)
+	r.report(ctx, err)
+
+	message.Attempts++
+	var coreErr exception.CoreInterface
+	if errors.As(err, &coreErr) {
+		message.LastError = coreErr.Format()
+	}
+	if r.config.Retryable(err) && message.Attempts < r.config.MaxAttempts {
+		message.NextAttemptAt = r.config.Clock.Now().Add(r.config.Backoff(message.Attempts))
+		return
+	}
+	message.Status = Failed
+}
+
+// report delivers an error to the OnError hook.
+func (r *Relay) report(ctx context.Context, err error) {
+	if r.config.OnError != nil {
+		r.config.OnError(ctx, err)
+	}
+}
@@ -0,0 +1,69 @@
+// Package pagination provides offset and cursor based pagination types.
+// This file defines the opaque cursor pagination style.
+package pagination
+
+import (
+	"net/url"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Cursor describes a cursor based pagination request and, once the page has
+// been fetched, the cursor pointing to the next page.
+type Cursor struct {
+	Cursor     string `json:"cursor,omitempty"`      // The opaque cursor of the current page; empty for the first page.
+	PerPage    int    `json:"per_page"`              // The number of items per page.
+	NextCursor string `json:"next_cursor,omitempty"` // The opaque cursor of the next page; empty when there are no more items.
+}
+
+// ParseCursor builds a Cursor from the "cursor" and "per_page" query parameters.
+// A missing cursor denotes the first page, and a missing page size falls back
+// to the configured default.
+//
+// Parameters:
+//
+//	query: The parsed URL query values (e.g., `r.URL.Query()`).
+//	config: The page size constraints. Zero values fall back to the package defaults.
+//
+// Returns:
+//
+//	The parsed Cursor, or an `exception.InvalidArgument` when the page size is
+//	not an integer and an `exception.OutOfRange` when it is outside its bounds.
+func ParseCursor(query url.Values, config Config) (Cursor, error) {
+	perPage, err := parsePerPage(query, config.normalize())
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	return Cursor{Cursor: query.Get(CursorParam), PerPage: perPage}, nil
+}
+
+// WithNext returns a copy of the Cursor with its NextCursor set.
+func (c Cursor) WithNext(next string) Cursor {
+	c.NextCursor = next
+	return c
+}
+
+// HasMore reports whether another page is available.
+func (c Cursor) HasMore() bool {
+	return c.NextCursor != ""
+}
+
+// Meta returns the pagination information as a map suitable for the "meta"
+// block of `response.Success`, nested under `MetaKey`. Its keys follow
+// `exception.SetKeyNaming` (e.g., "nextCursor" in camelCase).
+func (c Cursor) Meta() map[string]interface{} {
+	naming := exception.CurrentKeyNaming()
+	pagination := map[string]interface{}{
+		naming.Key("per_page"): c.PerPage,
+		naming.Key("has_more"): c.HasMore(),
+	}
+	if c.Cursor != "" {
+		pagination["cursor"] = c.Cursor
+	}
+	if c.NextCursor != "" {
+		pagination[naming.Key("next_cursor")] = c.NextCursor
+	}
+
+	return map[string]interface{}{MetaKey: pagination}
+}
@@ -0,0 +1,132 @@
+// Package pagination provides offset and cursor based pagination types.
+// This file defines the page-number (offset) pagination style.
+package pagination
+
+import (
+	"math"
+	"net/url"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Offset describes a page-number based pagination request and, once the
+// total number of items is known, its result.
+type Offset struct {
+	Page    int `json:"page"`     // The 1-based page number.
+	PerPage int `json:"per_page"` // The number of items per page.
+	Total   int `json:"total"`    // The total number of items across all pages.
+}
+
+// ParseOffset builds an Offset from the "page" and "per_page" query parameters.
+// Missing parameters fall back to page 1 and the configured default page size.
+//
+// Parameters:
+//
+//	query: The parsed URL query values (e.g., `r.URL.Query()`).
+//	config: The page size constraints. Zero values fall back to the package defaults.
+//
+// Returns:
+//
+//	The parsed Offset, or an `exception.InvalidArgument` when a parameter is
+//	not an integer, an `exception.OutOfRange` when it is outside its bounds,
+//	and an `exception.Range` when the page is so large that its offset would
+//	overflow an int.
+func ParseOffset(query url.Values, config Config) (Offset, error) {
+	config = config.normalize()
+
+	page, err := parseInt(query, PageParam, 1)
+	if err != nil {
+		return Offset{}, err
+	}
+	if page < 1 {
+		return Offset{}, outOfRange(PageParam, page, 1, math.MaxInt)
+	}
+
+	perPage, err := parsePerPage(query, config)
+	if err != nil {
+		return Offset{}, err
+	}
+	if lastPage := maxPage(perPage); page > lastPage {
+		return Offset{}, pageOutOfRange(page, lastPage)
+	}
+
+	return Offset{Page: page, PerPage: perPage}, nil
+}
+
+// Offset returns the number of items to skip to reach the current page,
+// saturated at `math.MaxInt` for the pages beyond the last addressable one.
+func (o Offset) Offset() int {
+	if o.PerPage > 0 && o.Page > maxPage(o.PerPage) {
+		return math.MaxInt
+	}
+	return (o.Page - 1) * o.PerPage
+}
+
+// Limit returns the maximum number of items to fetch for the current page.
+func (o Offset) Limit() int {
+	return o.PerPage
+}
+
+// TotalPages returns the number of pages needed to hold Total items.
+// It returns 0 when there are no items or when PerPage is not set.
+func (o Offset) TotalPages() int {
+	if o.Total <= 0 || o.PerPage <= 0 {
+		return 0
+	}
+	return (o.Total + o.PerPage - 1) / o.PerPage
+}
+
+// WithTotal returns a copy of the Offset with its Total set and validates that
+// the requested page exists. Requesting page 1 of an empty collection is allowed.
+//
+// Parameters:
+//
+//	total: The total number of items across all pages.
+//
+// Returns:
+//
+//	The updated Offset, or an `exception.Range` when the requested page is
+//	beyond the last page.
+func (o Offset) WithTotal(total int) (Offset, error) {
+	o.Total = total
+
+	if lastPage := max(o.TotalPages(), 1); o.Page > lastPage {
+		return o, pageOutOfRange(o.Page, lastPage)
+	}
+
+	return o, nil
+}
+
+// maxPage returns the last page whose offset fits in an int.
+func maxPage(perPage int) int {
+	return math.MaxInt/perPage + 1
+}
+
+// pageOutOfRange builds the error reported for a page beyond the last one.
+func pageOutOfRange(page, lastPage int) error {
+	return exception.NewRange(map[string]interface{}{
+		"message": "Requested page is beyond the last page.",
+		"details": map[string]interface{}{
+			"parameter": PageParam,
+			"value":     page,
+			"min":       1,
+			"max":       lastPage,
+			"error":     "page_out_of_range",
+		},
+	})
+}
+
+// Meta returns the pagination information as a map suitable for the "meta"
+// block of `response.Success`, nested under `MetaKey`. Its keys follow
+// `exception.SetKeyNaming` (e.g., "perPage" in camelCase).
+func (o Offset) Meta() map[string]interface{} {
+	naming := exception.CurrentKeyNaming()
+	return map[string]interface{}{
+		MetaKey: map[string]interface{}{
+			"page":                    o.Page,
+			naming.Key("per_page"):    o.PerPage,
+			"total":                   o.Total,
+			naming.Key("total_pages"): o.TotalPages(),
+		},
+	}
+}
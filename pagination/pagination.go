@@ -0,0 +1,101 @@
+// Package pagination provides offset and cursor based pagination types that
+// can be parsed from query parameters, validated through the exception
+// package, and rendered into the "meta" block of the response envelope.
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Query parameter names recognized by the parsers of this package.
+const (
+	PageParam    = "page"     // PageParam is the 1-based page number parameter for offset pagination.
+	PerPageParam = "per_page" // PerPageParam is the page size parameter shared by both pagination styles.
+	CursorParam  = "cursor"   // CursorParam is the opaque cursor parameter for cursor pagination.
+)
+
+// Default pagination settings applied when no Config is provided.
+const (
+	DefaultPerPage = 20  // DefaultPerPage is the page size used when none is requested.
+	MaxPerPage     = 100 // MaxPerPage is the largest page size a client may request.
+)
+
+// MetaKey is the key under which pagination information is placed in the
+// response envelope's "meta" block.
+const MetaKey = "pagination"
+
+// Config holds the page size constraints applied while parsing query parameters.
+type Config struct {
+	DefaultPerPage int // The page size used when the client does not request one.
+	MaxPerPage     int // The largest page size a client may request.
+}
+
+// DefaultConfig returns the Config built from `DefaultPerPage` and `MaxPerPage`.
+func DefaultConfig() Config {
+	return Config{DefaultPerPage: DefaultPerPage, MaxPerPage: MaxPerPage}
+}
+
+// normalize fills unset values of the Config with the package defaults.
+func (c Config) normalize() Config {
+	if c.MaxPerPage <= 0 {
+		c.MaxPerPage = MaxPerPage
+	}
+	if c.DefaultPerPage <= 0 || c.DefaultPerPage > c.MaxPerPage {
+		c.DefaultPerPage = min(DefaultPerPage, c.MaxPerPage)
+	}
+	return c
+}
+
+// parsePerPage reads and validates the page size parameter against the Config.
+// It returns an `exception.InvalidArgument` when the value is not an integer
+// and an `exception.OutOfRange` when it falls outside [1, MaxPerPage].
+func parsePerPage(query url.Values, config Config) (int, error) {
+	perPage, err := parseInt(query, PerPageParam, config.DefaultPerPage)
+	if err != nil {
+		return 0, err
+	}
+	if perPage < 1 || perPage > config.MaxPerPage {
+		return 0, outOfRange(PerPageParam, perPage, 1, config.MaxPerPage)
+	}
+	return perPage, nil
+}
+
+// parseInt reads an integer query parameter, returning fallback when the
+// parameter is absent or empty.
+func parseInt(query url.Values, name string, fallback int) (int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, exception.NewInvalidArgument(map[string]interface{}{
+			"message": "Pagination parameter must be an integer.",
+			"details": map[string]interface{}{
+				"parameter": name,
+				"value":     raw,
+				"error":     "not_an_integer",
+			},
+		})
+	}
+	return value, nil
+}
+
+// outOfRange builds the `exception.OutOfRange` returned when a pagination
+// parameter falls outside its allowed bounds.
+func outOfRange(name string, value, minimum, maximum int) error {
+	return exception.NewOutOfRange(map[string]interface{}{
+		"message": "Pagination parameter is out of range.",
+		"details": map[string]interface{}{
+			"parameter": name,
+			"value":     value,
+			"min":       minimum,
+			"max":       maximum,
+			"error":     "out_of_range",
+		},
+	})
+}
@@ -0,0 +1,197 @@
+// Package query provides a parser for list endpoint query parameters such as
+// `?sort=-created_at,name&filter[status]=active`. Parameters are validated
+// against an allowlist and described by a typed Criteria struct, while
+// invalid parameters are reported through `exception.InvalidArgument`.
+package query
+
+import (
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Query parameter names and syntax recognized by the Parser.
+const (
+	SortParam    = "sort"    // SortParam holds a comma-separated list of fields, prefixed by "-" for descending order.
+	FilterPrefix = "filter[" // FilterPrefix opens a filter parameter name such as "filter[status]".
+	FilterSuffix = "]"       // FilterSuffix closes a filter parameter name.
+)
+
+// Direction is the ordering applied to a sorted field.
+type Direction string
+
+const (
+	Ascending  Direction = "asc"  // Ascending sorts from the smallest to the largest value.
+	Descending Direction = "desc" // Descending sorts from the largest to the smallest value.
+)
+
+// Sort describes a single field of the requested ordering.
+type Sort struct {
+	Field     string    `json:"field"`     // The name of the field to sort on.
+	Direction Direction `json:"direction"` // The direction of the ordering.
+}
+
+// Filter describes a single filtered field. Comma-separated and repeated
+// values are collected into Values, to be interpreted as alternatives.
+type Filter struct {
+	Field  string   `json:"field"`  // The name of the filtered field.
+	Values []string `json:"values"` // The accepted values for the field.
+}
+
+// Criteria is the typed description of the sort and filter parameters of a request.
+type Criteria struct {
+	Sort    []Sort   `json:"sort"`    // The requested ordering, in priority order.
+	Filters []Filter `json:"filters"` // The requested filters, ordered by field name.
+}
+
+// Filter returns the filter applied to the given field, if any.
+func (c Criteria) Filter(field string) (Filter, bool) {
+	for _, filter := range c.Filters {
+		if filter.Field == field {
+			return filter, true
+		}
+	}
+	return Filter{}, false
+}
+
+// Parser validates sort and filter parameters against allowlists of fields.
+type Parser struct {
+	sortable   []string // The fields that may appear in the sort parameter.
+	filterable []string // The fields that may appear in filter parameters.
+}
+
+// NewParser creates a Parser accepting the given sortable and filterable fields.
+//
+// Parameters:
+//
+//	sortable: The field names clients are allowed to sort on.
+//	filterable: The field names clients are allowed to filter on.
+//
+// Returns:
+//
+//	A pointer to a new Parser.
+func NewParser(sortable []string, filterable []string) *Parser {
+	return &Parser{
+		sortable:   slices.Clone(sortable),
+		filterable: slices.Clone(filterable),
+	}
+}
+
+// Parse reads the sort and filter parameters from the query values. Every
+// invalid parameter is reported at once rather than stopping at the first one.
+//
+// Parameters:
+//
+//	values: The parsed URL query values (e.g., `r.URL.Query()`).
+//
+// Returns:
+//
+//	The parsed Criteria, or an `exception.InvalidArgument` whose "details" map
+//	holds one entry per offending parameter name; the SortParam entry lists
+//	one problem per rejected field.
+func (p *Parser) Parse(values url.Values) (Criteria, error) {
+	criteria := Criteria{Sort: []Sort{}, Filters: []Filter{}}
+	problems := map[string]interface{}{}
+
+	if raw := values.Get(SortParam); raw != "" {
+		criteria.Sort = p.parseSort(raw, problems)
+	}
+
+	for name, rawValues := range values {
+		field, ok := filterField(name)
+		if !ok {
+			continue
+		}
+		if !slices.Contains(p.filterable, field) {
+			problems[name] = problem(field, "filter_not_allowed", p.filterable)
+			continue
+		}
+		if filterValues := splitValues(rawValues); len(filterValues) > 0 {
+			criteria.Filters = append(criteria.Filters, Filter{Field: field, Values: filterValues})
+		}
+	}
+
+	if len(problems) > 0 {
+		return Criteria{}, exception.NewInvalidArgument(map[string]interface{}{
+			"message": "Invalid query parameters.",
+			"details": problems,
+		})
+	}
+
+	// Map iteration order is random; keep the output deterministic.
+	sort.Slice(criteria.Filters, func(i, j int) bool {
+		return criteria.Filters[i].Field < criteria.Filters[j].Field
+	})
+
+	return criteria, nil
+}
+
+// parseSort parses the comma-separated sort parameter, recording a problem
+// for every field that is not allowed or is requested more than once.
+func (p *Parser) parseSort(raw string, problems map[string]interface{}) []Sort {
+	sorts := []Sort{}
+	seen := map[string]bool{}
+	var rejected []interface{}
+
+	for _, token := range strings.Split(raw, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		direction := Ascending
+		if strings.HasPrefix(token, "-") {
+			direction = Descending
+			token = token[1:]
+		}
+
+		switch {
+		case !slices.Contains(p.sortable, token):
+			rejected = append(rejected, problem(token, "sort_not_allowed", p.sortable))
+		case seen[token]:
+			rejected = append(rejected, problem(token, "sort_duplicated", p.sortable))
+		default:
+			seen[token] = true
+			sorts = append(sorts, Sort{Field: token, Direction: direction})
+		}
+	}
+
+	if len(rejected) > 0 {
+		problems[SortParam] = rejected
+	}
+	return sorts
+}
+
+// filterField extracts the field name from a "filter[field]" parameter name.
+func filterField(name string) (string, bool) {
+	if !strings.HasPrefix(name, FilterPrefix) || !strings.HasSuffix(name, FilterSuffix) {
+		return "", false
+	}
+	field := name[len(FilterPrefix) : len(name)-len(FilterSuffix)]
+	return field, field != ""
+}
+
+// splitValues flattens repeated and comma-separated values, dropping empty ones.
+func splitValues(rawValues []string) []string {
+	values := []string{}
+	for _, raw := range rawValues {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// problem builds the detail entry describing a rejected parameter.
+func problem(field string, code string, allowed []string) map[string]interface{} {
+	return map[string]interface{}{
+		"field":   field,
+		"error":   code,
+		"allowed": slices.Clone(allowed),
+	}
+}
@@ -0,0 +1,93 @@
+// Package ratelimit provides per-key request rate limiters.
+// This file defines the HTTP middleware applying a limiter per client key.
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/osirisgate/golang-core/response"
+)
+
+// HTTP headers reporting the rate limit state to clients.
+const (
+	LimitHeader      = "X-RateLimit-Limit"     // LimitHeader carries the maximum number of requests in the period.
+	RemainingHeader  = "X-RateLimit-Remaining" // RemainingHeader carries the number of requests left in the period.
+	ResetHeader      = "X-RateLimit-Reset"     // ResetHeader carries the Unix time at which the quota resets.
+	RetryAfterHeader = "Retry-After"           // RetryAfterHeader carries the seconds to wait after a denied request.
+)
+
+// KeyFunc extracts the rate limiting key of a request. An empty key means
+// that the request is not rate limited.
+type KeyFunc func(r *http.Request) string
+
+// KeyByIP keys requests by the host part of `http.Request.RemoteAddr`.
+// Forwarding headers such as X-Forwarded-For are deliberately ignored since
+// they are client controlled; rewrite RemoteAddr in a trusted proxy
+// middleware first when running behind a load balancer.
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// KeyByHeader keys requests by the value of a header, e.g. an API key.
+//
+// Parameters:
+//
+//	name: The name of the header (e.g., "X-API-Key").
+//
+// Returns:
+//
+//	A KeyFunc reading the header; requests without it are not rate limited.
+func KeyByHeader(name string) KeyFunc {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// Middleware applies a limiter to each request, keyed by keyFunc. The rate
+// limit headers are set on every limited response; denied requests receive
+// a 429 response rendered by `response.WriteError` with a Retry-After header,
+// and next is not called. The key is not included in the error details, as
+// it may be an API key.
+//
+// Parameters:
+//
+//	limiter: The limiter deciding whether a request may proceed.
+//	keyFunc: The function extracting the key of a request. Defaults to KeyByIP.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the rate limiting.
+func Middleware(limiter Limiter, keyFunc KeyFunc) func(http.Handler) http.Handler {
+	if keyFunc == nil {
+		keyFunc = KeyByIP
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := keyFunc(r)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision := limiter.Allow(key)
+			header := w.Header()
+			header.Set(LimitHeader, strconv.Itoa(decision.Limit))
+			header.Set(RemainingHeader, strconv.Itoa(decision.Remaining))
+			header.Set(ResetHeader, strconv.FormatInt(decision.ResetAt.Unix(), 10))
+
+			if err := decision.Err(""); err != nil {
+				header.Set(RetryAfterHeader, strconv.Itoa(retryAfterSeconds(decision.RetryAfter)))
+				_ = response.WriteError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package ratelimit provides per-key request rate limiters (token bucket and
+// sliding window) and an HTTP middleware applying them. A denied request is
+// reported as an `exception.TooManyRequests` carrying the limit, the
+// remaining quota and the reset time, and the standard rate limit headers
+// are set on every response.
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Decision is the outcome of a rate limit check.
+type Decision struct {
+	Allowed    bool          // Whether the request may proceed.
+	Limit      int           // The maximum number of requests in the limiter's period.
+	Remaining  int           // The number of requests still allowed in the current period.
+	ResetAt    time.Time     // When the quota is fully (token bucket) or next (sliding window) replenished.
+	RetryAfter time.Duration // How long to wait before retrying a denied request; zero when allowed.
+}
+
+// Err returns nil for an allowed request, or an `exception.TooManyRequests`
+// describing the limit for a denied one.
+//
+// Parameters:
+//
+//	key: The key that was rate limited, reported in the exception details.
+//	     It is omitted when empty, e.g. when the key is sensitive.
+//
+// Returns:
+//
+//	Nil or an `exception.TooManyRequests`.
+func (d Decision) Err(key string) error {
+	if d.Allowed {
+		return nil
+	}
+
+	details := map[string]interface{}{
+		"limit":       d.Limit,
+		"remaining":   d.Remaining,
+		"reset_at":    d.ResetAt.UTC().Format(time.RFC3339),
+		"retry_after": retryAfterSeconds(d.RetryAfter),
+		"error":       "rate_limited",
+	}
+	if key != "" {
+		details["key"] = key
+	}
+
+	return exception.NewTooManyRequests(map[string]interface{}{
+		"message": "Too many requests, please retry later.",
+		"details": details,
+	})
+}
+
+// Limiter decides whether a request identified by a key may proceed.
+// Implementations must be safe for concurrent use.
+type Limiter interface {
+	// Allow consumes one unit of the key's quota if available.
+	Allow(key string) Decision
+}
+
+// retryAfterSeconds rounds a delay up to whole seconds, as used by the
+// Retry-After header.
+func retryAfterSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
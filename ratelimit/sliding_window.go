@@ -0,0 +1,128 @@
+// Package ratelimit provides per-key request rate limiters.
+// This file defines the sliding window limiter.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// window is the state of a single key of a SlidingWindow.
+type window struct {
+	start    time.Time // The start of the current fixed window.
+	current  int       // The requests counted in the current fixed window.
+	previous int       // The requests counted in the previous fixed window.
+}
+
+// SlidingWindow allows up to Limit requests per key within any period of
+// length Window. It uses the sliding window counter approximation: the
+// previous window's count is weighted by how much it still overlaps the
+// sliding period, which keeps the state per key constant.
+type SlidingWindow struct {
+	limit  int
+	period time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// NewSlidingWindow creates a SlidingWindow limiter.
+//
+// Parameters:
+//
+//	limit: The maximum number of requests per key within a period.
+//	period: The length of the sliding period (e.g., time.Minute).
+//	now: The clock to use. A nil clock falls back to `time.Now`.
+//
+// Returns:
+//
+//	A pointer to a new SlidingWindow.
+func NewSlidingWindow(limit int, period time.Duration, now func() time.Time) *SlidingWindow {
+	if now == nil {
+		now = time.Now
+	}
+	if period <= 0 {
+		period = time.Second
+	}
+	return &SlidingWindow{
+		limit:   max(limit, 1),
+		period:  period,
+		now:     now,
+		windows: map[string]*window{},
+	}
+}
+
+// Allow counts one request for the key if the quota allows it.
+func (l *SlidingWindow) Allow(key string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok {
+		w = &window{start: now.Truncate(l.period)}
+		l.windows[key] = w
+	}
+	l.advance(w, now)
+
+	// Weight of the previous window still covered by the sliding period.
+	overlap := 1 - float64(now.Sub(w.start))/float64(l.period)
+	used := float64(w.previous)*overlap + float64(w.current)
+	nextWindow := w.start.Add(l.period)
+
+	decision := Decision{Limit: l.limit, ResetAt: nextWindow}
+	if used+1 <= float64(l.limit) {
+		w.current++
+		used++
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = l.retryAfter(w, now, nextWindow)
+	}
+	decision.Remaining = max(l.limit-int(used+0.999999), 0)
+	return decision
+}
+
+// advance rolls the fixed windows forward to the one containing now.
+func (l *SlidingWindow) advance(w *window, now time.Time) {
+	start := now.Truncate(l.period)
+	switch elapsed := start.Sub(w.start); {
+	case elapsed <= 0:
+		return
+	case elapsed == l.period:
+		w.previous = w.current
+	default:
+		w.previous = 0
+	}
+	w.current = 0
+	w.start = start
+}
+
+// retryAfter estimates when the weighted count will drop enough for one
+// more request to be allowed.
+func (l *SlidingWindow) retryAfter(w *window, now time.Time, nextWindow time.Time) time.Duration {
+	if w.previous == 0 || w.current+1 > l.limit {
+		// Only the next window can make room.
+		return nextWindow.Sub(now)
+	}
+	// Solve previous*(1 - t/period) + current + 1 <= limit for t.
+	t := float64(l.period) * (1 - float64(l.limit-w.current-1)/float64(w.previous))
+	return max(w.start.Add(time.Duration(t)).Sub(now), time.Millisecond)
+}
+
+// sweep drops the keys that have been idle for two periods, so that the
+// limiter does not grow unbounded with the number of distinct keys.
+func (l *SlidingWindow) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < max(2*l.period, time.Minute) {
+		return
+	}
+	l.lastSweep = now
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= 2*l.period {
+			delete(l.windows, key)
+		}
+	}
+}
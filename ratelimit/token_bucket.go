@@ -0,0 +1,112 @@
+// Package ratelimit provides per-key request rate limiters.
+// This file defines the token bucket limiter.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// MaxRetryAfter caps the delays computed by a TokenBucket, which a bucket
+// refilled very slowly, or not at all, would otherwise overflow.
+const MaxRetryAfter = 24 * time.Hour
+
+// bucket is the state of a single key of a TokenBucket.
+type bucket struct {
+	tokens  float64   // The tokens currently available.
+	updated time.Time // When tokens was last computed.
+}
+
+// TokenBucket allows bursts of up to Burst requests per key, refilled at a
+// steady Rate of tokens per second.
+type TokenBucket struct {
+	rate  float64
+	burst int
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewTokenBucket creates a TokenBucket limiter.
+//
+// Parameters:
+//
+//	rate: The number of tokens added per second to each key's bucket. A
+//	      non-positive rate never refills the buckets: a key is only reset
+//	      once idle for MaxRetryAfter, the delay it is told to wait.
+//	burst: The capacity of each bucket, i.e. the largest allowed burst.
+//	now: The clock to use. A nil clock falls back to `time.Now`.
+//
+// Returns:
+//
+//	A pointer to a new TokenBucket.
+func NewTokenBucket(rate float64, burst int, now func() time.Time) *TokenBucket {
+	if now == nil {
+		now = time.Now
+	}
+	return &TokenBucket{
+		rate:    math.Max(rate, 0),
+		burst:   max(burst, 1),
+		now:     now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow consumes one token of the key's bucket if available.
+func (l *TokenBucket) Allow(key string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	decision := Decision{Limit: l.burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = l.durationFor(1 - b.tokens)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.ResetAt = now.Add(l.durationFor(float64(l.burst) - b.tokens))
+	return decision
+}
+
+// durationFor returns the time needed to refill the given number of tokens,
+// capped at MaxRetryAfter.
+func (l *TokenBucket) durationFor(tokens float64) time.Duration {
+	if l.rate <= 0 {
+		return MaxRetryAfter
+	}
+	seconds := tokens / l.rate
+	if seconds >= MaxRetryAfter.Seconds() {
+		return MaxRetryAfter
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// sweep drops the buckets that have been full for a while, so that the
+// limiter does not grow unbounded with the number of distinct keys.
+func (l *TokenBucket) sweep(now time.Time) {
+	fill := l.durationFor(float64(l.burst))
+	if now.Sub(l.lastSweep) < max(fill, time.Minute) {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) > fill {
+			delete(l.buckets, key)
+		}
+	}
+}
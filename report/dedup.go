@@ -0,0 +1,127 @@
+// Package report forwards exceptions to error trackers. This file defines
+// the Dedup component suppressing the duplicate exceptions.
+package report
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// DedupConfig holds the settings of a Dedup. Zero values fall back to the
+// defaults.
+type DedupConfig struct {
+	// Window is the period during which the exceptions of a fingerprint
+	// already let through are suppressed. Defaults to DefaultDedupWindow.
+	Window time.Duration
+
+	// Fingerprint groups the duplicate exceptions. Defaults to
+	// DefaultFingerprint.
+	Fingerprint func(err exception.CoreInterface) string
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// Dedup suppresses the exceptions whose fingerprint was already seen within
+// a window, for the logging and reporting paths only: the exceptions are
+// still returned to the callers that created them. It is a Reporter
+// forwarding the first exception of each fingerprint and window to another
+// one, and thus pluggable into the creation hook:
+//
+//	dedup := report.NewDedup(dispatcher, report.DedupConfig{Window: time.Minute})
+//	defer report.Install(dedup, report.SeverityError)()
+//
+// Other sinks, such as a logger, can consult Allow directly. It is safe for
+// concurrent use.
+type Dedup struct {
+	next   Reporter
+	config DedupConfig
+
+	mu         sync.Mutex
+	seen       map[string]*dedupEntry
+	suppressed atomic.Int64
+}
+
+// dedupEntry tracks a fingerprint.
+type dedupEntry struct {
+	allowedAt  time.Time
+	suppressed int // The occurrences suppressed since allowedAt.
+}
+
+// NewDedup creates a Dedup.
+//
+// Parameters:
+//
+//	next: The reporter of the exceptions let through; it may be nil when
+//	      only Allow is used.
+//	config: The dedup settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Dedup.
+func NewDedup(next Reporter, config DedupConfig) *Dedup {
+	if config.Window <= 0 {
+		config.Window = DefaultDedupWindow
+	}
+	if config.Fingerprint == nil {
+		config.Fingerprint = DefaultFingerprint
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Dedup{next: next, config: config, seen: map[string]*dedupEntry{}}
+}
+
+// Report forwards the exception to the next reporter unless it is a
+// duplicate (see Allow).
+func (d *Dedup) Report(ctx context.Context, err exception.CoreInterface) {
+	if err == nil || !d.Allow(err) || d.next == nil {
+		return
+	}
+	d.next.Report(ctx, err)
+}
+
+// Allow reports whether an exception is let through: the first one of its
+// fingerprint in a window is, the following ones are suppressed and counted
+// until the window ends.
+func (d *Dedup) Allow(err exception.CoreInterface) bool {
+	fingerprint := d.config.Fingerprint(err)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.config.Now()
+	// Forget the fingerprints that have been quiet for two windows.
+	for key, entry := range d.seen {
+		if now.Sub(entry.allowedAt) >= 2*d.config.Window {
+			delete(d.seen, key)
+		}
+	}
+
+	if entry, ok := d.seen[fingerprint]; ok && now.Sub(entry.allowedAt) < d.config.Window {
+		entry.suppressed++
+		d.suppressed.Add(1)
+		return false
+	}
+	d.seen[fingerprint] = &dedupEntry{allowedAt: now}
+	return true
+}
+
+// Suppressed returns the number of exceptions suppressed so far.
+func (d *Dedup) Suppressed() int64 {
+	return d.suppressed.Load()
+}
+
+// SuppressedOf returns the number of exceptions of a fingerprint suppressed
+// in its current window.
+func (d *Dedup) SuppressedOf(fingerprint string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if entry, ok := d.seen[fingerprint]; ok {
+		return entry.suppressed
+	}
+	return 0
+}
@@ -0,0 +1,224 @@
+// Package report forwards exceptions to error trackers. This file defines
+// the asynchronous batching Dispatcher.
+package report
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Default settings of a Dispatcher.
+const (
+	DefaultQueueSize     = 1024
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = 5 * time.Second
+)
+
+// Event is a reported exception.
+type Event struct {
+	Err      exception.CoreInterface
+	Severity Severity
+	Fields   map[string]interface{} // The request, correlation and tenant IDs of the context (see `ctxutil.Fields`), and the deployment context (see `exception.SetAppInfo`).
+	At       time.Time
+}
+
+// Config holds the settings of a Dispatcher. Zero values fall back to the
+// defaults.
+type Config struct {
+	// Send sends a batch of events to the error tracker. It is required, and
+	// is called from a single goroutine. The exceptions it creates are
+	// reported like any other when the Dispatcher is installed, so it should
+	// return plain errors to avoid a feedback loop while the tracker is down.
+	Send func(ctx context.Context, events []Event) error
+
+	// QueueSize is the number of events waiting to be sent beyond which
+	// Report drops the new ones. Defaults to DefaultQueueSize.
+	QueueSize int
+
+	// BatchSize is the maximum number of events of a batch. Defaults to
+	// DefaultBatchSize.
+	BatchSize int
+
+	// FlushInterval is the maximum delay before the queued events are sent.
+	// Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+
+	// OnError, when set, receives the errors of Send.
+	OnError func(err error)
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// Dispatcher is an asynchronous Reporter sending the reported exceptions in
+// batches. It is safe for concurrent use.
+type Dispatcher struct {
+	config  Config
+	queue   chan Event
+	flushes chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	closed  atomic.Bool
+	dropped atomic.Int64
+}
+
+// NewDispatcher creates a Dispatcher and starts its sending goroutine.
+//
+// Parameters:
+//
+//	config: The dispatcher settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Dispatcher. Call Close to flush the queued events
+//	and stop it.
+func NewDispatcher(config Config) *Dispatcher {
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultQueueSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	d := &Dispatcher{
+		config:  config,
+		queue:   make(chan Event, config.QueueSize),
+		flushes: make(chan chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// Report queues an exception without blocking. It is dropped when the queue
+// is full or the dispatcher closed (see Dropped).
+func (d *Dispatcher) Report(ctx context.Context, err exception.CoreInterface) {
+	if exception.IsNil(err) {
+		return
+	}
+	if d.closed.Load() {
+		d.dropped.Add(1)
+		return
+	}
+
+	fields := ctxutil.Fields(ctx)
+	for key, value := range exception.CurrentAppInfo().Fields() {
+		fields[key] = value
+	}
+	event := Event{Err: err, Severity: SeverityOf(err), Fields: fields, At: d.config.Now()}
+	select {
+	case d.queue <- event:
+	default:
+		d.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped so far.
+func (d *Dispatcher) Dropped() int64 {
+	return d.dropped.Load()
+}
+
+// Flush sends the queued events and waits for them to be sent.
+//
+// Returns:
+//
+//	Nil once sent, or the normalized error of ctx when it ends first.
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case d.flushes <- ack:
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return exception.Normalize(ctx.Err())
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return exception.Normalize(ctx.Err())
+	}
+}
+
+// Close stops accepting events, sends the queued ones and stops the
+// dispatcher. It is safe to call several times.
+//
+// Returns:
+//
+//	Nil once stopped, or the normalized error of ctx when it ends first; the
+//	queued events are then still sent in the background.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.once.Do(func() {
+		d.closed.Store(true)
+		close(d.stop)
+	})
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return exception.Normalize(ctx.Err())
+	}
+}
+
+// run batches the queued events until the dispatcher is closed.
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, d.config.BatchSize)
+	for {
+		select {
+		case event := <-d.queue:
+			if batch = append(batch, event); len(batch) >= d.config.BatchSize {
+				batch = d.send(batch)
+			}
+		case <-ticker.C:
+			batch = d.send(batch)
+		case ack := <-d.flushes:
+			batch = d.send(d.drain(batch))
+			close(ack)
+		case <-d.stop:
+			d.send(d.drain(batch))
+			return
+		}
+	}
+}
+
+// drain moves the queued events into batches, sending the full ones.
+func (d *Dispatcher) drain(batch []Event) []Event {
+	for {
+		select {
+		case event := <-d.queue:
+			if batch = append(batch, event); len(batch) >= d.config.BatchSize {
+				batch = d.send(batch)
+			}
+		default:
+			return batch
+		}
+	}
+}
+
+// send sends a batch, returning the emptied batch.
+func (d *Dispatcher) send(batch []Event) []Event {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := d.config.Send(context.Background(), append([]Event{}, batch...)); err != nil && d.config.OnError != nil {
+		d.config.OnError(err)
+	}
+	return batch[:0]
+}
@@ -0,0 +1,263 @@
+// Package report forwards exceptions to error trackers. This file defines
+// the Notifier posting critical exceptions to webhooks.
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/ratelimit"
+)
+
+// Default settings of a Notifier.
+const (
+	DefaultDedupWindow = 10 * time.Minute
+	DefaultTimeout     = 10 * time.Second
+)
+
+// limiterKey is the key of the notifications in the Limiter, which bounds
+// them all rather than each fingerprint.
+const limiterKey = "notifications"
+
+// PayloadFormat is the format of the notifications of an endpoint.
+type PayloadFormat int
+
+// Payload formats.
+const (
+	// FormatJSON posts {"fingerprint", "severity", "at", "repeated",
+	// "fields", "error"}, "error" being the `Format()` output of the
+	// exception.
+	FormatJSON PayloadFormat = iota
+
+	// FormatSlack posts the {"text": ...} payload of Slack compatible
+	// incoming webhooks.
+	FormatSlack
+)
+
+// Endpoint is a webhook receiving notifications.
+type Endpoint struct {
+	URL     string
+	Format  PayloadFormat
+	Headers map[string]string // Additional headers, e.g. an authorization.
+}
+
+// NotifierConfig holds the settings of a Notifier. Zero values fall back to
+// the defaults.
+type NotifierConfig struct {
+	// Endpoints receive the notifications. At least one is required.
+	Endpoints []Endpoint
+
+	// MinSeverity is the minimum severity of the notified events. Defaults
+	// to SeverityCritical.
+	MinSeverity Severity
+
+	// DedupWindow is the period during which the events of a fingerprint
+	// already notified are only counted, the next notification reporting
+	// them as "repeated". Defaults to DefaultDedupWindow.
+	DedupWindow time.Duration
+
+	// Limiter, when set, bounds the rate of the notifications (e.g.,
+	// `ratelimit.NewTokenBucket(1.0/60, 5, nil)` for bursts of 5 then one a
+	// minute). The events it denies are dropped.
+	Limiter ratelimit.Limiter
+
+	// Fingerprint groups the duplicate events. Defaults to
+	// DefaultFingerprint.
+	Fingerprint func(err exception.CoreInterface) string
+
+	// Client posts the notifications. Defaults to an `*http.Client` with a
+	// DefaultTimeout timeout.
+	Client *http.Client
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// Notifier posts exceptions to webhooks, deduplicating them by fingerprint.
+// Its Send method is meant to be the sender of a Dispatcher installed for
+// critical exceptions:
+//
+//	notifier := report.NewNotifier(report.NotifierConfig{Endpoints: endpoints})
+//	dispatcher := report.NewDispatcher(report.Config{Send: notifier.Send})
+//	defer report.Install(dispatcher, report.SeverityCritical)()
+//
+// It is safe for concurrent use.
+type Notifier struct {
+	config NotifierConfig
+
+	mu   sync.Mutex
+	seen map[string]*occurrence
+}
+
+// occurrence tracks the notifications of a fingerprint.
+type occurrence struct {
+	notifiedAt time.Time
+	repeated   int // The events suppressed since the last notification.
+}
+
+// DefaultFingerprint identifies an exception by its status code, its details
+// "error" code and its message: the first 16 hexadecimal digits of their
+// SHA-256.
+func DefaultFingerprint(err exception.CoreInterface) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s", err.GetStatusCode(), err.GetDetailsMessage(), err.Error())))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewNotifier creates a Notifier.
+//
+// Parameters:
+//
+//	config: The notifier settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Notifier.
+func NewNotifier(config NotifierConfig) *Notifier {
+	if config.MinSeverity == SeverityInfo {
+		config.MinSeverity = SeverityCritical
+	}
+	if config.DedupWindow <= 0 {
+		config.DedupWindow = DefaultDedupWindow
+	}
+	if config.Fingerprint == nil {
+		config.Fingerprint = DefaultFingerprint
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Notifier{config: config, seen: map[string]*occurrence{}}
+}
+
+// Send notifies the endpoints of the events of at least MinSeverity that
+// are neither duplicates nor rate limited.
+//
+// Parameters:
+//
+//	ctx: The context of the requests.
+//	events: The events to notify.
+//
+// Returns:
+//
+//	Nil, or the errors of the failed posts joined with `errors.Join`. They
+//	are plain errors, so that the failures of an installed notifier are not
+//	reported in turn.
+func (n *Notifier) Send(ctx context.Context, events []Event) error {
+	var errs []error
+	for _, event := range events {
+		if event.Severity < n.config.MinSeverity || event.Err == nil {
+			continue
+		}
+		fingerprint := n.config.Fingerprint(event.Err)
+		repeated, ok := n.admit(fingerprint)
+		if !ok {
+			continue
+		}
+		for _, endpoint := range n.config.Endpoints {
+			if err := n.post(ctx, endpoint, event, fingerprint, repeated); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// admit decides whether an event of a fingerprint is notified.
+//
+// Returns:
+//
+//	The number of duplicates suppressed since the last notification of the
+//	fingerprint, and false when the event must not be notified.
+func (n *Notifier) admit(fingerprint string) (int, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.config.Now()
+	// Forget the fingerprints that have been quiet for two windows.
+	for key, o := range n.seen {
+		if now.Sub(o.notifiedAt) >= 2*n.config.DedupWindow {
+			delete(n.seen, key)
+		}
+	}
+
+	o, ok := n.seen[fingerprint]
+	if ok && now.Sub(o.notifiedAt) < n.config.DedupWindow {
+		o.repeated++
+		return 0, false
+	}
+	if n.config.Limiter != nil && !n.config.Limiter.Allow(limiterKey).Allowed {
+		if ok {
+			o.repeated++
+		}
+		return 0, false
+	}
+
+	repeated := 0
+	if ok {
+		repeated = o.repeated
+	}
+	n.seen[fingerprint] = &occurrence{notifiedAt: now}
+	return repeated, true
+}
+
+// post posts the notification of an event to an endpoint.
+func (n *Notifier) post(ctx context.Context, endpoint Endpoint, event Event, fingerprint string, repeated int) error {
+	var payload interface{}
+	switch endpoint.Format {
+	case FormatSlack:
+		text := fmt.Sprintf("[%s] %s (status %d", event.Severity, event.Err.Error(), event.Err.GetStatusCode())
+		if code := event.Err.GetDetailsMessage(); code != "" {
+			text += ", " + code
+		}
+		text += ", fingerprint " + fingerprint + ")"
+		if repeated > 0 {
+			text += fmt.Sprintf("\nRepeated %d times since the last notification.", repeated)
+		}
+		payload = map[string]interface{}{"text": text}
+	default:
+		payload = map[string]interface{}{
+			"fingerprint": fingerprint,
+			"severity":    event.Severity.String(),
+			"at":          event.At.UTC().Format(time.RFC3339),
+			"repeated":    repeated,
+			"fields":      event.Fields,
+			"error":       event.Err.Format(),
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("report: encoding the notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("report: building the notification: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range endpoint.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := n.config.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("report: posting the notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("report: the notification was answered with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package report forwards exceptions to error trackers. A Reporter receives
+// the exceptions to report; the Dispatcher is an asynchronous Reporter
+// batching them through a bounded queue towards a sender, and flushing them
+// on shutdown. Install forwards every exception of a minimum severity, as
+// soon as it is created anywhere, through the creation hook of the exception
+// package, the Dedup suppresses the duplicates reported within a window, and
+// the Notifier posts the critical ones to webhooks (e.g., the Slack channel
+// of the incidents).
+package report
+
+import (
+	"context"
+	"errors"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.InternalServerError` constant of critical exceptions.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Severity is the severity of an exception.
+type Severity int
+
+// Severities, by increasing order.
+const (
+	SeverityInfo     Severity = iota // Not an error status (below 400).
+	SeverityWarning                  // A client error (4xx).
+	SeverityError                    // A server error (5xx) other than 500.
+	SeverityCritical                 // An internal server error (500), or an error that is not an exception.
+)
+
+// String returns the name of the severity (e.g., "error").
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "critical"
+	}
+}
+
+// SeverityOf returns the severity of an error, refining the levels of
+// `logger.LogException`: server errors are errors, client errors are
+// warnings. Internal server errors, which denote bugs and unexpected
+// failures rather than unavailable dependencies, and errors that are not
+// exceptions are critical.
+func SeverityOf(err error) Severity {
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || exception.IsNil(coreErr) {
+		return SeverityCritical
+	}
+	switch code := coreErr.GetStatusCode(); {
+	case code == status.InternalServerError.GetValue():
+		return SeverityCritical
+	case code >= 500:
+		return SeverityError
+	case code >= 400:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// Reporter reports exceptions, e.g. to an error tracker.
+type Reporter interface {
+	// Report reports an exception. It must not block, and must be safe for
+	// concurrent use.
+	Report(ctx context.Context, err exception.CoreInterface)
+}
+
+// ReporterFunc adapts a function to the Reporter interface.
+type ReporterFunc func(ctx context.Context, err exception.CoreInterface)
+
+// Report calls f.
+func (f ReporterFunc) Report(ctx context.Context, err exception.CoreInterface) {
+	f(ctx, err)
+}
+
+// Install forwards every exception of at least a severity to a reporter as
+// soon as it is created, through `exception.SetCreationHook`; as the hook
+// knows no request, the exceptions are reported with a background context.
+// The reporter receives a sealed snapshot of the exception (see
+// `exception.Snapshot`), of its type, which it may queue: the later changes
+// of the exception, such as `SetMessage`, are not reported. The snapshot is
+// only taken for the exceptions of at least the minimum severity.
+// The reporter must not create exceptions itself, which would be reported in
+// turn: use an asynchronous Dispatcher.
+//
+// Parameters:
+//
+//	reporter: The reporter of the exceptions.
+//	minimum: The minimum severity of the reported exceptions (e.g.,
+//	         SeverityError).
+//
+// Returns:
+//
+//	A function uninstalling the hook, restoring the previous one.
+func Install(reporter Reporter, minimum Severity) (uninstall func()) {
+	return exception.SetCreationHook(func(err exception.CoreInterface) {
+		if SeverityOf(err) >= minimum {
+			reporter.Report(context.Background(), exception.Snapshot(err))
+		}
+	})
+}
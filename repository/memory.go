@@ -0,0 +1,87 @@
+// Package repository defines the persistence contract shared by the domain
+// and the persistence adapters. This file defines the in-memory Repository.
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory is an in-memory Repository, intended for tests and prototypes.
+// It is safe for concurrent use.
+type Memory[T any, ID comparable] struct {
+	entity string
+	idOf   func(T) ID
+
+	mu       sync.RWMutex
+	entities map[ID]T
+}
+
+// NewMemory creates an empty in-memory Repository.
+//
+// Parameters:
+//
+//	entity: The name of the entity type, used in the exceptions (e.g., "user").
+//	idOf: The function returning the identifier of an entity.
+//
+// Returns:
+//
+//	A pointer to a new Memory repository.
+func NewMemory[T any, ID comparable](entity string, idOf func(T) ID) *Memory[T, ID] {
+	return &Memory[T, ID]{entity: entity, idOf: idOf, entities: map[ID]T{}}
+}
+
+// Find returns the entity with the given identifier, or an
+// `exception.NotFound` when there is none.
+func (m *Memory[T, ID]) Find(_ context.Context, id ID) (T, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.entities[id]
+	if !ok {
+		return entity, NewNotFound(m.entity, id)
+	}
+	return entity, nil
+}
+
+// Save inserts or updates an entity.
+func (m *Memory[T, ID]) Save(_ context.Context, entity T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entities[m.idOf(entity)] = entity
+	return nil
+}
+
+// Create inserts an entity, returning an `exception.Conflict` with the
+// "duplicate" reason when its identifier is already stored.
+func (m *Memory[T, ID]) Create(_ context.Context, entity T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.idOf(entity)
+	if _, exists := m.entities[id]; exists {
+		return NewConflict(m.entity, id, "duplicate")
+	}
+	m.entities[id] = entity
+	return nil
+}
+
+// Delete removes the entity with the given identifier, or returns an
+// `exception.NotFound` when there is none.
+func (m *Memory[T, ID]) Delete(_ context.Context, id ID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entities[id]; !ok {
+		return NewNotFound(m.entity, id)
+	}
+	delete(m.entities, id)
+	return nil
+}
+
+// Len returns the number of stored entities.
+func (m *Memory[T, ID]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entities)
+}
@@ -0,0 +1,99 @@
+// Package repository defines the persistence contract shared by the domain
+// and the persistence adapters: a generic Repository interface, the
+// exceptions reporting missing entities and conflicting writes, and an
+// in-memory implementation for tests and prototypes.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/pagination"
+	"github.com/osirisgate/golang-core/query"
+)
+
+// Repository stores and retrieves entities of type T identified by an ID.
+// Implementations report a missing entity with an `exception.NotFound` and a
+// write clashing with the stored state (duplicate key, stale version, ...)
+// with an `exception.Conflict`, e.g. built with NewNotFound and NewConflict.
+type Repository[T any, ID comparable] interface {
+	// Find returns the entity with the given identifier, or an
+	// `exception.NotFound` when there is none.
+	Find(ctx context.Context, id ID) (T, error)
+
+	// Save inserts or updates an entity, returning an `exception.Conflict`
+	// when the write clashes with the stored state.
+	Save(ctx context.Context, entity T) error
+
+	// Delete removes the entity with the given identifier, or returns an
+	// `exception.NotFound` when there is none.
+	Delete(ctx context.Context, id ID) error
+}
+
+// Lister is implemented by repositories able to list their entities.
+type Lister[T any] interface {
+	// List returns the entities matching the criteria on the requested page,
+	// along with the page updated with the total number of matching entities.
+	List(ctx context.Context, criteria query.Criteria, page pagination.Offset) ([]T, pagination.Offset, error)
+}
+
+// NewNotFound creates the exception reporting a missing entity.
+//
+// Parameters:
+//
+//	entity: The name of the entity type (e.g., "user").
+//	id: The identifier that matched nothing.
+//
+// Returns:
+//
+//	A pointer to an `exception.NotFound` with the entity and identifier in
+//	its details.
+func NewNotFound(entity string, id interface{}) *exception.NotFound {
+	return exception.NewNotFound(map[string]interface{}{
+		"message": fmt.Sprintf("The %s %v was not found.", entity, id),
+		"details": map[string]interface{}{
+			"entity": entity,
+			"id":     id,
+			"error":  "not_found",
+		},
+	})
+}
+
+// NewConflict creates the exception reporting a write clashing with the
+// stored state of an entity.
+//
+// Parameters:
+//
+//	entity: The name of the entity type (e.g., "user").
+//	id: The identifier of the entity.
+//	reason: A machine readable reason (e.g., "duplicate", "stale_version").
+//
+// Returns:
+//
+//	A pointer to an `exception.Conflict` with the entity, identifier and
+//	reason in its details.
+func NewConflict(entity string, id interface{}, reason string) *exception.Conflict {
+	return exception.NewConflict(map[string]interface{}{
+		"message": fmt.Sprintf("The %s %v conflicts with its stored state.", entity, id),
+		"details": map[string]interface{}{
+			"entity": entity,
+			"id":     id,
+			"reason": reason,
+			"error":  "conflict",
+		},
+	})
+}
+
+// IsNotFound reports whether the chain of err contains an `exception.NotFound`.
+func IsNotFound(err error) bool {
+	var notFound *exception.NotFound
+	return errors.As(err, &notFound)
+}
+
+// IsConflict reports whether the chain of err contains an `exception.Conflict`.
+func IsConflict(err error) bool {
+	var conflict *exception.Conflict
+	return errors.As(err, &conflict)
+}
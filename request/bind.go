@@ -0,0 +1,188 @@
+// Package request provides helpers reading incoming HTTP requests. Decoding
+// failures are reported as `exception.RequestParseBody` errors detailing
+// where the body is malformed, so that clients can fix their request.
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/negotiation"
+)
+
+// DefaultMaxBytes is the body size limit used when BindJSON is given a
+// non-positive limit (1 MiB).
+const DefaultMaxBytes int64 = 1 << 20
+
+// bindOptions holds the settings of BindJSON.
+type bindOptions struct {
+	strict       bool     // Whether unknown fields are rejected.
+	contentTypes []string // The accepted media types of the body; any when empty.
+}
+
+// Option configures BindJSON.
+type Option func(*bindOptions)
+
+// Strict rejects bodies containing fields that do not exist in the
+// destination, reporting the first unknown field.
+func Strict() Option {
+	return func(o *bindOptions) { o.strict = true }
+}
+
+// RequireContentType rejects requests whose Content-Type header matches none
+// of the given media types or ranges (see `negotiation.RequireContentType`),
+// before reading their body, e.g.
+// `RequireContentType(negotiation.ApplicationJSON)`.
+func RequireContentType(types ...string) Option {
+	return func(o *bindOptions) { o.contentTypes = append(o.contentTypes, types...) }
+}
+
+// BindJSON decodes the JSON body of a request into dst. The body must hold a
+// single JSON value.
+//
+// Parameters:
+//
+//	r: The request whose body is decoded.
+//	dst: A non-nil pointer to the destination value.
+//	maxBytes: The maximum size of the body. Non-positive values fall back to
+//	          DefaultMaxBytes.
+//	opts: Options such as Strict or RequireContentType.
+//
+// Returns:
+//
+//	Nil on success, or an `exception.RequestParseBody` whose details "error"
+//	is one of "empty_body", "body_too_large" (with the "limit"),
+//	"malformed_json" (with the byte "offset", "line" and "column"),
+//	"invalid_type" (with the "field", the "expected" type, the "actual" JSON
+//	value kind and the "offset"), "unknown_field" (with the "field") or
+//	"trailing_data". Exceptions returned by the UnmarshalJSON method of a
+//	destination field (e.g., an invalid value object) are returned as they
+//	are. An `exception.UnsupportedMediaType` is returned when the
+//	Content-Type is not one of RequireContentType, and an `exception.Logic`
+//	when dst is not a non-nil pointer.
+func BindJSON(r *http.Request, dst interface{}, maxBytes int64, opts ...Option) error {
+	options := bindOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if len(options.contentTypes) > 0 {
+		if _, err := negotiation.RequireContentType(r.Header.Get("Content-Type"), options.contentTypes); err != nil {
+			return err
+		}
+	}
+
+	if r.Body == nil {
+		return parseError("The request body is empty.", map[string]interface{}{"error": "empty_body"})
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return parseError(fmt.Sprintf("The request body exceeds %d bytes.", tooLarge.Limit), map[string]interface{}{
+				"limit": tooLarge.Limit,
+				"error": "body_too_large",
+			})
+		}
+		return parseError("The request body could not be read.", map[string]interface{}{"error": "unreadable_body"})
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return parseError("The request body is empty.", map[string]interface{}{"error": "empty_body"})
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if options.strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err, body)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		offset := decoder.InputOffset()
+		return parseError("The request body must hold a single JSON value.", withPosition(map[string]interface{}{
+			"error": "trailing_data",
+		}, body, offset))
+	}
+	return nil
+}
+
+// decodeError converts a decoding error into an exception.
+func decodeError(err error, body []byte) error {
+	var (
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		invalidErr   *json.InvalidUnmarshalError
+		coreErr      exception.CoreInterface
+		unknownField = `json: unknown field "`
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return parseError("The request body is not valid JSON.", withPosition(map[string]interface{}{
+			"error": "malformed_json",
+		}, body, syntaxErr.Offset))
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return parseError("The request body is not valid JSON.", withPosition(map[string]interface{}{
+			"error": "malformed_json",
+		}, body, int64(len(body))))
+
+	case errors.As(err, &typeErr):
+		return parseError(fmt.Sprintf("The field %q must be of type %s.", typeErr.Field, typeErr.Type), map[string]interface{}{
+			"field":    typeErr.Field,
+			"expected": typeErr.Type.String(),
+			"actual":   typeErr.Value,
+			"offset":   typeErr.Offset,
+			"error":    "invalid_type",
+		})
+
+	case strings.HasPrefix(err.Error(), unknownField):
+		field := strings.TrimSuffix(strings.TrimPrefix(err.Error(), unknownField), `"`)
+		return parseError(fmt.Sprintf("The field %q is not allowed.", field), map[string]interface{}{
+			"field": field,
+			"error": "unknown_field",
+		})
+
+	case errors.As(err, &invalidErr):
+		return exception.NewLogic(map[string]interface{}{
+			"message": "The destination of BindJSON must be a non-nil pointer.",
+			"details": map[string]interface{}{"type": fmt.Sprint(invalidErr.Type), "error": "invalid_destination"},
+		})
+
+	case errors.As(err, &coreErr):
+		return err
+
+	default:
+		return parseError("The request body contains an invalid value.", map[string]interface{}{"error": "invalid_value"})
+	}
+}
+
+// withPosition adds the byte offset, and the matching 1-based line and
+// column, to the details of an error.
+func withPosition(details map[string]interface{}, body []byte, offset int64) map[string]interface{} {
+	offset = min(max(offset, 0), int64(len(body)))
+	before := body[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+
+	details["offset"] = offset
+	details["line"] = line
+	details["column"] = column
+	return details
+}
+
+// parseError builds a RequestParseBody exception.
+func parseError(message string, details map[string]interface{}) error {
+	return exception.NewRequestParseBody(map[string]interface{}{
+		"message": message,
+		"details": details,
+	})
+}
@@ -0,0 +1,202 @@
+// Package resilience provides the fallback and hedging strategies of latency
+// sensitive read paths. Fallback serves the result of a secondary path when
+// the primary one fails, and Hedge fires a backup attempt when the primary
+// one is slower than a latency threshold, the first success winning. The
+// path that served each result is reported to the OnServed hooks, and the
+// `exception.Aggregate` returned when every path failed lists the attempted
+// paths in its details.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Paths reported in the outcomes and in the exception details.
+const (
+	PathPrimary   = "primary"
+	PathSecondary = "secondary"
+	PathHedge     = "hedge"
+)
+
+// Func is an operation producing a value.
+type Func[T any] func(ctx context.Context) (T, error)
+
+// Outcome describes the path that served the result of an operation.
+type Outcome struct {
+	Path string // The path that served the result (e.g., PathSecondary).
+	Err  error  // The error of the primary path when another path served, nil otherwise.
+}
+
+// config holds the settings of a strategy.
+type config struct {
+	fallbackIf func(error) bool
+	onServed   []func(Outcome)
+}
+
+// Option customizes Fallback and Hedge.
+type Option func(*config)
+
+// FallbackIf sets the classifier deciding whether a failure of the primary
+// path falls back on the other one. By default every error does, except the
+// cancellation of the context.
+func FallbackIf(classifier func(error) bool) Option {
+	return func(c *config) {
+		c.fallbackIf = classifier
+	}
+}
+
+// OnServed registers a hook called with the outcome of each successful
+// operation, e.g. to count the results served by the secondary path.
+func OnServed(hook func(Outcome)) Option {
+	return func(c *config) {
+		c.onServed = append(c.onServed, hook)
+	}
+}
+
+// newConfig applies the options over the defaults.
+func newConfig(opts []Option) config {
+	c := config{fallbackIf: func(err error) bool { return !errors.Is(err, context.Canceled) }}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// served reports the outcome of a successful operation.
+func (c config) served(path string, err error) {
+	for _, hook := range c.onServed {
+		hook(Outcome{Path: path, Err: err})
+	}
+}
+
+// Fallback combines two paths: the secondary one is called when the primary
+// one fails with an error accepted by the FallbackIf classifier.
+//
+// Parameters:
+//
+//	primary: The preferred path (e.g., the live service).
+//	secondary: The path serving a degraded result (e.g., a cache or a static
+//	           default).
+//	opts: Options customizing the classification and the hooks.
+//
+// Returns:
+//
+//	The combined operation. It returns the error of the primary path when it
+//	does not fall back, and an `exception.Aggregate` of the errors of both
+//	paths when they both fail.
+func Fallback[T any](primary Func[T], secondary Func[T], opts ...Option) Func[T] {
+	c := newConfig(opts)
+	return func(ctx context.Context) (T, error) {
+		value, err := primary(ctx)
+		if err == nil {
+			c.served(PathPrimary, nil)
+			return value, nil
+		}
+		if !c.fallbackIf(err) {
+			return value, err
+		}
+
+		value, secondaryErr := secondary(ctx)
+		if secondaryErr == nil {
+			c.served(PathSecondary, err)
+			return value, nil
+		}
+		return value, exhausted([]string{PathPrimary, PathSecondary}, err, secondaryErr)
+	}
+}
+
+// Hedge combines two attempts of an operation: the backup attempt is fired
+// when the primary one did not complete after threshold, or as soon as it
+// fails with an error accepted by the FallbackIf classifier. The first
+// success wins and the context of the other attempt is canceled.
+//
+// Parameters:
+//
+//	primary: The first attempt.
+//	backup: The backup attempt, typically primary itself against another
+//	        replica.
+//	threshold: The latency after which the backup attempt is fired, e.g. the
+//	           95th percentile of the latency of primary.
+//	opts: Options customizing the classification and the hooks.
+//
+// Returns:
+//
+//	The combined operation. It returns the error of the primary attempt when
+//	it does not fall back, and an `exception.Aggregate` of the errors of both
+//	attempts when they both fail.
+func Hedge[T any](primary Func[T], backup Func[T], threshold time.Duration, opts ...Option) Func[T] {
+	c := newConfig(opts)
+	return func(ctx context.Context) (T, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// The channel is buffered for both attempts, so that the loser never
+		// blocks once the operation returned.
+		type result struct {
+			path  string
+			value T
+			err   error
+		}
+		results := make(chan result, 2)
+		attempt := func(path string, fn Func[T]) {
+			go func() {
+				value, err := fn(ctx)
+				results <- result{path: path, value: value, err: err}
+			}()
+		}
+
+		attempt(PathPrimary, primary)
+		timer := time.NewTimer(threshold)
+		defer timer.Stop()
+
+		var primaryErr, hedgeErr error
+		pending, hedged := 1, false
+		for {
+			select {
+			case <-timer.C:
+				if !hedged {
+					hedged, pending = true, pending+1
+					attempt(PathHedge, backup)
+				}
+
+			case r := <-results:
+				pending--
+				if r.err == nil {
+					c.served(r.path, primaryErr)
+					return r.value, nil
+				}
+
+				if r.path == PathHedge {
+					hedgeErr = r.err
+				} else {
+					primaryErr = r.err
+					if !hedged {
+						if !c.fallbackIf(r.err) {
+							return r.value, r.err
+						}
+						hedged, pending = true, pending+1
+						attempt(PathHedge, backup)
+					}
+				}
+				if pending == 0 {
+					return r.value, exhausted([]string{PathPrimary, PathHedge}, primaryErr, hedgeErr)
+				}
+			}
+		}
+	}
+}
+
+// exhausted builds the exception reporting that every path failed.
+func exhausted(paths []string, errs ...error) error {
+	return exception.NewAggregate(map[string]interface{}{
+		"message": "Every path of the operation failed.",
+		"details": map[string]interface{}{
+			"paths": paths,
+			"error": "all_paths_failed",
+		},
+	}, errs...)
+}
@@ -0,0 +1,228 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines the result of batch operations and its Multi-Status
+// envelope.
+package response
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.MultiStatusCode` constant reported for mixed outcomes.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+)
+
+// BatchItem is the outcome of one item of a batch operation.
+type BatchItem struct {
+	ID         interface{}       // The identifier of the item chosen by the caller (e.g., its index or key).
+	StatusCode status.StatusCode // The status of the item.
+	Data       interface{}       // The payload of a successful item.
+	Err        error             // The failure of the item, nil when it succeeded.
+}
+
+// BatchResult records the outcome of every item of a batch operation (e.g.,
+// a bulk import) and reports them in one envelope, each item with its own
+// status. It is safe for concurrent use, so that items processed in parallel
+// can record their outcome directly.
+type BatchResult struct {
+	success status.StatusCode
+
+	mu    sync.Mutex
+	items []BatchItem
+}
+
+// NewBatchResult creates an empty BatchResult.
+//
+// Parameters:
+//
+//	success: The status of the successful items (e.g., `status.Created` for
+//	         an import). Zero uses `status.OK`.
+//
+// Returns:
+//
+//	A pointer to a new BatchResult.
+func NewBatchResult(success status.StatusCode) *BatchResult {
+	if success == 0 {
+		success = status.OK
+	}
+	return &BatchResult{success: success}
+}
+
+// Succeed records a successful item.
+func (b *BatchResult) Succeed(id interface{}, data interface{}) {
+	b.add(BatchItem{ID: id, StatusCode: b.success, Data: data})
+}
+
+// Fail records a failed item. Its status is the one of the exception carried
+// by err; errors that are not exceptions are recorded as
+// `status.InternalServerError`. A nil err records a successful item without
+// payload.
+func (b *BatchResult) Fail(id interface{}, err error) {
+	if err == nil {
+		b.Succeed(id, nil)
+		return
+	}
+	b.add(BatchItem{ID: id, StatusCode: status.StatusCode(toException(err).GetStatusCode()), Err: err})
+}
+
+// Record records an item, failed when err is not nil and successful otherwise.
+func (b *BatchResult) Record(id interface{}, data interface{}, err error) {
+	if err == nil {
+		b.Succeed(id, data)
+		return
+	}
+	b.Fail(id, err)
+}
+
+// add appends an item.
+func (b *BatchResult) add(item BatchItem) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.items = append(b.items, item)
+}
+
+// Items returns a copy of the recorded items, in recording order.
+func (b *BatchResult) Items() []BatchItem {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BatchItem(nil), b.items...)
+}
+
+// Failed returns the number of failed items.
+func (b *BatchResult) Failed() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failed := 0
+	for _, item := range b.items {
+		if item.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// StatusCode returns the overall status of the batch: the success status when
+// every item succeeded (or there is none), the shared status of the items
+// when they all failed with the same one, and `status.MultiStatusCode`
+// otherwise.
+func (b *BatchResult) StatusCode() status.StatusCode {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.items) == 0 {
+		return b.success
+	}
+	overall := b.items[0].StatusCode
+	for _, item := range b.items[1:] {
+		if item.StatusCode != overall {
+			return status.MultiStatusCode
+		}
+	}
+	return overall
+}
+
+// Batch builds the envelope of a batch result. Its "status" is
+// `status.ERROR` when every item failed and `status.SUCCESS` otherwise, its
+// "data" is the array of the items, and its "meta" block reports the number
+// of items, of successes and of failures. Each item carries its "id" and
+// "status_code" ("statusCode" under `exception.CamelCase`); a successful item carries its "data" under the "success"
+// status, and a failed item the fields of its exception envelope.
+//
+// Parameters:
+//
+//	result: The batch result to format.
+//	meta: Optional maps of metadata merged into the "meta" block.
+//
+// Returns:
+//
+//	A map representing the batch envelope.
+func Batch(result *BatchResult, meta ...map[string]interface{}) map[string]interface{} {
+	return batch(result, func(exception.CoreInterface) {}, meta...)
+}
+
+// BatchContext behaves like `Batch`, additionally placing the identifiers of
+// ctx in the "meta" block and translating the messages of the failed items
+// into the locale carried by ctx (see `i18n.Localize`).
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	result: The batch result to format.
+//	meta: Optional maps of metadata merged into the "meta" block.
+//
+// Returns:
+//
+//	A map representing the batch envelope.
+func BatchContext(ctx context.Context, result *BatchResult, meta ...map[string]interface{}) map[string]interface{} {
+	localize := func(coreErr exception.CoreInterface) { _ = i18n.Localize(ctx, coreErr) }
+	return batch(result, localize, append([]map[string]interface{}{idsMeta(ctx)}, meta...)...)
+}
+
+// batch builds the envelope of a batch result, preparing the exception of
+// each failed item before formatting it.
+func batch(result *BatchResult, prepare func(exception.CoreInterface), meta ...map[string]interface{}) map[string]interface{} {
+	items := result.Items()
+	statusCodeKey := exception.CurrentKeyNaming().Key("status_code")
+	data := make([]map[string]interface{}, len(items))
+	failed := 0
+	for i, item := range items {
+		var formatted map[string]interface{}
+		if item.Err != nil {
+			failed++
+			coreErr := toException(item.Err)
+			prepare(coreErr)
+			formatted = coreErr.Format()
+		} else {
+			formatted = map[string]interface{}{"status": status.SUCCESS, "data": item.Data}
+		}
+		formatted["id"] = item.ID
+		formatted[statusCodeKey] = item.StatusCode.GetValue()
+		data[i] = formatted
+	}
+
+	envelope := Success(data, append([]map[string]interface{}{{
+		"total":     len(items),
+		"succeeded": len(items) - failed,
+		"failed":    failed,
+	}}, meta...)...)
+	if len(items) > 0 && failed == len(items) {
+		envelope["status"] = status.ERROR
+	}
+	return envelope
+}
+
+// WriteBatch writes the envelope built by `Batch` with the overall status of
+// the result (see `BatchResult.StatusCode`).
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	result: The batch result to write.
+//	meta: Optional maps of metadata merged into the "meta" block.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteBatch(w http.ResponseWriter, result *BatchResult, meta ...map[string]interface{}) error {
+	return WriteJSON(w, result.StatusCode(), Batch(result, meta...))
+}
+
+// WriteBatchContext writes the envelope built by `BatchContext` with the
+// overall status of the result.
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	w: The `http.ResponseWriter` to write to.
+//	result: The batch result to write.
+//	meta: Optional maps of metadata merged into the "meta" block.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteBatchContext(ctx context.Context, w http.ResponseWriter, result *BatchResult, meta ...map[string]interface{}) error {
+	return WriteJSON(w, result.StatusCode(), BatchContext(ctx, result, meta...))
+}
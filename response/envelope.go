@@ -0,0 +1,135 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines the versions of the error envelopes, letting services
+// migrate from the flattened v1 envelope to the v2 one gradually.
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type used to set the HTTP response status.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// EnvelopeVersion is the version of the error envelopes written by the
+// response writers.
+type EnvelopeVersion int
+
+// Versions of the error envelopes.
+const (
+	EnvelopeV1 EnvelopeVersion = 1 // The flattened envelope of `exception.CoreInterface.Format`; the default.
+	EnvelopeV2 EnvelopeVersion = 2 // The nested envelope of `exception.FormatV2`, with a "meta" block.
+)
+
+// envelopeVersion holds the version set by SetEnvelopeVersion; zero is
+// EnvelopeV1.
+var envelopeVersion atomic.Int32
+
+// SetEnvelopeVersion sets the version of the error envelopes of the requests
+// whose context carries none (see WithEnvelopeVersion), once at startup.
+// Services migrating to EnvelopeV2 typically keep EnvelopeV1 here and opt
+// the new clients in per request, e.g. by API version (see
+// `versioning.Config.Envelopes`).
+//
+// Parameters:
+//
+//	version: The version; EnvelopeV1 is the default, and replaces the values
+//	         that are not versions.
+//
+// Returns:
+//
+//	A function restoring the previous version.
+func SetEnvelopeVersion(version EnvelopeVersion) (restore func()) {
+	if version != EnvelopeV2 {
+		version = EnvelopeV1
+	}
+	previous := envelopeVersion.Swap(int32(version))
+	return func() {
+		envelopeVersion.Store(previous)
+	}
+}
+
+// envelopeVersionKey is the context key of the envelope version of a
+// request.
+type envelopeVersionKey struct{}
+
+// WithEnvelopeVersion returns a copy of ctx carrying the version of the
+// error envelopes of a request, overriding SetEnvelopeVersion.
+//
+// Parameters:
+//
+//	ctx: The request context.
+//	version: The version.
+//
+// Returns:
+//
+//	The derived context.
+func WithEnvelopeVersion(ctx context.Context, version EnvelopeVersion) context.Context {
+	return context.WithValue(ctx, envelopeVersionKey{}, version)
+}
+
+// EnvelopeVersionOf returns the version of the error envelopes of the
+// request carried by ctx: the one of WithEnvelopeVersion, or the one of
+// SetEnvelopeVersion.
+func EnvelopeVersionOf(ctx context.Context) EnvelopeVersion {
+	var version EnvelopeVersion
+	ok := false
+	if ctx != nil {
+		version, ok = ctx.Value(envelopeVersionKey{}).(EnvelopeVersion)
+	}
+	if !ok {
+		version = EnvelopeVersion(envelopeVersion.Load())
+	}
+	if version != EnvelopeV2 {
+		return EnvelopeV1
+	}
+	return EnvelopeV2
+}
+
+// formatEnvelope returns the envelope of an exception in the version of the
+// request carried by ctx.
+func formatEnvelope(ctx context.Context, coreErr exception.CoreInterface) map[string]interface{} {
+	if EnvelopeVersionOf(ctx) == EnvelopeV2 {
+		return exception.FormatV2(coreErr, time.Now())
+	}
+	return coreErr.Format()
+}
+
+// writeEnvelope writes the envelope of an exception in the version of the
+// request carried by ctx, with its status code and headers.
+func writeEnvelope(ctx context.Context, w http.ResponseWriter, coreErr exception.CoreInterface) error {
+	if EnvelopeVersionOf(ctx) != EnvelopeV2 {
+		return writeException(w, coreErr)
+	}
+	setHeaders(w, coreErr)
+	return WriteJSON(w, status.StatusCode(coreErr.GetStatusCode()), exception.FormatV2(coreErr, time.Now()))
+}
+
+// AppendEnvelope appends the JSON encoding of the envelope of an exception,
+// in the version of the request carried by ctx (see EnvelopeVersionOf), to
+// dst, for the adapters writing the responses themselves.
+//
+// Parameters:
+//
+//	ctx: The request context.
+//	dst: The buffer to append to; it may be nil.
+//	coreErr: The exception, already prepared for the client.
+//
+// Returns:
+//
+//	The extended buffer, or dst unchanged and the encoding error.
+func AppendEnvelope(ctx context.Context, dst []byte, coreErr exception.CoreInterface) ([]byte, error) {
+	if appender, ok := coreErr.(jsonAppender); ok && EnvelopeVersionOf(ctx) != EnvelopeV2 {
+		return appender.AppendJSON(dst)
+	}
+	body, err := json.Marshal(formatEnvelope(ctx, coreErr))
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, body...), nil
+}
@@ -0,0 +1,186 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines the rendering of exceptions as HTML error pages, for the
+// routes answering browsers.
+package response
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+	"sync"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type selecting the templates.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// ContentTypeHTML is the Content-Type header value used for HTML error pages.
+const ContentTypeHTML = "text/html; charset=utf-8"
+
+// ErrorPage is the data of the templates of the HTML error pages.
+type ErrorPage struct {
+	StatusCode int    // The status code of the exception (e.g., 404).
+	Title      string // The reason phrase of the status code (e.g., "Not Found"; see `exception.StatusText`).
+	Message    string // The message of the exception, localized.
+	Hint       string // The remediation hint of the exception (see `exception.HintKey`); may be empty.
+	Code       string // The details "error" code of the exception; may be empty.
+	RequestID  string // The request ID of the context, to quote to the support; may be empty.
+}
+
+// DefaultHTMLTemplate is the template of the error pages of an HTMLRenderer
+// without a template for the status code of the exception.
+var DefaultHTMLTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.StatusCode}} {{.Title}}</title>
+</head>
+<body>
+<main>
+<h1>{{.StatusCode}} {{.Title}}</h1>
+<p>{{.Message}}</p>
+{{- if .Hint}}
+<p>{{.Hint}}</p>
+{{- end}}
+{{- if .RequestID}}
+<p><small>Request ID: <code>{{.RequestID}}</code></small></p>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// HTMLRenderer renders exceptions as HTML error pages, from a template per
+// status code or the default template. It is safe for concurrent use.
+type HTMLRenderer struct {
+	mu        sync.RWMutex
+	fallback  *template.Template
+	templates map[status.StatusCode]*template.Template
+}
+
+// HTMLOption configures an HTMLRenderer.
+type HTMLOption func(*HTMLRenderer)
+
+// WithHTMLTemplate replaces the default template of the renderer.
+func WithHTMLTemplate(tmpl *template.Template) HTMLOption {
+	return func(h *HTMLRenderer) {
+		h.fallback = tmpl
+	}
+}
+
+// WithStatusTemplate sets the template of the pages of a status code (e.g.,
+// a dedicated 404 page).
+func WithStatusTemplate(code status.StatusCode, tmpl *template.Template) HTMLOption {
+	return func(h *HTMLRenderer) {
+		h.templates[code] = tmpl
+	}
+}
+
+// NewHTMLRenderer creates an HTMLRenderer.
+//
+// Parameters:
+//
+//	opts: The options of the renderer; without any, every page is rendered
+//	      with DefaultHTMLTemplate.
+//
+// Returns:
+//
+//	A pointer to a new HTMLRenderer.
+func NewHTMLRenderer(opts ...HTMLOption) *HTMLRenderer {
+	h := &HTMLRenderer{fallback: DefaultHTMLTemplate, templates: map[status.StatusCode]*template.Template{}}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// SetTemplate sets the template of the pages of a status code after the
+// creation of the renderer, e.g. once the templates of the application are
+// parsed.
+func (h *HTMLRenderer) SetTemplate(code status.StatusCode, tmpl *template.Template) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.templates[code] = tmpl
+}
+
+// Render renders the error page of an error. Like `WriteErrorContext`, it
+// annotates the exception with the identifiers of ctx, localizes its message
+// and records it on the current span first. Errors that are not exceptions
+// are rendered as internal server errors, without their message.
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	err: The error to render; it must not be nil.
+//
+// Returns:
+//
+//	The status code of the page, the page, and an error if its template
+//	failed.
+func (h *HTMLRenderer) Render(ctx context.Context, err error) (status.StatusCode, []byte, error) {
+	coreErr := prepare(ctx, err)
+	code := status.StatusCode(coreErr.GetStatusCode())
+	page := ErrorPage{
+		StatusCode: code.GetValue(),
+		Title:      exception.StatusText(coreErr),
+		Message:    coreErr.Error(),
+		Hint:       exception.Hint(coreErr),
+		Code:       coreErr.GetDetailsMessage(),
+		RequestID:  ctxutil.RequestID(ctx),
+	}
+
+	h.mu.RLock()
+	tmpl, ok := h.templates[code]
+	if !ok {
+		tmpl = h.fallback
+	}
+	h.mu.RUnlock()
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return code, nil, err
+	}
+	return code, buf.Bytes(), nil
+}
+
+// WriteError writes the error page of an error (see Render), with the
+// headers implied by its exception.
+//
+// Parameters:
+//
+//	ctx: The request context.
+//	w: The `http.ResponseWriter` to write to.
+//	err: The error to write. A nil error, or nil exception (see
+//	`exception.IsNil`), writes nothing.
+//
+// Returns:
+//
+//	An error if the page could not be rendered or written, nil otherwise.
+//	Nothing is written when the template fails.
+func (h *HTMLRenderer) WriteError(ctx context.Context, w http.ResponseWriter, err error) error {
+	if exception.IsNil(err) {
+		return nil
+	}
+	code, page, renderErr := h.Render(ctx, err)
+	if renderErr != nil {
+		return renderErr
+	}
+	setHeaders(w, toException(err))
+	w.Header().Set("Content-Type", ContentTypeHTML)
+	w.WriteHeader(code.GetValue())
+	_, writeErr := w.Write(page)
+	return writeErr
+}
+
+// defaultHTMLRenderer is the renderer of WriteHTMLError.
+var defaultHTMLRenderer = NewHTMLRenderer()
+
+// WriteHTMLError writes the error page of an error with the default
+// templates (see `HTMLRenderer.WriteError`).
+func WriteHTMLError(ctx context.Context, w http.ResponseWriter, err error) error {
+	return defaultHTMLRenderer.WriteError(ctx, w, err)
+}
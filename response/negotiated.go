@@ -0,0 +1,104 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines the writing of exceptions in the format accepted by the
+// client.
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/negotiation"
+)
+
+// Content-Type header values of the negotiated error responses.
+const (
+	ContentTypeProblem = negotiation.ApplicationProblemJSON // ContentTypeProblem is used for RFC 9457 problem details.
+	ContentTypeXML     = "application/xml; charset=utf-8"   // ContentTypeXML is used for XML envelopes.
+)
+
+// negotiatedTypes lists the media types of the error responses, by
+// preference: JSON first, so that it wins the ties (e.g., "*/*").
+var negotiatedTypes = []string{negotiation.ApplicationJSON, ContentTypeProblem, negotiation.ApplicationXML, "text/xml", negotiation.TextHTML}
+
+// problemMembers lists the standard members of RFC 9457 problem details,
+// which the entries of the exceptions do not override.
+var problemMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// WriteNegotiated writes an error in the format selected by the Accept
+// header of the request (see `negotiation.ContentType`): the JSON envelope
+// of `WriteErrorContext` ("application/json", and the fallback when nothing
+// else is accepted), RFC 9457 problem details ("application/problem+json"),
+// the XML envelope of `exception.FormatXML` ("application/xml",
+// "text/xml") or the HTML page of `WriteHTMLError` ("text/html"). In every
+// case the exception is annotated, localized, recorded on the current
+// span first, the headers it implies are set, and the response varies on
+// Accept.
+//
+// The problem details have the description of the status code as "title",
+// the status code as "status", the message as "detail" and the path of the
+// request as "instance"; the entries of `Errors`, such as the remediation
+// "hint" (see `exception.HintKey`), are extension members.
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	r: The request, whose context carries the identifiers and the locale.
+//	err: The error to write. A nil error, or nil exception (see
+//	`exception.IsNil`), writes nothing.
+//
+// Returns:
+//
+//	An error if the response could not be encoded or written, nil otherwise.
+func WriteNegotiated(w http.ResponseWriter, r *http.Request, err error) error {
+	if exception.IsNil(err) {
+		return nil
+	}
+	w.Header().Add("Vary", "Accept")
+
+	ctx := r.Context()
+	contentType, negotiationErr := negotiation.ContentType(r.Header.Get("Accept"), negotiatedTypes)
+	switch {
+	case negotiationErr != nil || contentType == negotiation.ApplicationJSON:
+		return WriteErrorContext(ctx, w, err)
+	case contentType == negotiation.TextHTML:
+		return WriteHTMLError(ctx, w, err)
+	}
+
+	coreErr := prepare(ctx, err)
+	var body []byte
+	var encodeErr error
+	if contentType == ContentTypeProblem {
+		body, encodeErr = json.Marshal(problem(coreErr, r.URL.Path))
+	} else {
+		contentType = ContentTypeXML
+		body, encodeErr = exception.FormatXML(coreErr)
+	}
+	if encodeErr != nil {
+		return encodeErr
+	}
+	setHeaders(w, coreErr)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(coreErr.GetStatusCode())
+	_, err = w.Write(body)
+	return err
+}
+
+// problem builds the RFC 9457 problem details of an exception.
+func problem(coreErr exception.CoreInterface, instance string) map[string]interface{} {
+	details := map[string]interface{}{
+		"type":   "about:blank",
+		"title":  exception.StatusText(coreErr),
+		"status": coreErr.GetStatusCode(),
+		"detail": coreErr.Error(),
+	}
+	if instance != "" {
+		details["instance"] = instance
+	}
+	for key, value := range coreErr.GetErrors() {
+		if !problemMembers[key] {
+			details[key] = value
+		}
+	}
+	return details
+}
@@ -0,0 +1,163 @@
+// Package response provides the success half of the standardized API contract.
+// It builds response envelopes that mirror the structure produced by
+// `exception.CoreInterface.Format()`, so that successful and failed operations
+// share a single, predictable shape on the wire.
+package response
+
+import (
+	"context"
+	"errors"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/deprecation"
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.SUCCESS` constant used in the envelope.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/tracing"
+)
+
+// Success builds a success envelope around the provided data. The resulting
+// map always contains a "status" key set to `status.SUCCESS` and a "data" key
+// holding the payload. When one or more meta maps are supplied, they are merged
+// (later maps overriding earlier ones) into a single "meta" block.
+//
+// Parameters:
+//
+//	data: The payload to return to the client. It may be any JSON-serializable value.
+//	meta: Optional maps of metadata (pagination, request identifiers, etc.)
+//	      to be merged into the "meta" block of the envelope.
+//
+// Returns:
+//
+//	A map representing the success envelope, e.g.
+//	{"status": "success", "data": ..., "meta": ...}.
+func Success(data interface{}, meta ...map[string]interface{}) map[string]interface{} {
+	envelope := map[string]interface{}{
+		"status": status.SUCCESS,
+		"data":   data,
+	}
+
+	// Only include the "meta" block when there is something to report.
+	if merged := mergeMeta(meta...); len(merged) > 0 {
+		envelope["meta"] = merged
+	}
+
+	return envelope
+}
+
+// Error builds an error envelope from any Go error. If the error (or any error
+// in its chain) implements `exception.CoreInterface`, its `Format()` output is
+// returned as-is. Any other error is treated as an unclassified server-side
+// failure and formatted as a generic `exception.Error`, without exposing the
+// original error message to the client. Under `EnvelopeV2` (see
+// SetEnvelopeVersion), the envelope is that of `exception.FormatV2`.
+//
+// Parameters:
+//
+//	err: The error to convert into an envelope. A nil error yields a nil map.
+//
+// Returns:
+//
+//	A map representing the error envelope, identical in shape to
+//	`exception.CoreInterface.Format()`.
+func Error(err error) map[string]interface{} {
+	if exception.IsNil(err) {
+		return nil
+	}
+
+	return formatEnvelope(context.Background(), toException(err))
+}
+
+// SuccessContext behaves like `Success`, additionally placing the request,
+// correlation and tenant IDs carried by ctx in the "meta" block, keyed as set
+// by `exception.SetKeyNaming` (e.g., "requestId"), along with
+// the deprecation notice of the request when its `deprecation.Registry`
+// emits one, and the warnings added to the request (see AddWarning).
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	data: The payload to return to the client.
+//	meta: Optional maps of metadata merged into the "meta" block. They take
+//	      precedence over the values read from ctx.
+//
+// Returns:
+//
+//	A map representing the success envelope.
+func SuccessContext(ctx context.Context, data interface{}, meta ...map[string]interface{}) map[string]interface{} {
+	return Success(data, append([]map[string]interface{}{idsMeta(ctx), deprecation.Meta(ctx), WarningsMeta(Warnings(ctx)...)}, meta...)...)
+}
+
+// idsMeta returns the identifiers carried by ctx (see `ctxutil.Fields`) for
+// the "meta" block, keyed as set by `exception.SetKeyNaming`.
+func idsMeta(ctx context.Context) map[string]interface{} {
+	fields := ctxutil.Fields(ctx)
+	naming := exception.CurrentKeyNaming()
+	if naming == exception.SnakeCase {
+		return fields
+	}
+	renamed := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		renamed[naming.Key(key)] = value
+	}
+	return renamed
+}
+
+// ErrorContext behaves like `Error`, additionally injecting the request,
+// correlation and tenant IDs carried by ctx into the exception (see
+// `ctxutil.Annotate`), so that they appear in the envelope and in logs,
+// translating its message into the locale carried by ctx (see
+// `i18n.Localize`), and recording it on the span carried by ctx (see
+// `tracing.Record`). The envelope is in the version of ctx (see
+// EnvelopeVersionOf).
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	err: The error to convert into an envelope. A nil error yields a nil map.
+//
+// Returns:
+//
+//	A map representing the error envelope.
+func ErrorContext(ctx context.Context, err error) map[string]interface{} {
+	if exception.IsNil(err) {
+		return nil
+	}
+
+	coreErr := toException(err)
+	_ = ctxutil.Annotate(ctx, coreErr)
+	_ = i18n.Localize(ctx, coreErr)
+	_ = tracing.Record(ctx, coreErr)
+	return formatEnvelope(ctx, coreErr)
+}
+
+// toException resolves the `exception.CoreInterface` carried by err, falling
+// back to a generic `exception.Error` when the chain contains none.
+func toException(err error) exception.CoreInterface {
+	var coreErr exception.CoreInterface
+	if errors.As(err, &coreErr) && !exception.IsNil(coreErr) {
+		return coreErr
+	}
+
+	// Unknown errors are reported as internal server errors. Their message is
+	// intentionally not forwarded, as it may contain implementation details.
+	return exception.NewError(map[string]interface{}{})
+}
+
+// mergeMeta merges the provided meta maps into a single map, with keys from
+// later maps overriding those from earlier ones. It returns nil when no key
+// is present in any of the maps.
+func mergeMeta(meta ...map[string]interface{}) map[string]interface{} {
+	var merged map[string]interface{}
+	for _, m := range meta {
+		for key, value := range m {
+			if merged == nil {
+				merged = make(map[string]interface{}, len(m))
+			}
+			merged[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,190 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines the formatting of exceptions for the streaming endpoints:
+// as a Server-Sent Events "error" event and as a WebSocket close frame.
+package response
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` constants mapped to the close codes.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// ContentTypeEventStream is the Content-Type header value of the Server-Sent
+// Events streams.
+const ContentTypeEventStream = "text/event-stream"
+
+// WebSocket close codes (RFC 6455, section 7.4.1) of the close frames of
+// the exceptions.
+const (
+	CloseNormal          = 1000 // CloseNormal closes a connection without error.
+	CloseUnsupportedData = 1003 // CloseUnsupportedData is used for `status.UnsupportedMediaType`.
+	CloseInvalidPayload  = 1007 // CloseInvalidPayload is used for `status.BadRequest` and `status.UnprocessableContent`.
+	ClosePolicyViolation = 1008 // ClosePolicyViolation is used for the other client errors (e.g., `status.Forbidden`).
+	CloseMessageTooBig   = 1009 // CloseMessageTooBig is used for `status.ContentTooLarge`.
+	CloseInternalError   = 1011 // CloseInternalError is used for the other server errors and the errors that are not exceptions.
+	CloseTryAgainLater   = 1013 // CloseTryAgainLater is used for `status.TooManyRequests` and `status.ServiceUnavailable`.
+)
+
+// maxCloseReason is the maximum length in bytes of the reason of a close
+// frame: control frames carry at most 125 bytes, 2 of which are the code.
+const maxCloseReason = 123
+
+// FormatSSE formats an error as a Server-Sent Events "error" event, whose
+// data is the JSON error envelope in the version of ctx (see
+// EnvelopeVersionOf), annotated with the identifiers of ctx, localized and
+// recorded on the current span:
+//
+//	event: error
+//	data: {"error_code":404,"message":"Not Found","status":"error"}
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	err: The error to format. A nil error yields nil.
+//
+// Returns:
+//
+//	The event, terminated by a blank line, or an error if the envelope could
+//	not be encoded.
+func FormatSSE(ctx context.Context, err error) ([]byte, error) {
+	if exception.IsNil(err) {
+		return nil, nil
+	}
+
+	coreErr := prepare(ctx, err)
+	event, encodeErr := AppendEnvelope(ctx, append(make([]byte, 0, 512), "event: error\ndata: "...), coreErr)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	// JSON escapes the line breaks of the strings, so the envelope always
+	// fits on a single data line.
+	return append(event, "\n\n"...), nil
+}
+
+// WriteSSEError writes an error to a Server-Sent Events stream as an
+// "error" event (see FormatSSE), flushing it when w is an `http.Flusher`
+// or an `http.ResponseWriter` whose `http.ResponseController` can flush,
+// e.g. behind the recorders of the middlewares.
+// The stream is expected to be open already, with `ContentTypeEventStream`
+// as Content-Type; the status code of the exception is in the event only.
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	w: The stream to write to, typically the `http.ResponseWriter`.
+//	err: The error to write. A nil error writes nothing.
+//
+// Returns:
+//
+//	An error if the event could not be encoded or written, nil otherwise.
+func WriteSSEError(ctx context.Context, w io.Writer, err error) error {
+	event, encodeErr := FormatSSE(ctx, err)
+	if encodeErr != nil || event == nil {
+		return encodeErr
+	}
+	if _, writeErr := w.Write(event); writeErr != nil {
+		return writeErr
+	}
+	switch writer := w.(type) {
+	case http.ResponseWriter:
+		if flushErr := http.NewResponseController(writer).Flush(); flushErr != nil && !errors.Is(flushErr, http.ErrNotSupported) {
+			return flushErr
+		}
+	case http.Flusher:
+		writer.Flush()
+	}
+	return nil
+}
+
+// CloseCode returns the WebSocket close code matching the status code of
+// the exception of an error (see the Close constants), or CloseNormal for a
+// nil error.
+func CloseCode(err error) int {
+	if err == nil {
+		return CloseNormal
+	}
+
+	switch code := status.StatusCode(toException(err).GetStatusCode()); code {
+	case status.BadRequest, status.UnprocessableContent:
+		return CloseInvalidPayload
+	case status.ContentTooLarge:
+		return CloseMessageTooBig
+	case status.UnsupportedMediaType:
+		return CloseUnsupportedData
+	case status.TooManyRequests, status.ServiceUnavailable:
+		return CloseTryAgainLater
+	default:
+		if code.GetClass() == status.ClientErrorClass {
+			return ClosePolicyViolation
+		}
+		return CloseInternalError
+	}
+}
+
+// CloseFrame returns the close code (see CloseCode) and the reason of the
+// WebSocket close frame of an error, for the libraries taking them apart:
+//
+//	code, reason := response.CloseFrame(ctx, err)
+//	conn.Close(websocket.StatusCode(code), reason) // github.com/coder/websocket
+//
+// The reason is the message of the exception, annotated, localized and
+// recorded on the current span like the other error responses, and
+// truncated to the 123 bytes allowed by the protocol on a UTF-8 boundary.
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	err: The error closing the connection. A nil error yields CloseNormal
+//	     and an empty reason.
+//
+// Returns:
+//
+//	The close code and the reason.
+func CloseFrame(ctx context.Context, err error) (int, string) {
+	if exception.IsNil(err) {
+		return CloseNormal, ""
+	}
+	return CloseCode(err), truncateReason(prepare(ctx, err).Error())
+}
+
+// FormatCloseFrame returns the payload of the WebSocket close frame of an
+// error (see CloseFrame): the close code in big-endian order followed by
+// the reason, like `websocket.FormatCloseMessage` of gorilla/websocket:
+//
+//	conn.WriteControl(websocket.CloseMessage, response.FormatCloseFrame(ctx, err), deadline)
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	err: The error closing the connection. A nil error yields the payload
+//	     of CloseNormal.
+//
+// Returns:
+//
+//	The payload, at most 125 bytes long.
+func FormatCloseFrame(ctx context.Context, err error) []byte {
+	code, reason := CloseFrame(ctx, err)
+	payload := binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(reason)), uint16(code))
+	return append(payload, reason...)
+}
+
+// truncateReason truncates a reason to maxCloseReason bytes, without
+// splitting a multi-byte character.
+func truncateReason(reason string) string {
+	if len(reason) <= maxCloseReason {
+		return reason
+	}
+	end := maxCloseReason
+	for end > 0 && !utf8.RuneStart(reason[end]) {
+		end--
+	}
+	return reason[:end]
+}
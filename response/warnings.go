@@ -0,0 +1,151 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines the non-fatal warnings carried by the "meta" block of
+// success envelopes, such as partial-data notes.
+package response
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// WarningsMetaKey is the key of the warnings in the "meta" block of success
+// envelopes.
+const WarningsMetaKey = "warnings"
+
+// Warning is a non-fatal problem of a successful operation, shaped like a
+// lightweight exception, e.g. a recommendation service that timed out while
+// the rest of the page was built.
+type Warning struct {
+	Code    string                 `json:"code"`              // A stable code for the clients (e.g., "partial_data").
+	Message string                 `json:"message"`           // A human-readable description.
+	Details map[string]interface{} `json:"details,omitempty"` // Additional information; may be nil.
+}
+
+// NewWarning creates a Warning.
+//
+// Parameters:
+//
+//	code: The code of the warning (e.g., "partial_data").
+//	message: The description of the warning.
+//	details: Additional information; may be nil.
+//
+// Returns:
+//
+//	The Warning.
+func NewWarning(code string, message string, details map[string]interface{}) Warning {
+	return Warning{Code: code, Message: message, Details: details}
+}
+
+// WarningFromError downgrades the error of an optional part of an operation
+// to a warning: its code is the "error" entry of the details of its
+// exception, or its status code, and its details are those of the
+// exception. Errors that are not exceptions yield the generic 500 warning,
+// without their message.
+//
+// Parameters:
+//
+//	err: The error to downgrade.
+//
+// Returns:
+//
+//	The Warning.
+func WarningFromError(err error) Warning {
+	var coreErr exception.CoreInterface
+	if !errors.As(exception.Normalize(err), &coreErr) {
+		coreErr = exception.NewError(map[string]interface{}{})
+	}
+
+	code := coreErr.GetDetailsMessage()
+	if code == "" {
+		code = strconv.Itoa(coreErr.GetStatusCode())
+	}
+	details := coreErr.GetDetails()
+	if len(details) == 0 {
+		details = nil
+	}
+	return Warning{Code: code, Message: coreErr.Error(), Details: details}
+}
+
+// WarningsMeta returns the "meta" block of a list of warnings, to pass to
+// `Success` and the writers:
+//
+//	response.WriteSuccess(w, status.OK, page, response.WarningsMeta(warning))
+//
+// Parameters:
+//
+//	warnings: The warnings.
+//
+// Returns:
+//
+//	The meta block, or nil when there is no warning.
+func WarningsMeta(warnings ...Warning) map[string]interface{} {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return map[string]interface{}{WarningsMetaKey: warnings}
+}
+
+// warnings collects the warnings of a request.
+type warnings struct {
+	mu   sync.Mutex
+	list []Warning
+}
+
+// warningsKey is the context key of the warnings of a request.
+type warningsKey struct{}
+
+// WithWarnings returns a copy of ctx collecting the warnings of a request,
+// added by AddWarning anywhere down the call chain and emitted by
+// `SuccessContext` in its "meta" block.
+//
+// Parameters:
+//
+//	ctx: The request context.
+//
+// Returns:
+//
+//	The derived context.
+func WithWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningsKey{}, &warnings{})
+}
+
+// AddWarning adds a warning to the request carried by ctx. It is safe for
+// concurrent use, e.g. by the goroutines of an `exception.Group`.
+//
+// Parameters:
+//
+//	ctx: A context derived from WithWarnings.
+//	warning: The warning to add.
+//
+// Returns:
+//
+//	True if the warning was added, false when ctx does not collect warnings.
+func AddWarning(ctx context.Context, warning Warning) bool {
+	collected, ok := ctx.Value(warningsKey{}).(*warnings)
+	if !ok {
+		return false
+	}
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+	collected.list = append(collected.list, warning)
+	return true
+}
+
+// Warnings returns a copy of the warnings added to the request carried by
+// ctx, in the order they were added, or nil.
+func Warnings(ctx context.Context) []Warning {
+	collected, ok := ctx.Value(warningsKey{}).(*warnings)
+	if !ok {
+		return nil
+	}
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+	if len(collected.list) == 0 {
+		return nil
+	}
+	return append([]Warning{}, collected.list...)
+}
@@ -0,0 +1,185 @@
+// Package response provides the success half of the standardized API contract.
+// This file defines helpers for writing envelopes as JSON to an
+// `http.ResponseWriter`.
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/tracing"
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type used to set the HTTP response status.
+	status "github.com/osirisgate/golang-core/enum"
+)
+
+// ContentTypeJSON is the Content-Type header value used for JSON envelopes.
+const ContentTypeJSON = "application/json; charset=utf-8"
+
+// WriteJSON serializes the payload as JSON and writes it to the response
+// writer with the given status code and a JSON Content-Type header.
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	statusCode: The HTTP status code to send.
+//	payload: Any JSON-serializable value, typically an envelope map.
+//
+// Returns:
+//
+//	An error if the payload could not be encoded or written, nil otherwise.
+func WriteJSON(w http.ResponseWriter, statusCode status.StatusCode, payload interface{}) error {
+	// Encode before touching the writer so that an encoding failure does not
+	// leave a half-written response with a misleading status code.
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(statusCode.GetValue())
+	_, err = w.Write(body)
+	return err
+}
+
+// WriteSuccess writes a success envelope built by `Success` to the response
+// writer with the given status code.
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	statusCode: The HTTP status code to send (e.g., `status.OK`, `status.Created`).
+//	data: The payload to return to the client.
+//	meta: Optional maps of metadata merged into the "meta" block.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteSuccess(w http.ResponseWriter, statusCode status.StatusCode, data interface{}, meta ...map[string]interface{}) error {
+	return WriteJSON(w, statusCode, Success(data, meta...))
+}
+
+// WriteError writes an error envelope built by `Error` to the response writer.
+// The HTTP status code and the headers it implies (see
+// `exception.CoreInterface.Headers`) are taken from the exception carried by
+// err; errors that are not exceptions are written as
+// `status.InternalServerError`. The envelope is in the version set by
+// SetEnvelopeVersion.
+//
+// Parameters:
+//
+//	w: The `http.ResponseWriter` to write to.
+//	err: The error to write. A nil error, or nil exception (see
+//	`exception.IsNil`), writes nothing.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteError(w http.ResponseWriter, err error) error {
+	if exception.IsNil(err) {
+		return nil
+	}
+
+	return writeEnvelope(context.Background(), w, toException(err))
+}
+
+// WriteSuccessContext writes a success envelope built by `SuccessContext`,
+// carrying the identifiers of ctx in its "meta" block.
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	w: The `http.ResponseWriter` to write to.
+//	statusCode: The HTTP status code to send.
+//	data: The payload to return to the client.
+//	meta: Optional maps of metadata merged into the "meta" block.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteSuccessContext(ctx context.Context, w http.ResponseWriter, statusCode status.StatusCode, data interface{}, meta ...map[string]interface{}) error {
+	return WriteJSON(w, statusCode, SuccessContext(ctx, data, meta...))
+}
+
+// WriteErrorContext writes an error envelope built by `ErrorContext`,
+// carrying the identifiers of ctx and translated into its locale. The
+// exception is recorded on the span carried by ctx, then sealed, which
+// caches its envelope for the later formatting and rejects its later
+// modifications (see `exception.CoreException.Seal`). The envelope is in the
+// version of ctx (see EnvelopeVersionOf).
+//
+// Parameters:
+//
+//	ctx: The request context, typically enriched by `ctxutil.Middleware`.
+//	w: The `http.ResponseWriter` to write to.
+//	err: The error to write. A nil error, or nil exception (see
+//	`exception.IsNil`), writes nothing.
+//
+// Returns:
+//
+//	An error if the envelope could not be encoded or written, nil otherwise.
+func WriteErrorContext(ctx context.Context, w http.ResponseWriter, err error) error {
+	if exception.IsNil(err) {
+		return nil
+	}
+
+	return writeEnvelope(ctx, w, prepare(ctx, err))
+}
+
+// prepare resolves the exception of err, annotated with the identifiers of
+// ctx, localized and recorded on the current span. The exception is then
+// complete: it is sealed, so that the client and the loggers and reporters
+// formatting it afterwards see the same envelope, computed once.
+func prepare(ctx context.Context, err error) exception.CoreInterface {
+	coreErr := toException(err)
+	_ = ctxutil.Annotate(ctx, coreErr)
+	_ = i18n.Localize(ctx, coreErr)
+	_ = tracing.Record(ctx, coreErr)
+	if sealer, ok := coreErr.(interface{ Seal() }); ok {
+		sealer.Seal()
+	}
+	return coreErr
+}
+
+// jsonAppender is implemented by the exceptions encoding their envelope
+// directly, such as every type embedding `exception.CoreException` (see
+// `exception.CoreException.AppendJSON`).
+type jsonAppender interface {
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+// writeException writes the envelope of an exception with its status code
+// and headers, encoding it without the map of `Format` when the exception
+// supports it.
+func writeException(w http.ResponseWriter, coreErr exception.CoreInterface) error {
+	setHeaders(w, coreErr)
+	appender, ok := coreErr.(jsonAppender)
+	if !ok {
+		return WriteJSON(w, status.StatusCode(coreErr.GetStatusCode()), coreErr.Format())
+	}
+
+	body, err := appender.AppendJSON(make([]byte, 0, 512))
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(coreErr.GetStatusCode())
+	_, err = w.Write(body)
+	return err
+}
+
+// setHeaders sets the response headers implied by an exception (see
+// `exception.CoreInterface.Headers`), except those already set, e.g. the
+// Retry-After of `ratelimit.Middleware`.
+func setHeaders(w http.ResponseWriter, coreErr exception.CoreInterface) {
+	header := w.Header()
+	for name, value := range coreErr.Headers() {
+		if header.Get(name) == "" {
+			header.Set(name, value)
+		}
+	}
+}
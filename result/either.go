@@ -0,0 +1,112 @@
+// Package result provides generic types for representing the outcome of an
+// operation as a value. This file defines Either[L, R], which holds one of two
+// valid outcomes (e.g., a cached value on the left and a fresh one on the right).
+package result
+
+import (
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Either holds exactly one of two values: a left value of type L or a right
+// value of type R. By convention, the right side holds the "primary" outcome.
+// The zero value is a Left holding the zero value of L.
+type Either[L, R any] struct {
+	left    L    // The left value, meaningful only when isRight is false.
+	right   R    // The right value, meaningful only when isRight is true.
+	isRight bool // Reports which side is held.
+}
+
+// Left creates an Either holding the given left value.
+func Left[L, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right creates an Either holding the given right value.
+func Right[L, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// IsLeft reports whether the Either holds a left value.
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight reports whether the Either holds a right value.
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// Left returns the left value and true, or the zero value of L and false
+// when the Either holds a right value.
+func (e Either[L, R]) Left() (L, bool) {
+	return e.left, !e.isRight
+}
+
+// Right returns the right value and true, or the zero value of R and false
+// when the Either holds a left value.
+func (e Either[L, R]) Right() (R, bool) {
+	return e.right, e.isRight
+}
+
+// Swap returns an Either with the left and right sides exchanged.
+func (e Either[L, R]) Swap() Either[R, L] {
+	if e.isRight {
+		return Left[R, L](e.right)
+	}
+	return Right[R](e.left)
+}
+
+// Fold reduces the Either to a single value by applying onLeft or onRight,
+// depending on which side is held.
+func Fold[L, R, T any](e Either[L, R], onLeft func(L) T, onRight func(R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+// MapLeft applies fn to the left value, leaving a right value unchanged.
+func MapLeft[L, R, T any](e Either[L, R], fn func(L) T) Either[T, R] {
+	if e.isRight {
+		return Right[T](e.right)
+	}
+	return Left[T, R](fn(e.left))
+}
+
+// MapRight applies fn to the right value, leaving a left value unchanged.
+func MapRight[L, R, T any](e Either[L, R], fn func(R) T) Either[L, T] {
+	if e.isRight {
+		return Right[L](fn(e.right))
+	}
+	return Left[L, T](e.left)
+}
+
+// ToResult converts the Either into a Result holding the right value. The
+// left side becomes the Result's error:
+//
+//   - a left value carrying an `exception.CoreInterface` is kept as-is;
+//   - any other error is normalized (see `exception.Normalize`) into an
+//     internal server error exception unwrapping to it, which does not
+//     disclose its message to clients;
+//   - a left value that is not an error yields an `exception.UnexpectedValue`
+//     describing the value, since the caller expected the right side.
+func ToResult[L, R any](e Either[L, R]) Result[R] {
+	if e.isRight {
+		return Ok(e.right)
+	}
+
+	switch left := any(e.left).(type) {
+	case error:
+		return Err[R](exception.Normalize(left))
+	default:
+		return Err[R](exception.NewUnexpectedValue(map[string]interface{}{
+			"message": "Expected a right value but the left one was held.",
+			"details": map[string]interface{}{
+				"left": fmt.Sprintf("%v", left),
+				"type": fmt.Sprintf("%T", left),
+			},
+		}))
+	}
+}
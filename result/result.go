@@ -0,0 +1,96 @@
+// Package result provides generic types for representing the outcome of an
+// operation as a value. Result[T] holds either a value or an error, while
+// Either[L, R] holds one of two valid outcomes. Errors carried by these types
+// follow the exception contract so they can be formatted like any other error.
+package result
+
+import (
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Result holds either a successful value of type T or an error.
+// The zero value is a successful Result holding the zero value of T.
+type Result[T any] struct {
+	value T     // The successful value, meaningful only when err is nil.
+	err   error // The failure, nil for a successful Result.
+}
+
+// Ok creates a successful Result holding the given value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err creates a failed Result holding the given error. Passing a nil error is
+// a programming mistake and yields a Result holding an `exception.Logic`, so
+// that a failed Result can never be mistaken for a successful one.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		err = exception.NewLogic(map[string]interface{}{
+			"message": "A failed result was created without an error.",
+		})
+	}
+	return Result[T]{err: err}
+}
+
+// From creates a Result from the conventional (value, error) return pair.
+func From[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// IsOk reports whether the Result holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr reports whether the Result holds an error.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Get returns the held value and error as a conventional Go return pair.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Error returns the held error, or nil for a successful Result.
+func (r Result[T]) Error() error {
+	return r.err
+}
+
+// ValueOr returns the held value, or fallback when the Result holds an error.
+func (r Result[T]) ValueOr(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// ToEither converts the Result into an Either holding the error on the left
+// and the value on the right.
+func (r Result[T]) ToEither() Either[error, T] {
+	if r.err != nil {
+		return Left[error, T](r.err)
+	}
+	return Right[error](r.value)
+}
+
+// Map applies fn to the value of a successful Result. A failed Result is
+// returned unchanged.
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// Then chains an operation that may itself fail onto a successful Result.
+// A failed Result is returned unchanged without calling fn.
+func Then[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return fn(r.value)
+}
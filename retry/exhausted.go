@@ -0,0 +1,73 @@
+// Package retry provides a retry loop with exponential backoff and jitter.
+// This file defines the exception reporting a failure that persisted across
+// every attempt.
+package retry
+
+import (
+	"errors"
+	"maps"
+	"time"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.StatusCode` type and the `status.InternalServerError` fallback.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Reasons reported in the "retry" entry of an `*Exhausted` exception.
+const (
+	ReasonAttemptsExhausted = "attempts_exhausted" // ReasonAttemptsExhausted means every allowed attempt failed.
+	ReasonContextDone       = "context_done"       // ReasonContextDone means the context ended while waiting to retry.
+)
+
+// Exhausted is the exception returned by `Do` when a retryable failure
+// persisted across every attempt. It keeps the status code, message and error
+// details of the last failure when it is an exception, and the generic
+// description of `status.InternalServerError` otherwise, so that the message
+// of a plain error is not disclosed to clients. It adds a "retry" entry
+// holding the attempt metadata, and has the last error as its cause (see
+// `exception.CoreException.WithCause`), so that `errors.Is`/`errors.As` still
+// reach it and the logs show it.
+// It embeds `exception.CoreException` to inherit all its properties and
+// methods, ensuring consistent error reporting and formatting.
+type Exhausted struct {
+	exception.CoreException               // Embeds CoreException to inherit its fields and methods.
+	Attempts                int           // The number of attempts made.
+	Elapsed                 time.Duration // The time spent across all attempts.
+	Reason                  string        // Why retrying stopped (see the Reason constants).
+}
+
+// newExhausted wraps the last error of a retry loop into an `*Exhausted`.
+func newExhausted(last error, attempts int, elapsed time.Duration, reason string) *Exhausted {
+	errorsMap := map[string]interface{}{}
+	statusCode := status.InternalServerError
+
+	var coreErr exception.CoreInterface
+	if errors.As(last, &coreErr) && !exception.IsNil(coreErr) {
+		maps.Copy(errorsMap, coreErr.GetErrors())
+		errorsMap["message"] = coreErr.Error()
+		statusCode = status.StatusCode(coreErr.GetStatusCode())
+	}
+	errorsMap["retry"] = map[string]interface{}{
+		"attempts":   attempts,
+		"elapsed_ms": elapsed.Milliseconds(),
+		"reason":     reason,
+	}
+
+	base := exception.NewInstance(errorsMap, statusCode)
+	exhausted := &Exhausted{
+		CoreException: *base,
+		Attempts:      attempts,
+		Elapsed:       elapsed,
+		Reason:        reason,
+	}
+	_ = exhausted.WithCause(last)
+	exception.NotifyCreation(exhausted)
+	return exhausted
+}
+
+// IsRetryable reports false: the retry budget has already been spent, so
+// outer retry loops must not multiply the attempts.
+func (e *Exhausted) IsRetryable() bool {
+	return false
+}
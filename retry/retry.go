@@ -0,0 +1,223 @@
+// Package retry provides a retry loop with exponential backoff and jitter.
+// Whether a failure is retried is decided by `exception.IsRetryable` (or a
+// custom classifier), and a failure that persists after the last attempt is
+// reported as an `*Exhausted` exception carrying the attempt metadata.
+// Exceptions asking the caller to wait, through a "retry_after" number of
+// seconds in their details (rate limiters, open circuit breakers, upstream
+// Retry-After headers), delay the next attempt accordingly, even beyond the
+// maximum delay of the backoff.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/metrics"
+)
+
+// Default retry settings.
+const (
+	DefaultMaxAttempts  = 3                      // DefaultMaxAttempts is the total number of attempts, including the first one.
+	DefaultInitialDelay = 100 * time.Millisecond // DefaultInitialDelay is the delay before the second attempt.
+	DefaultMaxDelay     = 10 * time.Second       // DefaultMaxDelay caps the delay between two attempts.
+	DefaultMultiplier   = 2.0                    // DefaultMultiplier is the growth factor of the delay.
+	DefaultJitter       = 0.2                    // DefaultJitter is the fraction of the delay randomized on each attempt.
+)
+
+// Attempt describes a failed attempt, as passed to the OnRetry hook.
+type Attempt struct {
+	Number int           // The 1-based number of the failed attempt.
+	Err    error         // The error returned by the attempt.
+	Delay  time.Duration // The delay before the next attempt.
+}
+
+// config holds the settings of a retry loop.
+type config struct {
+	maxAttempts  int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	multiplier   float64
+	jitter       float64
+	retryable    func(error) bool
+	onRetry      []func(Attempt)
+	meter        metrics.Meter
+	operation    string
+}
+
+// Option customizes the behavior of `Do`.
+type Option func(*config)
+
+// MaxAttempts sets the total number of attempts, including the first one.
+// Values lower than 1 are ignored.
+func MaxAttempts(n int) Option {
+	return func(c *config) {
+		if n >= 1 {
+			c.maxAttempts = n
+		}
+	}
+}
+
+// Backoff sets the delay before the second attempt and the cap applied to
+// the exponentially growing delay, jitter included. The cap does not apply
+// to the delays requested through "retry_after".
+func Backoff(initial time.Duration, maximum time.Duration) Option {
+	return func(c *config) {
+		c.initialDelay = initial
+		c.maxDelay = maximum
+	}
+}
+
+// Multiplier sets the growth factor of the delay between attempts.
+// Values lower than 1 are ignored.
+func Multiplier(factor float64) Option {
+	return func(c *config) {
+		if factor >= 1 {
+			c.multiplier = factor
+		}
+	}
+}
+
+// Jitter sets the fraction (between 0 and 1) of each delay that is
+// randomized, so that concurrent clients do not retry in lockstep.
+func Jitter(fraction float64) Option {
+	return func(c *config) {
+		c.jitter = min(max(fraction, 0), 1)
+	}
+}
+
+// RetryIf replaces `exception.IsRetryable` as the classifier deciding
+// whether a failure is retried.
+func RetryIf(classifier func(error) bool) Option {
+	return func(c *config) {
+		if classifier != nil {
+			c.retryable = classifier
+		}
+	}
+}
+
+// OnRetry registers a hook called after each failed attempt that is about to
+// be retried, e.g. for logging or metrics.
+func OnRetry(hook func(Attempt)) Option {
+	return func(c *config) {
+		if hook != nil {
+			c.onRetry = append(c.onRetry, hook)
+		}
+	}
+}
+
+// Metrics emits the attempts of the loop through a Meter, labeled with the
+// name of the operation:
+//
+//	retry_attempts_total{operation}: every attempt, including the first one.
+//	retry_retries_total{operation}: every failed attempt that is retried.
+//	retry_exhausted_total{operation,reason}: every loop ending with an
+//	`*Exhausted` exception, by reason.
+func Metrics(meter metrics.Meter, operation string) Option {
+	return func(c *config) {
+		c.meter = metrics.OrNop(meter)
+		c.operation = operation
+	}
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, the attempts
+// are exhausted or ctx is done.
+//
+// Parameters:
+//
+//	ctx: The context bounding the whole retry loop, passed to each attempt.
+//	fn: The operation to run.
+//	opts: Options customizing attempts, backoff, classification and hooks.
+//
+// Returns:
+//
+//	Nil on success, including a nil exception returned by fn (see
+//	`exception.IsNil`). A non-retryable error is returned unchanged. When the
+//	attempts are exhausted or ctx is done while waiting, an `*Exhausted`
+//	exception wrapping the last error is returned.
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// DoValue behaves like `Do` for operations returning a value.
+func DoValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...Option) (T, error) {
+	c := config{
+		maxAttempts:  DefaultMaxAttempts,
+		initialDelay: DefaultInitialDelay,
+		maxDelay:     DefaultMaxDelay,
+		multiplier:   DefaultMultiplier,
+		jitter:       DefaultJitter,
+		retryable:    exception.IsRetryable,
+		meter:        metrics.Nop(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	attempts := c.meter.Counter("retry_attempts_total", "Number of attempts of retried operations.")
+	retries := c.meter.Counter("retry_retries_total", "Number of failed attempts that are retried.")
+	exhausted := c.meter.Counter("retry_exhausted_total", "Number of retry loops ending exhausted.")
+	labels := metrics.Labels{"operation": c.operation}
+
+	var zero T
+	started := time.Now()
+	delay := c.initialDelay
+
+	for attempt := 1; ; attempt++ {
+		metrics.Inc(attempts, labels)
+		value, err := fn(ctx)
+		if exception.IsNil(err) {
+			return value, nil
+		}
+		if !c.retryable(err) {
+			return zero, err
+		}
+		if attempt >= c.maxAttempts {
+			exhausted.Add(1, metrics.Labels{"operation": c.operation, "reason": ReasonAttemptsExhausted})
+			return zero, newExhausted(err, attempt, time.Since(started), ReasonAttemptsExhausted)
+		}
+		metrics.Inc(retries, labels)
+
+		wait := max(min(c.withJitter(delay), c.maxDelay), retryAfter(err))
+		for _, hook := range c.onRetry {
+			hook(Attempt{Number: attempt, Err: err, Delay: wait})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			exhausted.Add(1, metrics.Labels{"operation": c.operation, "reason": ReasonContextDone})
+			return zero, newExhausted(err, attempt, time.Since(started), ReasonContextDone)
+		case <-timer.C:
+		}
+
+		delay = min(time.Duration(float64(delay)*c.multiplier), c.maxDelay)
+	}
+}
+
+// withJitter randomizes the given fraction of a delay.
+func (c config) withJitter(delay time.Duration) time.Duration {
+	if c.jitter == 0 || delay <= 0 {
+		return delay
+	}
+	spread := float64(delay) * c.jitter
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
+
+// retryAfter returns the delay requested by the `exception.RetryAfterKey`
+// detail of the first exception of the chain of err, or 0 when there is none.
+func retryAfter(err error) time.Duration {
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || exception.IsNil(coreErr) {
+		return 0
+	}
+	if seconds, ok := exception.IntOf(coreErr.GetDetails(), exception.RetryAfterKey); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
@@ -0,0 +1,156 @@
+// Package rules evaluates declarative business rules. Named rules, each a
+// predicate with a machine readable code and the key of its message, are
+// grouped into rule sets; evaluating a set against a subject reports every
+// violated rule at once in a single `exception.Logic`, for the business
+// validation that goes beyond the field-level checks of the validator
+// package (e.g., "an order of a suspended customer cannot exceed 100 EUR").
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+)
+
+// Rule is a named business rule about subjects of type T.
+type Rule[T any] struct {
+	// ID identifies the rule (e.g., "order.max_amount_when_suspended").
+	ID string
+
+	// Code is the machine readable code reported when the rule is violated
+	// (e.g., "amount_limit_exceeded"). Defaults to ID.
+	Code string
+
+	// MessageKey is the i18n key of the message of a violation (e.g.,
+	// "rules.order.amount_limit"), translated by EvaluateContext.
+	MessageKey string
+
+	// Message is the message of a violation when it is not translated.
+	// Defaults to MessageKey.
+	Message string
+
+	// Holds reports whether the subject satisfies the rule.
+	Holds func(subject T) bool
+}
+
+// Violation describes a violated rule.
+type Violation struct {
+	Rule       string `json:"rule"`
+	Code       string `json:"code"`
+	MessageKey string `json:"message_key,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Set is a named set of rules. It is safe for concurrent evaluation once
+// its rules are added.
+type Set[T any] struct {
+	name  string
+	rules []Rule[T]
+}
+
+// NewSet creates a rule set.
+//
+// Parameters:
+//
+//	name: The name of the set, reported in the exception details (e.g.,
+//	      "order.placement").
+//	rules: The rules of the set, evaluated in order.
+//
+// Returns:
+//
+//	A pointer to a new Set.
+func NewSet[T any](name string, rules ...Rule[T]) *Set[T] {
+	return &Set[T]{name: name, rules: append([]Rule[T]{}, rules...)}
+}
+
+// Name returns the name of the set.
+func (s *Set[T]) Name() string {
+	return s.name
+}
+
+// Add appends rules to the set, returning it for chaining.
+func (s *Set[T]) Add(rules ...Rule[T]) *Set[T] {
+	s.rules = append(s.rules, rules...)
+	return s
+}
+
+// Violations evaluates every rule of the set against a subject.
+//
+// Returns:
+//
+//	The violated rules, in the order of the set, or nil when the subject
+//	satisfies them all.
+func (s *Set[T]) Violations(subject T) []Violation {
+	var violations []Violation
+	for _, rule := range s.rules {
+		if rule.Holds(subject) {
+			continue
+		}
+		violation := Violation{Rule: rule.ID, Code: rule.Code, MessageKey: rule.MessageKey, Message: rule.Message}
+		if violation.Code == "" {
+			violation.Code = rule.ID
+		}
+		if violation.Message == "" {
+			violation.Message = rule.MessageKey
+		}
+		violations = append(violations, violation)
+	}
+	return violations
+}
+
+// Evaluate evaluates every rule of the set against a subject.
+//
+// Parameters:
+//
+//	subject: The value to evaluate (e.g., the order being placed).
+//
+// Returns:
+//
+//	Nil when the subject satisfies every rule, or an `exception.Logic`
+//	whose details hold the "rule_set", the "violations" (see Violation) and
+//	the identifier of the first violated rule under "rule", with the
+//	"business_rule_violated" error code.
+func (s *Set[T]) Evaluate(subject T) error {
+	return s.failure(s.Violations(subject))
+}
+
+// EvaluateContext behaves like Evaluate, translating the messages of the
+// violations into the locale carried by ctx (see `i18n.T`). The details of
+// the violation are the parameters of the templates. Rules whose key has no
+// translation keep their Message.
+func (s *Set[T]) EvaluateContext(ctx context.Context, subject T) error {
+	violations := s.Violations(subject)
+	for i, violation := range violations {
+		if violation.MessageKey == "" {
+			continue
+		}
+		params := map[string]interface{}{"rule": violation.Rule, "code": violation.Code}
+		if message := i18n.T(ctx, violation.MessageKey, params); message != violation.MessageKey {
+			violations[i].Message = message
+		}
+	}
+	return s.failure(violations)
+}
+
+// failure builds the exception reporting violated rules.
+func (s *Set[T]) failure(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	message := violations[0].Message
+	if len(violations) > 1 {
+		message = fmt.Sprintf("%d business rules are violated.", len(violations))
+	}
+	return exception.NewLogic(map[string]interface{}{
+		"message": message,
+		"details": map[string]interface{}{
+			"rule_set":   s.name,
+			"rule":       violations[0].Rule,
+			"violations": violations,
+			"error":      "business_rule_violated",
+		},
+	})
+}
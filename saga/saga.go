@@ -0,0 +1,133 @@
+// Package saga orchestrates the steps of a distributed workflow. Each step
+// pairs an action with the compensation undoing it; when a step fails, the
+// compensations of the completed steps run in reverse order, and the outcome
+// is reported as an `exception.Aggregate` grouping the failure with the
+// errors of the compensations, its details naming the compensations that
+// ran.
+package saga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Step is a step of a saga.
+type Step struct {
+	// Name identifies the step in the exception details (e.g.,
+	// "reserve_stock").
+	Name string
+
+	// Execute performs the step.
+	Execute func(ctx context.Context) error
+
+	// Compensate, when set, undoes the step once it completed. It must be
+	// idempotent, as it may be retried by the caller.
+	Compensate func(ctx context.Context) error
+}
+
+// Saga is a sequence of steps. It is immutable and safe for concurrent use.
+type Saga struct {
+	name  string
+	steps []Step
+}
+
+// New creates a Saga.
+//
+// Parameters:
+//
+//	name: The name of the saga, reported in the exception details (e.g.,
+//	      "place_order").
+//	steps: The steps, in execution order.
+//
+// Returns:
+//
+//	A pointer to a new Saga.
+func New(name string, steps ...Step) *Saga {
+	return &Saga{name: name, steps: append([]Step{}, steps...)}
+}
+
+// Name returns the name of the saga.
+func (s *Saga) Name() string {
+	return s.name
+}
+
+// Run executes the steps in order. When a step fails or panics, the
+// compensations of the completed steps run in reverse order, with a context
+// that is not canceled with ctx so that a canceled request still undoes its
+// effects.
+//
+// Parameters:
+//
+//	ctx: The context passed to the steps.
+//
+// Returns:
+//
+//	Nil when every step completed. Otherwise an `exception.Aggregate`
+//	grouping the error of the failed step (normalized with
+//	`exception.Normalize`, or an `exception.Runtime` when the step panicked)
+//	and the errors of the failed compensations. Its details hold the
+//	"saga", the "failed_step", the "compensated" steps and the
+//	"compensation_failed" ones, with the "saga_failed" error code, or
+//	"saga_compensation_failed" when a compensation failed.
+func (s *Saga) Run(ctx context.Context) error {
+	for i, step := range s.steps {
+		if err := execute(ctx, step.Name, step.Execute); err != nil {
+			return s.compensate(context.WithoutCancel(ctx), i, err)
+		}
+	}
+	return nil
+}
+
+// compensate runs the compensations of the steps preceding the failed one.
+func (s *Saga) compensate(ctx context.Context, failed int, cause error) error {
+	compensated, compensationFailed := []string{}, []string{}
+	errs := []error{cause}
+	for i := failed - 1; i >= 0; i-- {
+		step := s.steps[i]
+		if step.Compensate == nil {
+			continue
+		}
+		if err := execute(ctx, step.Name, step.Compensate); err != nil {
+			compensationFailed = append(compensationFailed, step.Name)
+			errs = append(errs, err)
+			continue
+		}
+		compensated = append(compensated, step.Name)
+	}
+
+	code := "saga_failed"
+	message := fmt.Sprintf("The saga %q failed at step %q and was compensated.", s.name, s.steps[failed].Name)
+	if len(compensationFailed) > 0 {
+		code = "saga_compensation_failed"
+		message = fmt.Sprintf("The saga %q failed at step %q and could not be fully compensated.", s.name, s.steps[failed].Name)
+	}
+	return exception.NewAggregate(map[string]interface{}{
+		"message": message,
+		"details": map[string]interface{}{
+			"saga":                s.name,
+			"failed_step":         s.steps[failed].Name,
+			"compensated":         compensated,
+			"compensation_failed": compensationFailed,
+			"error":               code,
+		},
+	}, errs...)
+}
+
+// execute runs a function of a step, converting a panic into an exception.
+func execute(ctx context.Context, name string, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = exception.NewRuntime(map[string]interface{}{
+				"message": fmt.Sprintf("The step %q panicked.", name),
+				"details": map[string]interface{}{
+					"step":  name,
+					"panic": fmt.Sprint(recovered),
+					"error": "step_panicked",
+				},
+			})
+		}
+	}()
+	return exception.Normalize(fn(ctx))
+}
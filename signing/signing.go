@@ -0,0 +1,277 @@
+// Package signing signs and verifies payloads with HMAC keys. Keys are named
+// by an ID and can be rotated: payloads are signed with the active key and
+// verified with the key named by the signature, or any configured key when
+// the signature names none, so that signatures made with a retired key stay
+// valid until it is removed. Comparisons are made in constant time, and
+// verification failures are reported as `exception.Unauthorized` exceptions
+// whose details "error" names the reason. The package is shared by the
+// webhook verification and any other component signing its tokens (e.g.,
+// idempotency keys or signed URLs).
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Encoding is the text encoding of a signature.
+type Encoding string
+
+const (
+	Hex       Encoding = "hex"       // Hex encodes signatures in lower-case hexadecimal.
+	Base64    Encoding = "base64"    // Base64 encodes signatures in standard padded base64.
+	Base64URL Encoding = "base64url" // Base64URL encodes signatures in unpadded URL-safe base64.
+)
+
+// Default signer settings.
+const (
+	DefaultSignatureHeader = "X-Signature"        // DefaultSignatureHeader is the header carrying the signature.
+	DefaultKeyIDHeader     = "X-Signature-Key-Id" // DefaultKeyIDHeader is the header naming the signing key.
+)
+
+// Reason codes reported under the details "error" key of verification failures.
+const (
+	ReasonMissingSignature   = "missing_signature"   // ReasonMissingSignature reports a missing signature.
+	ReasonMalformedSignature = "malformed_signature" // ReasonMalformedSignature reports a signature that cannot be decoded.
+	ReasonInvalidSignature   = "invalid_signature"   // ReasonInvalidSignature reports a signature that does not match the payload.
+	ReasonUnknownKey         = "unknown_key"         // ReasonUnknownKey reports a signature made with a key that is not configured.
+)
+
+// Key is a named HMAC secret.
+type Key struct {
+	ID     string // The identifier of the key, sent along with the signatures. May be empty.
+	Secret []byte // The shared secret.
+}
+
+// Config holds the settings of a Signer. Zero values fall back to the
+// defaults.
+type Config struct {
+	// Keys are the accepted keys. The first one is the active key signing the
+	// payloads; the others, typically retired keys, are only used to verify.
+	// At least one key is required.
+	Keys []Key
+
+	// Hash builds the hash function of the HMAC. Defaults to `sha256.New`.
+	Hash func() hash.Hash
+
+	// Encoding is the text encoding of the signatures. Defaults to Hex.
+	Encoding Encoding
+
+	// SignatureHeader is the header carrying the signature. Defaults to
+	// DefaultSignatureHeader.
+	SignatureHeader string
+
+	// Prefix is the scheme prefixing the signature in the header, e.g.
+	// "sha256=" for GitHub style signatures. Defaults to no prefix.
+	Prefix string
+
+	// KeyIDHeader is the header naming the signing key, set when the active
+	// key has an ID. Defaults to DefaultKeyIDHeader.
+	KeyIDHeader string
+}
+
+// Signer signs and verifies payloads. It is safe for concurrent use.
+type Signer struct {
+	config Config
+	byID   map[string]Key
+}
+
+// New creates a Signer.
+//
+// Parameters:
+//
+//	config: The signer settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Signer, or an `exception.Configuration` whose details
+//	"error" is "missing_keys" when no key is configured, or "missing_secret"
+//	when a key has an empty secret, which would let anyone forge the
+//	signatures.
+func New(config Config) (*Signer, error) {
+	if len(config.Keys) == 0 {
+		return nil, exception.NewConfiguration(map[string]interface{}{
+			"message": "No signing key is configured.",
+			"details": map[string]interface{}{"error": "missing_keys"},
+		})
+	}
+	for _, key := range config.Keys {
+		if len(key.Secret) == 0 {
+			return nil, exception.NewConfiguration(map[string]interface{}{
+				"message": "The signing secret is empty.",
+				"details": map[string]interface{}{"key_id": key.ID, "error": "missing_secret"},
+			})
+		}
+	}
+
+	if config.Hash == nil {
+		config.Hash = sha256.New
+	}
+	if config.Encoding == "" {
+		config.Encoding = Hex
+	}
+	if config.SignatureHeader == "" {
+		config.SignatureHeader = DefaultSignatureHeader
+	}
+	if config.KeyIDHeader == "" {
+		config.KeyIDHeader = DefaultKeyIDHeader
+	}
+
+	byID := make(map[string]Key, len(config.Keys))
+	for _, key := range config.Keys {
+		if key.ID != "" {
+			byID[key.ID] = key
+		}
+	}
+	return &Signer{config: config, byID: byID}, nil
+}
+
+// Sign signs a payload with the active key.
+//
+// Parameters:
+//
+//	payload: The payload to sign.
+//
+// Returns:
+//
+//	The ID of the active key (possibly empty) and the encoded signature.
+func (s *Signer) Sign(payload []byte) (keyID string, signature string) {
+	key := s.config.Keys[0]
+	return key.ID, s.encode(s.mac(key, payload))
+}
+
+// Verify checks the signature of a payload.
+//
+// Parameters:
+//
+//	payload: The payload, exactly as signed.
+//	keyID: The ID of the signing key. An empty ID accepts any configured key.
+//	signature: The encoded signature, without prefix.
+//
+// Returns:
+//
+//	Nil when the signature is valid, or an `exception.Unauthorized` whose
+//	details "error" is one of the Reason constants.
+func (s *Signer) Verify(payload []byte, keyID string, signature string) error {
+	return s.verify(payload, keyID, signature, map[string]interface{}{})
+}
+
+// SignHeader signs a payload and sets the signature header, with its prefix,
+// and the key ID header when the active key has an ID.
+func (s *Signer) SignHeader(header http.Header, payload []byte) {
+	keyID, signature := s.Sign(payload)
+	header.Set(s.config.SignatureHeader, s.config.Prefix+signature)
+	if keyID != "" {
+		header.Set(s.config.KeyIDHeader, keyID)
+	}
+}
+
+// VerifyHeader checks the signature carried by the headers of a request.
+//
+// Parameters:
+//
+//	header: The headers of the request.
+//	payload: The payload, exactly as received.
+//
+// Returns:
+//
+//	Nil when the signature is valid, or an `exception.Unauthorized` as for
+//	Verify, whose details also hold the signature "header".
+func (s *Signer) VerifyHeader(header http.Header, payload []byte) error {
+	name := s.config.SignatureHeader
+	details := map[string]interface{}{"header": name}
+
+	value := header.Get(name)
+	if value == "" {
+		return failure(fmt.Sprintf("The %s header is missing.", name), ReasonMissingSignature, details)
+	}
+	signature, ok := strings.CutPrefix(value, s.config.Prefix)
+	if !ok {
+		return failure("The signature is malformed.", ReasonMalformedSignature, details)
+	}
+	return s.verify(payload, header.Get(s.config.KeyIDHeader), signature, details)
+}
+
+// verify checks a signature, reporting failures with the given details.
+func (s *Signer) verify(payload []byte, keyID string, signature string, details map[string]interface{}) error {
+	decoded, err := s.decode(signature)
+	if err != nil || signature == "" {
+		return failure("The signature is malformed.", ReasonMalformedSignature, details)
+	}
+
+	candidates := s.config.Keys
+	if keyID != "" {
+		key, ok := s.byID[keyID]
+		if !ok {
+			details["key_id"] = keyID
+			return failure(fmt.Sprintf("The signing key %q is unknown.", keyID), ReasonUnknownKey, details)
+		}
+		candidates = []Key{key}
+	}
+
+	for _, key := range candidates {
+		if hmac.Equal(decoded, s.mac(key, payload)) {
+			return nil
+		}
+	}
+	return failure("The signature is invalid.", ReasonInvalidSignature, details)
+}
+
+// Equal reports whether two byte slices are equal, in a time independent of
+// their contents, so that comparing secrets leaks nothing to timing attacks.
+func Equal(a []byte, b []byte) bool {
+	return hmac.Equal(a, b)
+}
+
+// EqualString behaves like Equal for strings.
+func EqualString(a string, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
+
+// mac computes the HMAC of a payload with a key.
+func (s *Signer) mac(key Key, payload []byte) []byte {
+	mac := hmac.New(s.config.Hash, key.Secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// encode encodes a signature in the configured encoding.
+func (s *Signer) encode(signature []byte) string {
+	switch s.config.Encoding {
+	case Base64:
+		return base64.StdEncoding.EncodeToString(signature)
+	case Base64URL:
+		return base64.RawURLEncoding.EncodeToString(signature)
+	default:
+		return hex.EncodeToString(signature)
+	}
+}
+
+// decode decodes a signature from the configured encoding.
+func (s *Signer) decode(signature string) ([]byte, error) {
+	switch s.config.Encoding {
+	case Base64:
+		return base64.StdEncoding.DecodeString(signature)
+	case Base64URL:
+		return base64.RawURLEncoding.DecodeString(signature)
+	default:
+		return hex.DecodeString(signature)
+	}
+}
+
+// failure builds the Unauthorized exception of a verification failure.
+func failure(message string, reason string, details map[string]interface{}) error {
+	details["error"] = reason
+	return exception.NewUnauthorized(map[string]interface{}{
+		"message": message,
+		"details": details,
+	})
+}
@@ -0,0 +1,212 @@
+// Package specification provides the specification pattern: reusable,
+// composable business rules over candidates of type T. An unsatisfied
+// specification can be turned into an `exception.Domain` naming the rules
+// that failed, so that the same rules serve both for filtering and for
+// enforcing invariants.
+package specification
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Specification is a business rule a candidate satisfies or not.
+type Specification[T any] interface {
+	// IsSatisfiedBy reports whether the candidate satisfies the rule.
+	IsSatisfiedBy(candidate T) bool
+}
+
+// Identified is implemented by specifications carrying a rule identifier,
+// reported when they are not satisfied. Other specifications are identified
+// by their Go type.
+type Identified interface {
+	// Rule returns the identifier of the rule (e.g., "order.minimum_amount").
+	Rule() string
+}
+
+// Spec is the Specification built by this package. It can be composed
+// further with its And, Or and Not methods.
+type Spec[T any] struct {
+	rule      string
+	satisfied func(candidate T) bool
+	failures  func(candidate T) []string // The identifiers of the failing rules.
+}
+
+// New creates a specification from a predicate.
+//
+// Parameters:
+//
+//	rule: The identifier of the rule (e.g., "customer.adult").
+//	predicate: The function reporting whether a candidate satisfies the rule.
+//
+// Returns:
+//
+//	The Spec wrapping the predicate.
+func New[T any](rule string, predicate func(candidate T) bool) Spec[T] {
+	return Spec[T]{rule: rule, satisfied: predicate}
+}
+
+// Of converts any Specification into a Spec, so that it can be composed with
+// methods. A Spec is returned unchanged.
+func Of[T any](spec Specification[T]) Spec[T] {
+	if s, ok := spec.(Spec[T]); ok {
+		return s
+	}
+	return Spec[T]{rule: ruleOf(spec), satisfied: spec.IsSatisfiedBy}
+}
+
+// IsSatisfiedBy reports whether the candidate satisfies the rule.
+func (s Spec[T]) IsSatisfiedBy(candidate T) bool {
+	return s.satisfied(candidate)
+}
+
+// Rule returns the identifier of the rule. Composite rules are identified by
+// the composition of their operands, e.g. "and(a,not(b))".
+func (s Spec[T]) Rule() string {
+	return s.rule
+}
+
+// And returns a specification satisfied when s and every other specification
+// are satisfied. See the And function.
+func (s Spec[T]) And(others ...Specification[T]) Spec[T] {
+	return And(append([]Specification[T]{s}, others...)...)
+}
+
+// Or returns a specification satisfied when s or any other specification is
+// satisfied. See the Or function.
+func (s Spec[T]) Or(others ...Specification[T]) Spec[T] {
+	return Or(append([]Specification[T]{s}, others...)...)
+}
+
+// Not returns the negation of s. See the Not function.
+func (s Spec[T]) Not() Spec[T] {
+	return Not[T](s)
+}
+
+// And returns a specification satisfied when every specification is
+// satisfied. When it is not, every failing operand is reported.
+func And[T any](specs ...Specification[T]) Spec[T] {
+	return Spec[T]{
+		rule: composite("and", specs),
+		satisfied: func(candidate T) bool {
+			for _, spec := range specs {
+				if !spec.IsSatisfiedBy(candidate) {
+					return false
+				}
+			}
+			return true
+		},
+		failures: func(candidate T) []string {
+			var failed []string
+			for _, spec := range specs {
+				failed = append(failed, failures(spec, candidate)...)
+			}
+			return failed
+		},
+	}
+}
+
+// Or returns a specification satisfied when at least one specification is
+// satisfied. When it is not, every operand is reported.
+func Or[T any](specs ...Specification[T]) Spec[T] {
+	return Spec[T]{
+		rule: composite("or", specs),
+		satisfied: func(candidate T) bool {
+			for _, spec := range specs {
+				if spec.IsSatisfiedBy(candidate) {
+					return true
+				}
+			}
+			return false
+		},
+		failures: func(candidate T) []string {
+			var failed []string
+			for _, spec := range specs {
+				failed = append(failed, failures(spec, candidate)...)
+			}
+			return failed
+		},
+	}
+}
+
+// Not returns a specification satisfied when spec is not. When it is not,
+// the negation itself is reported, e.g. "not(order.shipped)".
+func Not[T any](spec Specification[T]) Spec[T] {
+	return Spec[T]{
+		rule:      "not(" + ruleOf(spec) + ")",
+		satisfied: func(candidate T) bool { return !spec.IsSatisfiedBy(candidate) },
+	}
+}
+
+// Check enforces a specification.
+//
+// Parameters:
+//
+//	spec: The specification the candidate must satisfy.
+//	candidate: The value to check.
+//
+// Returns:
+//
+//	Nil when the candidate satisfies the specification, or an
+//	`exception.Domain` otherwise. Its details hold the identifier of the
+//	first failing rule under "rule" and those of every failing rule under
+//	"rules".
+func Check[T any](spec Specification[T], candidate T) error {
+	if spec.IsSatisfiedBy(candidate) {
+		return nil
+	}
+
+	failed := failures(spec, candidate)
+	return exception.NewDomain(map[string]interface{}{
+		"message": fmt.Sprintf("The business rule %q is not satisfied.", failed[0]),
+		"details": map[string]interface{}{
+			"rule":  failed[0],
+			"rules": failed,
+			"error": "specification_unsatisfied",
+		},
+	})
+}
+
+// Filter returns the candidates satisfying a specification.
+func Filter[T any](spec Specification[T], candidates []T) []T {
+	var satisfied []T
+	for _, candidate := range candidates {
+		if spec.IsSatisfiedBy(candidate) {
+			satisfied = append(satisfied, candidate)
+		}
+	}
+	return satisfied
+}
+
+// failures returns the identifiers of the rules of spec that the candidate
+// does not satisfy.
+func failures[T any](spec Specification[T], candidate T) []string {
+	if spec.IsSatisfiedBy(candidate) {
+		return nil
+	}
+	if s, ok := spec.(Spec[T]); ok && s.failures != nil {
+		if failed := s.failures(candidate); len(failed) > 0 {
+			return failed
+		}
+	}
+	return []string{ruleOf(spec)}
+}
+
+// ruleOf returns the identifier of a specification.
+func ruleOf(spec interface{}) string {
+	if identified, ok := spec.(Identified); ok {
+		return identified.Rule()
+	}
+	return fmt.Sprintf("%T", spec)
+}
+
+// composite returns the identifier of a composite specification.
+func composite[T any](operator string, specs []Specification[T]) string {
+	rules := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		rules = append(rules, ruleOf(spec))
+	}
+	return operator + "(" + strings.Join(rules, ",") + ")"
+}
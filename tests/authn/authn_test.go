@@ -0,0 +1,130 @@
+package authn_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/authn"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+var now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// tokens maps the accepted tokens to their claims.
+var tokens = map[string]authn.Claims{
+	"valid":       {Subject: "u1", Audience: []string{"api"}, ExpiresAt: now.Add(time.Hour)},
+	"expired":     {Subject: "u1", Audience: []string{"api"}, ExpiresAt: now.Add(-time.Hour)},
+	"early":       {Subject: "u1", Audience: []string{"api"}, NotBefore: now.Add(time.Hour)},
+	"skewed":      {Subject: "u1", Audience: []string{"api"}, ExpiresAt: now.Add(-10 * time.Second)},
+	"foreign":     {Subject: "u1", Audience: []string{"admin"}},
+	"no-audience": {Subject: "u1"},
+}
+
+var unavailable = exception.NewServiceUnavailable(map[string]interface{}{"message": "Key set unreachable."})
+
+func newAuthenticator() *authn.Authenticator {
+	return authn.New(authn.Config{
+		Audience: "api",
+		Now:      func() time.Time { return now },
+		Verifier: authn.VerifierFunc(func(_ context.Context, token string) (authn.Claims, error) {
+			if token == "outage" {
+				return authn.Claims{}, unavailable
+			}
+			claims, ok := tokens[token]
+			if !ok {
+				return authn.Claims{}, errors.New("bad signature")
+			}
+			return claims, nil
+		}),
+	})
+}
+
+func reasonOf(err error) string {
+	var unauthorized *exception.Unauthorized
+	if !errors.As(err, &unauthorized) {
+		return ""
+	}
+	return unauthorized.GetDetailsMessage()
+}
+
+func TestAuthenticateRequest(t *testing.T) {
+	authenticator := newAuthenticator()
+	tests := []struct {
+		name          string
+		authorization string
+		reason        string
+	}{
+		{"Valid", "Bearer valid", ""},
+		{"CaseInsensitiveScheme", "bearer valid", ""},
+		{"WithinLeeway", "Bearer skewed", ""},
+		{"Missing", "", authn.ReasonMissingToken},
+		{"OtherScheme", "Basic dXNlcjpwYXNz", authn.ReasonMalformedToken},
+		{"EmptyToken", "Bearer ", authn.ReasonMalformedToken},
+		{"Rejected", "Bearer forged", authn.ReasonInvalidToken},
+		{"Expired", "Bearer expired", authn.ReasonExpiredToken},
+		{"NotYetValid", "Bearer early", authn.ReasonTokenNotYetValid},
+		{"ForeignAudience", "Bearer foreign", authn.ReasonInvalidAudience},
+		{"NoAudience", "Bearer no-audience", authn.ReasonInvalidAudience},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			claims, err := authenticator.AuthenticateRequest(r)
+			if tt.reason == "" {
+				if err != nil || claims.Subject != "u1" {
+					t.Fatalf("AuthenticateRequest() = %+v, %v", claims, err)
+				}
+				return
+			}
+			if got := reasonOf(err); got != tt.reason {
+				t.Errorf("reason = %q (%v), expected %q", got, err, tt.reason)
+			}
+		})
+	}
+
+	if _, err := authenticator.Authenticate(context.Background(), "outage"); !errors.Is(err, unavailable) {
+		t.Errorf("Authenticate() = %v, expected the verifier exception", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := authn.Middleware(newAuthenticator())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := authn.ClaimsFromContext(r.Context())
+		if !ok {
+			t.Error("claims missing from the context")
+		}
+		_, _ = w.Write([]byte(claims.Subject))
+	}))
+
+	tests := []struct {
+		authorization string
+		code          int
+		challenge     string
+	}{
+		{"Bearer valid", http.StatusOK, ""},
+		{"", http.StatusUnauthorized, "Bearer"},
+		{"Token abc", http.StatusUnauthorized, `Bearer error="invalid_request"`},
+		{"Bearer expired", http.StatusUnauthorized, `Bearer error="invalid_token"`},
+		{"Bearer outage", http.StatusServiceUnavailable, ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		if rec.Code != tt.code || rec.Header().Get("WWW-Authenticate") != tt.challenge {
+			t.Errorf("%q: got %d with challenge %q, expected %d with %q",
+				tt.authorization, rec.Code, rec.Header().Get("WWW-Authenticate"), tt.code, tt.challenge)
+		}
+	}
+}
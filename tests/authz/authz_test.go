@@ -0,0 +1,99 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/authz"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+type user struct {
+	id    string
+	admin bool
+}
+
+type document struct {
+	owner string
+	draft bool
+}
+
+var (
+	admin = authz.New("admin", func(_ context.Context, subject interface{}, _ string, _ interface{}) (bool, error) {
+		return subject.(user).admin, nil
+	})
+	owner = authz.New("document.owner", func(_ context.Context, subject interface{}, _ string, resource interface{}) (bool, error) {
+		return resource.(document).owner == subject.(user).id, nil
+	})
+	draft = authz.New("document.draft", func(_ context.Context, _ interface{}, _ string, resource interface{}) (bool, error) {
+		return resource.(document).draft, nil
+	})
+)
+
+func TestAuthorize(t *testing.T) {
+	policy := authz.AnyOf(admin, authz.AllOf(owner, draft))
+	if policy.Name() != "any_of(admin,all_of(document.owner,document.draft))" {
+		t.Errorf("Name() = %q", policy.Name())
+	}
+
+	tests := []struct {
+		name     string
+		subject  user
+		resource document
+		denied   []string
+	}{
+		{"Admin", user{id: "u1", admin: true}, document{owner: "u2"}, nil},
+		{"OwnerOfDraft", user{id: "u1"}, document{owner: "u1", draft: true}, nil},
+		{"OwnerOfPublished", user{id: "u1"}, document{owner: "u1"}, []string{"admin", "document.draft"}},
+		{"Stranger", user{id: "u1"}, document{owner: "u2", draft: true}, []string{"admin", "document.owner"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authz.Authorize(context.Background(), policy, tt.subject, "document.delete", tt.resource)
+			if tt.denied == nil {
+				if err != nil {
+					t.Fatalf("Authorize() = %v, expected nil", err)
+				}
+				return
+			}
+
+			var forbidden *exception.Forbidden
+			if !errors.As(err, &forbidden) {
+				t.Fatalf("Authorize() = %v, expected *exception.Forbidden", err)
+			}
+			details := forbidden.GetDetails()
+			if details["policy"] != tt.denied[0] || !reflect.DeepEqual(details["policies"], tt.denied) ||
+				details["action"] != "document.delete" || forbidden.GetDetailsMessage() != "access_denied" {
+				t.Errorf("unexpected details %+v", details)
+			}
+		})
+	}
+}
+
+type denyAll struct{}
+
+func (denyAll) Allow(context.Context, interface{}, string, interface{}) (bool, error) {
+	return false, nil
+}
+
+func TestForeignCheckersAndErrors(t *testing.T) {
+	err := authz.Authorize(context.Background(), denyAll{}, nil, "read", nil)
+	var forbidden *exception.Forbidden
+	if !errors.As(err, &forbidden) || forbidden.GetDetails()["policy"] != "authz_test.denyAll" {
+		t.Errorf("Authorize() = %v", err)
+	}
+
+	failure := errors.New("permission store unreachable")
+	broken := authz.CheckerFunc(func(context.Context, interface{}, string, interface{}) (bool, error) {
+		return false, failure
+	})
+	if err := authz.Authorize(context.Background(), authz.AllOf(broken), nil, "read", nil); !errors.Is(err, failure) {
+		t.Errorf("Authorize() = %v, expected the checker error", err)
+	}
+	if allowed, err := authz.AnyOf(denyAll{}, admin).Allow(context.Background(), user{admin: true}, "read", nil); !allowed || err != nil {
+		t.Errorf("Allow() = %v, %v", allowed, err)
+	}
+}
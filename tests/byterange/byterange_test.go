@@ -0,0 +1,77 @@
+package byterange_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/byterange"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		size     int64
+		expected []byterange.Range
+		err      bool
+	}{
+		{"Closed", "bytes=0-499", 1000, []byterange.Range{{Start: 0, End: 499}}, false},
+		{"Open", "bytes=900-", 1000, []byterange.Range{{Start: 900, End: 999}}, false},
+		{"Suffix", "bytes=-100", 1000, []byterange.Range{{Start: 900, End: 999}}, false},
+		{"SuffixLargerThanSize", "bytes=-5000", 1000, []byterange.Range{{Start: 0, End: 999}}, false},
+		{"Truncated", "bytes=500-5000", 1000, []byterange.Range{{Start: 500, End: 999}}, false},
+		{"Multiple", "bytes=0-0, -1", 1000, []byterange.Range{{Start: 0, End: 0}, {Start: 999, End: 999}}, false},
+		{"UnsatisfiableDropped", "bytes=0-9,2000-", 1000, []byterange.Range{{Start: 0, End: 9}}, false},
+		{"Unsatisfiable", "bytes=1000-", 1000, nil, true},
+		{"ZeroSuffix", "bytes=-0", 1000, nil, true},
+		{"EmptyResource", "bytes=0-", 0, nil, true},
+		{"OtherUnit", "items=0-5", 1000, nil, false},
+		{"Reversed", "bytes=5-1", 1000, nil, false},
+		{"Malformed", "bytes=a-b", 1000, nil, false},
+		{"Signed", "bytes=+1-2", 1000, nil, false},
+		{"Empty", "", 1000, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := byterange.Parse(tt.header, tt.size)
+			if !reflect.DeepEqual(ranges, tt.expected) {
+				t.Errorf("Parse() = %v, expected %v", ranges, tt.expected)
+			}
+			var unsatisfiable *exception.RangeNotSatisfiable
+			if errors.As(err, &unsatisfiable) != tt.err {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if tt.err && (unsatisfiable.GetStatusCode() != 416 || unsatisfiable.GetDetails()["size"] != tt.size ||
+				unsatisfiable.GetDetails()["content_range"] != fmt.Sprintf("bytes */%d", tt.size)) {
+				t.Errorf("unexpected exception %d %v", unsatisfiable.GetStatusCode(), unsatisfiable.GetDetails())
+			}
+		})
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := byterange.Range{Start: 10, End: 19}
+	if r.Length() != 10 || r.ContentRange(100) != "bytes 10-19/100" {
+		t.Errorf("Length() = %d, ContentRange() = %q", r.Length(), r.ContentRange(100))
+	}
+}
+
+func TestParseRequest(t *testing.T) {
+	get := httptest.NewRequest(http.MethodGet, "/file", nil)
+	get.Header.Set("Range", "bytes=0-1")
+	if ranges, err := byterange.ParseRequest(get, 10); err != nil || len(ranges) != 1 {
+		t.Errorf("ParseRequest(GET) = %v, %v", ranges, err)
+	}
+
+	put := httptest.NewRequest(http.MethodPut, "/file", nil)
+	put.Header.Set("Range", "bytes=0-1")
+	if ranges, err := byterange.ParseRequest(put, 10); err != nil || ranges != nil {
+		t.Errorf("ParseRequest(PUT) = %v, %v", ranges, err)
+	}
+}
@@ -0,0 +1,127 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/cache"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/metrics"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestLRUGetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU[string](cache.LRUConfig{})
+
+	if _, err := c.Get(ctx, "a"); !cache.IsMiss(err) {
+		t.Fatalf("Get on empty cache = %v, want a miss", err)
+	}
+	_ = c.Set(ctx, "a", "1", 0)
+	if value, err := c.Get(ctx, "a"); err != nil || value != "1" {
+		t.Fatalf("Get = %q, %v", value, err)
+	}
+	_ = c.Delete(ctx, "a")
+	_, err := c.Get(ctx, "a")
+
+	var miss *exception.CacheMiss
+	if !errors.As(err, &miss) {
+		t.Fatalf("Get after Delete = %v, want *exception.CacheMiss", err)
+	}
+	if miss.GetStatusCode() != 404 || miss.GetDetails()["key"] != "a" || miss.GetDetailsMessage() != "cache_miss" {
+		t.Errorf("unexpected miss %d %v", miss.GetStatusCode(), miss.GetDetails())
+	}
+}
+
+func TestLRUExpiration(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := cache.NewLRU[int](cache.LRUConfig{Now: clock.Now})
+
+	_ = c.Set(ctx, "short", 1, time.Minute)
+	_ = c.Set(ctx, "forever", 2, 0)
+	clock.now = clock.now.Add(time.Minute)
+
+	if _, err := c.Get(ctx, "short"); !cache.IsMiss(err) {
+		t.Errorf("expired entry: err = %v, want a miss", err)
+	}
+	if value, err := c.Get(ctx, "forever"); err != nil || value != 2 {
+		t.Errorf("entry without ttl = %d, %v", value, err)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len = %d, want 1", c.Len())
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU[int](cache.LRUConfig{Capacity: 2})
+
+	_ = c.Set(ctx, "a", 1, 0)
+	_ = c.Set(ctx, "b", 2, 0)
+	_, _ = c.Get(ctx, "a") // "b" becomes the least recently used entry.
+	_ = c.Set(ctx, "c", 3, 0)
+
+	tests := []struct {
+		key  string
+		miss bool
+	}{
+		{"a", false},
+		{"b", true},
+		{"c", false},
+	}
+	for _, tt := range tests {
+		if _, err := c.Get(ctx, tt.key); cache.IsMiss(err) != tt.miss {
+			t.Errorf("Get(%q) miss = %v, want %v", tt.key, cache.IsMiss(err), tt.miss)
+		}
+	}
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Errorf("Len after Purge = %d", c.Len())
+	}
+}
+
+type failing struct{ cache.Cache[string] }
+
+func (failing) Get(context.Context, string) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+func (failing) Set(context.Context, string, string, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func TestWithMetrics(t *testing.T) {
+	ctx := context.Background()
+	registry := metrics.NewRegistry()
+	c := cache.WithMetrics[string](cache.NewLRU[string](cache.LRUConfig{}), registry, "sessions")
+	broken := cache.WithMetrics[string](failing{}, registry, "broken")
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_, _ = c.Get(ctx, "a")
+	_, _ = c.Get(ctx, "a")
+	_, _ = c.Get(ctx, "b")
+	if _, err := broken.Get(ctx, "a"); err == nil || cache.IsMiss(err) {
+		t.Errorf("failing Get = %v, want an infrastructure error", err)
+	}
+	_ = broken.Set(ctx, "a", "1", 0)
+
+	text := registry.Text()
+	for _, line := range []string{
+		`cache_lookups_total{cache="sessions",result="hit"} 2`,
+		`cache_lookups_total{cache="sessions",result="miss"} 1`,
+		`cache_lookups_total{cache="broken",result="error"} 1`,
+		`cache_errors_total{cache="broken",operation="set"} 1`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("missing line %q in:\n%s", line, text)
+		}
+	}
+}
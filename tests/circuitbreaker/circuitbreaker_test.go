@@ -0,0 +1,207 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/circuitbreaker"
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func failWith(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestBreakerLifecycle(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	var transitions []circuitbreaker.State
+	breaker := circuitbreaker.New("payments", circuitbreaker.Config{
+		Thresholds:  map[status.StatusClass]int{status.ServerErrorClass: 2},
+		OpenTimeout: 10 * time.Second,
+		Now:         clock.Now,
+		OnStateChange: func(name string, from, to circuitbreaker.State) {
+			transitions = append(transitions, to)
+		},
+	})
+	ctx := context.Background()
+	serverErr := exception.NewRuntime(nil)
+
+	_ = breaker.Execute(ctx, failWith(serverErr))
+	_ = breaker.Execute(ctx, failWith(exception.NewInvalidArgument(nil))) // Client errors do not count.
+	if breaker.State() != circuitbreaker.Closed {
+		t.Fatalf("Breaker opened before reaching the threshold")
+	}
+	_ = breaker.Execute(ctx, failWith(serverErr))
+	if breaker.State() != circuitbreaker.Open {
+		t.Fatalf("Breaker should be open, got %s", breaker.State())
+	}
+
+	called := false
+	err := breaker.Execute(ctx, func(context.Context) error { called = true; return nil })
+	var unavailable *exception.ServiceUnavailable
+	if !errors.As(err, &unavailable) || called {
+		t.Fatalf("Expected a *ServiceUnavailable rejection without calling fn, got %v", err)
+	}
+	details := unavailable.GetDetails()
+	if details["breaker"] != "payments" || details["reopen_at"] != "2026-01-01T12:00:10Z" || details["retry_after"] != 10 {
+		t.Errorf("Unexpected rejection details: %+v", details)
+	}
+
+	clock.now = clock.now.Add(10 * time.Second)
+	if breaker.State() != circuitbreaker.HalfOpen {
+		t.Fatalf("Breaker should be half-open, got %s", breaker.State())
+	}
+	if err := breaker.Execute(ctx, failWith(nil)); err != nil {
+		t.Fatalf("Probe call returned %v", err)
+	}
+	if breaker.State() != circuitbreaker.Closed {
+		t.Fatalf("A successful probe should close the breaker, got %s", breaker.State())
+	}
+
+	expected := []circuitbreaker.State{circuitbreaker.Open, circuitbreaker.HalfOpen, circuitbreaker.Closed}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Transitions = %v, expected %v", transitions, expected)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("Transitions = %v, expected %v", transitions, expected)
+		}
+	}
+}
+
+func TestHalfOpenProbeFailureReopens(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	breaker := circuitbreaker.New("search", circuitbreaker.Config{
+		Thresholds: map[status.StatusClass]int{status.ServerErrorClass: 1},
+		Now:        clock.Now,
+	})
+
+	_ = breaker.Execute(context.Background(), failWith(errors.New("boom")))
+	clock.now = clock.now.Add(circuitbreaker.DefaultOpenTimeout)
+
+	done, err := breaker.Allow()
+	if err != nil {
+		t.Fatalf("The first probe should be allowed: %v", err)
+	}
+	if _, err := breaker.Allow(); err == nil {
+		t.Error("Only one concurrent probe should be allowed by default")
+	}
+	done(errors.New("still down"))
+
+	if breaker.State() != circuitbreaker.Open {
+		t.Errorf("A failed probe should reopen the breaker, got %s", breaker.State())
+	}
+}
+
+func TestStaleOutcomeIgnored(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	breaker := circuitbreaker.New("search", circuitbreaker.Config{
+		Thresholds: map[status.StatusClass]int{status.ServerErrorClass: 1},
+		Now:        clock.Now,
+	})
+
+	slow, err := breaker.Allow() // Admitted while closed, completing after the trip.
+	if err != nil {
+		t.Fatalf("The call should be allowed: %v", err)
+	}
+	_ = breaker.Execute(context.Background(), failWith(errors.New("boom")))
+	clock.now = clock.now.Add(circuitbreaker.DefaultOpenTimeout)
+	if breaker.State() != circuitbreaker.HalfOpen {
+		t.Fatalf("Breaker should be half-open, got %s", breaker.State())
+	}
+
+	slow(nil)
+	if breaker.State() != circuitbreaker.HalfOpen {
+		t.Errorf("A call admitted before the trip should not close the breaker, got %s", breaker.State())
+	}
+	probe, err := breaker.Allow()
+	if err != nil {
+		t.Fatalf("The stale call should not consume the probe: %v", err)
+	}
+	probe(nil)
+	if breaker.State() != circuitbreaker.Closed {
+		t.Errorf("A successful probe should close the breaker, got %s", breaker.State())
+	}
+}
+
+func TestPanickingProbeReleased(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := circuitbreaker.New("payments", circuitbreaker.Config{
+		Thresholds:  map[status.StatusClass]int{status.ServerErrorClass: 1},
+		OpenTimeout: time.Second,
+		Now:         clock.Now,
+	})
+	ctx := context.Background()
+
+	_ = breaker.Execute(ctx, failWith(exception.NewRuntime(nil)))
+	clock.now = clock.now.Add(time.Second)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Execute() should propagate the panic of fn")
+			}
+		}()
+		_ = breaker.Execute(ctx, func(context.Context) error { panic("boom") })
+	}()
+	if breaker.State() != circuitbreaker.Open {
+		t.Fatalf("A panicking probe should reopen the breaker, got %s", breaker.State())
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if err := breaker.Execute(ctx, failWith(nil)); err != nil || breaker.State() != circuitbreaker.Closed {
+		t.Errorf("The next probe should close the breaker, got %v in state %s", err, breaker.State())
+	}
+}
+
+func TestCallerCancellationIgnored(t *testing.T) {
+	breaker := circuitbreaker.New("payments", circuitbreaker.Config{
+		Thresholds: map[status.StatusClass]int{status.ServerErrorClass: 1},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := breaker.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+	if !errors.Is(err, context.Canceled) || breaker.State() != circuitbreaker.Closed {
+		t.Errorf("A canceled caller should not open the breaker, got %v in state %s", err, breaker.State())
+	}
+
+	_ = breaker.Execute(context.Background(), failWith(context.DeadlineExceeded))
+	if breaker.State() != circuitbreaker.Open {
+		t.Errorf("A deadline of the dependency should still count, got %s", breaker.State())
+	}
+}
+
+func TestOnStateChangeMayCallBreaker(t *testing.T) {
+	var breaker *circuitbreaker.Breaker
+	var observed []circuitbreaker.State
+	breaker = circuitbreaker.New("payments", circuitbreaker.Config{
+		Thresholds: map[status.StatusClass]int{status.ServerErrorClass: 1},
+		OnStateChange: func(name string, from, to circuitbreaker.State) {
+			observed = append(observed, breaker.State())
+			_, _ = breaker.Allow()
+		},
+	})
+
+	_ = breaker.Execute(context.Background(), failWith(exception.NewRuntime(nil)))
+	if len(observed) != 1 || observed[0] != circuitbreaker.Open {
+		t.Errorf("OnStateChange observed %v, want [open]", observed)
+	}
+}
+
+func TestExecuteValue(t *testing.T) {
+	breaker := circuitbreaker.New("inventory", circuitbreaker.Config{})
+	value, err := circuitbreaker.Execute(context.Background(), breaker, func(context.Context) (int, error) {
+		return 42, nil
+	})
+	if err != nil || value != 42 {
+		t.Errorf("Execute() = %d, %v", value, err)
+	}
+}
@@ -0,0 +1,32 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFake(start)
+
+	c.Advance(90 * time.Second)
+	if got := c.Now(); !got.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("Now() = %v after Advance", got)
+	}
+	c.Set(start)
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v after Set", got)
+	}
+}
+
+func TestOrSystem(t *testing.T) {
+	if now := clock.OrSystem(nil).Now(); now.Location() != time.UTC || time.Since(now) > time.Minute {
+		t.Errorf("The system clock should return the current UTC time, got %v", now)
+	}
+	fake := clock.NewFake(time.Unix(0, 0))
+	if clock.OrSystem(fake) != clock.Clock(fake) {
+		t.Error("OrSystem should return a non-nil clock unchanged")
+	}
+}
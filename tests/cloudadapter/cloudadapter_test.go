@@ -0,0 +1,125 @@
+package cloudadapter_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/cloudadapter"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/tests/cloudadapter/googleapi"
+)
+
+// apiError mimics `smithy.GenericAPIError`.
+type apiError struct{ code string }
+
+func (e *apiError) Error() string        { return "api error " + e.code }
+func (e *apiError) ErrorCode() string    { return e.code }
+func (e *apiError) ErrorMessage() string { return "secret bucket name" }
+
+// responseError mimics `awshttp.ResponseError`.
+type responseError struct {
+	status int
+	err    error
+}
+
+func (e *responseError) Error() string       { return "http response error" }
+func (e *responseError) HTTPStatusCode() int { return e.status }
+func (e *responseError) Unwrap() error       { return e.err }
+
+// operationError mimics `smithy.OperationError`.
+type operationError struct {
+	service string
+	err     error
+}
+
+func (e *operationError) Error() string   { return "operation error " + e.service }
+func (e *operationError) Service() string { return e.service }
+func (e *operationError) Unwrap() error   { return e.err }
+
+// grpcStatus mimics `status.Status` of grpc.
+type grpcStatus struct{ code uint32 }
+
+func (s *grpcStatus) Code() uint32 { return s.code }
+
+// statusError mimics the errors of grpc.
+type statusError struct{ code uint32 }
+
+func (e *statusError) Error() string           { return "rpc error" }
+func (e *statusError) GRPCStatus() *grpcStatus { return &grpcStatus{e.code} }
+
+func TestAWS(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    interface{}
+		details map[string]interface{}
+	}{
+		{"Throttling", &operationError{"DynamoDB", &responseError{400, &apiError{"ThrottlingException"}}}, &exception.TooManyRequests{},
+			map[string]interface{}{"error": cloudadapter.Throttled, "provider": "aws", "service": "DynamoDB", "code": "ThrottlingException"}},
+		{"AccessDenied", &apiError{"AccessDenied"}, &exception.Forbidden{},
+			map[string]interface{}{"error": cloudadapter.AccessDenied, "provider": "aws", "code": "AccessDenied"}},
+		{"NoSuchKey", fmt.Errorf("get: %w", &operationError{"S3", &apiError{"NoSuchKey"}}), &exception.NotFound{},
+			map[string]interface{}{"error": cloudadapter.ResourceNotFound, "provider": "aws", "service": "S3", "code": "NoSuchKey"}},
+		{"Status5xx", &operationError{"SQS", &responseError{503, &apiError{"Unmapped"}}}, &exception.UpstreamFailure{},
+			map[string]interface{}{"error": cloudadapter.ServiceFailure, "provider": "aws", "service": "SQS", "code": "Unmapped"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cloudadapter.AWS(tt.err)
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Fatalf("AWS() = %T, want %T", got, tt.want)
+			}
+			if !reflect.DeepEqual(got.GetDetails(), tt.details) {
+				t.Errorf("GetDetails() = %v, want %v", got.GetDetails(), tt.details)
+			}
+		})
+	}
+
+	for _, err := range []error{errors.New("plain"), &apiError{"ValidationException"}, &responseError{status: 400}} {
+		if got := cloudadapter.AWS(err); got != nil {
+			t.Errorf("AWS(%v) = %v, want nil", err, got)
+		}
+	}
+}
+
+func TestGCP(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want interface{}
+	}{
+		{"ResourceExhausted", &statusError{8}, &exception.TooManyRequests{}},
+		{"PermissionDenied", fmt.Errorf("publish: %w", &statusError{7}), &exception.Forbidden{}},
+		{"Unavailable", &statusError{14}, &exception.UpstreamFailure{}},
+		{"GoogleAPINotFound", &googleapi.Error{Code: 404, Message: "No such object"}, &exception.NotFound{}},
+		{"GoogleAPI500", &googleapi.Error{Code: 500}, &exception.UpstreamFailure{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cloudadapter.GCP(tt.err); reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Errorf("GCP() = %T, want %T", got, tt.want)
+			}
+		})
+	}
+
+	for _, err := range []error{errors.New("plain"), &statusError{3}, &googleapi.Error{Code: 400}} {
+		if got := cloudadapter.GCP(err); got != nil {
+			t.Errorf("GCP(%v) = %v, want nil", err, got)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	unregister := cloudadapter.Register()
+	got := exception.FromError(&apiError{"NoSuchBucket"})
+	unregister()
+
+	if _, ok := got.(*exception.NotFound); !ok {
+		t.Errorf("FromError() = %T, want *exception.NotFound", got)
+	}
+	if got := exception.FromError(&apiError{"NoSuchBucket"}); got.GetStatusCode() != 500 {
+		t.Errorf("FromError() after unregister = %d, want 500", got.GetStatusCode())
+	}
+}
@@ -0,0 +1,16 @@
+// Package googleapi mimics the error type of google.golang.org/api/googleapi
+// for the tests of the cloudadapter package.
+package googleapi
+
+import "fmt"
+
+// Error mimics `googleapi.Error`.
+type Error struct {
+	Code    int
+	Message string
+}
+
+// Error returns the status and the message of the error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("googleapi: Error %d: %s", e.Code, e.Message)
+}
@@ -0,0 +1,102 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/config"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+type settings struct {
+	Port    int                `env:"PORT" default:"8080" validate:"min=1,max=65535"`
+	Mode    string             `env:"MODE" default:"production" validate:"oneof=development production"`
+	Timeout time.Duration      `env:"TIMEOUT" default:"30s"`
+	Debug   bool               `env:"DEBUG"`
+	Hosts   []string           `env:"HOSTS"`
+	Admin   *valueobject.Email `env:"ADMIN_EMAIL"`
+	Name    string             `json:"name" validate:"required"`
+
+	Database struct {
+		URL      string `env:"DATABASE_URL" validate:"required"`
+		MaxConns uint8  `env:"DATABASE_MAX_CONNS" default:"10"`
+	}
+}
+
+func env(values map[string]string) config.Option {
+	return config.WithLookup(func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "config.json")
+	dotenvFile := filepath.Join(dir, ".env")
+	_ = os.WriteFile(jsonFile, []byte(`{"name":"orders","Port":9000}`), 0o600)
+	_ = os.WriteFile(dotenvFile, []byte("# Local settings\nexport APP_DATABASE_URL=\"postgres://localhost/orders\"\nAPP_MODE=development\n"), 0o600)
+
+	var cfg settings
+	err := config.Load(&cfg,
+		config.WithPrefix("APP_"),
+		config.WithFile(jsonFile),
+		config.WithFile(dotenvFile),
+		config.WithFile(filepath.Join(dir, "missing.env")),
+		env(map[string]string{"APP_MODE": "production", "APP_HOSTS": "a, b", "APP_DEBUG": "true", "APP_ADMIN_EMAIL": "ops@example.com"}),
+	)
+	if err != nil {
+		t.Fatalf("Load() returned %v", err)
+	}
+
+	if cfg.Port != 9000 || cfg.Mode != "production" || cfg.Timeout != 30*time.Second || !cfg.Debug || cfg.Name != "orders" {
+		t.Errorf("Unexpected settings: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.Hosts, []string{"a", "b"}) || cfg.Admin == nil || cfg.Admin.String() != "ops@example.com" {
+		t.Errorf("Unexpected list or text settings: %v %v", cfg.Hosts, cfg.Admin)
+	}
+	if cfg.Database.URL != "postgres://localhost/orders" || cfg.Database.MaxConns != 10 {
+		t.Errorf("Unexpected nested settings: %+v", cfg.Database)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	var cfg settings
+	err := config.Load(&cfg, env(map[string]string{
+		"PORT":               "70000",
+		"MODE":               "staging",
+		"TIMEOUT":            "soon",
+		"DATABASE_MAX_CONNS": "300",
+		"ADMIN_EMAIL":        "not-an-email",
+	}))
+
+	var configuration *exception.Configuration
+	if !errors.As(err, &configuration) {
+		t.Fatalf("Expected a *Configuration, got %T: %v", err, err)
+	}
+	problems := configuration.GetErrors()["errors"].(map[string]interface{})
+	for _, key := range []string{"PORT", "MODE", "TIMEOUT", "DATABASE_MAX_CONNS", "ADMIN_EMAIL", "DATABASE_URL", "name"} {
+		if _, ok := problems[key]; !ok {
+			t.Errorf("Expected a problem for %s, got %v", key, problems)
+		}
+	}
+	if len(problems) != 7 || configuration.GetDetails()["count"] != 7 {
+		t.Errorf("Expected 7 problems, got %d: %v", len(problems), problems)
+	}
+}
+
+func TestLoadRejectsInvalidDestinations(t *testing.T) {
+	var unsupported struct {
+		Handler func() `env:"HANDLER"`
+	}
+	for _, dst := range []interface{}{nil, settings{}, &unsupported} {
+		if err := config.Load(dst); !errors.As(err, new(*exception.Logic)) {
+			t.Errorf("Load(%T): expected a *Logic, got %v", dst, err)
+		}
+	}
+}
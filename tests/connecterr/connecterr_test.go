@@ -0,0 +1,165 @@
+package connecterr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/connecterr"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want connecterr.Code
+	}{
+		{exception.NewNotFound(map[string]interface{}{}), connecterr.CodeNotFound},
+		{exception.NewInvalidArgument(map[string]interface{}{}), connecterr.CodeInvalidArgument},
+		{exception.NewUnauthorized(map[string]interface{}{}), connecterr.CodeUnauthenticated},
+		{exception.NewForbidden(map[string]interface{}{}), connecterr.CodePermissionDenied},
+		{exception.NewConflict(map[string]interface{}{}), connecterr.CodeAlreadyExists},
+		{exception.NewTooManyRequests(map[string]interface{}{}), connecterr.CodeResourceExhausted},
+		{exception.NewServiceUnavailable(map[string]interface{}{}), connecterr.CodeUnavailable},
+		{exception.NewError(map[string]interface{}{}), connecterr.CodeInternal},
+		{fmt.Errorf("wrapped: %w", exception.NewNotFound(map[string]interface{}{})), connecterr.CodeNotFound},
+		{context.Canceled, connecterr.CodeCanceled},
+		{context.DeadlineExceeded, connecterr.CodeDeadlineExceeded},
+		{errors.New("plain"), connecterr.CodeInternal},
+	}
+	for _, tt := range tests {
+		if got := connecterr.CodeOf(tt.err); got != tt.want {
+			t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCodeNumberAndHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code   connecterr.Code
+		number uint32
+		status int
+	}{
+		{connecterr.CodeCanceled, 1, 499},
+		{connecterr.CodeNotFound, 5, 404},
+		{connecterr.CodeFailedPrecondition, 9, 400},
+		{connecterr.CodeUnauthenticated, 16, 401},
+		{connecterr.Code("bogus"), 2, 500},
+	}
+	for _, tt := range tests {
+		if got := tt.code.Number(); got != tt.number {
+			t.Errorf("%q.Number() = %d, want %d", tt.code, got, tt.number)
+		}
+		if got := tt.code.HTTPStatus(); got != tt.status {
+			t.Errorf("%q.HTTPStatus() = %d, want %d", tt.code, got, tt.status)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := exception.NewNotFound(map[string]interface{}{
+		"message": "The order was not found.",
+		"details": map[string]interface{}{
+			"error":    "ORDER_NOT_FOUND",
+			"order_id": 42,
+			"tags":     []interface{}{"a", true, nil, 1.5},
+		},
+	})
+
+	connectErr := connecterr.FromException(original)
+	if connectErr.Code != connecterr.CodeNotFound || connectErr.Message != "The order was not found." {
+		t.Fatalf("FromException() = %+v", connectErr)
+	}
+	if len(connectErr.Details) != 1 || connectErr.Details[0].Type != connecterr.StructType {
+		t.Fatalf("Details = %+v, want one %s", connectErr.Details, connecterr.StructType)
+	}
+	if strings.HasSuffix(connectErr.Details[0].Value, "=") {
+		t.Errorf("Value %q is padded", connectErr.Details[0].Value)
+	}
+
+	// Through the wire format, as a Connect client would see it.
+	encoded, err := json.Marshal(connectErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded connecterr.Error
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := connecterr.ToException(&decoded)
+	if _, ok := restored.(*exception.NotFound); !ok {
+		t.Fatalf("ToException() = %T, want *exception.NotFound", restored)
+	}
+	if restored.Error() != "The order was not found." || restored.GetDetailsMessage() != "ORDER_NOT_FOUND" {
+		t.Errorf("ToException() = %q, %q", restored.Error(), restored.GetDetailsMessage())
+	}
+	want := map[string]interface{}{
+		"error":    "ORDER_NOT_FOUND",
+		"order_id": float64(42),
+		"tags":     []interface{}{"a", true, nil, 1.5},
+	}
+	if got := restored.GetDetails(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDetails() = %v, want %v", got, want)
+	}
+}
+
+func TestToExceptionWithoutDetails(t *testing.T) {
+	tests := []struct {
+		err        *connecterr.Error
+		statusCode int
+		message    string
+	}{
+		{&connecterr.Error{Code: connecterr.CodePermissionDenied, Message: "No."}, 403, "No."},
+		{&connecterr.Error{Code: connecterr.CodeFailedPrecondition}, 412, "Precondition Failed"},
+		{&connecterr.Error{Code: connecterr.Code("bogus")}, 500, "Internal Server Error"},
+	}
+	for _, tt := range tests {
+		got := connecterr.ToException(tt.err)
+		if got.GetStatusCode() != tt.statusCode || got.Error() != tt.message {
+			t.Errorf("ToException(%+v) = %d %q, want %d %q", tt.err, got.GetStatusCode(), got.Error(), tt.statusCode, tt.message)
+		}
+		if got.GetDetailsMessage() != string(tt.err.Code) {
+			t.Errorf("GetDetailsMessage() = %q, want %q", got.GetDetailsMessage(), tt.err.Code)
+		}
+	}
+	if connecterr.ToException(nil) != nil || connecterr.FromException(nil) != nil {
+		t.Error("nil errors are not converted to nil")
+	}
+}
+
+func TestWriteAndReadError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := fmt.Errorf("lookup: %w", exception.NewUnauthorized(map[string]interface{}{"message": "Token expired."}))
+	if writeErr := connecterr.WriteError(recorder, err); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+	if recorder.Code != http.StatusUnauthorized || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("response = %d %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if challenge := recorder.Header().Get("WWW-Authenticate"); challenge != exception.DefaultChallenge {
+		t.Errorf("WWW-Authenticate = %q, want %q", challenge, exception.DefaultChallenge)
+	}
+
+	got := connecterr.ReadError(recorder.Result())
+	if got.Code != connecterr.CodeUnauthenticated || got.Message != "Token expired." {
+		t.Errorf("ReadError() = %+v", got)
+	}
+	if got.Error() != "unauthenticated: Token expired." {
+		t.Errorf("Error() = %q", got.Error())
+	}
+
+	// A response that is not a Connect error, e.g. from a proxy.
+	proxy := httptest.NewRecorder()
+	proxy.WriteHeader(http.StatusBadGateway)
+	_, _ = proxy.WriteString("<html>Bad Gateway</html>")
+	if got := connecterr.ReadError(proxy.Result()); got.Code != connecterr.CodeUnavailable {
+		t.Errorf("ReadError(502) code = %q, want unavailable", got.Code)
+	}
+}
@@ -0,0 +1,114 @@
+package cqrs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/cqrs"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/logger"
+)
+
+type registerUser struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func (registerUser) CommandName() string { return "user.register" }
+
+type getUser struct{ ID int }
+
+func (getUser) QueryName() string { return "user.get" }
+
+type fakeTx struct{ committed, rolledBack int }
+
+func (tx *fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		tx.rolledBack++
+		return err
+	}
+	tx.committed++
+	return nil
+}
+
+func TestSendAndAsk(t *testing.T) {
+	tx := &fakeTx{}
+	var recorded []string
+	bus := cqrs.New(
+		cqrs.Logging(logger.Nop()),
+		cqrs.Metrics(func(msg cqrs.Message, d time.Duration, err error) { recorded = append(recorded, msg.Name) }),
+		cqrs.Validation(nil),
+		cqrs.Transaction(tx),
+	)
+
+	var registered []string
+	if err := cqrs.HandleCommand(bus, func(ctx context.Context, cmd registerUser) error {
+		registered = append(registered, cmd.Email)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cqrs.HandleQuery(bus, func(ctx context.Context, q getUser) (string, error) {
+		return "user-42", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cqrs.HandleCommand(bus, func(ctx context.Context, cmd registerUser) error { return nil }); err == nil {
+		t.Error("Registering a second handler for a command should fail")
+	}
+
+	ctx := context.Background()
+	if err := bus.Send(ctx, registerUser{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("Send() returned %v", err)
+	}
+	user, err := cqrs.Ask[string](ctx, bus, getUser{ID: 42})
+	if err != nil || user != "user-42" {
+		t.Fatalf("Ask() = %q, %v", user, err)
+	}
+
+	err = bus.Send(ctx, registerUser{Email: "not-an-email"})
+	var validation *exception.Validation
+	if !errors.As(err, &validation) {
+		t.Fatalf("Expected a *Validation, got %T", err)
+	}
+
+	if len(registered) != 1 || tx.committed != 1 || tx.rolledBack != 0 || len(recorded) != 3 {
+		t.Errorf("Unexpected pipeline: registered=%v tx=%+v recorded=%v", registered, tx, recorded)
+	}
+}
+
+func TestErrorNormalization(t *testing.T) {
+	bus := cqrs.New()
+	ctx := context.Background()
+
+	var coreErr exception.CoreInterface
+	if err := bus.Send(ctx, registerUser{}); !errors.As(err, &coreErr) || coreErr.GetDetailsMessage() != "handler_not_found" {
+		t.Errorf("Expected handler_not_found, got %v", err)
+	}
+
+	_ = cqrs.HandleCommand(bus, func(ctx context.Context, cmd registerUser) error { return context.Canceled })
+	err := bus.Send(ctx, registerUser{})
+	if !errors.As(err, &coreErr) || coreErr.GetStatusCode() != 500 || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a 500 exception wrapping the cause, got %v", err)
+	}
+	if coreErr.Error() == context.Canceled.Error() {
+		t.Error("The cause should not be exposed as the message")
+	}
+
+	_ = cqrs.HandleQuery(bus, func(ctx context.Context, q getUser) (int, error) { panic("boom") })
+	if _, err := cqrs.Ask[int](ctx, bus, getUser{}); !errors.As(err, &coreErr) || coreErr.GetDetailsMessage() != "handler_panicked" {
+		t.Errorf("Expected handler_panicked, got %v", err)
+	}
+}
+
+func TestAskResultMismatch(t *testing.T) {
+	bus := cqrs.New()
+	_ = cqrs.HandleQuery(bus, func(ctx context.Context, q getUser) (int, error) { return 42, nil })
+
+	_, err := cqrs.Ask[string](context.Background(), bus, getUser{})
+	var logic *exception.Logic
+	if !errors.As(err, &logic) || logic.GetDetailsMessage() != "query_result_mismatch" {
+		t.Errorf("Expected query_result_mismatch, got %v", err)
+	}
+}
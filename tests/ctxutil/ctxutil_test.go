@@ -0,0 +1,110 @@
+package ctxutil_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+)
+
+func TestContextHelpers(t *testing.T) {
+	ctx := ctxutil.WithTenantID(ctxutil.WithRequestID(context.Background(), "req-1"), "acme")
+
+	if ctxutil.RequestID(ctx) != "req-1" || ctxutil.TenantID(ctx) != "acme" || ctxutil.CorrelationID(ctx) != "" {
+		t.Errorf("Unexpected identifiers in context")
+	}
+
+	expected := map[string]interface{}{"request_id": "req-1", "tenant_id": "acme"}
+	if got := ctxutil.Fields(ctx); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields() = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var captured context.Context
+	handler := ctxutil.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = r.Context()
+	}))
+
+	t.Run("Generated", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		requestID := ctxutil.RequestID(captured)
+		if len(requestID) != 36 {
+			t.Errorf("Expected a generated UUID request ID, got %q", requestID)
+		}
+		if ctxutil.CorrelationID(captured) != requestID {
+			t.Error("Correlation ID should default to the request ID")
+		}
+		if recorder.Header().Get(ctxutil.RequestIDHeader) != requestID {
+			t.Error("Request ID was not echoed in the response headers")
+		}
+	})
+
+	t.Run("Propagated", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(ctxutil.RequestIDHeader, "incoming")
+		request.Header.Set(ctxutil.CorrelationIDHeader, "flow-9")
+		request.Header.Set(ctxutil.TenantIDHeader, "acme")
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if ctxutil.RequestID(captured) != "incoming" || ctxutil.CorrelationID(captured) != "flow-9" {
+			t.Errorf("Identifiers were not propagated: %+v", ctxutil.Fields(captured))
+		}
+		if ctxutil.TenantID(captured) != "" {
+			t.Errorf("The tenant header should not be trusted by default, got %q", ctxutil.TenantID(captured))
+		}
+	})
+
+	t.Run("TenantResolver", func(t *testing.T) {
+		tenantHandler := ctxutil.MiddlewareWithTenant(ctxutil.TenantFromHeader)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			captured = r.Context()
+		}))
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(ctxutil.TenantIDHeader, "acme")
+		tenantHandler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if ctxutil.TenantID(captured) != "acme" || len(ctxutil.RequestID(captured)) != 36 {
+			t.Errorf("The resolved tenant was not stored: %+v", ctxutil.Fields(captured))
+		}
+	})
+
+	t.Run("RejectedHeader", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(ctxutil.RequestIDHeader, strings.Repeat("x", 500))
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		if len(ctxutil.RequestID(captured)) != 36 {
+			t.Errorf("An oversized request ID should be replaced, got %q", ctxutil.RequestID(captured))
+		}
+	})
+}
+
+func TestInjection(t *testing.T) {
+	ctx := ctxutil.WithRequestID(context.Background(), "req-2")
+
+	err := exception.NewRuntime(nil)
+	if ctxutil.Annotate(ctx, err) != error(err) {
+		t.Fatal("Annotate() should return the same error")
+	}
+	if err.Format()["request_id"] != "req-2" {
+		t.Errorf("Format() = %+v, expected the request ID", err.Format())
+	}
+
+	envelope := response.SuccessContext(ctx, "ok", map[string]interface{}{"page": 1})
+	expected := map[string]interface{}{"request_id": "req-2", "page": 1}
+	if !reflect.DeepEqual(envelope["meta"], expected) {
+		t.Errorf("SuccessContext() meta = %+v, expected %+v", envelope["meta"], expected)
+	}
+
+	if got := response.ErrorContext(ctx, exception.NewDomain(nil)); got["request_id"] != "req-2" {
+		t.Errorf("ErrorContext() = %+v, expected the request ID", got)
+	}
+}
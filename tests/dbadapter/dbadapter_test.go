@@ -0,0 +1,83 @@
+package dbadapter_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/dbadapter"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// pgError mimics `pgconn.PgError`.
+type pgError struct {
+	Code           string
+	Message        string
+	ConstraintName string
+	TableName      string
+}
+
+func (e *pgError) Error() string    { return e.Message }
+func (e *pgError) SQLState() string { return e.Code }
+
+// errRecordNotFound stands for `gorm.ErrRecordNotFound`.
+var errRecordNotFound = errors.New("record not found")
+
+func TestTranslate(t *testing.T) {
+	translator := dbadapter.New(dbadapter.Config{NotFound: []error{errRecordNotFound}})
+
+	tests := []struct {
+		name    string
+		err     error
+		want    interface{}
+		details map[string]interface{}
+	}{
+		{"ErrNoRows", fmt.Errorf("find user: %w", sql.ErrNoRows), &exception.NotFound{},
+			map[string]interface{}{"error": "record_not_found"}},
+		{"ConfigNotFound", errRecordNotFound, &exception.NotFound{},
+			map[string]interface{}{"error": "record_not_found"}},
+		{"UniqueViolation", &pgError{Code: "23505", Message: "duplicate key value (email)=(a@b.c)", ConstraintName: "users_email_key", TableName: "users"}, &exception.Conflict{},
+			map[string]interface{}{"error": "unique_violation", "sqlstate": "23505", "constraint": "users_email_key", "table": "users"}},
+		{"ForeignKeyViolation", fmt.Errorf("insert: %w", &pgError{Code: "23503", ConstraintName: "orders_user_fk"}), &exception.Conflict{},
+			map[string]interface{}{"error": "foreign_key_violation", "sqlstate": "23503", "constraint": "orders_user_fk"}},
+		{"SerializationFailure", &pgError{Code: "40001"}, &exception.ConcurrencyConflict{},
+			map[string]interface{}{"error": "serialization_failure", "sqlstate": "40001"}},
+		{"QueryCanceled", &pgError{Code: "57014"}, &exception.Timeout{},
+			map[string]interface{}{"error": "query_canceled", "sqlstate": "57014"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translator.Translate(tt.err)
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Fatalf("Translate() = %T, want %T", got, tt.want)
+			}
+			if details := got.(exception.CoreInterface).GetDetails(); !reflect.DeepEqual(details, tt.details) {
+				t.Errorf("GetDetails() = %v, want %v", details, tt.details)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Translate() does not unwrap to %v", tt.err)
+			}
+		})
+	}
+}
+
+func TestTranslateUnchanged(t *testing.T) {
+	notFound := exception.NewNotFound(map[string]interface{}{})
+	other := &pgError{Code: "22001"}
+	plain := errors.New("connection refused")
+
+	for _, err := range []error{nil, notFound, other, plain, errRecordNotFound} {
+		if got := dbadapter.Translate(err); got != err {
+			t.Errorf("Translate(%v) = %v, want it unchanged", err, got)
+		}
+	}
+	var stateErr *pgError
+	if !errors.As(dbadapter.Translate(fmt.Errorf("insert: %w", &pgError{Code: dbadapter.UniqueViolation})), &stateErr) || stateErr.Code != dbadapter.UniqueViolation {
+		t.Error("the driver error is not reachable with errors.As")
+	}
+	if !exception.IsRetryable(dbadapter.Translate(&pgError{Code: dbadapter.SerializationFailure})) {
+		t.Error("a serialization failure is not retryable")
+	}
+}
@@ -0,0 +1,151 @@
+package deprecation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/deprecation"
+	"github.com/osirisgate/golang-core/response"
+)
+
+var (
+	since  = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset = time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC)
+)
+
+func TestPolicySetHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy deprecation.Policy
+		want   http.Header
+	}{
+		{
+			name:   "undated",
+			policy: deprecation.Policy{},
+			want:   http.Header{"Deprecation": {"true"}},
+		},
+		{
+			name: "complete",
+			policy: deprecation.Policy{
+				Since:         since,
+				Sunset:        sunset,
+				Successor:     "/v2/users",
+				Documentation: "https://example.com/docs/v1-sunset",
+			},
+			want: http.Header{
+				"Deprecation": {"@1767225600"},
+				"Sunset":      {"Thu, 31 Dec 2026 23:59:59 GMT"},
+				"Link":        {`</v2/users>; rel="successor-version"`, `<https://example.com/docs/v1-sunset>; rel="deprecation"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			tt.policy.SetHeaders(header)
+			if !reflect.DeepEqual(header, tt.want) {
+				t.Errorf("headers = %v, want %v", header, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	registry := deprecation.NewRegistry()
+	registry.Register("/v1/", deprecation.Policy{Message: "version"})
+	registry.Register("/v1/legacy/", deprecation.Policy{Message: "legacy"})
+	registry.Register("/v2/users", deprecation.Policy{Message: "path"})
+	registry.Register("DELETE /v2/users", deprecation.Policy{Message: "method"})
+
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/v1/users", "version"},
+		{http.MethodGet, "/v1/legacy/export", "legacy"},
+		{http.MethodGet, "/v2/users", "path"},
+		{http.MethodDelete, "/v2/users", "method"},
+		{http.MethodGet, "/v2/orders", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			policy, ok := registry.Lookup(httptest.NewRequest(tt.method, tt.path, nil))
+			if ok != (tt.want != "") || policy.Message != tt.want {
+				t.Errorf("Lookup() = %q, %v, want %q", policy.Message, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	policy := deprecation.Policy{Since: since, Successor: "/v2/users/{id}", Message: "Use the v2 API."}
+
+	tests := []struct {
+		name     string
+		opts     []deprecation.Option
+		path     string
+		wantMeta map[string]interface{}
+	}{
+		{
+			name:     "deprecated route with meta",
+			opts:     []deprecation.Option{deprecation.EmitMeta()},
+			path:     "/v1/users/42",
+			wantMeta: map[string]interface{}{"deprecation": policy.Notice()},
+		},
+		{
+			name: "deprecated route without meta",
+			path: "/v1/users/42",
+		},
+		{
+			name: "current route",
+			opts: []deprecation.Option{deprecation.EmitMeta()},
+			path: "/v2/users/42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := deprecation.NewRegistry(tt.opts...)
+			registry.Register("GET /v1/users/{id}", policy)
+
+			var envelope map[string]interface{}
+			handler := deprecation.Middleware(registry)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				envelope = response.SuccessContext(r.Context(), nil)
+			}))
+			// Inside the mux, the middleware sees the pattern of the route.
+			mux := http.NewServeMux()
+			mux.Handle("GET /v1/users/{id}", handler)
+			mux.Handle("GET /v2/users/{id}", handler)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			deprecated := tt.path == "/v1/users/42"
+			if got := rec.Header().Get("Deprecation") != ""; got != deprecated {
+				t.Errorf("Deprecation header set = %v, want %v", got, deprecated)
+			}
+			if deprecated && rec.Header().Get("Link") != `</v2/users/{id}>; rel="successor-version"` {
+				t.Errorf("Link = %q", rec.Header().Get("Link"))
+			}
+			if !reflect.DeepEqual(envelope["meta"], tt.wantMeta) && !(tt.wantMeta == nil && envelope["meta"] == nil) {
+				t.Errorf("meta = %v, want %v", envelope["meta"], tt.wantMeta)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if _, ok := deprecation.FromContext(context.Background()); ok {
+		t.Error("FromContext() reported a deprecation on a bare context")
+	}
+	if meta := deprecation.Meta(context.Background()); meta != nil {
+		t.Errorf("Meta() = %v, want nil", meta)
+	}
+}
@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/domain"
+	"github.com/osirisgate/golang-core/id"
+	"github.com/osirisgate/golang-core/repository"
+)
+
+type orderPlaced struct{ OrderID string }
+
+type order struct {
+	domain.AggregateRoot[string]
+	Total int `json:"total"`
+}
+
+func placeOrder(generator id.Generator[string], c clock.Clock, total int) *order {
+	o := &order{AggregateRoot: domain.NewAggregateRoot(generator, c), Total: total}
+	o.RecordEvent(orderPlaced{OrderID: o.ID})
+	return o
+}
+
+func TestAggregateRoot(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	o := placeOrder(id.NewSequence("order-"), c, 100)
+
+	if o.ID != "order-1" || !o.CreatedAt.Equal(c.Now()) || !o.UpdatedAt.Equal(c.Now()) || o.Version != 0 {
+		t.Fatalf("Unexpected entity: %+v", o.Entity)
+	}
+
+	c.Advance(time.Hour)
+	o.Touch(c)
+	if !o.UpdatedAt.Equal(c.Now()) || o.CreatedAt.Equal(o.UpdatedAt) {
+		t.Errorf("Touch() should only update UpdatedAt: %+v", o.Entity)
+	}
+
+	if len(o.Events()) != 1 {
+		t.Fatalf("Expected one recorded event, got %d", len(o.Events()))
+	}
+	events := o.PullEvents()
+	if len(events) != 1 || events[0] != (orderPlaced{OrderID: "order-1"}) || len(o.PullEvents()) != 0 {
+		t.Errorf("PullEvents() should return the events once, got %+v", events)
+	}
+
+	encoded, _ := json.Marshal(o)
+	if string(encoded) != `{"id":"order-1","created_at":"2026-01-01T12:00:00Z","updated_at":"2026-01-01T13:00:00Z","version":0,"total":100}` {
+		t.Errorf("Unexpected JSON: %s", encoded)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	e := domain.NewEntity(id.NewSequence("user-"), nil)
+	if err := e.CheckVersion(0); err != nil {
+		t.Fatalf("CheckVersion() returned %v", err)
+	}
+	e.IncrementVersion()
+
+	err := e.CheckVersion(2)
+	if !repository.IsConflict(err) {
+		t.Fatalf("Expected a Conflict, got %v", err)
+	}
+	if !e.SameIdentity(domain.Entity[string]{ID: "user-1"}) {
+		t.Error("Entities with the same ID should have the same identity")
+	}
+}
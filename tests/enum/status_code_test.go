@@ -39,6 +39,8 @@ func TestGetDescription(t *testing.T) {
 		{name: "Unauthorized", input: status.Unauthorized, expected: "Unauthorized"},
 		{name: "IMATeapot", input: status.IMATeapot, expected: "I'm a teapot"},
 		{name: "UnknownStatus", input: status.StatusCode(999), expected: "Unknown Status Code"},
+		{name: "UnassignedStatus", input: status.StatusCode(299), expected: "Unknown Status Code"},
+		{name: "NegativeStatus", input: status.StatusCode(-1), expected: "Unknown Status Code"},
 	}
 
 	for _, tt := range tests {
@@ -61,6 +63,9 @@ func TestNewStatusCode(t *testing.T) {
 		{name: "ValidCode-404", input: 404, expectedCode: status.NotFound, expectedSuccess: true},
 		{name: "InvalidCode", input: 999, expectedCode: status.StatusCode(0), expectedSuccess: false},
 		{name: "ValidCode-500", input: 500, expectedCode: status.InternalServerError, expectedSuccess: true},
+		{name: "InvalidCode-0", input: 0, expectedCode: status.StatusCode(0), expectedSuccess: false},
+		{name: "InvalidCode-Negative", input: -404, expectedCode: status.StatusCode(0), expectedSuccess: false},
+		{name: "InvalidCode-600", input: 600, expectedCode: status.StatusCode(0), expectedSuccess: false},
 	}
 
 	for _, tt := range tests {
@@ -82,6 +87,39 @@ func TestGetStatusTexts(t *testing.T) {
 	statusTexts := status.GetStatusTexts()
 	expectedSize := 62
 
+	if statusTexts.Len() != expectedSize {
+		t.Errorf("The view has a size of %d, but %d was expected", statusTexts.Len(), expectedSize)
+	}
+
+	if description, ok := statusTexts.Get(status.OK); !ok || description != "OK" {
+		t.Errorf("The description for OK is incorrect: got %q, expected %q", description, "OK")
+	}
+	if _, ok := statusTexts.Get(status.StatusCode(299)); ok {
+		t.Error("An unknown status code should not be found.")
+	}
+
+	var previous status.StatusCode
+	count := 0
+	for code, description := range statusTexts.All() {
+		if code <= previous || description != code.GetDescription() {
+			t.Errorf("Unexpected entry %d %q after %d", code, description, previous)
+		}
+		previous = code
+		count++
+	}
+	if count != expectedSize {
+		t.Errorf("All() yielded %d entries, but %d were expected", count, expectedSize)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = status.GetStatusTexts().Get(status.NotFound) }); allocs != 0 {
+		t.Errorf("GetStatusTexts() made %v allocations, expected none", allocs)
+	}
+}
+
+func TestCopyStatusTexts(t *testing.T) {
+	statusTexts := status.CopyStatusTexts()
+	expectedSize := 62
+
 	if len(statusTexts) != expectedSize {
 		t.Errorf("The map has a size of %d, but %d was expected", len(statusTexts), expectedSize)
 	}
@@ -92,7 +130,7 @@ func TestGetStatusTexts(t *testing.T) {
 
 	statusTexts[status.OK] = "Not OK"
 
-	originalStatusTexts := status.GetStatusTexts()
+	originalStatusTexts := status.CopyStatusTexts()
 	if originalStatusTexts[status.OK] == "Not OK" {
 		t.Error("The original map was modified, indicating that a copy was not returned.")
 	}
@@ -100,4 +138,30 @@ func TestGetStatusTexts(t *testing.T) {
 	if reflect.DeepEqual(statusTexts, originalStatusTexts) {
 		t.Error("The two maps are identical, which indicates a copy was not created.")
 	}
+
+	if description, _ := status.GetStatusTexts().Get(status.OK); description != "OK" {
+		t.Error("Modifying a copy should not affect the view.")
+	}
+}
+
+func TestGetClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    status.StatusCode
+		expected status.StatusClass
+	}{
+		{name: "Continue", input: status.Continue, expected: status.InformationalClass},
+		{name: "OK", input: status.OK, expected: status.SuccessClass},
+		{name: "NotFound", input: status.NotFound, expected: status.ClientErrorClass},
+		{name: "GatewayTimeout", input: status.GatewayTimeout, expected: status.ServerErrorClass},
+		{name: "OutOfRange", input: status.StatusCode(600), expected: status.UnknownClass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.input.GetClass(); got != tt.expected {
+				t.Errorf("GetClass() for %v returned %d, but expected %d", tt.input, got, tt.expected)
+			}
+		})
+	}
 }
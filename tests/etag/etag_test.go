@@ -0,0 +1,124 @@
+package etag_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/etag"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+func TestTags(t *testing.T) {
+	strong := etag.Strong([]byte("payload"))
+	weak := etag.Weak([]byte("payload"))
+	if strong.String() != `"`+strong.Value+`"` || weak.String() != `W/"`+strong.Value+`"` {
+		t.Errorf("String() = %s, %s", strong, weak)
+	}
+	if strong == etag.Strong([]byte("payload!")) {
+		t.Error("different payloads should have different tags")
+	}
+	if !strong.WeakMatch(weak) || strong.StrongMatch(weak) || !strong.StrongMatch(strong) {
+		t.Error("unexpected comparison result")
+	}
+
+	tests := []struct {
+		value    string
+		expected etag.ETag
+		ok       bool
+	}{
+		{`"abc"`, etag.ETag{Value: "abc"}, true},
+		{` W/"abc" `, etag.ETag{Value: "abc", Weak: true}, true},
+		{`abc`, etag.ETag{}, false},
+		{`"a"b"`, etag.ETag{}, false},
+	}
+	for _, tt := range tests {
+		if got, ok := etag.Parse(tt.value); got != tt.expected || ok != tt.ok {
+			t.Errorf("Parse(%q) = %+v, %v", tt.value, got, ok)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	current := etag.ETag{Value: "v2"}
+	modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := modified.Add(-time.Hour).Format(http.TimeFormat)
+	after := modified.Add(time.Hour).Format(http.TimeFormat)
+
+	tests := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		current  etag.ETag
+		expected status.StatusCode
+	}{
+		{"Unconditional", http.MethodGet, nil, current, status.OK},
+		{"IfNoneMatchHit", http.MethodGet, map[string]string{"If-None-Match": `"v1", W/"v2"`}, current, status.NotModified},
+		{"IfNoneMatchMiss", http.MethodGet, map[string]string{"If-None-Match": `"v1"`}, current, status.OK},
+		{"IfNoneMatchOnWrite", http.MethodPut, map[string]string{"If-None-Match": `"v2"`}, current, status.PreconditionFailed},
+		{"CreateIfAbsent", http.MethodPut, map[string]string{"If-None-Match": "*"}, etag.ETag{}, status.OK},
+		{"CreateIfPresent", http.MethodPut, map[string]string{"If-None-Match": "*"}, current, status.PreconditionFailed},
+		{"IfMatchHit", http.MethodPut, map[string]string{"If-Match": `"v2"`}, current, status.OK},
+		{"IfMatchWeak", http.MethodPut, map[string]string{"If-Match": `W/"v2"`}, current, status.PreconditionFailed},
+		{"IfMatchStale", http.MethodPut, map[string]string{"If-Match": `"v1"`}, current, status.PreconditionFailed},
+		{"IfMatchAnyAbsent", http.MethodPut, map[string]string{"If-Match": "*"}, etag.ETag{}, status.PreconditionFailed},
+		{"NotModifiedSince", http.MethodGet, map[string]string{"If-Modified-Since": after}, current, status.NotModified},
+		{"ModifiedSince", http.MethodGet, map[string]string{"If-Modified-Since": before}, current, status.OK},
+		{"IfNoneMatchWinsOverDate", http.MethodGet, map[string]string{"If-None-Match": `"v1"`, "If-Modified-Since": after}, current, status.OK},
+		{"UnmodifiedSince", http.MethodDelete, map[string]string{"If-Unmodified-Since": after}, current, status.OK},
+		{"ModifiedSinceWrite", http.MethodDelete, map[string]string{"If-Unmodified-Since": before}, current, status.PreconditionFailed},
+		{"InvalidDate", http.MethodDelete, map[string]string{"If-Unmodified-Since": "yesterday"}, current, status.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/", nil)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+			lastModified := modified
+			if tt.current.IsZero() {
+				lastModified = time.Time{} // The resource does not exist.
+			}
+			decision, err := etag.Evaluate(r, tt.current, lastModified)
+			if decision != tt.expected {
+				t.Errorf("Evaluate() = %d, expected %d", decision, tt.expected)
+			}
+			var failed *exception.PreconditionFailed
+			if (tt.expected == status.PreconditionFailed) != errors.As(err, &failed) {
+				t.Errorf("Evaluate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	current := etag.Strong([]byte(`{"id":1}`))
+	modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		method  string
+		header  string
+		value   string
+		written bool
+		code    int
+	}{
+		{http.MethodGet, "If-None-Match", current.String(), true, http.StatusNotModified},
+		{http.MethodPatch, "If-Match", `"stale"`, true, http.StatusPreconditionFailed},
+		{http.MethodPatch, "If-Match", current.String(), false, http.StatusOK},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/", nil)
+		r.Header.Set(tt.header, tt.value)
+		rec := httptest.NewRecorder()
+		if written := etag.Check(rec, r, current, modified); written != tt.written || rec.Code != tt.code {
+			t.Errorf("%s %s: Check() = %v with %d", tt.method, tt.value, written, rec.Code)
+		}
+		if rec.Header().Get("ETag") != current.String() || rec.Header().Get("Last-Modified") != modified.Format(http.TimeFormat) {
+			t.Errorf("unexpected validators %v", rec.Header())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/osirisgate/golang-core/eventbus"
+	"github.com/osirisgate/golang-core/exception"
+)
+
+type domainEvent interface{ AggregateID() string }
+
+type orderPlaced struct{ ID string }
+
+func (e orderPlaced) EventName() string   { return "order.placed" }
+func (e orderPlaced) AggregateID() string { return e.ID }
+
+type orderCancelled struct{ ID string }
+
+func (e orderCancelled) AggregateID() string { return e.ID }
+
+func TestPublish(t *testing.T) {
+	bus := eventbus.New()
+	ctx := context.Background()
+
+	var placed, domain, all []string
+	eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error {
+		placed = append(placed, e.ID)
+		return nil
+	})
+	eventbus.Subscribe(bus, func(ctx context.Context, e domainEvent) error {
+		domain = append(domain, e.AggregateID())
+		return nil
+	})
+	unsubscribe := bus.SubscribeAll(func(ctx context.Context, e interface{}) error {
+		all = append(all, eventbus.Name(e))
+		return nil
+	})
+
+	if err := bus.Publish(ctx, orderPlaced{ID: "1"}); err != nil {
+		t.Fatalf("Publish() returned %v", err)
+	}
+	unsubscribe()
+	unsubscribe()
+	_ = bus.Publish(ctx, orderCancelled{ID: "2"})
+
+	if len(placed) != 1 || len(domain) != 2 || len(all) != 1 || all[0] != "order.placed" {
+		t.Errorf("Unexpected dispatch: placed=%v domain=%v all=%v", placed, domain, all)
+	}
+}
+
+func TestPublishAggregatesFailures(t *testing.T) {
+	bus := eventbus.New()
+	boom := errors.New("boom")
+	calls := 0
+
+	eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error { calls++; return boom })
+	eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error { calls++; panic("nil map") })
+	eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error { calls++; return nil })
+
+	err := bus.Publish(context.Background(), orderPlaced{ID: "1"})
+	var aggregate *exception.Aggregate
+	if !errors.As(err, &aggregate) {
+		t.Fatalf("Expected an *Aggregate, got %T", err)
+	}
+	if calls != 3 || aggregate.Len() != 2 || !errors.Is(err, boom) {
+		t.Errorf("Unexpected outcome: %d calls, %d errors", calls, aggregate.Len())
+	}
+	var runtime *exception.Runtime
+	if !errors.As(aggregate.Unwrap()[1], &runtime) || runtime.GetDetailsMessage() != "handler_panicked" {
+		t.Errorf("A panic should be reported as a Runtime exception, got %v", aggregate.Unwrap()[1])
+	}
+	if details := aggregate.GetDetails(); details["event"] != "order.placed" || details["failed"] != 2 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+}
+
+func TestPublishTypedNil(t *testing.T) {
+	bus := eventbus.New()
+	eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error {
+		var notFound *exception.NotFound
+		return notFound
+	})
+	eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error { return exception.NewConflict(nil) })
+
+	err := bus.Publish(context.Background(), orderPlaced{ID: "1"})
+	var aggregate *exception.Aggregate
+	if !errors.As(err, &aggregate) || aggregate.Len() != 1 || aggregate.GetDetails()["failed"] != 1 {
+		t.Fatalf("Publish() = %v, want the nil exception counted as a success", err)
+	}
+	_ = aggregate.Format()
+}
+
+func TestPublishAsync(t *testing.T) {
+	bus := eventbus.New()
+	var calls atomic.Int32
+	for i := 0; i < 3; i++ {
+		eventbus.Subscribe(bus, func(ctx context.Context, e orderPlaced) error {
+			calls.Add(1)
+			return nil
+		})
+	}
+
+	if err := <-bus.PublishAsync(context.Background(), orderPlaced{ID: "1"}); err != nil {
+		t.Fatalf("PublishAsync() returned %v", err)
+	}
+	bus.PublishAsync(context.Background(), orderPlaced{ID: "2"})
+	bus.Wait()
+
+	if calls.Load() != 6 {
+		t.Errorf("Expected 6 handler calls, got %d", calls.Load())
+	}
+}
@@ -1,11 +1,21 @@
 package exception_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	status "github.com/osirisgate/golang-core/enum"
 	"github.com/osirisgate/golang-core/exception"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+	"unsafe"
 )
 
 func TestNewInstance(t *testing.T) {
@@ -105,7 +115,7 @@ func TestCoreExceptionInterfaceMethods(t *testing.T) {
 				"details":    map[string]interface{}{"error": "email_format_error", "code": 123},
 				"extra_data": "some_value",
 			},
-			"stack_trace": coreException.StackTrace,
+			"stack_trace": coreException.GetStackTrace(),
 		}
 
 		got := coreException.GetErrorsForLog()
@@ -159,3 +169,1577 @@ func TestNewBadFunctionCall(t *testing.T) {
 		t.Errorf("GetDetailsMessage returned '%s', expected 'argument_count_mismatch'", msg)
 	}
 }
+
+func TestNewAggregate(t *testing.T) {
+	notFound := exception.NewOutOfRange(map[string]interface{}{"message": "Index out of range."})
+	plain := errors.New("connection reset")
+
+	t.Run("MixedErrors", func(t *testing.T) {
+		err := exception.NewAggregate(nil, notFound, nil, plain)
+
+		if err.Len() != 2 || err.Error() != "2 errors occurred." {
+			t.Fatalf("Unexpected aggregate: %d errors, message '%s'", err.Len(), err.Error())
+		}
+		if err.StatusCode != status.InternalServerError {
+			t.Errorf("The status code is %d, expected %d", err.StatusCode, status.InternalServerError)
+		}
+		if !errors.Is(err, plain) {
+			t.Error("errors.Is should see the grouped errors")
+		}
+		var outOfRange *exception.OutOfRange
+		if !errors.As(err, &outOfRange) {
+			t.Error("errors.As should see the grouped exceptions")
+		}
+
+		grouped := err.GetErrors()["errors"].([]map[string]interface{})
+		if grouped[0]["message"] != "Index out of range." || grouped[1]["message"] == plain.Error() {
+			t.Errorf("Unexpected grouped errors: %+v", grouped)
+		}
+		causes, _ := err.GetErrorsForLog()[exception.CausesKey].([]interface{})
+		if len(causes) != 2 || causes[0].(map[string]interface{})["message"] != "Index out of range." || causes[1].(map[string]interface{})["message"] != "connection reset" {
+			t.Errorf("Unexpected causes: %+v", causes)
+		}
+
+		_ = err.WithCause(exception.NewNotFound(nil))
+		causes, _ = err.GetErrorsForLog()[exception.CausesKey].([]interface{})
+		if len(causes) != 3 || causes[2].(map[string]interface{})["type"] != "*exception.NotFound" {
+			t.Errorf("The cause should follow the grouped errors: %+v", causes)
+		}
+	})
+
+	t.Run("ClientErrors", func(t *testing.T) {
+		shared := exception.NewAggregate(nil, exception.NewInvalidArgument(nil), exception.NewInvalidArgument(nil))
+		if shared.StatusCode != status.BadRequest {
+			t.Errorf("The status code is %d, expected the shared %d", shared.StatusCode, status.BadRequest)
+		}
+		mixed := exception.NewAggregate(nil, exception.NewValidation(nil), exception.NewTooManyRequests(nil))
+		if mixed.StatusCode != status.BadRequest {
+			t.Errorf("The status code is %d, expected %d", mixed.StatusCode, status.BadRequest)
+		}
+		if mixed.IsRetryable() {
+			t.Error("An aggregate with a permanent error should not be retryable")
+		}
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	if exception.Normalize(nil) != nil {
+		t.Error("Normalize(nil) should return nil")
+	}
+
+	invalid := exception.NewInvalidArgument(nil)
+	if err := exception.Normalize(invalid); err != invalid {
+		t.Errorf("Normalize should return exceptions unchanged, got %v", err)
+	}
+
+	cause := errors.New("connection refused")
+	err := exception.Normalize(cause)
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || coreErr.GetStatusCode() != 500 || !errors.Is(err, cause) {
+		t.Fatalf("Expected a 500 exception wrapping the cause, got %v", err)
+	}
+	if coreErr.Error() == cause.Error() || coreErr.GetErrorsForLog()["cause"] != cause.Error() {
+		t.Errorf("The cause should be logged but not exposed: %+v", coreErr.GetErrorsForLog())
+	}
+}
+
+func TestFromStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     status.StatusCode
+		expected interface{}
+		status   status.StatusCode
+	}{
+		{"NotFound", status.NotFound, &exception.NotFound{}, status.NotFound},
+		{"NotAcceptable", status.NotAcceptable, &exception.NotAcceptable{}, status.NotAcceptable},
+		{"RequestTimeout", status.RequestTimeout, &exception.Timeout{}, status.RequestTimeout},
+		{"Validation", status.UnprocessableContent, &exception.Validation{}, status.UnprocessableContent},
+		{"PreconditionFailed", status.PreconditionFailed, &exception.PreconditionFailed{}, status.PreconditionFailed},
+		{"UnsupportedMediaType", status.UnsupportedMediaType, &exception.UnsupportedMediaType{}, status.UnsupportedMediaType},
+		{"RangeNotSatisfiable", status.RangeNotSatisfiable, &exception.RangeNotSatisfiable{}, status.RangeNotSatisfiable},
+		{"Unmapped", status.IMATeapot, &exception.Error{}, status.IMATeapot},
+		{"NotAnError", status.OK, &exception.Error{}, status.InternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exception.FromStatus(tt.code, nil)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.expected) {
+				t.Errorf("FromStatus(%d) returned a %T, expected a %T", tt.code, err, tt.expected)
+			}
+			if err.GetStatusCode() != tt.status.GetValue() || err.Error() != tt.status.GetDescription() {
+				t.Errorf("FromStatus(%d) = %d %q", tt.code, err.GetStatusCode(), err.Error())
+			}
+		})
+	}
+
+	err := exception.FromStatus(status.Conflict, map[string]interface{}{"message": "Version mismatch."})
+	if err.Error() != "Version mismatch." {
+		t.Errorf("The provided message should be kept, got %q", err.Error())
+	}
+}
+
+func TestSetCreationHook(t *testing.T) {
+	var created, live []exception.CoreInterface
+	restore := exception.SetCreationHook(func(e exception.CoreInterface) {
+		live = append(live, e)
+		created = append(created, exception.Snapshot(e))
+	})
+
+	notFound := exception.NewNotFound(map[string]interface{}{"message": "The user was not found.", "details": map[string]interface{}{"error": "USER_NOT_FOUND"}})
+	exception.FromStatus(status.StatusCode(418), nil)
+	exception.FromStatus(status.GatewayTimeout, nil)
+	exception.NewInstance(nil, status.BadRequest)
+	restore()
+	exception.NewNotFound(map[string]interface{}{})
+
+	if len(created) != 3 {
+		t.Fatalf("The hook saw %d exceptions, expected 3", len(created))
+	}
+	if _, ok := created[0].(*exception.NotFound); !ok || created[0].Error() != "The user was not found." || created[0].GetStatusCode() != 404 {
+		t.Errorf("Unexpected first exception: %T %v (%d)", created[0], created[0], created[0].GetStatusCode())
+	}
+	if created[1].GetStatusCode() != 418 {
+		t.Errorf("FromStatus notified status %d, expected 418", created[1].GetStatusCode())
+	}
+	if _, ok := created[2].(*exception.Timeout); !ok || created[2].GetStatusCode() != 504 {
+		t.Errorf("FromStatus notified a %T of status %d, expected a 504 Timeout", created[2], created[2].GetStatusCode())
+	}
+
+	if live[0] != error(notFound) {
+		t.Errorf("The hook should receive the exception itself, got %p", live[0])
+	}
+
+	// The snapshot is sealed and unaffected by the later changes.
+	_ = notFound.SetMessage("Changed.")
+	notFound.GetErrors()["details"].(map[string]interface{})["error"] = "CHANGED"
+	if created[0].Error() != "The user was not found." || created[0].GetDetails()["error"] != "USER_NOT_FOUND" {
+		t.Errorf("The snapshot should not follow the exception: %q %+v", created[0].Error(), created[0].GetDetails())
+	}
+	if err := created[0].(*exception.NotFound).SetError("key", "value"); err == nil {
+		t.Error("The snapshot should be sealed")
+	}
+}
+
+func TestVerifyCatalog(t *testing.T) {
+	if report := exception.VerifyCatalog(); !report.OK() {
+		t.Fatalf("The catalog of the package should conform:\n%s", report)
+	}
+
+	tests := []struct {
+		name        string
+		constructor exception.Constructor
+		problem     string
+	}{
+		{"Duplicate", func(e map[string]interface{}) exception.CoreInterface { return exception.NewNotFound(e) }, "create the same type"},
+		{"NotAnError", func(e map[string]interface{}) exception.CoreInterface { return exception.NewInstance(e, status.OK) }, "not an error status"},
+		{"NilResult", func(map[string]interface{}) exception.CoreInterface { return (*exception.NotFound)(nil) }, "non-nil pointer"},
+		{"Panicking", func(map[string]interface{}) exception.CoreInterface { panic("boom") }, "panics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unregister := exception.RegisterType(tt.name, tt.constructor)
+			report := exception.VerifyCatalog()
+			unregister()
+
+			if report.OK() || !strings.Contains(report.String(), tt.problem) {
+				t.Errorf("The report should mention %q, got:\n%s", tt.problem, report)
+			}
+			if len(exception.Catalog()) != len(report.Types)-1 {
+				t.Errorf("unregister() should remove the type from the catalog")
+			}
+		})
+	}
+
+	unregister := exception.RegisterType("NotFound", func(e map[string]interface{}) exception.CoreInterface { return exception.NewDomain(e) })
+	defer unregister()
+	if report := exception.VerifyCatalog(); !strings.Contains(report.String(), `the name "NotFound" is registered more than once`) {
+		t.Errorf("The report should mention the duplicate name, got:\n%s", report)
+	}
+}
+
+func TestGetStackTrace(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{})
+
+	trace := err.GetStackTrace()
+	if !strings.HasPrefix(trace, "github.com/osirisgate/golang-core/exception.NewNotFound\n") {
+		t.Errorf("The stack trace should start at the constructor, got:\n%s", trace)
+	}
+	if !strings.Contains(trace, "exception_test.TestGetStackTrace") {
+		t.Errorf("The stack trace should contain the caller, got:\n%s", trace)
+	}
+	if err.GetErrorsForLog()["stack_trace"] != trace || err.GetStackTrace() != trace {
+		t.Errorf("The stack trace should be formatted once and logged")
+	}
+
+	err.StackTrace = "explicit"
+	if err.GetStackTrace() != "explicit" {
+		t.Errorf("An explicit stack trace should replace the captured one, got %q", err.GetStackTrace())
+	}
+}
+
+func BenchmarkNewInstance(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		exception.NewInstance(map[string]interface{}{}, status.BadRequest)
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		errors map[string]interface{}
+	}{
+		{"Empty", map[string]interface{}{}},
+		{"Message", map[string]interface{}{"message": "Quote \" <b>&</b>\n\u2028 \x01 é"}},
+		{"Details", map[string]interface{}{
+			"details": map[string]interface{}{"error": "order_not_found", "id": 42, "ratio": 0.5, "tiny": 1e-9, "huge": 1e21, "ok": true, "none": nil},
+			"errors":  map[string]string{"email": "invalid_email"},
+			"items":   []interface{}{"a", 1, int64(2), uint8(3), float32(1.5), []string{"x"}},
+			"ids":     []string{"b", "a"},
+		}},
+		{"Fallback", map[string]interface{}{"status_code": status.NotFound, "at": struct {
+			A int `json:"a"`
+		}{1}}},
+		{"Override", map[string]interface{}{"status": "custom", "error_code": "E42"}},
+		{"NilCollections", map[string]interface{}{"map": map[string]interface{}(nil), "list": []interface{}(nil)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exception.NewNotFound(tt.errors)
+			want, _ := json.Marshal(err.Format())
+
+			got, appendErr := err.AppendJSON([]byte("prefix:"))
+			if appendErr != nil {
+				t.Fatalf("AppendJSON() error = %v", appendErr)
+			}
+			if string(got) != "prefix:"+string(want) {
+				t.Errorf("AppendJSON() =\n%s\nexpected\n%s", got, want)
+			}
+
+			var buf bytes.Buffer
+			if formatErr := err.FormatTo(&buf); formatErr != nil || buf.String() != string(want) {
+				t.Errorf("FormatTo() = %s, %v", buf.String(), formatErr)
+			}
+		})
+	}
+
+	err := exception.NewNotFound(map[string]interface{}{"ratio": math.NaN()})
+	if got, appendErr := err.AppendJSON([]byte("prefix:")); appendErr == nil || string(got) != "prefix:" {
+		t.Errorf("AppendJSON() = %q, %v, expected an error and dst unchanged", got, appendErr)
+	}
+
+	common := exception.NewNotFound(map[string]interface{}{"details": map[string]interface{}{"error": "order_not_found", "id": "o-1"}})
+	buf := make([]byte, 0, 256)
+	if allocs := testing.AllocsPerRun(100, func() { buf, _ = common.AppendJSON(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendJSON() made %v allocations, expected none", allocs)
+	}
+}
+
+func TestDefine(t *testing.T) {
+	errOrderNotFound := exception.Define("ORDER_NOT_FOUND", status.NotFound, "The order was not found.")
+	errOther := exception.Define("OTHER", status.NotFound, "")
+
+	if errOther.Error() != "Not Found" {
+		t.Errorf("The message should default to the status description, got %q", errOther.Error())
+	}
+
+	formatted := errOrderNotFound.Format()
+	expected := map[string]interface{}{
+		"status":     status.ERROR,
+		"error_code": 404,
+		"message":    "The order was not found.",
+		"details":    map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+	}
+	if !reflect.DeepEqual(formatted, expected) {
+		t.Errorf("Format() returned %+v, expected %+v", formatted, expected)
+	}
+	formatted["message"] = "Changed."
+	formatted["details"].(map[string]interface{})["error"] = "CHANGED"
+	if again := errOrderNotFound.Format(); again["message"] != "The order was not found." || !reflect.DeepEqual(again["details"], expected["details"]) {
+		t.Errorf("Modifying the output of Format() should not affect the sentinel, got %+v", again)
+	}
+
+	want, _ := json.Marshal(errOrderNotFound.Format())
+	if got, _ := errOrderNotFound.AppendJSON(nil); string(got) != string(want) {
+		t.Errorf("AppendJSON() = %s, expected %s", got, want)
+	}
+
+	raised := errOrderNotFound.Raise(map[string]interface{}{"order_id": "o-1"})
+	var err error = raised
+	if !errors.Is(err, errOrderNotFound) || errors.Is(err, errOther) || raised.Sentinel() != errOrderNotFound {
+		t.Error("The raised exception should match its sentinel only")
+	}
+	if raised.GetStatusCode() != 404 || raised.Error() != "The order was not found." || raised.GetStackTrace() == "" {
+		t.Errorf("Unexpected raised exception: %d %q", raised.GetStatusCode(), raised.Error())
+	}
+	if details := raised.GetDetails(); details["error"] != "ORDER_NOT_FOUND" || details["order_id"] != "o-1" {
+		t.Errorf("The details should overlay the sentinel code, got %+v", details)
+	}
+	if _, ok := errOrderNotFound.GetDetails()["order_id"]; ok {
+		t.Error("Raising should not modify the sentinel")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      status.StatusCode
+		keyValues []interface{}
+		message   string
+		errors    map[string]interface{}
+	}{
+		{
+			name:      "Pairs",
+			code:      status.UnprocessableContent,
+			keyValues: []interface{}{"message", "The email is invalid.", "field", "email", "details", map[string]interface{}{"error": "invalid_email"}},
+			message:   "The email is invalid.",
+			errors:    map[string]interface{}{"field": "email", "details": map[string]interface{}{"error": "invalid_email"}},
+		},
+		{
+			name:    "NoPairs",
+			code:    status.NotFound,
+			message: "Not Found",
+			errors:  map[string]interface{}{},
+		},
+		{
+			name:      "BadKeys",
+			code:      status.Conflict,
+			keyValues: []interface{}{"field", "id", 42, "dangling"},
+			message:   "Conflict",
+			errors:    map[string]interface{}{"field": "id", exception.BadKey: "dangling"},
+		},
+		{
+			name:      "RepeatedKey",
+			code:      status.BadRequest,
+			keyValues: []interface{}{"field", "a", "field", "b"},
+			message:   "Bad Request",
+			errors:    map[string]interface{}{"field": "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exception.New(tt.code, tt.keyValues...)
+			if err.GetStatusCode() != tt.code.GetValue() || err.Error() != tt.message {
+				t.Errorf("New() = %d %q, expected %d %q", err.GetStatusCode(), err.Error(), tt.code, tt.message)
+			}
+			if !reflect.DeepEqual(err.GetErrors(), tt.errors) {
+				t.Errorf("GetErrors() returned %+v, expected %+v", err.GetErrors(), tt.errors)
+			}
+		})
+	}
+
+	if _, ok := exception.New(status.NotFound).(*exception.NotFound); !ok {
+		t.Error("New() should create the exception type of the status code")
+	}
+}
+
+func TestAcquireRelease(t *testing.T) {
+	var created int
+	restore := exception.SetCreationHook(func(exception.CoreInterface) { created++ })
+	defer restore()
+
+	e := exception.Acquire(status.TooManyRequests, "")
+	if e.Error() != "Too Many Requests" || e.GetStatusCode() != 429 || len(e.GetErrors()) != 0 {
+		t.Errorf("Unexpected acquired exception: %d %q %+v", e.GetStatusCode(), e.Error(), e.GetErrors())
+	}
+	if !strings.Contains(e.GetStackTrace(), "TestAcquireRelease") {
+		t.Errorf("The stack of the caller should be captured, got:\n%s", e.GetStackTrace())
+	}
+	e.SetError("details", map[string]interface{}{"error": "rate_limited"})
+	exception.Release(e)
+	exception.Release(e) // Released twice: ignored.
+
+	e = exception.Acquire(status.NotFound, "The order was not found.")
+	if e.Error() != "The order was not found." || len(e.GetErrors()) != 0 {
+		t.Errorf("A reused exception should be reset, got %q %+v", e.Error(), e.GetErrors())
+	}
+	exception.Release(e)
+
+	exception.Release(&exception.NewInvalidArgument(nil).CoreException) // Not acquired: ignored.
+	if created != 1 {
+		t.Errorf("The hook saw %d exceptions, expected only the one not acquired", created)
+	}
+
+	buf := make([]byte, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		e := exception.Acquire(status.TooManyRequests, "")
+		e.SetError("retry_after", 30)
+		buf, _ = e.AppendJSON(buf[:0])
+		exception.Release(e)
+	})
+	if allocs > 1 {
+		t.Errorf("A pooled exception made %v allocations, expected at most 1", allocs)
+	}
+}
+
+func TestGetStackFrames(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{})
+
+	frames := err.GetStackFrames()
+	if len(frames) < 2 || frames[0].Function != "github.com/osirisgate/golang-core/exception.NewNotFound" {
+		t.Fatalf("The frames should start at the constructor, got %+v", frames)
+	}
+	if !strings.HasSuffix(frames[1].Function, "TestGetStackFrames") || !strings.HasSuffix(frames[1].File, "exception_test.go") || frames[1].Line == 0 {
+		t.Errorf("The second frame should be the caller, got %+v", frames[1])
+	}
+
+	frames[0].Function = "changed"
+	if err.GetStackFrames()[0].Function == "changed" {
+		t.Error("Modifying the returned frames should not affect the exception")
+	}
+	if !strings.HasPrefix(err.GetStackTrace(), err.GetStackFrames()[0].Function+"\n") {
+		t.Error("The stack trace should be consistent with the frames")
+	}
+
+	if frames := (&exception.CoreException{}).GetStackFrames(); frames != nil || (&exception.CoreException{}).GetStackTrace() != "" {
+		t.Errorf("An exception without a captured stack should have no frames, got %+v", frames)
+	}
+}
+
+func TestSetInterning(t *testing.T) {
+	build := func(parts ...string) string { return strings.Join(parts, "") }
+	create := func() *exception.Validation {
+		return exception.NewValidation(map[string]interface{}{
+			"message": build("The ", "email is invalid."),
+			"errors":  map[string]interface{}{build("em", "ail"): []interface{}{build("req", "uired")}},
+		})
+	}
+	sameData := func(a, b string) bool { return unsafe.StringData(a) == unsafe.StringData(b) }
+	rule := func(e *exception.Validation) string {
+		return e.GetErrors()["errors"].(map[string]interface{})["email"].([]interface{})[0].(string)
+	}
+
+	first, second := create(), create()
+	if sameData(first.Error(), second.Error()) {
+		t.Fatal("Without interning, the messages should not share their data")
+	}
+
+	restore := exception.SetInterning(true)
+	first, second = create(), create()
+	restore()
+
+	if first.Error() != "The email is invalid." || !sameData(first.Error(), second.Error()) {
+		t.Errorf("The messages should be interned, got %q", first.Error())
+	}
+	if rule(first) != "required" || !sameData(rule(first), rule(second)) {
+		t.Errorf("The nested values should be interned, got %q", rule(first))
+	}
+	for key := range first.GetErrors()["errors"].(map[string]interface{}) {
+		if !sameData(key, exception.Intern("email")) {
+			t.Error("The nested keys should be interned")
+		}
+	}
+
+	long := strings.Repeat("x", 300)
+	if !sameData(exception.Intern(long), long) {
+		t.Error("Long strings should not be interned")
+	}
+}
+
+func TestSetStackSampling(t *testing.T) {
+	restore := exception.SetStackSampling(exception.SampleByClass(1, 0.25))
+	captured := map[int]int{}
+	for i := 0; i < 8; i++ {
+		for _, err := range []exception.CoreInterface{exception.NewError(map[string]interface{}{}), exception.NewNotFound(map[string]interface{}{})} {
+			if err.GetStackTrace() != "" {
+				captured[err.GetStatusCode()]++
+			}
+		}
+	}
+	restore()
+	if captured[500] != 8 || captured[404] != 2 {
+		t.Errorf("Captured stacks = %v, expected all the 500 and a quarter of the 404", captured)
+	}
+	if exception.NewNotFound(map[string]interface{}{}).GetStackTrace() == "" {
+		t.Error("restore() should capture every stack again")
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	perSecond := exception.SamplePerSecond(2, func() time.Time { return now })
+	got := []bool{
+		perSecond(status.NotFound, "a"), perSecond(status.NotFound, "a"), perSecond(status.NotFound, "a"),
+		perSecond(status.NotFound, "b"),
+	}
+	now = now.Add(time.Second)
+	got = append(got, perSecond(status.NotFound, "a"))
+	if !reflect.DeepEqual(got, []bool{true, true, false, true, true}) {
+		t.Errorf("SamplePerSecond() = %v", got)
+	}
+
+	never := exception.SampleByClass(0, 0)
+	if exception.SampleAny(never, exception.SampleByClass(1, 1))(status.NotFound, "") != true || exception.SampleAny(never)(status.NotFound, "") {
+		t.Error("SampleAny() should sample when any policy does")
+	}
+
+	defer exception.SetStackSampling(never)()
+	if e := exception.Acquire(status.NotFound, ""); e.GetStackTrace() != "" {
+		t.Error("Acquire() should follow the sampling policy")
+	} else {
+		exception.Release(e)
+	}
+}
+
+func TestCacheFormat(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{"details": map[string]interface{}{"error": "order_not_found"}})
+	err.CacheFormat()
+
+	first := err.Format()
+	first["message"] = "Changed."
+	first["details"].(map[string]interface{})["error"] = "changed"
+	if formatted := err.Format(); formatted["message"] != "Not Found" || formatted["details"].(map[string]interface{})["error"] != "order_not_found" {
+		t.Error("Modifying the output of Format() should not affect the cache")
+	}
+
+	encoded, _ := err.AppendJSON(nil)
+	err.Message = "Bypassed."
+	if err.Format()["message"] != "Not Found" {
+		t.Error("The cached envelope should be reused until a mutation API is used")
+	}
+	if again, _ := err.AppendJSON(nil); string(again) != string(encoded) {
+		t.Errorf("AppendJSON() should reuse the cache, got %s", again)
+	}
+
+	err.SetMessage("The order was not found.")
+	err.SetError("order_id", "o-1")
+	if formatted := err.Format(); formatted["message"] != "The order was not found." || formatted["order_id"] != "o-1" {
+		t.Errorf("The mutation APIs should invalidate the cache, got %+v", formatted)
+	}
+
+	err.CacheFormat()
+	buf := make([]byte, 0, 256)
+	if allocs := testing.AllocsPerRun(100, func() { buf, _ = err.AppendJSON(buf[:0]) }); allocs != 0 {
+		t.Errorf("A cached AppendJSON() made %v allocations, expected none", allocs)
+	}
+}
+
+func TestFormatJSONRPC(t *testing.T) {
+	tests := []struct {
+		name string
+		err  interface{ FormatJSONRPC() map[string]interface{} }
+		code int
+	}{
+		{"InvalidArgument", exception.NewInvalidArgument(map[string]interface{}{}), exception.JSONRPCInvalidParams},
+		{"Validation", exception.NewValidation(map[string]interface{}{}), exception.JSONRPCInvalidParams},
+		{"RequestParseBody", exception.NewRequestParseBody(map[string]interface{}{}), exception.JSONRPCParseError},
+		{"Error", exception.NewError(map[string]interface{}{}), exception.JSONRPCInternalError},
+		{"NotImplemented", exception.FromStatus(status.NotImplemented, nil).(*exception.Error), exception.JSONRPCMethodNotFound},
+		{"NotFound", exception.NewNotFound(map[string]interface{}{}), exception.JSONRPCServerError},
+		{"Sentinel", exception.Define("ORDER_NOT_FOUND", status.NotFound, ""), exception.JSONRPCServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted := tt.err.FormatJSONRPC()
+			if formatted["code"] != tt.code {
+				t.Errorf("code = %v, want %d", formatted["code"], tt.code)
+			}
+		})
+	}
+
+	e := exception.NewNotFound(map[string]interface{}{
+		"message": "The order was not found.",
+		"details": map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+	})
+	want := map[string]interface{}{
+		"code":    exception.JSONRPCServerError,
+		"message": "The order was not found.",
+		"data": map[string]interface{}{
+			"error_code": 404,
+			"details":    map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+		},
+	}
+	if got := e.FormatJSONRPC(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FormatJSONRPC() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatSOAPFault(t *testing.T) {
+	e := exception.NewNotFound(map[string]interface{}{
+		"message": "Order <42> & co.",
+		"details": map[string]interface{}{"error": "ORDER_NOT_FOUND", "ids": []int{1, 2}, "1st key": true},
+	})
+
+	t.Run("SOAP11", func(t *testing.T) {
+		got, err := exception.FormatSOAPFault(e, exception.SOAP11)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `<soap:Fault xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+			`<faultcode>soap:Client</faultcode><faultstring>Order &lt;42&gt; &amp; co.</faultstring>` +
+			`<detail><details><_1st_key>true</_1st_key><error>ORDER_NOT_FOUND</error><ids><item>1</item><item>2</item></ids></details>` +
+			`<error_code>404</error_code></detail></soap:Fault>`
+		if string(got) != want {
+			t.Errorf("FormatSOAPFault() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("SOAP12", func(t *testing.T) {
+		got, err := exception.FormatSOAPFault(exception.NewError(map[string]interface{}{}), exception.SOAP12)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `<env:Fault xmlns:env="http://www.w3.org/2003/05/soap-envelope">` +
+			`<env:Code><env:Value>env:Receiver</env:Value></env:Code>` +
+			`<env:Reason><env:Text xml:lang="en">Internal Server Error</env:Text></env:Reason>` +
+			`<env:Detail><error_code>500</error_code></env:Detail></env:Fault>`
+		if string(got) != want {
+			t.Errorf("FormatSOAPFault() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("UnsupportedValue", func(t *testing.T) {
+		e := exception.NewError(map[string]interface{}{"ratio": math.NaN()})
+		if _, err := exception.FormatSOAPFault(e, exception.SOAP11); err == nil {
+			t.Error("expected an error for NaN")
+		}
+	})
+}
+
+func TestFormatXML(t *testing.T) {
+	got, err := exception.FormatXML(exception.NewConflict(map[string]interface{}{
+		"message": "Version <3> is stale.",
+		"details": map[string]interface{}{"version": 3, "ratio": 0.5},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<error><details><ratio>0.5</ratio><version>3</version></details><error_code>409</error_code>` +
+		`<message>Version &lt;3&gt; is stale.</message><status>error</status></error>`
+	if string(got) != want {
+		t.Errorf("FormatXML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFromError(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	unregister := exception.RegisterClassifier(func(err error) exception.CoreInterface {
+		if errors.Is(err, errQuota) {
+			return exception.NewTooManyRequests(map[string]interface{}{})
+		}
+		return nil
+	})
+	defer unregister()
+
+	notFound := exception.NewNotFound(map[string]interface{}{})
+	if got := exception.FromError(notFound); got != notFound {
+		t.Errorf("FromError(exception) = %v, want it unchanged", got)
+	}
+	if got := exception.FromError(errQuota); got.GetStatusCode() != 429 {
+		t.Errorf("FromError(classified) = %d, want 429", got.GetStatusCode())
+	}
+	cause := errors.New("boom")
+	if got := exception.FromError(cause); got.GetStatusCode() != 500 || !errors.Is(got, cause) {
+		t.Errorf("FromError(unknown) = %d, want a 500 wrapping the cause", got.GetStatusCode())
+	}
+	if exception.FromError(nil) != nil {
+		t.Error("FromError(nil) is not nil")
+	}
+
+	unregister()
+	if got := exception.FromError(errQuota); got.GetStatusCode() != 500 {
+		t.Errorf("FromError() after unregister = %d, want 500", got.GetStatusCode())
+	}
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("CollectsEveryError", func(t *testing.T) {
+		group, _ := exception.Group(context.Background())
+		group.Go(func() error { return exception.NewNotFound(map[string]interface{}{"message": "No user."}) })
+		group.Go(func() error { return nil })
+		group.Go(func() error { return exception.NewNotFound(map[string]interface{}{"message": "No order."}) })
+
+		var aggregate *exception.Aggregate
+		if err := group.Wait(); !errors.As(err, &aggregate) {
+			t.Fatalf("Wait() = %v, want an *Aggregate", err)
+		}
+		if aggregate.Len() != 2 || aggregate.GetStatusCode() != 404 {
+			t.Errorf("aggregate = %d errors, status %d", aggregate.Len(), aggregate.GetStatusCode())
+		}
+		if got := aggregate.Unwrap()[1].Error(); got != "No order." {
+			t.Errorf("second error = %q", got)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		group, ctx := exception.Group(context.Background())
+		group.Go(func() error { return nil })
+		if err := group.Wait(); err != nil {
+			t.Errorf("Wait() = %v", err)
+		}
+		if ctx.Err() == nil {
+			t.Error("the context is not canceled after Wait")
+		}
+	})
+
+	t.Run("TypedNil", func(t *testing.T) {
+		group, ctx := exception.Group(context.Background(), exception.FailFast())
+		group.Go(func() error {
+			var notFound *exception.NotFound
+			return notFound
+		})
+		if err := group.Wait(); err != nil {
+			t.Errorf("Wait() = %v, want nil for a typed nil", err)
+		}
+		if context.Cause(ctx) != context.Canceled {
+			t.Errorf("Cause() = %v, want the group not failed", context.Cause(ctx))
+		}
+	})
+
+	t.Run("FailFast", func(t *testing.T) {
+		group, ctx := exception.Group(context.Background(), exception.FailFast())
+		failure := exception.NewServiceUnavailable(map[string]interface{}{})
+		started := make(chan struct{})
+		group.Go(func() error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		<-started
+		group.Go(func() error { return failure })
+
+		err := group.Wait()
+		var aggregate *exception.Aggregate
+		if !errors.As(err, &aggregate) || aggregate.Len() != 1 || !errors.Is(err, failure) {
+			t.Fatalf("Wait() = %v, want only the failure", err)
+		}
+		if !errors.Is(context.Cause(ctx), failure) {
+			t.Errorf("Cause() = %v", context.Cause(ctx))
+		}
+
+		ran := false
+		group.Go(func() error { ran = true; return nil })
+		if ran {
+			t.Error("a goroutine ran after the failure")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		group, _ := exception.Group(context.Background(), exception.WithGroupLimit(1))
+		group.Go(func() error { panic("boom") })
+		var runtime *exception.Runtime
+		if err := group.Wait(); !errors.As(err, &runtime) {
+			t.Errorf("Wait() = %v, want a *Runtime", err)
+		}
+	})
+}
+
+func TestClassify(t *testing.T) {
+	unavailable := exception.NewServiceUnavailable(map[string]interface{}{})
+	conflict := exception.NewConflict(map[string]interface{}{})
+
+	tests := []struct {
+		name string
+		err  error
+		want exception.Classification
+	}{
+		{"Nil", nil, exception.PermanentFailure},
+		{"Plain", errors.New("boom"), exception.PermanentFailure},
+		{"TransientStatus", unavailable, exception.TransientFailure},
+		{"PermanentStatus", conflict, exception.PermanentFailure},
+		{"RetryableType", exception.NewConcurrencyConflict(map[string]interface{}{}), exception.TransientFailure},
+		{"MarkedPermanent", exception.Permanent(unavailable), exception.PermanentFailure},
+		{"MarkedTransient", exception.Transient(conflict), exception.TransientFailure},
+		{"OutermostMark", exception.Permanent(exception.Transient(conflict)), exception.PermanentFailure},
+		{"WrappedMark", fmt.Errorf("consume: %w", exception.Transient(conflict)), exception.TransientFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exception.Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	marked := exception.Permanent(unavailable)
+	var coreErr exception.CoreInterface
+	if !errors.As(marked, &coreErr) || coreErr.GetStatusCode() != 503 || marked.Error() != unavailable.Error() {
+		t.Errorf("Permanent() hides the exception: %v", marked)
+	}
+	if exception.Permanent(nil) != nil || exception.Transient(nil) != nil {
+		t.Error("marking nil does not yield nil")
+	}
+}
+
+func TestErrorCodeRegistry(t *testing.T) {
+	userNotFound := exception.ErrorCode{
+		Namespace: "USR", Number: 42, Status: status.NotFound,
+		Description: "The user was not found.", DocsURL: "https://docs.example.com/errors/USR-0042",
+	}
+	unregister, err := exception.RegisterCode(userNotFound)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unregister()
+	unregisterOrder, _ := exception.RegisterCode(exception.ErrorCode{Namespace: "ORD", Number: 7, Status: status.Conflict})
+	defer unregisterOrder()
+
+	t.Run("Collision", func(t *testing.T) {
+		var logic *exception.Logic
+		if _, err := exception.RegisterCode(userNotFound); !errors.As(err, &logic) || logic.GetDetailsMessage() != "error_code_collision" {
+			t.Errorf("RegisterCode(duplicate) = %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, code := range []exception.ErrorCode{
+			{Namespace: "usr", Number: 1, Status: status.NotFound},
+			{Namespace: "USR", Number: 10000, Status: status.NotFound},
+			{Namespace: "USR", Number: 1, Status: status.OK},
+		} {
+			if _, err := exception.RegisterCode(code); err == nil {
+				t.Errorf("RegisterCode(%+v) accepted an invalid code", code)
+			}
+		}
+	})
+
+	t.Run("MustRegisterPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("MustRegisterCode(duplicate) did not panic")
+			}
+		}()
+		exception.MustRegisterCode(userNotFound)
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		if code, ok := exception.LookupCode("USR-0042"); !ok || code != userNotFound {
+			t.Errorf("LookupCode() = %+v, %v", code, ok)
+		}
+		if _, ok := exception.LookupCode("USR-0001"); ok {
+			t.Error("LookupCode() found an unregistered code")
+		}
+		codes := exception.Codes()
+		if len(codes) != 2 || codes[0].String() != "ORD-0007" || codes[1].String() != "USR-0042" {
+			t.Errorf("Codes() = %v", codes)
+		}
+	})
+
+	t.Run("New", func(t *testing.T) {
+		e := exception.FromCode("USR-0042", map[string]interface{}{"details": map[string]interface{}{"user_id": 7}})
+		var notFound *exception.NotFound
+		if !errors.As(e, &notFound) || e.Error() != "The user was not found." {
+			t.Fatalf("FromCode() = %T %v", e, e)
+		}
+		want := map[string]interface{}{"error": "USR-0042", "docs_url": userNotFound.DocsURL, "user_id": 7}
+		if got := notFound.GetDetails(); !reflect.DeepEqual(got, want) {
+			t.Errorf("details = %v, want %v", got, want)
+		}
+
+		unknown := exception.FromCode("PAY-0001", nil)
+		if unknown.GetStatusCode() != 500 || unknown.GetDetailsMessage() != "PAY-0001" {
+			t.Errorf("FromCode(unknown) = %d %q", unknown.GetStatusCode(), unknown.GetDetailsMessage())
+		}
+	})
+}
+
+func TestStatusText(t *testing.T) {
+	e := exception.NewValidation(map[string]interface{}{exception.StatusTextKey: "Validation Failed"})
+	if e.GetStatusText() != "Validation Failed" || e.Error() != "Validation Failed" || e.GetStatusCode() != 422 {
+		t.Errorf("exception = %q %q %d", e.GetStatusText(), e.Error(), e.GetStatusCode())
+	}
+	if _, ok := e.Format()[exception.StatusTextKey]; ok {
+		t.Error("the status text is left in the envelope")
+	}
+	if got := status.StatusCode(422).GetDescription(); got != "Unprocessable Content" {
+		t.Errorf("the global description was altered: %q", got)
+	}
+
+	explicit := exception.NewValidation(map[string]interface{}{"message": "Invalid order.", exception.StatusTextKey: "Validation Failed"})
+	if explicit.Error() != "Invalid order." || exception.StatusText(explicit) != "Validation Failed" {
+		t.Errorf("explicit message = %q, status text %q", explicit.Error(), exception.StatusText(explicit))
+	}
+
+	if got := exception.StatusText(exception.NewNotFound(map[string]interface{}{})); got != "Not Found" {
+		t.Errorf("StatusText() = %q, want the description", got)
+	}
+	sentinel := exception.Define("GONE", status.Gone, "")
+	if got := exception.StatusText(sentinel); got != "Gone" {
+		t.Errorf("StatusText(sentinel) = %q", got)
+	}
+}
+
+func TestTypedAccessors(t *testing.T) {
+	var e exception.CoreInterface = exception.NewTooManyRequests(map[string]interface{}{
+		"request_id":  "req-1",
+		"retry_after": 30,
+		"limit":       float64(100),
+		"ratio":       0.5,
+		"count":       json.Number("7"),
+		"small":       int8(3),
+		"details":     map[string]interface{}{"error": "rate_limited"},
+		"fields":      []string{"name", "email"},
+		"items":       []interface{}{1, "two"},
+	})
+
+	if got, ok := e.GetString("request_id"); !ok || got != "req-1" {
+		t.Errorf("GetString() = %q, %v", got, ok)
+	}
+	if _, ok := e.GetString("retry_after"); ok {
+		t.Error("GetString() accepted an int")
+	}
+
+	for key, want := range map[string]int{"retry_after": 30, "limit": 100, "count": 7, "small": 3} {
+		if got, ok := e.GetInt(key); !ok || got != want {
+			t.Errorf("GetInt(%q) = %d, %v, want %d", key, got, ok, want)
+		}
+	}
+	for _, key := range []string{"ratio", "request_id", "missing"} {
+		if _, ok := e.GetInt(key); ok {
+			t.Errorf("GetInt(%q) accepted a non-integer", key)
+		}
+	}
+
+	if details, ok := e.GetMap("details"); !ok || details["error"] != "rate_limited" {
+		t.Errorf("GetMap() = %v, %v", details, ok)
+	}
+	if _, ok := e.GetMap("fields"); ok {
+		t.Error("GetMap() accepted a slice")
+	}
+	_ = e.Format()
+	details, _ := e.GetMap("details")
+	details["error"] = "changed"
+	if e.GetDetailsMessage() != "rate_limited" || e.Format()["details"].(map[string]interface{})["error"] != "rate_limited" {
+		t.Error("GetMap() should return a copy of the entry")
+	}
+
+	if fields, ok := e.GetSlice("fields"); !ok || !reflect.DeepEqual(fields, []interface{}{"name", "email"}) {
+		t.Errorf("GetSlice([]string) = %v, %v", fields, ok)
+	}
+	if items, ok := e.GetSlice("items"); !ok || len(items) != 2 {
+		t.Errorf("GetSlice([]interface{}) = %v, %v", items, ok)
+	}
+	if _, ok := e.GetSlice("request_id"); ok {
+		t.Error("GetSlice() accepted a string")
+	}
+
+	sentinel := exception.Define("ORDER_NOT_FOUND", status.NotFound, "")
+	if details, ok := sentinel.GetMap("details"); !ok || details["error"] != "ORDER_NOT_FOUND" {
+		t.Errorf("Sentinel.GetMap() = %v, %v", details, ok)
+	}
+}
+
+func TestValidateEnvelope(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(exception.EnvelopeSchema, &schema); err != nil || schema["$schema"] == nil {
+		t.Fatalf("EnvelopeSchema is not a JSON Schema: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		envelope  string
+		violation string
+	}{
+		{"Valid", `{"status":"error","error_code":404,"message":"Not Found","details":{"error":"ORDER_NOT_FOUND"}}`, ""},
+		{"ValidAggregate", `{"status":"error","error_code":400,"message":"2 errors occurred.","errors":[{"error_code":400}]}`, ""},
+		{"Missing", `{"status":"error","message":"Not Found"}`, `/: the member "error_code" is missing`},
+		{"Status", `{"status":"success","error_code":404,"message":"Not Found"}`, `/status: "success" is not "error"`},
+		{"NotAnError", `{"status":"error","error_code":200,"message":"OK"}`, "/error_code: 200 is less than 400"},
+		{"Float", `{"status":"error","error_code":404.5,"message":"Not Found"}`, "/error_code: 404.5 is not of type integer"},
+		{"Reason", `{"status":"error","error_code":404,"message":"Not Found","details":{"error":7}}`, "/details/error: 7 is not of type string"},
+		{"NotJSON", `{"status":`, "the envelope is not valid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exception.ValidateEnvelope([]byte(tt.envelope))
+			if tt.violation == "" {
+				if err != nil {
+					t.Errorf("ValidateEnvelope() = %v", err)
+				}
+				return
+			}
+			var invalid *exception.InvalidArgument
+			if !errors.As(err, &invalid) {
+				t.Fatalf("ValidateEnvelope() = %v, want an *InvalidArgument", err)
+			}
+			violations, _ := invalid.GetDetails()["violations"].([]string)
+			if len(violations) == 0 || !strings.HasPrefix(violations[0], tt.violation) {
+				t.Errorf("violations = %q, want %q", violations, tt.violation)
+			}
+		})
+	}
+
+	t.Run("StrictMode", func(t *testing.T) {
+		defer exception.SetStrictEnvelopes(true)()
+
+		valid := exception.NewNotFound(map[string]interface{}{"details": map[string]interface{}{"error": "ORDER_NOT_FOUND"}})
+		if _, err := valid.AppendJSON(nil); err != nil {
+			t.Errorf("AppendJSON(valid) = %v", err)
+		}
+		_ = valid.Format()
+
+		invalid := exception.NewNotFound(map[string]interface{}{"status": "missing"})
+		if _, err := invalid.AppendJSON(nil); err == nil {
+			t.Error("AppendJSON(invalid) succeeded in strict mode")
+		}
+		defer func() {
+			if recover() == nil {
+				t.Error("Format(invalid) did not panic in strict mode")
+			}
+		}()
+		_ = invalid.Format()
+	})
+
+	t.Run("Catalog", func(t *testing.T) {
+		for _, entry := range exception.Catalog() {
+			encoded, _ := json.Marshal(entry.New(map[string]interface{}{}).Format())
+			if err := exception.ValidateEnvelope(encoded); err != nil {
+				t.Errorf("%s: %v", entry.Name, err)
+			}
+		}
+	})
+}
+
+func TestSeal(t *testing.T) {
+	e := exception.NewNotFound(map[string]interface{}{"message": "Order not found."})
+	if err := e.SetError("request_id", "req-1"); err != nil {
+		t.Fatalf("SetError() before Seal = %v", err)
+	}
+	if err := e.SetMessage("Commande introuvable."); err != nil {
+		t.Fatalf("SetMessage() before Seal = %v", err)
+	}
+	if e.IsSealed() {
+		t.Fatal("IsSealed() = true before Seal")
+	}
+
+	e.Seal()
+	if !e.IsSealed() {
+		t.Fatal("IsSealed() = false after Seal")
+	}
+	before := e.Format()
+
+	for name, mutate := range map[string]func() error{
+		"SetError":   func() error { return e.SetError("request_id", "req-2") },
+		"SetMessage": func() error { return e.SetMessage("Changed.") },
+	} {
+		err := mutate()
+		var logic *exception.Logic
+		if !errors.As(err, &logic) {
+			t.Fatalf("%s() after Seal = %v, want a *Logic", name, err)
+		}
+		if got := logic.GetDetailsMessage(); got != "exception_sealed" {
+			t.Errorf("%s() details error = %q, want %q", name, got, "exception_sealed")
+		}
+	}
+	if after := e.Format(); !reflect.DeepEqual(after, before) {
+		t.Errorf("Format() after rejected mutations = %v, want %v", after, before)
+	}
+	if e.Message != "Commande introuvable." || e.Errors["request_id"] != "req-1" {
+		t.Errorf("sealed exception was modified: %q, %v", e.Message, e.Errors)
+	}
+}
+
+func TestAppInfo(t *testing.T) {
+	e := exception.NewNotFound(map[string]interface{}{"message": "Order not found."})
+	if _, ok := e.GetErrorsForLog()["service"]; ok {
+		t.Fatal("GetErrorsForLog() has a service before SetAppInfo")
+	}
+
+	restore := exception.SetAppInfo("orders", "1.4.2", "production", "pod-7")
+	want := exception.AppInfo{Service: "orders", Version: "1.4.2", Environment: "production", Hostname: "pod-7"}
+	if got := exception.CurrentAppInfo(); got != want {
+		t.Errorf("CurrentAppInfo() = %+v, want %+v", got, want)
+	}
+	entry := e.GetErrorsForLog()
+	for key, value := range want.Fields() {
+		if entry[key] != value {
+			t.Errorf("GetErrorsForLog()[%q] = %v, want %v", key, entry[key], value)
+		}
+	}
+	if entry["message"] != "Order not found." {
+		t.Errorf("GetErrorsForLog() message = %v", entry["message"])
+	}
+	sentinel := exception.Define("ORDER_NOT_FOUND", status.NotFound, "Not found.")
+	if sentinel.GetErrorsForLog()["version"] != "1.4.2" {
+		t.Errorf("Sentinel GetErrorsForLog() = %v, want the version", sentinel.GetErrorsForLog())
+	}
+	restore()
+
+	if got := exception.CurrentAppInfo(); got != (exception.AppInfo{}) {
+		t.Errorf("CurrentAppInfo() after restore = %+v", got)
+	}
+	defer exception.SetAppInfo("orders", "", "", "")()
+	if exception.CurrentAppInfo().Hostname == "" {
+		t.Error("SetAppInfo() did not default the hostname")
+	}
+	if fields := (exception.AppInfo{Service: "orders"}).Fields(); len(fields) != 1 {
+		t.Errorf("Fields() = %v, want only the service", fields)
+	}
+}
+
+func TestWithRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders?page=2", nil)
+	req.RemoteAddr = "203.0.113.7:52100"
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("User-Agent", "curl/8.0")
+	req.Header.Set("X-Internal", "hidden")
+
+	e := exception.NewError(map[string]interface{}{})
+	if _, ok := e.GetErrorsForLog()[exception.RequestLogKey]; ok {
+		t.Fatal("GetErrorsForLog() has a request before WithRequest")
+	}
+	if err := e.WithRequest(req); err != nil {
+		t.Fatalf("WithRequest() = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"method":    http.MethodPost,
+		"path":      "/orders",
+		"query":     "page=2",
+		"remote_ip": "203.0.113.7",
+		"headers": map[string]interface{}{
+			"Authorization": "[REDACTED]",
+			"Cookie":        "[REDACTED]",
+			"User-Agent":    "curl/8.0",
+		},
+	}
+	if got := e.GetErrorsForLog()[exception.RequestLogKey]; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetErrorsForLog()[request] = %v, want %v", got, want)
+	}
+	if _, ok := e.Format()[exception.RequestLogKey]; ok {
+		t.Error("Format() exposes the request")
+	}
+
+	t.Run("SelectedHeaders", func(t *testing.T) {
+		defer exception.SetSnapshotHeaders("x-internal", "cookie")()
+		snapshot := exception.SnapshotRequest(req)
+		if len(snapshot.Headers) != 2 || snapshot.Headers["X-Internal"] != "hidden" || snapshot.Headers["Cookie"] != "[REDACTED]" {
+			t.Errorf("Headers = %v", snapshot.Headers)
+		}
+	})
+
+	t.Run("AttachRequest", func(t *testing.T) {
+		notFound := exception.NewNotFound(map[string]interface{}{})
+		wrapped := fmt.Errorf("loading: %w", notFound)
+		if got := exception.AttachRequest(wrapped, req); got != wrapped {
+			t.Errorf("AttachRequest() = %v, want err", got)
+		}
+		if snapshot, ok := notFound.Request(); !ok || snapshot.Path != "/orders" {
+			t.Errorf("Request() = %+v, %v", snapshot, ok)
+		}
+		if exception.AttachRequest(nil, req) != nil {
+			t.Error("AttachRequest(nil) != nil")
+		}
+	})
+
+	t.Run("Sealed", func(t *testing.T) {
+		sealed := exception.NewError(map[string]interface{}{})
+		sealed.Seal()
+		if err := sealed.WithRequest(req); err == nil {
+			t.Error("WithRequest() succeeded on a sealed exception")
+		}
+	})
+}
+
+func TestHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		err  exception.CoreInterface
+		want map[string]string
+	}{
+		{"RetryAfter", exception.NewTooManyRequests(map[string]interface{}{"details": map[string]interface{}{"retry_after": 30}}), map[string]string{"Retry-After": "30"}},
+		{"RetryAfterUnavailable", exception.NewServiceUnavailable(map[string]interface{}{"details": map[string]interface{}{"retry_after": 5.0}}), map[string]string{"Retry-After": "5"}},
+		{"NoRetryAfter", exception.NewTooManyRequests(map[string]interface{}{}), nil},
+		{"Allow", exception.FromStatus(status.MethodNotAllowed, map[string]interface{}{"details": map[string]interface{}{"allow": []string{"GET", "HEAD"}}}), map[string]string{"Allow": "GET, HEAD"}},
+		{"Challenge", exception.NewUnauthorized(map[string]interface{}{"details": map[string]interface{}{"challenge": `Basic realm="api"`}}), map[string]string{"WWW-Authenticate": `Basic realm="api"`}},
+		{"DefaultChallenge", exception.NewUnauthorized(map[string]interface{}{}), map[string]string{"WWW-Authenticate": exception.DefaultChallenge}},
+		{"None", exception.NewNotFound(map[string]interface{}{}), nil},
+		{"Sentinel", exception.Define("TOKEN_MISSING", status.Unauthorized, "A token is required."), map[string]string{"WWW-Authenticate": exception.DefaultChallenge}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Headers(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Headers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHint(t *testing.T) {
+	e := exception.NewInvalidArgument(map[string]interface{}{"message": "The amount is invalid."})
+	if got := exception.Hint(e); got != "" {
+		t.Fatalf("Hint() = %q before WithHint", got)
+	}
+	e.CacheFormat()
+	if err := e.WithHint("Check that the amount is positive."); err != nil {
+		t.Fatalf("WithHint() = %v", err)
+	}
+	if got := exception.Hint(e); got != "Check that the amount is positive." {
+		t.Errorf("Hint() = %q", got)
+	}
+	formatted := e.Format()
+	if formatted[exception.HintKey] != "Check that the amount is positive." {
+		t.Errorf("Format() = %v, want the hint", formatted)
+	}
+	encoded, _ := json.Marshal(formatted)
+	if err := exception.ValidateEnvelope(encoded); err != nil {
+		t.Errorf("ValidateEnvelope() = %v", err)
+	}
+
+	if err := e.WithHint(""); err != nil || exception.Hint(e) != "" {
+		t.Errorf("WithHint(\"\") = %v, Hint() = %q, want the hint removed", err, exception.Hint(e))
+	}
+	if _, ok := e.Format()[exception.HintKey]; ok {
+		t.Error("Format() kept the removed hint")
+	}
+
+	fromMap := exception.NewInvalidArgument(map[string]interface{}{exception.HintKey: "Retry later."})
+	if got := exception.Hint(fromMap); got != "Retry later." {
+		t.Errorf("Hint() of a constructor map = %q", got)
+	}
+	fromMap.Seal()
+	if err := fromMap.WithHint("Other."); err == nil || exception.Hint(fromMap) != "Retry later." {
+		t.Errorf("WithHint() on a sealed exception = %v", err)
+	}
+
+	if err := exception.ValidateEnvelope([]byte(`{"status":"error","error_code":400,"message":"Bad.","hint":""}`)); err == nil {
+		t.Error("ValidateEnvelope() accepted an empty hint")
+	}
+}
+
+func TestWithCause(t *testing.T) {
+	root := errors.New("connection reset by peer")
+	upstream := exception.NewUpstreamFailure(map[string]interface{}{
+		"message": "The payment service failed.",
+		"details": map[string]interface{}{"error": "payment_failed"},
+	})
+	if err := upstream.WithCause(fmt.Errorf("charging: %w", root)); err != nil {
+		t.Fatalf("WithCause() = %v", err)
+	}
+	e := exception.NewConflict(map[string]interface{}{"message": "The order cannot be paid."})
+	e.CacheFormat()
+	if err := e.WithCause(upstream); err != nil {
+		t.Fatalf("WithCause() = %v", err)
+	}
+
+	if !errors.Is(e, root) {
+		t.Error("errors.Is() does not reach the root cause")
+	}
+	var target *exception.UpstreamFailure
+	if !errors.As(e, &target) || target != upstream {
+		t.Error("errors.As() does not reach the cause")
+	}
+
+	formatted := e.Format()
+	want := []interface{}{map[string]interface{}{
+		"type":       "*exception.UpstreamFailure",
+		"message":    "The payment service failed.",
+		"error_code": upstream.GetStatusCode(),
+		"error":      "payment_failed",
+	}}
+	if !reflect.DeepEqual(formatted[exception.CausesKey], want) {
+		t.Errorf("Format()[causes] = %v, want %v", formatted[exception.CausesKey], want)
+	}
+	encoded, _ := json.Marshal(formatted)
+	appended, err := e.AppendJSON(nil)
+	if err != nil || string(appended) != string(encoded) {
+		t.Errorf("AppendJSON() = %s, %v, want %s", appended, err, encoded)
+	}
+	if err := exception.ValidateEnvelope(encoded); err != nil {
+		t.Errorf("ValidateEnvelope() = %v", err)
+	}
+
+	logged, _ := e.GetErrorsForLog()[exception.CausesKey].([]interface{})
+	if len(logged) != 3 {
+		t.Fatalf("GetErrorsForLog()[causes] = %v, want the upstream failure, the wrapper and the root", logged)
+	}
+	if last := logged[2].(map[string]interface{}); last["message"] != "connection reset by peer" || last["type"] != "*errors.errorString" {
+		t.Errorf("root cause = %v", last)
+	}
+
+	t.Run("Depth", func(t *testing.T) {
+		defer exception.SetMaxCauseDepth(1)()
+		if logged, _ := e.GetErrorsForLog()[exception.CausesKey].([]interface{}); len(logged) != 1 {
+			t.Errorf("causes = %v, want 1 with a depth of 1", logged)
+		}
+		exception.SetMaxCauseDepth(0)
+		if _, ok := e.GetErrorsForLog()[exception.CausesKey]; ok {
+			t.Error("causes rendered with a depth of 0")
+		}
+	})
+
+	t.Run("Joined", func(t *testing.T) {
+		joined := exception.NewError(map[string]interface{}{})
+		_ = joined.WithCause(errors.Join(exception.NewNotFound(map[string]interface{}{}), exception.NewTimeout(map[string]interface{}{})))
+		if causes, _ := joined.Format()[exception.CausesKey].([]interface{}); len(causes) != 2 {
+			t.Errorf("causes = %v, want both joined exceptions", causes)
+		}
+	})
+
+	t.Run("NoCause", func(t *testing.T) {
+		plain := exception.NewNotFound(map[string]interface{}{})
+		if _, ok := plain.Format()[exception.CausesKey]; ok {
+			t.Error("Format() has causes without WithCause")
+		}
+		if plain.Unwrap() != nil {
+			t.Error("Unwrap() != nil without WithCause")
+		}
+	})
+}
+
+func TestAggregateUnwrap(t *testing.T) {
+	errOrderNotFound := exception.Define("ORDER_NOT_FOUND", status.NotFound, "The order was not found.")
+	batch := exception.NewAggregate(nil,
+		exception.NewTimeout(map[string]interface{}{}),
+		exception.NewAggregate(nil, fmt.Errorf("order 42: %w", errOrderNotFound.Raise(nil))),
+	)
+
+	if got := len(batch.Unwrap()); got != 2 {
+		t.Fatalf("len(Unwrap()) = %d, want 2", got)
+	}
+	if !errors.Is(batch, errOrderNotFound) {
+		t.Error("errors.Is() does not detect the sentinel inside the nested batch")
+	}
+	var timeout *exception.Timeout
+	if !errors.As(batch, &timeout) {
+		t.Error("errors.As() does not find the grouped *Timeout")
+	}
+	var conflict *exception.Conflict
+	if errors.As(batch, &conflict) {
+		t.Error("errors.As() found a *Conflict that is not grouped")
+	}
+
+	cause := errors.New("queue closed")
+	_ = batch.WithCause(cause)
+	if unwrapped := batch.Unwrap(); len(unwrapped) != 3 || unwrapped[2] != cause || batch.Len() != 2 {
+		t.Errorf("Unwrap() = %v, Len() = %d, want the grouped errors followed by the cause", unwrapped, batch.Len())
+	}
+	if !errors.Is(batch, cause) {
+		t.Error("errors.Is() does not reach the cause of the batch")
+	}
+}
+
+func TestNilSafety(t *testing.T) {
+	var nilErr *exception.CoreException
+	if nilErr.Error() != "" || nilErr.GetStatusCode() != 0 || nilErr.GetErrors() != nil || nilErr.GetStackTrace() != "" {
+		t.Error("The accessors of a nil exception should return empty values")
+	}
+	if len(nilErr.Format()) != 0 || len(nilErr.GetErrorsForLog()) != 0 || len(nilErr.GetDetails()) != 0 || nilErr.Headers() != nil {
+		t.Error("The maps of a nil exception should be empty")
+	}
+	if got, err := nilErr.AppendJSON(nil); err != nil || string(got) != "{}" {
+		t.Errorf("AppendJSON() = %q, %v, want {}", got, err)
+	}
+	if nilErr.Unwrap() != nil || nilErr.IsSealed() {
+		t.Error("A nil exception should have no cause and not be sealed")
+	}
+	nilErr.Seal()
+
+	var logic *exception.Logic
+	if err := nilErr.SetMessage("changed"); !errors.As(err, &logic) {
+		t.Errorf("SetMessage() on a nil exception = %v, want a *Logic exception", err)
+	}
+	if err := nilErr.WithHint("retry"); !errors.As(err, &logic) {
+		t.Errorf("WithHint() on a nil exception = %v, want a *Logic exception", err)
+	}
+
+	notFound := exception.NewNotFound(nil)
+	if notFound.GetErrors() == nil || notFound.GetStatusCode() != status.NotFound.GetValue() {
+		t.Errorf("NewNotFound(nil) = %+v, want an empty errors map", notFound.GetErrors())
+	}
+	if err := notFound.SetError("id", 42); err != nil {
+		t.Errorf("SetError() on an exception built from a nil map = %v", err)
+	}
+
+	var typedNil *exception.NotFound
+	var err error = typedNil
+	if !exception.IsNil(err) || !exception.IsNil(nil) || exception.IsNil(notFound) {
+		t.Error("IsNil() should detect nil errors and nil exceptions only")
+	}
+	if exception.Normalize(err) != nil || exception.FromError(err) != nil {
+		t.Error("Normalize() and FromError() should treat a nil exception as no error")
+	}
+}
+
+func TestTypedNilErrors(t *testing.T) {
+	var notFound *exception.NotFound
+	var typedNil error = notFound
+	joined := errors.Join(typedNil)
+
+	aggregate := exception.NewAggregate(nil, typedNil, exception.NewConflict(nil), joined)
+	if aggregate.Len() != 2 || aggregate.GetStatusCode() != 500 {
+		t.Errorf("NewAggregate() = %d errors, status %d, want the nil exception skipped", aggregate.Len(), aggregate.GetStatusCode())
+	}
+	if formatted := aggregate.Format(); len(formatted["errors"].([]map[string]interface{})) != 2 {
+		t.Errorf("Format() = %v", formatted)
+	}
+	_ = aggregate.GetErrorsForLog()
+
+	if exception.IsRetryable(typedNil) || exception.IsRetryable(joined) {
+		t.Error("IsRetryable() should not retry nil exceptions")
+	}
+
+	err := exception.NewConflict(nil)
+	_ = err.WithCause(typedNil)
+	if err.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v, want a nil exception cause ignored", err.Unwrap())
+	}
+	_ = err.WithCause(joined)
+	_ = err.Format()
+	_ = err.GetErrorsForLog()
+
+	if normalized := exception.Normalize(joined); exception.IsNil(normalized) || normalized.(exception.CoreInterface).GetStatusCode() != 500 {
+		t.Errorf("Normalize() = %v, want an internal error", normalized)
+	} else if entry := normalized.(exception.CoreInterface).GetErrorsForLog(); entry["cause"] != "<nil>" {
+		t.Errorf("GetErrorsForLog() cause = %v", entry["cause"])
+	}
+	if exception.FromError(joined) == nil {
+		t.Error("FromError() should convert a chain holding only nil exceptions")
+	}
+	if exception.AttachRequest(joined, httptest.NewRequest(http.MethodGet, "/", nil)) != joined {
+		t.Error("AttachRequest() should return the error unchanged")
+	}
+}
+
+func TestKeyNaming(t *testing.T) {
+	restore := exception.SetKeyNaming(exception.CamelCase)
+	defer restore()
+
+	err := exception.NewNotFound(map[string]interface{}{
+		"details":  map[string]interface{}{"error": "order_not_found", "order_id": 42},
+		"order_id": 42,
+	})
+	_ = err.SetError("request_id", "req-1")
+	_ = err.WithCause(exception.NewTimeout(nil))
+
+	formatted := err.Format()
+	if formatted["errorCode"] != 404 || formatted["requestId"] != "req-1" {
+		t.Errorf("Format() = %v, want the camelCase built-in keys", formatted)
+	}
+	if _, ok := formatted["error_code"]; ok {
+		t.Error("Format() should not hold the snake_case error_code")
+	}
+	if formatted["order_id"] != 42 || formatted["details"].(map[string]interface{})["order_id"] != 42 {
+		t.Errorf("Format() = %v, want the user-provided keys unchanged", formatted)
+	}
+	if cause := formatted["causes"].([]interface{})[0].(map[string]interface{}); cause["errorCode"] != 504 {
+		t.Errorf("causes = %v, want the camelCase errorCode", formatted["causes"])
+	}
+
+	encoded, _ := err.AppendJSON(nil)
+	marshaled, _ := json.Marshal(formatted)
+	if !bytes.Equal(encoded, marshaled) {
+		t.Errorf("AppendJSON() = %s, want %s", encoded, marshaled)
+	}
+	if validateErr := exception.ValidateEnvelope(encoded); validateErr != nil {
+		t.Errorf("ValidateEnvelope() = %v, want the camelCase envelope to be valid", validateErr)
+	}
+
+	errOrderNotFound := exception.Define("ORDER_NOT_FOUND", status.NotFound, "")
+	if sentinel := errOrderNotFound.Format(); sentinel["errorCode"] != 404 {
+		t.Errorf("Sentinel Format() = %v, want errorCode", sentinel)
+	}
+	if log := err.GetErrorsForLog(); log["status_code"] != 404 || log[exception.CausesKey].([]interface{})[0].(map[string]interface{})["error_code"] != 504 {
+		t.Errorf("GetErrorsForLog() = %v, want the snake_case keys", log)
+	}
+	if exception.BuiltinKey("errorCode") != "error_code" || exception.SnakeCase.Key("error_code") != "error_code" {
+		t.Error("BuiltinKey() and Key() should map the built-in keys")
+	}
+
+	restore()
+	if formatted := err.Format(); formatted["error_code"] != 404 || formatted["request_id"] != "req-1" {
+		t.Errorf("Format() = %v after restore, want the snake_case keys", formatted)
+	}
+}
+
+func TestFormatV2(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{
+		"details":  map[string]interface{}{"error": "order_not_found"},
+		"order_id": 42,
+	})
+	_ = err.SetError("request_id", "req-1")
+	_ = err.SetError("trace_id", "trace-1")
+	timestamp := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	formatted := exception.FormatV2(err, timestamp)
+	expected := map[string]interface{}{
+		"status": status.ERROR,
+		"error": map[string]interface{}{
+			"code":     404,
+			"message":  "Not Found",
+			"details":  map[string]interface{}{"error": "order_not_found"},
+			"order_id": 42,
+		},
+		"meta": map[string]interface{}{
+			"timestamp":  "2024-05-01T12:00:00Z",
+			"request_id": "req-1",
+			"trace_id":   "trace-1",
+		},
+	}
+	if !reflect.DeepEqual(formatted, expected) {
+		t.Errorf("FormatV2() = %v, want %v", formatted, expected)
+	}
+
+	encoded, _ := json.Marshal(formatted)
+	if validateErr := exception.ValidateEnvelopeV2(encoded); validateErr != nil {
+		t.Errorf("ValidateEnvelopeV2() = %v", validateErr)
+	}
+	if exception.ValidateEnvelopeV2([]byte(`{"status":"error","error_code":404,"message":"Not Found"}`)) == nil {
+		t.Error("ValidateEnvelopeV2() should reject the v1 envelope")
+	}
+
+	defer exception.SetKeyNaming(exception.CamelCase)()
+	formatted = exception.FormatV2(err, timestamp)
+	if meta := formatted["meta"].(map[string]interface{}); meta["requestId"] != "req-1" {
+		t.Errorf("meta = %v, want the camelCase requestId", meta)
+	}
+	encoded, _ = json.Marshal(formatted)
+	if validateErr := exception.ValidateEnvelopeV2(encoded); validateErr != nil {
+		t.Errorf("ValidateEnvelopeV2() in camelCase = %v", validateErr)
+	}
+}
+
+func TestHasStatus(t *testing.T) {
+	wrapped := fmt.Errorf("loading order 42: %w", exception.NewNotFound(nil))
+	joined := errors.Join(errors.New("cache miss"), wrapped)
+
+	for _, err := range []error{wrapped, joined} {
+		if !exception.HasStatus(err, status.NotFound) || exception.HasStatus(err, status.Conflict) {
+			t.Errorf("HasStatus(%v) does not match the status of the exception of the chain", err)
+		}
+		if got := exception.CodeOf(err); got != 404 {
+			t.Errorf("CodeOf(%v) = %d, want 404", err, got)
+		}
+	}
+
+	var notFound *exception.NotFound
+	for _, err := range []error{nil, errors.New("plain"), notFound} {
+		if exception.HasStatus(err, status.NotFound) || exception.CodeOf(err) != 0 {
+			t.Errorf("HasStatus() and CodeOf() should not match %v", err)
+		}
+	}
+}
+
+func TestGetDetailsCopy(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{"details": map[string]interface{}{"error": "order_not_found"}})
+
+	details := err.GetDetails()
+	details["error"] = "changed"
+	details["injected"] = true
+	if got := err.Format()["details"]; !reflect.DeepEqual(got, map[string]interface{}{"error": "order_not_found"}) {
+		t.Errorf("Format() details = %v, want them unaltered by the copy", got)
+	}
+
+	if setErr := err.SetDetail("order_id", 42); setErr != nil {
+		t.Fatalf("SetDetail() = %v", setErr)
+	}
+	if got := err.GetDetails(); got["order_id"] != 42 || got["error"] != "order_not_found" {
+		t.Errorf("GetDetails() = %v, want the entry set by SetDetail", got)
+	}
+
+	bare := exception.NewConflict(nil)
+	_ = bare.SetDetail("error", "duplicate_order")
+	if bare.GetDetailsMessage() != "duplicate_order" {
+		t.Errorf("SetDetail() on an exception without details = %v", bare.GetErrors())
+	}
+
+	err.Seal()
+	var logic *exception.Logic
+	if setErr := err.SetDetail("order_id", 43); !errors.As(setErr, &logic) || err.GetDetails()["order_id"] != 42 {
+		t.Errorf("SetDetail() on a sealed exception = %v, want a *Logic exception", setErr)
+	}
+}
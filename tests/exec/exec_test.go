@@ -0,0 +1,117 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/exec"
+)
+
+func TestWithTimeout(t *testing.T) {
+	failure := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		fn          exec.Func
+		wantErr     error
+		wantTimeout bool
+	}{
+		{
+			name: "completes in time",
+			fn:   func(context.Context) error { return nil },
+		},
+		{
+			name:    "fails in time",
+			fn:      func(context.Context) error { return failure },
+			wantErr: failure,
+		},
+		{
+			name: "ignores its context",
+			fn: func(context.Context) error {
+				time.Sleep(100 * time.Millisecond)
+				return nil
+			},
+			wantTimeout: true,
+		},
+		{
+			name: "returns the context error",
+			fn: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := exec.WithTimeout(context.Background(), 10*time.Millisecond, tt.fn, exec.Operation("load_profile"))
+			if !tt.wantTimeout {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("WithTimeout() = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			var timeout *exception.Timeout
+			if !errors.As(err, &timeout) {
+				t.Fatalf("WithTimeout() = %v, want *exception.Timeout", err)
+			}
+			details := timeout.GetDetails()
+			if details["operation"] != "load_profile" || details["timeout_ms"] != int64(10) || details["error"] != "operation_timeout" {
+				t.Errorf("details = %v", details)
+			}
+			if elapsed, _ := details["elapsed_ms"].(int64); elapsed < 10 {
+				t.Errorf("elapsed_ms = %v, want at least 10", details["elapsed_ms"])
+			}
+		})
+	}
+}
+
+func TestWithDeadlineGrace(t *testing.T) {
+	returned := make(chan struct{})
+	err := exec.WithDeadline(context.Background(), time.Now().Add(10*time.Millisecond), func(ctx context.Context) error {
+		defer close(returned)
+		<-ctx.Done()
+		time.Sleep(5 * time.Millisecond)
+		return ctx.Err()
+	}, exec.Grace(time.Second))
+
+	select {
+	case <-returned:
+	default:
+		t.Error("WithDeadline() returned before the function")
+	}
+	var timeout *exception.Timeout
+	if !errors.As(err, &timeout) {
+		t.Errorf("WithDeadline() = %v, want *exception.Timeout", err)
+	}
+}
+
+func TestWithTimeoutParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := exec.WithTimeout(ctx, time.Second, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	var timeout *exception.Timeout
+	if err == nil || errors.As(err, &timeout) {
+		t.Errorf("WithTimeout() = %v, want a cancellation error", err)
+	}
+}
+
+func TestWithTimeoutPanic(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered != "boom" {
+			t.Errorf("recovered %v, want the panic of the function", recovered)
+		}
+	}()
+	_ = exec.WithTimeout(context.Background(), time.Second, func(context.Context) error {
+		panic("boom")
+	})
+}
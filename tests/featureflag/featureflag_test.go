@@ -0,0 +1,83 @@
+package featureflag_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/featureflag"
+)
+
+func TestTypedEvaluation(t *testing.T) {
+	ctx := context.Background()
+	memory := featureflag.NewMemory(map[string]interface{}{
+		"new-checkout": true,
+		"variant":      "blue",
+		"max-items":    float64(25), // As decoded from JSON.
+		"rollout":      "0.25",
+		"broken":       []string{"not", "a", "bool"},
+	})
+	env := featureflag.NewEnv("FEATURE_", func(key string) (string, bool) {
+		if key == "FEATURE_NEW_CHECKOUT" {
+			return "false", true
+		}
+		return "", false
+	})
+	client := featureflag.New(featureflag.Chain(env, memory))
+
+	if env.Variable("new-checkout") != "FEATURE_NEW_CHECKOUT" {
+		t.Errorf("Unexpected variable name: %s", env.Variable("new-checkout"))
+	}
+	if client.Enabled(ctx, "new-checkout") {
+		t.Error("The environment should override the memory provider")
+	}
+	if got := client.String(ctx, "variant", "red"); got != "blue" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := client.Int(ctx, "max-items", 10); got != 25 {
+		t.Errorf("Int() = %d", got)
+	}
+	if got := client.Float(ctx, "rollout", 0); got != 0.25 {
+		t.Errorf("Float() = %v", got)
+	}
+	if !client.Bool(ctx, "broken", true) || client.Int(ctx, "missing", 7) != 7 {
+		t.Error("Invalid and missing flags should fall back to the default")
+	}
+
+	failing := featureflag.New(featureflag.ProviderFunc(func(context.Context, string) (interface{}, bool, error) {
+		return nil, false, errors.New("provider unavailable")
+	}))
+	if !failing.Bool(ctx, "new-checkout", true) {
+		t.Error("A failing provider should yield the default")
+	}
+}
+
+func TestGuards(t *testing.T) {
+	memory := featureflag.NewMemory(nil)
+	client := featureflag.New(memory)
+
+	err := featureflag.Require(context.Background(), client, "beta")
+	var disabled *exception.FeatureDisabled
+	if !errors.As(err, &disabled) || disabled.GetStatusCode() != http.StatusForbidden || disabled.GetDetails()["flag"] != "beta" {
+		t.Fatalf("Expected a 403 *FeatureDisabled, got %T: %v", err, err)
+	}
+
+	handler := featureflag.Middleware(client, "beta")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/beta", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected a 403 response, got %d", rec.Code)
+	}
+
+	memory.Set("beta", true)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/beta", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected the handler to run once enabled, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,113 @@
+package golden_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/golden"
+)
+
+// recordingTB captures the failures of an assertion.
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestMarshal(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{
+		"message": "The order was not found.",
+		"details": map[string]interface{}{"error": "order_not_found", "request_id": "req-42"},
+	})
+
+	tests := []struct {
+		name  string
+		value interface{}
+		opts  []golden.Option
+		want  []string
+	}{
+		{
+			name:  "exception is serialized through Format",
+			value: err,
+			want:  []string{`"message": "The order was not found."`, `"request_id": "req-42"`},
+		},
+		{
+			name:  "scrubbed keys",
+			value: err,
+			opts:  []golden.Option{golden.Scrub("request_id")},
+			want:  []string{`"request_id": "<scrubbed>"`},
+		},
+		{
+			name:  "structs are sorted like maps",
+			value: struct{ B, A int }{B: 2, A: 1},
+			want:  []string{"{\n  \"A\": 1,\n  \"B\": 2\n}\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mErr := golden.Marshal(tt.value, tt.opts...)
+			if mErr != nil {
+				t.Fatalf("Marshal() error = %v", mErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("Marshal() = %s, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+
+	first, _ := golden.Marshal(err)
+	second, _ := golden.Marshal(err)
+	if string(first) != string(second) {
+		t.Error("Marshal() is not deterministic")
+	}
+}
+
+func TestAssert(t *testing.T) {
+	dir := t.TempDir()
+	err := exception.NewConflict(map[string]interface{}{"message": "The order already exists."})
+
+	t.Setenv(golden.UpdateEnv, "1")
+	golden.Assert(t, "orders/conflict", err, golden.Dir(dir))
+	if _, statErr := os.Stat(filepath.Join(dir, "orders", "conflict.golden")); statErr != nil {
+		t.Fatalf("Assert() did not write the golden file: %v", statErr)
+	}
+
+	t.Setenv(golden.UpdateEnv, "")
+	golden.Assert(t, "orders/conflict", err, golden.Dir(dir))
+
+	rec := &recordingTB{TB: t}
+	changed := exception.NewConflict(map[string]interface{}{"message": "The order is a duplicate."})
+	golden.Assert(rec, "orders/conflict", changed, golden.Dir(dir))
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], `+   "message": "The order is a duplicate."`) {
+		t.Errorf("failures = %q, want a diff of the message", rec.failures)
+	}
+
+	rec = &recordingTB{TB: t}
+	golden.Assert(rec, "missing", err, golden.Dir(dir))
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "-update") {
+		t.Errorf("failures = %q, want a hint to create the missing file", rec.failures)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	got := golden.Diff("a\nb\nc\n", "a\nx\nc\n")
+	want := "  a\n- b\n+ x\n  c\n"
+	if got != want {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+}
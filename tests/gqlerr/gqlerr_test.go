@@ -0,0 +1,80 @@
+package gqlerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/gqlerr"
+	"github.com/osirisgate/golang-core/mocks"
+)
+
+func TestPresent(t *testing.T) {
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+
+	tests := []struct {
+		name    string
+		err     error
+		message string
+		code    string
+		status  int
+	}{
+		{
+			"DetailsCode",
+			fmt.Errorf("resolve order: %w", exception.NewNotFound(map[string]interface{}{
+				"message": "The order was not found.",
+				"details": map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+			})),
+			"The order was not found.", "ORDER_NOT_FOUND", 404,
+		},
+		{"StatusCode", exception.NewValidation(map[string]interface{}{}), "Unprocessable Content", "UNPROCESSABLE_CONTENT", 422},
+		{"NotAnException", errors.New("pq: connection refused"), "Internal Server Error", "INTERNAL_SERVER_ERROR", 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gqlerr.Present(ctx, tt.err)
+			if got.Message != tt.message {
+				t.Errorf("Message = %q, want %q", got.Message, tt.message)
+			}
+			if got.Extensions["code"] != tt.code {
+				t.Errorf("code = %v, want %q", got.Extensions["code"], tt.code)
+			}
+			if got.Extensions["error_code"] != tt.status {
+				t.Errorf("error_code = %v, want %d", got.Extensions["error_code"], tt.status)
+			}
+			if _, ok := got.Extensions["message"]; ok {
+				t.Error("extensions repeat the message")
+			}
+			if got.Extensions[ctxutil.RequestIDField] != "req-1" {
+				t.Errorf("extensions = %v, want the request ID", got.Extensions)
+			}
+		})
+	}
+
+	if gqlerr.Present(ctx, nil) != nil {
+		t.Error("Present(nil) is not nil")
+	}
+}
+
+func TestRecoverFunc(t *testing.T) {
+	log := mocks.NewLogger()
+	err := gqlerr.RecoverFunc(log)(context.Background(), "boom")
+
+	var runtime *exception.Runtime
+	if !errors.As(err, &runtime) {
+		t.Fatalf("RecoverFunc() = %T, want *exception.Runtime", err)
+	}
+	if runtime.GetDetails()["panic"] != "boom" || runtime.GetDetailsMessage() != "resolver_panicked" {
+		t.Errorf("details = %v", runtime.GetDetails())
+	}
+	if len(log.EntriesAt(mocks.LevelError)) != 1 {
+		t.Errorf("entries = %v, want one error", log.Entries())
+	}
+
+	if got := gqlerr.Present(context.Background(), err); got.Message != "The resolver panicked." || got.Extensions["code"] != "resolver_panicked" {
+		t.Errorf("Present() = %+v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package httpadapter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/httpadapter"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/response"
+)
+
+type entry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+type memoryLogger struct {
+	mu      sync.Mutex
+	entries []entry
+}
+
+func (l *memoryLogger) log(level string, msg string, fields []logger.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		values[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry{level: level, msg: msg, fields: values})
+}
+
+func (l *memoryLogger) Debug(msg string, fields ...logger.Field) { l.log("debug", msg, fields) }
+func (l *memoryLogger) Info(msg string, fields ...logger.Field)  { l.log("info", msg, fields) }
+func (l *memoryLogger) Warn(msg string, fields ...logger.Field)  { l.log("warn", msg, fields) }
+func (l *memoryLogger) Error(msg string, fields ...logger.Field) { l.log("error", msg, fields) }
+func (l *memoryLogger) With(...logger.Field) logger.Logger       { return l }
+
+func serve(handler http.Handler) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+	return rec
+}
+
+func envelope(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Response body is not valid JSON: %v (%s)", err, rec.Body.String())
+	}
+	return body
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		log := &memoryLogger{}
+		rec := serve(httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}, log))
+		if rec.Code != http.StatusNoContent || len(log.entries) != 0 {
+			t.Errorf("response = %d, entries = %+v", rec.Code, log.entries)
+		}
+	})
+
+	t.Run("Exception", func(t *testing.T) {
+		log := &memoryLogger{}
+		rec := serve(httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			return exception.NewNotFound(map[string]interface{}{"message": "The order was not found."})
+		}, log))
+		if body := envelope(t, rec); rec.Code != http.StatusNotFound || body["message"] != "The order was not found." {
+			t.Errorf("response = %d %v", rec.Code, body)
+		}
+		if len(log.entries) != 1 || log.entries[0].level != "warn" {
+			t.Fatalf("entries = %+v, want one warning", log.entries)
+		}
+		if _, ok := log.entries[0].fields[exception.RequestLogKey]; ok {
+			t.Error("client error logged with the request snapshot")
+		}
+	})
+
+	t.Run("PlainError", func(t *testing.T) {
+		log := &memoryLogger{}
+		rec := serve(httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("dial tcp: connection refused")
+		}, log))
+		if body := envelope(t, rec); rec.Code != http.StatusInternalServerError || body["message"] == "dial tcp: connection refused" {
+			t.Errorf("response = %d %v, want a 500 not disclosing the error", rec.Code, body)
+		}
+		if len(log.entries) != 1 || log.entries[0].level != "error" || log.entries[0].fields["cause"] != "dial tcp: connection refused" {
+			t.Fatalf("entries = %+v, want the cause logged", log.entries)
+		}
+		if snapshot, _ := log.entries[0].fields[exception.RequestLogKey].(map[string]interface{}); snapshot["path"] != "/orders/42" {
+			t.Errorf("request = %v, want the snapshot of the request", log.entries[0].fields[exception.RequestLogKey])
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		log := &memoryLogger{}
+		rec := serve(httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		}, log))
+		details, _ := envelope(t, rec)["details"].(map[string]interface{})
+		if rec.Code != http.StatusInternalServerError || details["error"] != "handler_panicked" {
+			t.Errorf("response = %d %s", rec.Code, rec.Body.String())
+		}
+		if len(log.entries) != 1 || log.entries[0].level != "error" {
+			t.Errorf("entries = %+v, want the panic logged", log.entries)
+		}
+	})
+
+	t.Run("AbortHandler", func(t *testing.T) {
+		defer func() {
+			if recover() != http.ErrAbortHandler {
+				t.Error("http.ErrAbortHandler was not propagated")
+			}
+		}()
+		serve(httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			panic(http.ErrAbortHandler)
+		}, nil))
+	})
+
+	t.Run("ResponseStarted", func(t *testing.T) {
+		log := &memoryLogger{}
+		rec := serve(httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("partial"))
+			return exception.NewError(map[string]interface{}{})
+		}, log))
+		if rec.Code != http.StatusOK || rec.Body.String() != "partial" || len(log.entries) != 1 {
+			t.Errorf("response = %d %q, entries = %d, want the error logged only", rec.Code, rec.Body.String(), len(log.entries))
+		}
+	})
+
+	t.Run("WithErrorWriter", func(t *testing.T) {
+		handler := httpadapter.Wrap(func(w http.ResponseWriter, r *http.Request) error {
+			return exception.NewNotFound(map[string]interface{}{})
+		}, nil, httpadapter.WithErrorWriter(response.WriteNegotiated))
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+		req.Header.Set("Accept", "application/problem+json")
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound || rec.Header().Get("Content-Type") != response.ContentTypeProblem {
+			t.Errorf("response = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/httpclient"
+	"github.com/osirisgate/golang-core/response"
+	"github.com/osirisgate/golang-core/retry"
+)
+
+func TestErrorResponses(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/problem", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"type":"https://example.com/probs/missing","title":"Missing","status":404,"detail":"Order 42 does not exist.","order_id":42}`))
+	})
+	mux.HandleFunc("/envelope", func(w http.ResponseWriter, r *http.Request) {
+		_ = response.WriteError(w, exception.NewValidation(map[string]interface{}{
+			"message": "Validation failed.",
+			"errors":  map[string]interface{}{"email": "invalid"},
+		}))
+	})
+	mux.HandleFunc("/limited", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := httpclient.New(server.Client())
+	ctx := context.Background()
+
+	err := client.DoJSON(ctx, http.MethodGet, server.URL+"/problem", nil, nil)
+	var notFound *exception.NotFound
+	if !errors.As(err, &notFound) || notFound.Error() != "Order 42 does not exist." {
+		t.Fatalf("Expected a *NotFound with the problem detail, got %T: %v", err, err)
+	}
+	if details := notFound.GetDetails(); details["order_id"] != float64(42) || details["title"] != "Missing" || details["upstream_status"] != 404 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+
+	err = client.DoJSON(ctx, http.MethodPost, server.URL+"/envelope", map[string]string{"email": "x"}, nil)
+	var validation *exception.Validation
+	if !errors.As(err, &validation) || validation.Error() != "Validation failed." || validation.GetErrors()["errors"] == nil {
+		t.Errorf("Expected the upstream Validation to be rebuilt, got %T: %v %+v", err, err, validation)
+	}
+
+	err = client.DoJSON(ctx, http.MethodGet, server.URL+"/limited", nil, nil)
+	var tooMany *exception.TooManyRequests
+	if !errors.As(err, &tooMany) || tooMany.GetDetails()["retry_after"] != 7 || !exception.IsRetryable(err) {
+		t.Errorf("Expected a retryable *TooManyRequests with retry_after, got %T: %v", err, err)
+	}
+}
+
+func TestSuccessAndRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	client := httpclient.New(server.Client(), httpclient.WithRetry(retry.Backoff(time.Millisecond, 5*time.Millisecond)))
+	var dst struct{ ID int }
+	if err := client.DoJSON(context.Background(), http.MethodPut, server.URL, map[string]int{"id": 42}, &dst); err != nil {
+		t.Fatalf("DoJSON() returned %v", err)
+	}
+	if dst.ID != 42 || calls.Load() != 3 {
+		t.Errorf("Unexpected outcome: %+v after %d calls", dst, calls.Load())
+	}
+
+	calls.Store(0)
+	err := client.DoJSON(context.Background(), http.MethodPost, server.URL, nil, nil)
+	if calls.Load() != 1 || !errors.As(err, new(*exception.ServiceUnavailable)) {
+		t.Errorf("A POST should not be retried: %d calls, %v", calls.Load(), err)
+	}
+}
+
+func TestTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := httpclient.New(&http.Client{Timeout: 5 * time.Millisecond})
+	err := client.DoJSON(context.Background(), http.MethodGet, server.URL, nil, nil)
+	var timeout *exception.Timeout
+	if !errors.As(err, &timeout) || timeout.GetDetailsMessage() != "upstream_timeout" {
+		t.Errorf("Expected a *Timeout, got %T: %v", err, err)
+	}
+
+	server.Close()
+	err = client.DoJSON(context.Background(), http.MethodGet, server.URL, nil, nil)
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || coreErr.GetStatusCode() != 502 {
+		t.Errorf("Expected a 502 exception, got %T: %v", err, err)
+	}
+}
+
+func TestFromHTTPResponse(t *testing.T) {
+	respond := func(code int, contentType string, body string) *http.Response {
+		recorder := httptest.NewRecorder()
+		recorder.Header().Set("Content-Type", contentType)
+		recorder.WriteHeader(code)
+		_, _ = recorder.WriteString(body)
+		resp := recorder.Result()
+		resp.Request = httptest.NewRequest(http.MethodGet, "http://orders.internal/orders/42", nil)
+		return resp
+	}
+
+	t.Run("Envelope", func(t *testing.T) {
+		// The envelope written by a service built with the core.
+		recorder := httptest.NewRecorder()
+		_ = response.WriteError(recorder, exception.NewValidation(map[string]interface{}{
+			"message": "Invalid order.",
+			"errors":  map[string]interface{}{"quantity": "required"},
+			"details": map[string]interface{}{"error": "invalid_order"},
+		}))
+		resp := recorder.Result()
+		resp.Request = httptest.NewRequest(http.MethodGet, "http://orders.internal/", nil)
+
+		got := httpclient.FromHTTPResponse(resp)
+		if _, ok := got.(*exception.Validation); !ok {
+			t.Fatalf("FromHTTPResponse() = %T, want *exception.Validation", got)
+		}
+		if got.Error() != "Invalid order." || got.GetDetailsMessage() != "invalid_order" {
+			t.Errorf("FromHTTPResponse() = %q, %q", got.Error(), got.GetDetailsMessage())
+		}
+		if !reflect.DeepEqual(got.GetErrors()["errors"], map[string]interface{}{"quantity": "required"}) {
+			t.Errorf("errors = %v", got.GetErrors()["errors"])
+		}
+	})
+
+	t.Run("RemoteErrorCode", func(t *testing.T) {
+		// A gateway answering 400 for a remote 409.
+		got := httpclient.FromHTTPResponse(respond(400, "application/json", `{"status":"error","error_code":409,"message":"Taken."}`))
+		if _, ok := got.(*exception.Conflict); !ok || got.GetStatusCode() != 409 {
+			t.Fatalf("FromHTTPResponse() = %T %d, want *exception.Conflict 409", got, got.GetStatusCode())
+		}
+		if got.GetDetails()["upstream_status"] != 400 || got.GetDetails()["upstream"] != "orders.internal" {
+			t.Errorf("details = %v", got.GetDetails())
+		}
+	})
+
+	t.Run("CamelCaseEnvelope", func(t *testing.T) {
+		got := httpclient.FromHTTPResponse(respond(400, "application/json", `{"status":"error","errorCode":409,"message":"Taken.","requestId":"req-1"}`))
+		if _, ok := got.(*exception.Conflict); !ok || got.Error() != "Taken." {
+			t.Fatalf("FromHTTPResponse() = %T %q, want *exception.Conflict", got, got.Error())
+		}
+		if got.GetErrors()["request_id"] != "req-1" {
+			t.Errorf("errors = %v, want the snake_case request_id", got.GetErrors())
+		}
+	})
+
+	t.Run("Problem", func(t *testing.T) {
+		resp := respond(404, "application/problem+json", `{"type":"about:blank","title":"Not Found","detail":"No order 42.","status":404}`)
+		resp.Header.Set("Retry-After", "5")
+		got := httpclient.FromHTTPResponse(resp)
+		if _, ok := got.(*exception.NotFound); !ok || got.Error() != "No order 42." {
+			t.Fatalf("FromHTTPResponse() = %T %q", got, got.Error())
+		}
+		if got.GetDetails()["retry_after"] != 5 || got.GetDetails()["type"] != "about:blank" {
+			t.Errorf("details = %v", got.GetDetails())
+		}
+	})
+
+	t.Run("UnknownBody", func(t *testing.T) {
+		for _, resp := range []*http.Response{
+			respond(503, "text/html", "<h1>Service Unavailable</h1>"),
+			respond(500, "application/json", `{"oops":true}`),
+		} {
+			got := httpclient.FromHTTPResponse(resp)
+			if _, ok := got.(*exception.UpstreamFailure); !ok {
+				t.Fatalf("FromHTTPResponse() = %T, want *exception.UpstreamFailure", got)
+			}
+			if !strings.Contains(got.Error(), "orders.internal") || got.GetDetails()["upstream_status"] != resp.StatusCode {
+				t.Errorf("FromHTTPResponse() = %q %v", got.Error(), got.GetDetails())
+			}
+		}
+	})
+
+	if httpclient.FromHTTPResponse(respond(200, "application/json", "{}")) != nil || httpclient.FromHTTPResponse(nil) != nil {
+		t.Error("successful responses yield an exception")
+	}
+}
@@ -0,0 +1,120 @@
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/repository"
+	"github.com/osirisgate/golang-core/response"
+)
+
+func newBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+	bundle := i18n.NewBundle("en")
+	err := bundle.LoadFS(fstest.MapFS{
+		"locales/en.json":    {Data: []byte(`{"greeting": "Hello {name}!", "error": {"not_found": "{entity} {id} was not found."}, "status": {"500": "Something went wrong."}}`)},
+		"locales/fr.json":    {Data: []byte(`{"greeting": "Bonjour {name} !", "error": {"not_found": "{entity} {id} introuvable."}}`)},
+		"locales/fr-CA.json": {Data: []byte(`{"greeting": "Allô {name}!"}`)},
+	}, "locales")
+	if err != nil {
+		t.Fatalf("LoadFS() returned %v", err)
+	}
+	return bundle
+}
+
+func TestTranslate(t *testing.T) {
+	bundle := newBundle(t)
+	params := map[string]interface{}{"name": "Ada"}
+
+	tests := []struct {
+		locale string
+		key    string
+		want   string
+	}{
+		{"fr-CA", "greeting", "Allô Ada!"},
+		{"fr_ca", "greeting", "Allô Ada!"},
+		{"fr-FR", "greeting", "Bonjour Ada !"},
+		{"de", "greeting", "Hello Ada!"},
+	}
+	for _, tt := range tests {
+		if got, ok := bundle.Translate(tt.locale, tt.key, params); !ok || got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, %v; want %q", tt.locale, tt.key, got, ok, tt.want)
+		}
+	}
+	if _, ok := bundle.Translate("fr", "missing", nil); ok {
+		t.Error("A missing key should not be found")
+	}
+	if got := i18n.Fallbacks("zh-Hant-TW", "en-US"); !reflect.DeepEqual(got, []string{"zh-Hant-TW", "zh-Hant", "zh", "en-US", "en"}) {
+		t.Errorf("Unexpected fallback chain: %v", got)
+	}
+
+	err := bundle.LoadFS(fstest.MapFS{"de.json": {Data: []byte(`{"count": 3}`)}}, ".")
+	if !errors.As(err, new(*exception.Configuration)) {
+		t.Errorf("Expected a *Configuration for an invalid catalog, got %v", err)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	available := []string{"en", "fr", "pt-BR"}
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"de, pt;q=0.5", "pt-BR"},
+		{"fr;q=0, en", "en"},
+		{"*", "en"},
+		{"ja", "en"},
+	}
+	for _, tt := range tests {
+		if got := i18n.Negotiate(tt.header, available, "en"); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizedResponses(t *testing.T) {
+	bundle := newBundle(t)
+	handler := i18n.Middleware(bundle)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hello" {
+			_ = response.WriteSuccessContext(r.Context(), w, 200, i18n.T(r.Context(), "greeting", map[string]interface{}{"name": "Ada"}))
+			return
+		}
+		_ = response.WriteErrorContext(r.Context(), w, repository.NewNotFound("Order", 42))
+	}))
+
+	for path, want := range map[string]string{"/hello": "Bonjour Ada !", "/orders/42": "Order 42 introuvable."} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Language", "fr-BE, en;q=0.5")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var envelope map[string]interface{}
+		_ = json.Unmarshal(rec.Body.Bytes(), &envelope)
+		got := envelope["message"]
+		if path == "/hello" {
+			got = envelope["data"]
+		}
+		if got != want || rec.Header().Get("Content-Language") != "fr" {
+			t.Errorf("%s: got %v (%s), want %q", path, got, rec.Header().Get("Content-Language"), want)
+		}
+	}
+
+	ctx := i18n.WithLocale(context.Background(), bundle, "fr")
+	err := i18n.Localize(ctx, exception.NewError(map[string]interface{}{}))
+	if err.Error() != "Something went wrong." {
+		t.Errorf("Expected the status message of the default locale, got %q", err.Error())
+	}
+	if i18n.T(context.Background(), "greeting", nil) != "greeting" {
+		t.Error("T without a Localizer should return the key")
+	}
+}
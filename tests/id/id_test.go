@@ -0,0 +1,35 @@
+package id_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/id"
+)
+
+func TestSequence(t *testing.T) {
+	seq := id.NewSequence("user-")
+	if first, second := seq.NewID(), seq.NewID(); first != "user-1" || second != "user-2" {
+		t.Errorf("NewID() = %q, %q", first, second)
+	}
+}
+
+func TestUUIDv7(t *testing.T) {
+	c := clock.NewFake(time.UnixMilli(1767225600123))
+	generator := id.UUIDv7(c)
+
+	first := generator.NewID()
+	c.Advance(time.Millisecond)
+	second := generator.NewID()
+
+	if first.Version() != 7 || first.String()[:13] != "019b76da-a87b" {
+		t.Errorf("Unexpected UUIDv7: %s (version %d)", first, first.Version())
+	}
+	if second.String() <= first.String() {
+		t.Errorf("UUIDv7 values should be time-ordered: %s <= %s", second, first)
+	}
+	if v4 := id.UUID().NewID(); v4.Version() != 4 {
+		t.Errorf("Expected a version 4 UUID, got %d", v4.Version())
+	}
+}
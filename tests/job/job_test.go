@@ -0,0 +1,122 @@
+package job_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/job"
+	"github.com/osirisgate/golang-core/repository"
+)
+
+func TestLifecycle(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	j := job.New("42", c)
+	if j.Status != job.Pending || !j.StartedAt.IsZero() {
+		t.Fatalf("unexpected new job %+v", j)
+	}
+
+	c.Advance(time.Second)
+	if err := j.SetProgress(150, c); err != nil {
+		t.Fatalf("SetProgress() returned an error: %v", err)
+	}
+	if j.Status != job.Running || j.Progress != 100 || !j.StartedAt.Equal(c.Now()) {
+		t.Errorf("unexpected running job %+v", j)
+	}
+
+	c.Advance(time.Second)
+	if err := j.Succeed(map[string]int{"imported": 3}, c); err != nil {
+		t.Fatalf("Succeed() returned an error: %v", err)
+	}
+	if !j.Status.IsTerminal() || !j.FinishedAt.Equal(c.Now()) {
+		t.Errorf("unexpected succeeded job %+v", j)
+	}
+
+	var conflict *exception.Conflict
+	if err := j.Cancel(c); !errors.As(err, &conflict) || conflict.GetDetailsMessage() != "job_finished" {
+		t.Errorf("Cancel() on a finished job = %v, expected a job_finished conflict", err)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	tests := []struct {
+		name   string
+		finish func(j *job.Job)
+	}{
+		{"Pending", func(j *job.Job) {}},
+		{"Succeeded", func(j *job.Job) { _ = j.Succeed(map[string]int{"imported": 3}, c) }},
+		{"Failed", func(j *job.Job) {
+			_ = j.Fail(exception.NewValidation(map[string]interface{}{"message": "Bad file.", "details": map[string]interface{}{"error": "bad_file"}}), c)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := job.New("42", c)
+			tt.finish(j)
+
+			data, err := json.Marshal(j)
+			if err != nil {
+				t.Fatalf("Marshal() returned an error: %v", err)
+			}
+			var decoded job.Job
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal() returned an error: %v", err)
+			}
+
+			if decoded.ID != j.ID || decoded.Status != j.Status || decoded.Progress != j.Progress ||
+				!decoded.CreatedAt.Equal(j.CreatedAt) || !decoded.FinishedAt.Equal(j.FinishedAt) {
+				t.Errorf("decoded %+v, expected %+v", decoded, j)
+			}
+			if j.Result != nil {
+				if raw, ok := decoded.Result.(json.RawMessage); !ok || string(raw) != `{"imported":3}` {
+					t.Errorf("decoded result = %#v", decoded.Result)
+				}
+			}
+			if j.Err != nil {
+				var validation *exception.Validation
+				if !errors.As(decoded.Err, &validation) || validation.Message != "Bad file." || validation.GetDetailsMessage() != "bad_file" {
+					t.Errorf("decoded error = %#v", decoded.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	store := repository.NewMemory("job", func(j job.Job) string { return j.ID })
+	j := job.New("42", nil)
+	_ = store.Save(context.Background(), *j)
+
+	rec := httptest.NewRecorder()
+	if err := job.WriteAccepted(context.Background(), rec, j, "/jobs/42"); err != nil {
+		t.Fatalf("WriteAccepted() returned an error: %v", err)
+	}
+	if rec.Code != http.StatusAccepted || rec.Header().Get("Location") != "/jobs/42" {
+		t.Errorf("WriteAccepted() wrote %d with Location %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /jobs/{id}", job.Handler(store, nil))
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{"/jobs/42", http.StatusOK},
+		{"/jobs/43", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.expected {
+			t.Errorf("GET %s = %d, expected %d", tt.path, rec.Code, tt.expected)
+		}
+	}
+}
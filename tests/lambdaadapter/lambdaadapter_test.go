@@ -0,0 +1,122 @@
+package lambdaadapter_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/lambdaadapter"
+	"github.com/osirisgate/golang-core/mocks"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// throttled is an exception implying a response header.
+type throttled struct {
+	*exception.TooManyRequests
+}
+
+func (throttled) Headers() map[string]string {
+	return map[string]string{"Retry-After": "30"}
+}
+
+// request stands for `events.APIGatewayProxyRequest`.
+type request struct {
+	Path string
+}
+
+func TestFromException(t *testing.T) {
+	e := exception.NewNotFound(map[string]interface{}{
+		"message": "The order was not found.",
+		"details": map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+	})
+	resp, err := lambdaadapter.FromException(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 404 || resp.Headers["Content-Type"] != response.ContentTypeJSON {
+		t.Errorf("response = %d %v", resp.StatusCode, resp.Headers)
+	}
+	want, _ := json.Marshal(e.Format())
+	if resp.Body != string(want) {
+		t.Errorf("Body = %s, want %s", resp.Body, want)
+	}
+
+	resp, err = lambdaadapter.FromException(throttled{exception.NewTooManyRequests(map[string]interface{}{})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 429 || resp.Headers["Retry-After"] != "30" {
+		t.Errorf("response = %d %v, want 429 with Retry-After", resp.StatusCode, resp.Headers)
+	}
+
+	resp, _ = lambdaadapter.FromException(exception.NewServiceUnavailable(map[string]interface{}{
+		"details": map[string]interface{}{"retry_after": 5},
+	}))
+	if resp.StatusCode != 503 || resp.Headers["Retry-After"] != "5" {
+		t.Errorf("response = %d %v, want 503 with the implied Retry-After", resp.StatusCode, resp.Headers)
+	}
+
+	resp, _ = lambdaadapter.FromException(errors.New("dial tcp: refused"))
+	var body map[string]interface{}
+	_ = json.Unmarshal([]byte(resp.Body), &body)
+	if resp.StatusCode != 500 || body["message"] != "Internal Server Error" {
+		t.Errorf("response = %d %v, want a 500 not disclosing the error", resp.StatusCode, body)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+
+	t.Run("Success", func(t *testing.T) {
+		ok := lambdaadapter.Response{StatusCode: 200, Body: "{}"}
+		handler := lambdaadapter.Wrap(func(context.Context, request) (lambdaadapter.Response, error) {
+			return ok, nil
+		}, nil)
+		resp, err := handler(ctx, request{})
+		if err != nil || !reflect.DeepEqual(resp, ok) {
+			t.Errorf("handler() = %+v, %v", resp, err)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		log := mocks.NewLogger()
+		handler := lambdaadapter.Wrap(func(context.Context, request) (lambdaadapter.Response, error) {
+			return lambdaadapter.Response{}, exception.NewForbidden(map[string]interface{}{})
+		}, log)
+		resp, err := handler(ctx, request{})
+		if err != nil || resp.StatusCode != 403 {
+			t.Fatalf("handler() = %+v, %v", resp, err)
+		}
+		var body map[string]interface{}
+		_ = json.Unmarshal([]byte(resp.Body), &body)
+		if body[ctxutil.RequestIDField] != "req-1" {
+			t.Errorf("Body = %s, want the request ID", resp.Body)
+		}
+		if len(log.EntriesAt(mocks.LevelWarn)) != 1 {
+			t.Errorf("entries = %v, want one warning", log.Entries())
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		log := mocks.NewLogger()
+		handler := lambdaadapter.Wrap(func(_ context.Context, r request) (lambdaadapter.Response, error) {
+			panic("nil map: " + r.Path)
+		}, log)
+		resp, err := handler(ctx, request{Path: "/orders"})
+		if err != nil || resp.StatusCode != 500 {
+			t.Fatalf("handler() = %+v, %v", resp, err)
+		}
+		var body map[string]interface{}
+		_ = json.Unmarshal([]byte(resp.Body), &body)
+		if body["message"] != "The Lambda handler panicked." {
+			t.Errorf("Body = %s", resp.Body)
+		}
+		if len(log.EntriesAt(mocks.LevelError)) != 1 {
+			t.Errorf("entries = %v, want one error", log.Entries())
+		}
+	})
+}
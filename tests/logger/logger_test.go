@@ -0,0 +1,87 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/logger"
+)
+
+func newJSONLogger(buffer *bytes.Buffer) logger.Logger {
+	return logger.NewSlog(slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+func decode(t *testing.T, buffer *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &entry); err != nil {
+		t.Fatalf("Log output is not a single JSON entry: %v (%s)", err, buffer.String())
+	}
+	return entry
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	newJSONLogger(&buffer).With(logger.Any("service", "orders")).Info("started", logger.Any("port", 8080))
+
+	entry := decode(t, &buffer)
+	if entry["msg"] != "started" || entry["level"] != "INFO" || entry["service"] != "orders" || entry["port"] != float64(8080) {
+		t.Errorf("Unexpected log entry: %+v", entry)
+	}
+}
+
+func TestLogException(t *testing.T) {
+	t.Run("ClientError", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := exception.NewInvalidArgument(map[string]interface{}{"message": "Bad input.", "field": "email"})
+		logger.LogException(newJSONLogger(&buffer), err)
+
+		entry := decode(t, &buffer)
+		if entry["level"] != "WARN" || entry["msg"] != "Bad input." || entry["status_code"] != float64(400) {
+			t.Errorf("Unexpected log entry: %+v", entry)
+		}
+		if _, ok := entry["stack_trace"]; !ok {
+			t.Error("The stack trace should be logged")
+		}
+	})
+
+	t.Run("ServerErrorWithContext", func(t *testing.T) {
+		var buffer bytes.Buffer
+		ctx := ctxutil.WithRequestID(context.Background(), "req-7")
+		logger.LogExceptionContext(ctx, newJSONLogger(&buffer), exception.NewRuntime(nil))
+
+		entry := decode(t, &buffer)
+		if entry["level"] != "ERROR" || entry["request_id"] != "req-7" {
+			t.Errorf("Unexpected log entry: %+v", entry)
+		}
+	})
+
+	t.Run("PlainError", func(t *testing.T) {
+		var buffer bytes.Buffer
+		logger.LogException(newJSONLogger(&buffer), errors.New("disk full"))
+
+		if entry := decode(t, &buffer); entry["level"] != "ERROR" || entry["msg"] != "disk full" {
+			t.Errorf("Unexpected log entry: %+v", entry)
+		}
+	})
+
+	t.Run("NilException", func(t *testing.T) {
+		var buffer bytes.Buffer
+		var notFound *exception.NotFound
+		logger.LogException(newJSONLogger(&buffer), notFound)
+
+		if buffer.Len() != 0 {
+			t.Errorf("A nil exception should log nothing, got %q", buffer.String())
+		}
+	})
+
+	t.Run("Nop", func(t *testing.T) {
+		logger.LogException(logger.Nop(), exception.NewRuntime(nil))
+	})
+}
@@ -0,0 +1,115 @@
+package mapper_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/osirisgate/golang-core/domain"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/mapper"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+type user struct {
+	domain.Entity[string]
+	Email    valueobject.Email
+	Age      int64
+	Nickname *string
+	Address  address
+	Tags     []string
+}
+
+type address struct {
+	City string
+	Zip  int
+}
+
+type userDTO struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Age      uint8  `json:"age"`
+	Nickname string `json:"nickname"`
+	Address  *struct{ City string }
+	Tags     []string `json:"tags"`
+	Internal string   `json:"-"`
+}
+
+func TestMapInto(t *testing.T) {
+	nickname := "jd"
+	email, _ := valueobject.NewEmail("jane@example.com")
+	src := user{
+		Entity:   domain.Entity[string]{ID: "user-1"},
+		Email:    email,
+		Age:      42,
+		Nickname: &nickname,
+		Address:  address{City: "Lyon", Zip: 69001},
+		Tags:     []string{"admin"},
+	}
+
+	var dto userDTO
+	if err := mapper.MapInto(src, &dto); err != nil {
+		t.Fatalf("MapInto() returned %v", err)
+	}
+	if dto.ID != "user-1" || dto.Email != "jane@example.com" || dto.Age != 42 || dto.Nickname != "jd" ||
+		dto.Address == nil || dto.Address.City != "Lyon" || len(dto.Tags) != 1 {
+		t.Errorf("Unexpected DTO: %+v", dto)
+	}
+
+	var back user
+	if err := mapper.MapInto(&dto, &back); err != nil {
+		t.Fatalf("MapInto() returned %v", err)
+	}
+	if back.ID != "user-1" || !back.Email.Equal(email) || *back.Nickname != "jd" || back.Address.City != "Lyon" {
+		t.Errorf("Unexpected entity: %+v", back)
+	}
+}
+
+func TestMapIntoFailures(t *testing.T) {
+	src := struct {
+		Email string
+		Age   int
+		Tags  []int
+	}{Email: "not-an-email", Age: 300, Tags: []int{1}}
+
+	var dst struct {
+		Email valueobject.Email
+		Age   uint8
+		Tags  []string
+	}
+	err := mapper.MapInto(src, &dst)
+
+	var unexpected *exception.UnexpectedValue
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("Expected an *UnexpectedValue, got %T", err)
+	}
+	fields := unexpected.GetDetails()["fields"].(map[string]interface{})
+	for _, path := range []string{"Email", "Age", "Tags[0]"} {
+		if _, ok := fields[path]; !ok {
+			t.Errorf("Expected %q to be reported, got %+v", path, fields)
+		}
+	}
+
+	var logic *exception.Logic
+	if err := mapper.MapInto(src, dst); !errors.As(err, &logic) {
+		t.Errorf("A non-pointer destination should yield a *Logic, got %T", err)
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	type source struct{ Value float64 }
+	type target struct{ Value int }
+
+	got, err := mapper.MapSlice(mapper.Auto[source, target](), []source{{1}, {2}})
+	if err != nil || len(got) != 2 || got[1].Value != 2 {
+		t.Fatalf("MapSlice() = %+v, %v", got, err)
+	}
+
+	_, err = mapper.MapSlice(mapper.Auto[source, target](), []source{{1}, {2.5}})
+	var unexpected *exception.UnexpectedValue
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("Expected an *UnexpectedValue, got %T", err)
+	}
+	if _, ok := unexpected.GetDetails()["fields"].(map[string]interface{})["[1].Value"]; !ok {
+		t.Errorf("Expected [1].Value to be reported, got %+v", unexpected.GetDetails())
+	}
+}
@@ -0,0 +1,141 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/messaging"
+	"github.com/osirisgate/golang-core/report"
+	"github.com/osirisgate/golang-core/repository"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+type orderPaid struct {
+	OrderID int `json:"order_id"`
+}
+
+func TestEnvelope(t *testing.T) {
+	ctx := ctxutil.WithTenantID(ctxutil.WithCorrelationID(context.Background(), "corr-1"), "acme")
+	envelope, err := messaging.New(ctx, "order.paid", orderPaid{OrderID: 42})
+	if err != nil {
+		t.Fatalf("New() returned %v", err)
+	}
+	if envelope.ID == "" || envelope.Attempt != 1 || envelope.CorrelationID != "corr-1" || envelope.Header(ctxutil.TenantIDHeader) != "acme" {
+		t.Errorf("Unexpected envelope: %+v", envelope)
+	}
+
+	encoded, _ := json.Marshal(envelope)
+	var received messaging.Envelope
+	_ = json.Unmarshal(encoded, &received)
+	payload, err := messaging.Decode[orderPaid](received)
+	if err != nil || payload.OrderID != 42 {
+		t.Errorf("Decode() = %+v, %v", payload, err)
+	}
+	consumerCtx := received.Redelivered().Context(context.Background())
+	if ctxutil.CorrelationID(consumerCtx) != "corr-1" || ctxutil.TenantID(consumerCtx) != "acme" || received.Redelivered().Attempt != 2 {
+		t.Error("The consumer context should carry the identifiers of the envelope")
+	}
+
+	if _, err := messaging.Decode[[]string](received); !errors.As(err, new(*exception.UnexpectedValue)) {
+		t.Errorf("Expected an *UnexpectedValue, got %v", err)
+	}
+}
+
+func TestDeadLetter(t *testing.T) {
+	envelope, _ := messaging.New(context.Background(), "order.paid", orderPaid{OrderID: 42})
+	cause := repository.NewNotFound("Order", 42)
+	cause.SetError("api_key", valueobject.NewSecret("sk_live_123"))
+
+	failed := envelope.Redelivered().DeadLetter(cause)
+	if len(envelope.Headers) != 0 {
+		t.Error("DeadLetter should not modify the original envelope")
+	}
+	if failed.Header(messaging.ErrorStatusHeader) != "404" || failed.Header(messaging.ErrorCodeHeader) != "not_found" ||
+		failed.Header(messaging.ErrorAttemptHeader) != "2" || failed.Header(messaging.ErrorTypeHeader) != "*exception.NotFound" ||
+		failed.Header(messaging.ErrorFingerprintHeader) != report.DefaultFingerprint(cause) {
+		t.Errorf("Unexpected dead-letter headers: %v", failed.Headers)
+	}
+	if strings.Contains(failed.Header(messaging.ErrorErrorsHeader), "sk_live") {
+		t.Error("Secrets must stay redacted in the headers")
+	}
+
+	rebuilt := failed.Err()
+	var notFound *exception.NotFound
+	if !errors.As(rebuilt, &notFound) || notFound.Error() != cause.Error() || notFound.GetDetails()["entity"] != "Order" {
+		t.Errorf("Unexpected rebuilt exception: %T %v", rebuilt, rebuilt)
+	}
+
+	plain := messaging.ErrorHeaders(errors.New("connection reset"))
+	if plain[messaging.ErrorStatusHeader] != "500" || strings.Contains(plain[messaging.ErrorMessageHeader], "reset") {
+		t.Errorf("Plain errors should be serialized as generic 500 exceptions: %v", plain)
+	}
+	if envelope.Err() != nil {
+		t.Error("An envelope without error headers should have no error")
+	}
+}
+
+func TestBrokerHeaders(t *testing.T) {
+	cause := exception.NewTooManyRequests(map[string]interface{}{
+		"message": "Quota exceeded.",
+		"details": map[string]interface{}{"error": "quota_exceeded", "limit": 10},
+	})
+
+	headers := messaging.BrokerHeaders(cause)
+	if headers[messaging.ErrorStatusHeader] != "429" || headers[messaging.ErrorCodeHeader] != "quota_exceeded" ||
+		headers[messaging.ErrorMessageHeader] != "Quota exceeded." || headers[messaging.ErrorFingerprintHeader] != report.DefaultFingerprint(cause) {
+		t.Errorf("Unexpected broker headers: %v", headers)
+	}
+
+	assertRebuilt := func(t *testing.T, rebuilt exception.CoreInterface) {
+		t.Helper()
+		var tooMany *exception.TooManyRequests
+		if !errors.As(rebuilt, &tooMany) || tooMany.Error() != "Quota exceeded." || tooMany.GetDetailsMessage() != "quota_exceeded" ||
+			tooMany.GetDetails()["fingerprint"] != report.DefaultFingerprint(cause) {
+			t.Errorf("Unexpected rebuilt exception: %T %v", rebuilt, rebuilt)
+		}
+	}
+
+	assertRebuilt(t, messaging.ErrorFromHeaders(headers))
+
+	t.Run("Kafka", func(t *testing.T) {
+		kafkaHeaders := messaging.KafkaHeaders(cause)
+		if len(kafkaHeaders) != 4 || kafkaHeaders[0].Key != messaging.ErrorCodeHeader {
+			t.Errorf("Unexpected Kafka headers: %v", kafkaHeaders)
+		}
+		kafkaHeaders = append([]messaging.KafkaHeader{{Key: "trace-id", Value: []byte("abc")}}, kafkaHeaders...)
+		assertRebuilt(t, messaging.ErrorFromKafkaHeaders(kafkaHeaders))
+	})
+
+	t.Run("AMQP", func(t *testing.T) {
+		table := messaging.AMQPHeaders(cause)
+		if table[messaging.ErrorStatusHeader] != int32(429) {
+			t.Errorf("Unexpected AMQP status: %#v", table[messaging.ErrorStatusHeader])
+		}
+		assertRebuilt(t, messaging.ErrorFromAMQPHeaders(table))
+
+		table[messaging.ErrorMessageHeader] = []byte("Quota exceeded.")
+		table[messaging.ErrorStatusHeader] = int64(429)
+		assertRebuilt(t, messaging.ErrorFromAMQPHeaders(table))
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		plain := messaging.BrokerHeaders(errors.New("connection reset"))
+		if plain[messaging.ErrorStatusHeader] != "500" || strings.Contains(plain[messaging.ErrorMessageHeader], "reset") {
+			t.Errorf("Plain errors should be flattened as generic 500 exceptions: %v", plain)
+		}
+	})
+
+	t.Run("Absent", func(t *testing.T) {
+		if messaging.BrokerHeaders(nil) != nil || messaging.KafkaHeaders(nil) != nil || messaging.AMQPHeaders(nil) != nil {
+			t.Error("A nil error should yield no headers")
+		}
+		if messaging.ErrorFromKafkaHeaders(nil) != nil || messaging.ErrorFromAMQPHeaders(map[string]interface{}{}) != nil {
+			t.Error("Headers without a status should yield no exception")
+		}
+	})
+}
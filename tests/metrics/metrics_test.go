@@ -0,0 +1,136 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/circuitbreaker"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/metrics"
+	"github.com/osirisgate/golang-core/middleware"
+	"github.com/osirisgate/golang-core/retry"
+)
+
+func assertContains(t *testing.T, text string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("missing line %q in:\n%s", line, text)
+		}
+	}
+}
+
+func TestRegistryText(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.Counter("jobs_total", "Number of jobs.").Add(2, metrics.Labels{"queue": "mail"})
+	registry.Counter("jobs_total", "").Add(1, metrics.Labels{"queue": "mail"})
+	registry.Counter("jobs_total", "").Add(-5, metrics.Labels{"queue": "mail"})
+	registry.Gauge("queue_size", "").Set(7, nil)
+	histogram := registry.Histogram("latency_seconds", "Latency.", []float64{1, 0.1})
+	histogram.Observe(0.05, metrics.Labels{"op": `a"b`})
+	histogram.Observe(0.5, metrics.Labels{"op": `a"b`})
+	histogram.Observe(3, metrics.Labels{"op": `a"b`})
+
+	assertContains(t, registry.Text(),
+		"# HELP jobs_total Number of jobs.",
+		"# TYPE jobs_total counter",
+		`jobs_total{queue="mail"} 3`,
+		"# TYPE queue_size gauge",
+		"queue_size 7",
+		"# TYPE latency_seconds histogram",
+		`latency_seconds_bucket{op="a\"b",le="0.1"} 1`,
+		`latency_seconds_bucket{op="a\"b",le="1"} 2`,
+		`latency_seconds_bucket{op="a\"b",le="+Inf"} 3`,
+		`latency_seconds_sum{op="a\"b"} 3.55`,
+		`latency_seconds_count{op="a\"b"} 3`,
+	)
+
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); got != metrics.PrometheusContentType {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
+
+func TestRegistryKindConflict(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.Counter("things", "")
+
+	defer func() {
+		var logic *exception.Logic
+		if err, _ := recover().(error); !errors.As(err, &logic) {
+			t.Fatalf("recovered %v, want *exception.Logic", err)
+		}
+	}()
+	registry.Gauge("things", "")
+}
+
+func TestNop(t *testing.T) {
+	m := metrics.OrNop(nil)
+	m.Counter("a", "").Add(1, nil)
+	m.Histogram("b", "", nil).Observe(1, nil)
+	m.Gauge("c", "").Set(1, nil)
+}
+
+func TestExceptions(t *testing.T) {
+	registry := metrics.NewRegistry()
+	count := metrics.Exceptions(registry)
+	count(exception.NewNotFound(map[string]interface{}{"details": map[string]interface{}{"error": "user_not_found"}}))
+	count(errors.New("boom"))
+	count(nil)
+
+	assertContains(t, registry.Text(),
+		`exceptions_total{error_code="user_not_found",status_code="404"} 1`,
+		`exceptions_total{error_code="",status_code="500"} 1`,
+	)
+}
+
+func TestRetryMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	err := retry.Do(context.Background(), func(context.Context) error {
+		return exception.NewServiceUnavailable(map[string]interface{}{})
+	}, retry.Backoff(time.Millisecond, time.Millisecond), retry.MaxAttempts(2), retry.Metrics(registry, "fetch"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	assertContains(t, registry.Text(),
+		`retry_attempts_total{operation="fetch"} 2`,
+		`retry_retries_total{operation="fetch"} 1`,
+		`retry_exhausted_total{operation="fetch",reason="`+retry.ReasonAttemptsExhausted+`"} 1`,
+	)
+}
+
+func TestCircuitBreakerMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	breaker := circuitbreaker.New("payments", circuitbreaker.Config{
+		Meter: registry,
+	})
+	for i := 0; i < circuitbreaker.DefaultFailureThreshold+1; i++ {
+		_ = breaker.Execute(context.Background(), func(context.Context) error { return errors.New("down") })
+	}
+
+	assertContains(t, registry.Text(),
+		`circuit_breaker_state{breaker="payments"} 2`,
+		`circuit_breaker_transitions_total{breaker="payments",from="closed",to="open"} 1`,
+		`circuit_breaker_rejections_total{breaker="payments"} 1`,
+	)
+}
+
+func TestMiddlewareMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	handler := middleware.Metrics(registry)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users/42", nil))
+
+	assertContains(t, registry.Text(),
+		`http_requests_total{method="POST",status="201"} 1`,
+		`http_request_duration_seconds_count{method="POST",status="201"} 1`,
+	)
+}
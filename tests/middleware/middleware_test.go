@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/middleware"
+)
+
+type entry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+type memoryLogger struct {
+	mu      sync.Mutex
+	entries []entry
+}
+
+func (l *memoryLogger) log(level string, msg string, fields []logger.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		values[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry{level: level, msg: msg, fields: values})
+}
+
+func (l *memoryLogger) Debug(msg string, fields ...logger.Field) { l.log("debug", msg, fields) }
+func (l *memoryLogger) Info(msg string, fields ...logger.Field)  { l.log("info", msg, fields) }
+func (l *memoryLogger) Warn(msg string, fields ...logger.Field)  { l.log("warn", msg, fields) }
+func (l *memoryLogger) Error(msg string, fields ...logger.Field) { l.log("error", msg, fields) }
+func (l *memoryLogger) With(...logger.Field) logger.Logger       { return l }
+
+func tag(name string, order *[]string) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*order = append(*order, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	base := middleware.New(tag("a", &order), tag("b", &order))
+	route := base.Use(tag("c", &order))
+
+	route.ThenFunc(func(w http.ResponseWriter, r *http.Request) { order = append(order, "handler") }).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if strings.Join(order, ",") != "a,b,c,handler" {
+		t.Errorf("Unexpected order: %v", order)
+	}
+	if base.Len() != 2 || route.Len() != 3 || base.Extend(route).Len() != 5 {
+		t.Errorf("Use and Extend should not modify their receiver: %d %d", base.Len(), route.Len())
+	}
+}
+
+func TestRecoveryAndLogging(t *testing.T) {
+	log := &memoryLogger{}
+	handler := middleware.New(middleware.RequestID(), middleware.Logging(log), middleware.Recovery(log)).
+		ThenFunc(func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.Header.Set(ctxutil.RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var envelope map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &envelope)
+	details, _ := envelope["details"].(map[string]interface{})
+	if rec.Code != http.StatusInternalServerError || details["error"] != "handler_panicked" || envelope[ctxutil.RequestIDField] != "req-1" {
+		t.Fatalf("Unexpected response: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if len(log.entries) != 2 || log.entries[0].level != "error" || log.entries[1].msg != "HTTP request served." {
+		t.Fatalf("Expected the panic and the request to be logged, got %+v", log.entries)
+	}
+	served := log.entries[1].fields
+	if served["status"] != 500 || served["path"] != "/orders" || served[ctxutil.RequestIDField] != "req-1" || log.entries[1].level != "error" {
+		t.Errorf("Unexpected request entry: %+v", log.entries[1])
+	}
+	if snapshot, _ := log.entries[0].fields["request"].(map[string]interface{}); snapshot["method"] != http.MethodPost || snapshot["path"] != "/orders" {
+		t.Errorf("Expected the panic entry to carry the request, got %+v", log.entries[0].fields)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	handler := middleware.New(middleware.Timeout(20 * time.Millisecond)).
+		ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/slow" {
+				<-r.Context().Done()
+				time.Sleep(5 * time.Millisecond)
+				if _, err := w.Write([]byte("late")); err != http.ErrHandlerTimeout {
+					t.Errorf("Expected ErrHandlerTimeout, got %v", err)
+				}
+				return
+			}
+			w.Header().Set("X-Fast", "1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("done"))
+		})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if rec.Code != http.StatusCreated || rec.Body.String() != "done" || rec.Header().Get("X-Fast") != "1" {
+		t.Errorf("Unexpected fast response: %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if rec.Code != http.StatusGatewayTimeout || !strings.Contains(rec.Body.String(), "request_timeout") {
+		t.Errorf("Unexpected slow response: %d %s", rec.Code, rec.Body.String())
+	}
+	time.Sleep(20 * time.Millisecond)
+}
@@ -0,0 +1,105 @@
+package mocks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/logger"
+	"github.com/osirisgate/golang-core/mocks"
+	"github.com/osirisgate/golang-core/report"
+	"github.com/osirisgate/golang-core/response"
+)
+
+func TestException(t *testing.T) {
+	var err error = &mocks.Exception{
+		Message:    "The order was not found.",
+		StatusCode: 404,
+		Errors:     map[string]interface{}{"details": map[string]interface{}{"error": "order_not_found"}},
+	}
+
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) {
+		t.Fatal("errors.As() did not find the stub")
+	}
+	if coreErr.GetDetailsMessage() != "order_not_found" || coreErr.GetStackTrace() != "" {
+		t.Errorf("stub = %+v", coreErr)
+	}
+
+	rec := httptest.NewRecorder()
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+	if wErr := response.WriteErrorContext(ctx, rec, err); wErr != nil {
+		t.Fatalf("WriteErrorContext() error = %v", wErr)
+	}
+	var body map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != 404 || body["message"] != "The order was not found." || body["request_id"] != "req-1" {
+		t.Errorf("response = %d %v", rec.Code, body)
+	}
+
+	stub := &mocks.Exception{FormatFunc: func() map[string]interface{} { return map[string]interface{}{"custom": true} }}
+	if stub.Format()["custom"] != true {
+		t.Errorf("Format() = %v, want the result of FormatFunc", stub.Format())
+	}
+}
+
+func TestReporter(t *testing.T) {
+	reporter := &mocks.Reporter{}
+	defer report.Install(reporter, report.SeverityError)()
+
+	exception.NewNotFound(map[string]interface{}{})
+	exception.NewError(map[string]interface{}{})
+	if reported := reporter.Reported(); len(reported) != 1 || reported[0].GetStatusCode() != 500 {
+		t.Errorf("Reported() = %v, want the server error", reported)
+	}
+	reporter.Reset()
+	if len(reporter.Reported()) != 0 {
+		t.Error("Reset() kept the reported exceptions")
+	}
+}
+
+func TestLogger(t *testing.T) {
+	log := mocks.NewLogger()
+	child := log.With(logger.Any("component", "orders"))
+
+	logger.LogException(child, &mocks.Exception{Message: "The database is down.", StatusCode: 503})
+	log.Info("started")
+
+	entries := log.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Entries() = %v, want 2 entries", entries)
+	}
+	errorsAt := log.EntriesAt(mocks.LevelError)
+	if len(errorsAt) != 1 || errorsAt[0].Message != "The database is down." {
+		t.Errorf("EntriesAt(error) = %v", errorsAt)
+	}
+	if value, ok := errorsAt[0].Field("component"); !ok || value != "orders" {
+		t.Errorf("Field(component) = %v, %v, want the field added by With", value, ok)
+	}
+	if _, ok := entries[1].Field("component"); ok {
+		t.Error("the parent logger carries the fields of its child")
+	}
+}
+
+func TestClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := mocks.NewClock(start, start.Add(time.Second))
+
+	tests := []time.Time{start, start.Add(time.Second), start.Add(time.Second)}
+	for i, want := range tests {
+		if got := c.Now(); !got.Equal(want) {
+			t.Errorf("call %d: Now() = %v, want %v", i+1, got, want)
+		}
+	}
+	if c.Calls() != 3 {
+		t.Errorf("Calls() = %d, want 3", c.Calls())
+	}
+	if !mocks.NewClock().Now().IsZero() {
+		t.Error("Now() of an empty sequence is not the zero time")
+	}
+}
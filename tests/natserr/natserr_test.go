@@ -0,0 +1,89 @@
+package natserr_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/natserr"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want interface{}
+		code int
+	}{
+		{"NotFound", exception.NewNotFound(map[string]interface{}{"message": "No order."}), &exception.NotFound{}, 404},
+		// Domain and Validation share 422: the type header tells them apart.
+		{"Domain", exception.NewDomain(map[string]interface{}{}), &exception.Domain{}, 0},
+		{"Logic", fmt.Errorf("wrapped: %w", exception.NewLogic(map[string]interface{}{})), &exception.Logic{}, 0},
+		{"NotAnException", errors.New("secret"), &exception.Error{}, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, data, err := natserr.Encode(tt.err)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := natserr.Decode(header, data)
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Fatalf("Decode() = %T, want %T", got, tt.want)
+			}
+
+			var original exception.CoreInterface
+			if errors.As(exception.Normalize(tt.err), &original) {
+				if got.GetStatusCode() != original.GetStatusCode() || got.Error() != original.Error() {
+					t.Errorf("Decode() = %d %q, want %d %q", got.GetStatusCode(), got.Error(), original.GetStatusCode(), original.Error())
+				}
+			}
+			if tt.code != 0 && got.GetStatusCode() != tt.code {
+				t.Errorf("status code = %d, want %d", got.GetStatusCode(), tt.code)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	e := exception.NewNotFound(map[string]interface{}{
+		"details": map[string]interface{}{"error": "ORDER_NOT_FOUND", "order_id": "42"},
+	})
+	header, data, _ := natserr.Encode(e)
+	if header.Get(natserr.ErrorCodeHeader) != "404" || header.Get(natserr.ErrorTypeHeader) != "NotFound" {
+		t.Errorf("headers = %v", header)
+	}
+
+	got := natserr.Decode(header, data)
+	if !reflect.DeepEqual(got.GetDetails(), e.GetDetails()) {
+		t.Errorf("GetDetails() = %v, want %v", got.GetDetails(), e.GetDetails())
+	}
+}
+
+func TestDecodeMicroError(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  natserr.Header
+		status  int
+		code    string
+		message string
+	}{
+		{"StatusCode", natserr.Header{natserr.ErrorHeader: {"busy"}, natserr.ErrorCodeHeader: {"503"}}, 503, "", "busy"},
+		{"OtherCode", natserr.Header{natserr.ErrorHeader: {"bad shard"}, natserr.ErrorCodeHeader: {"E42"}}, 500, "E42", "bad shard"},
+		{"UnknownType", natserr.Header{natserr.ErrorCodeHeader: {"404"}, natserr.ErrorTypeHeader: {"Missing"}}, 404, "", "Not Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := natserr.Decode(tt.header, []byte("plain text"))
+			if got.GetStatusCode() != tt.status || got.GetDetailsMessage() != tt.code || got.Error() != tt.message {
+				t.Errorf("Decode() = %d %q %q, want %d %q %q", got.GetStatusCode(), got.GetDetailsMessage(), got.Error(), tt.status, tt.code, tt.message)
+			}
+		})
+	}
+
+	if natserr.Decode(natserr.Header{}, []byte(`{"ok":true}`)) != nil {
+		t.Error("Decode() of a successful reply is not nil")
+	}
+}
@@ -0,0 +1,143 @@
+package negotiation_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/negotiation"
+)
+
+func TestParse(t *testing.T) {
+	got := negotiation.Parse(`text/html;level=1, application/json;q=0.9, */*;q=0.1, bad;q=x, text/csv;q=0`)
+	expected := []negotiation.Preference{
+		{Value: "text/html", Quality: 1, Params: map[string]string{"level": "1"}},
+		{Value: "application/json", Quality: 0.9},
+		{Value: "*/*", Quality: 0.1},
+		{Value: "text/csv", Quality: 0},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Parse() = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	types := []string{"application/json", "text/csv"}
+	languages := []string{"en", "fr-FR", "pt"}
+	encodings := []string{"br", "gzip", "identity"}
+
+	tests := []struct {
+		name      string
+		negotiate func(header string, offers []string) (string, error)
+		header    string
+		offers    []string
+		expected  string
+	}{
+		{"TypeEmptyHeader", negotiation.ContentType, "", types, "application/json"},
+		{"TypeExact", negotiation.ContentType, "text/csv", types, "text/csv"},
+		{"TypeQuality", negotiation.ContentType, "application/json;q=0.5, text/csv", types, "text/csv"},
+		{"TypeSubtypeWildcard", negotiation.ContentType, "text/*", types, "text/csv"},
+		{"TypeSpecificWins", negotiation.ContentType, "*/*, application/json;q=0", types, "text/csv"},
+		{"TypeTie", negotiation.ContentType, "*/*", types, "application/json"},
+		{"TypeNone", negotiation.ContentType, "image/png", types, ""},
+		{"LanguageVariant", negotiation.Language, "fr", languages, "fr-FR"},
+		{"LanguageParent", negotiation.Language, "pt-BR, en;q=0.5", languages, "pt"},
+		{"LanguageCaseInsensitive", negotiation.Language, "FR-fr", languages, "fr-FR"},
+		{"LanguageWildcard", negotiation.Language, "de, *;q=0.1", languages, "en"},
+		{"LanguageNone", negotiation.Language, "de", languages, ""},
+		{"EncodingPreferred", negotiation.Encoding, "gzip, br;q=0.8", encodings, "gzip"},
+		{"EncodingIdentityImplied", negotiation.Encoding, "deflate", encodings, "identity"},
+		{"EncodingWildcard", negotiation.Encoding, "*", encodings, "br"},
+		{"EncodingIdentityExcluded", negotiation.Encoding, "deflate, identity;q=0", encodings, ""},
+		{"EncodingAllExcluded", negotiation.Encoding, "*;q=0", encodings, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.negotiate(tt.header, tt.offers)
+			if got != tt.expected {
+				t.Errorf("negotiated %q, expected %q", got, tt.expected)
+			}
+			if tt.expected != "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+
+			var notAcceptable *exception.NotAcceptable
+			if !errors.As(err, &notAcceptable) {
+				t.Fatalf("error = %v, expected *exception.NotAcceptable", err)
+			}
+			if !reflect.DeepEqual(notAcceptable.GetDetails()["supported"], tt.offers) || notAcceptable.GetStatusCode() != 406 {
+				t.Errorf("unexpected exception %d %+v", notAcceptable.GetStatusCode(), notAcceptable.GetDetails())
+			}
+		})
+	}
+}
+
+func TestParseMediaType(t *testing.T) {
+	got, err := negotiation.ParseMediaType(`Multipart/Form-Data; Boundary="xyz"; charset=UTF-8`)
+	expected := negotiation.MediaType{Type: "multipart", Subtype: "form-data", Params: map[string]string{"boundary": "xyz", "charset": "UTF-8"}}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Fatalf("ParseMediaType() = %+v, %v, expected %+v", got, err, expected)
+	}
+	if got.Essence() != negotiation.MultipartFormData || got.Boundary() != "xyz" || got.Charset() != "UTF-8" {
+		t.Errorf("unexpected accessors %q %q %q", got.Essence(), got.Boundary(), got.Charset())
+	}
+	if got.String() != "multipart/form-data; boundary=xyz; charset=UTF-8" {
+		t.Errorf("String() = %q", got.String())
+	}
+
+	for _, value := range []string{"", "json", "*/json", "text/html; charset"} {
+		var invalid *exception.InvalidArgument
+		if _, err := negotiation.ParseMediaType(value); !errors.As(err, &invalid) || invalid.GetDetails()["error"] != "invalid_media_type" {
+			t.Errorf("ParseMediaType(%q) error = %v, expected an invalid_media_type", value, err)
+		}
+	}
+}
+
+func TestMediaTypeMatches(t *testing.T) {
+	tests := []struct {
+		mediaRange string
+		mediaType  string
+		expected   bool
+	}{
+		{"application/json", "application/json; charset=utf-8", true},
+		{"text/*", "text/csv", true},
+		{"*/*", "image/png", true},
+		{"text/*", "application/json", false},
+		{"application/json", "application/problem+json", false},
+		{"text/csv", "text/*", false},
+	}
+
+	for _, tt := range tests {
+		mediaRange, _ := negotiation.ParseMediaType(tt.mediaRange)
+		mediaType, _ := negotiation.ParseMediaType(tt.mediaType)
+		if got := mediaRange.Matches(mediaType); got != tt.expected {
+			t.Errorf("%q.Matches(%q) = %v, expected %v", tt.mediaRange, tt.mediaType, got, tt.expected)
+		}
+	}
+}
+
+func TestRequireContentType(t *testing.T) {
+	supported := []string{negotiation.ApplicationJSON, "text/*"}
+
+	got, err := negotiation.RequireContentType("text/plain; charset=utf-8", supported)
+	if err != nil || got.Essence() != negotiation.TextPlain || got.Charset() != "utf-8" {
+		t.Errorf("RequireContentType() = %+v, %v", got, err)
+	}
+
+	for _, header := range []string{"", "application/xml", "*/*", "not a type"} {
+		var unsupported *exception.UnsupportedMediaType
+		_, err := negotiation.RequireContentType(header, supported)
+		if !errors.As(err, &unsupported) {
+			t.Fatalf("RequireContentType(%q) error = %v, expected *exception.UnsupportedMediaType", header, err)
+		}
+		details := unsupported.GetDetails()
+		if unsupported.GetStatusCode() != 415 || details["header"] != "Content-Type" || !reflect.DeepEqual(details["supported"], supported) {
+			t.Errorf("unexpected exception %d %+v", unsupported.GetStatusCode(), details)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/clock"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/messaging"
+	"github.com/osirisgate/golang-core/outbox"
+)
+
+func TestNewMessage(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	message, err := outbox.NewMessage(context.Background(), "order-1", "order.paid", map[string]int{"amount": 42}, clock.NewFake(now))
+	if err != nil {
+		t.Fatalf("NewMessage() error = %v", err)
+	}
+	if message.ID() == "" || message.AggregateID != "order-1" || message.Envelope.Type != "order.paid" {
+		t.Errorf("message = %+v", message)
+	}
+	if message.Status != outbox.Pending || message.Attempts != 0 || !message.NextAttemptAt.Equal(now) {
+		t.Errorf("delivery state = %v, %d, %v", message.Status, message.Attempts, message.NextAttemptAt)
+	}
+	if string(message.Envelope.Payload) != `{"amount":42}` {
+		t.Errorf("payload = %s", message.Envelope.Payload)
+	}
+
+	if _, err := outbox.NewMessage(context.Background(), "order-1", "order.paid", func() {}, nil); err == nil {
+		t.Error("NewMessage() accepted a payload that cannot be encoded")
+	}
+}
+
+func TestRelayOnce(t *testing.T) {
+	unavailable := exception.NewServiceUnavailable(map[string]interface{}{})
+	rejected := exception.NewInvalidArgument(map[string]interface{}{})
+
+	tests := []struct {
+		name         string
+		errs         []error // The successive publication errors.
+		maxAttempts  int
+		runs         int // The number of relays; defaults to one more than the errors.
+		wantStatus   string
+		wantAttempts int
+	}{
+		{name: "published", wantStatus: "success"},
+		{name: "retryable failure", errs: []error{unavailable}, runs: 1, wantStatus: "pending", wantAttempts: 1},
+		{name: "published after retries", errs: []error{unavailable, unavailable}, wantStatus: "success", wantAttempts: 2},
+		{name: "permanent failure", errs: []error{rejected}, wantStatus: "error", wantAttempts: 1},
+		{name: "exhausted attempts", errs: []error{unavailable, unavailable}, maxAttempts: 2, wantStatus: "error", wantAttempts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+			store := outbox.NewMemory()
+			message, _ := outbox.NewMessage(ctx, "order-1", "order.paid", nil, fake)
+			if err := store.Add(ctx, message); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+
+			calls := 0
+			var reported []error
+			relay := outbox.NewRelay(outbox.RelayConfig{
+				Store: store,
+				Publisher: outbox.PublisherFunc(func(context.Context, messaging.Envelope) error {
+					calls++
+					if calls <= len(tt.errs) {
+						return tt.errs[calls-1]
+					}
+					return nil
+				}),
+				MaxAttempts: tt.maxAttempts,
+				OnError:     func(_ context.Context, err error) { reported = append(reported, err) },
+				Clock:       fake,
+			})
+
+			if tt.runs == 0 {
+				tt.runs = len(tt.errs) + 1
+			}
+			for i := 0; i < tt.runs; i++ {
+				if _, err := relay.RelayOnce(ctx); err != nil {
+					t.Fatalf("RelayOnce() error = %v", err)
+				}
+				fake.Advance(time.Hour)
+			}
+
+			stored, _ := store.Find(ctx, message.ID())
+			if string(stored.Status) != tt.wantStatus || stored.Attempts != tt.wantAttempts {
+				t.Errorf("status = %v, attempts = %d, want %v, %d", stored.Status, stored.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if want := min(len(tt.errs), calls); len(reported) != want {
+				t.Errorf("reported %d errors, want %d", len(reported), want)
+			}
+			if tt.wantStatus == "error" && stored.LastError == nil {
+				t.Error("LastError is not recorded")
+			}
+		})
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	store := outbox.NewMemory()
+	message, _ := outbox.NewMessage(ctx, "order-1", "order.paid", nil, fake)
+	_ = store.Add(ctx, message)
+
+	calls := 0
+	relay := outbox.NewRelay(outbox.RelayConfig{
+		Store: store,
+		Publisher: outbox.PublisherFunc(func(context.Context, messaging.Envelope) error {
+			calls++
+			return exception.NewTimeout(map[string]interface{}{})
+		}),
+		Clock: fake,
+	})
+
+	_, _ = relay.RelayOnce(ctx)
+	_, _ = relay.RelayOnce(ctx) // Not due before the backoff delay.
+	fake.Advance(outbox.DefaultBackoff(1))
+	_, _ = relay.RelayOnce(ctx)
+	if calls != 2 {
+		t.Errorf("Publish() called %d times, want 2", calls)
+	}
+}
+
+func TestDefaultBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 20: 5 * time.Minute} {
+		if got := outbox.DefaultBackoff(attempts); got != want {
+			t.Errorf("DefaultBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestRelayStoreError(t *testing.T) {
+	relay := outbox.NewRelay(outbox.RelayConfig{Store: failingStore{}, Publisher: outbox.PublisherFunc(nil)})
+	var coreErr exception.CoreInterface
+	if _, err := relay.RelayOnce(context.Background()); !errors.As(err, &coreErr) {
+		t.Errorf("RelayOnce() error = %v, want a normalized store error", err)
+	}
+}
+
+type failingStore struct{ outbox.Store }
+
+func (failingStore) Due(context.Context, time.Time, int) ([]outbox.Message, error) {
+	return nil, errors.New("connection refused")
+}
@@ -0,0 +1,141 @@
+package pagination_test
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/pagination"
+	"github.com/osirisgate/golang-core/response"
+)
+
+func TestParseOffset(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		got, err := pagination.ParseOffset(url.Values{}, pagination.DefaultConfig())
+		if err != nil {
+			t.Fatalf("ParseOffset() returned an error: %v", err)
+		}
+		if got.Page != 1 || got.PerPage != pagination.DefaultPerPage {
+			t.Errorf("ParseOffset() = %+v, expected page 1 and default page size", got)
+		}
+	})
+
+	t.Run("Explicit", func(t *testing.T) {
+		got, err := pagination.ParseOffset(url.Values{"page": {"3"}, "per_page": {"10"}}, pagination.Config{})
+		if err != nil {
+			t.Fatalf("ParseOffset() returned an error: %v", err)
+		}
+		if got.Offset() != 20 || got.Limit() != 10 {
+			t.Errorf("Offset() = %d, Limit() = %d, expected 20 and 10", got.Offset(), got.Limit())
+		}
+	})
+
+	t.Run("NotAnInteger", func(t *testing.T) {
+		_, err := pagination.ParseOffset(url.Values{"page": {"abc"}}, pagination.Config{})
+		var invalid *exception.InvalidArgument
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected *InvalidArgument, got %T", err)
+		}
+		if invalid.GetDetails()["parameter"] != "page" {
+			t.Errorf("Unexpected details: %+v", invalid.GetDetails())
+		}
+	})
+
+	t.Run("PerPageTooLarge", func(t *testing.T) {
+		_, err := pagination.ParseOffset(url.Values{"per_page": {"500"}}, pagination.Config{MaxPerPage: 50})
+		var outOfRange *exception.OutOfRange
+		if !errors.As(err, &outOfRange) {
+			t.Fatalf("Expected *OutOfRange, got %T", err)
+		}
+		if outOfRange.GetDetails()["max"] != 50 {
+			t.Errorf("Unexpected details: %+v", outOfRange.GetDetails())
+		}
+	})
+
+	t.Run("PageOverflow", func(t *testing.T) {
+		_, err := pagination.ParseOffset(url.Values{"page": {"9223372036854775807"}, "per_page": {"10"}}, pagination.Config{})
+		var rangeErr *exception.Range
+		if !errors.As(err, &rangeErr) {
+			t.Fatalf("Expected *Range, got %T", err)
+		}
+		if rangeErr.GetDetails()["error"] != "page_out_of_range" {
+			t.Errorf("Unexpected details: %+v", rangeErr.GetDetails())
+		}
+		if got := (pagination.Offset{Page: math.MaxInt, PerPage: 10}).Offset(); got != math.MaxInt {
+			t.Errorf("Offset() = %d, expected it saturated at math.MaxInt", got)
+		}
+	})
+}
+
+func TestOffsetWithTotal(t *testing.T) {
+	page := pagination.Offset{Page: 2, PerPage: 10}
+
+	got, err := page.WithTotal(15)
+	if err != nil {
+		t.Fatalf("WithTotal() returned an error: %v", err)
+	}
+	if got.TotalPages() != 2 {
+		t.Errorf("TotalPages() = %d, expected 2", got.TotalPages())
+	}
+
+	_, err = page.WithTotal(5)
+	var rangeErr *exception.Range
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("Expected *Range for a page beyond the last one, got %T", err)
+	}
+
+	if _, err := (pagination.Offset{Page: 1, PerPage: 10}).WithTotal(0); err != nil {
+		t.Errorf("WithTotal(0) on the first page returned an error: %v", err)
+	}
+}
+
+func TestMetaInEnvelope(t *testing.T) {
+	page := pagination.Offset{Page: 1, PerPage: 10, Total: 25}
+	envelope := response.Success([]int{1, 2}, page.Meta())
+
+	expected := map[string]interface{}{
+		"pagination": map[string]interface{}{
+			"page":        1,
+			"per_page":    10,
+			"total":       25,
+			"total_pages": 3,
+		},
+	}
+	if !reflect.DeepEqual(envelope["meta"], expected) {
+		t.Errorf("Envelope meta = %+v, expected %+v", envelope["meta"], expected)
+	}
+}
+
+func TestMetaKeyNaming(t *testing.T) {
+	defer exception.SetKeyNaming(exception.CamelCase)()
+
+	offset := pagination.Offset{Page: 1, PerPage: 10, Total: 25}.Meta()["pagination"]
+	expected := map[string]interface{}{"page": 1, "perPage": 10, "total": 25, "totalPages": 3}
+	if !reflect.DeepEqual(offset, expected) {
+		t.Errorf("Offset meta = %+v, expected %+v", offset, expected)
+	}
+
+	cursor := pagination.Cursor{PerPage: 10}.WithNext("abc").Meta()["pagination"]
+	expected = map[string]interface{}{"perPage": 10, "hasMore": true, "nextCursor": "abc"}
+	if !reflect.DeepEqual(cursor, expected) {
+		t.Errorf("Cursor meta = %+v, expected %+v", cursor, expected)
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	got, err := pagination.ParseCursor(url.Values{"cursor": {"abc"}, "per_page": {"5"}}, pagination.Config{})
+	if err != nil {
+		t.Fatalf("ParseCursor() returned an error: %v", err)
+	}
+	if got.Cursor != "abc" || got.PerPage != 5 || got.HasMore() {
+		t.Errorf("ParseCursor() = %+v", got)
+	}
+
+	meta := got.WithNext("def").Meta()["pagination"].(map[string]interface{})
+	if meta["next_cursor"] != "def" || meta["has_more"] != true {
+		t.Errorf("Meta() = %+v", meta)
+	}
+}
@@ -0,0 +1,91 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/query"
+)
+
+func TestParse(t *testing.T) {
+	parser := query.NewParser([]string{"created_at", "name"}, []string{"status", "type"})
+
+	t.Run("Valid", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=-created_at,name&filter[status]=active,pending&filter[type]=a&page=2")
+		got, err := parser.Parse(values)
+		if err != nil {
+			t.Fatalf("Parse() returned an error: %v", err)
+		}
+
+		expected := query.Criteria{
+			Sort: []query.Sort{
+				{Field: "created_at", Direction: query.Descending},
+				{Field: "name", Direction: query.Ascending},
+			},
+			Filters: []query.Filter{
+				{Field: "status", Values: []string{"active", "pending"}},
+				{Field: "type", Values: []string{"a"}},
+			},
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Parse() returned:\n got %+v,\n expected %+v", got, expected)
+		}
+
+		if filter, ok := got.Filter("status"); !ok || filter.Values[0] != "active" {
+			t.Errorf("Filter(status) = %+v, %v", filter, ok)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		got, err := parser.Parse(url.Values{})
+		if err != nil {
+			t.Fatalf("Parse() returned an error: %v", err)
+		}
+		if len(got.Sort) != 0 || len(got.Filters) != 0 {
+			t.Errorf("Parse() = %+v, expected empty criteria", got)
+		}
+	})
+
+	t.Run("NotAllowed", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=password&filter[secret]=x&filter[status]=ok")
+		_, err := parser.Parse(values)
+
+		var invalid *exception.InvalidArgument
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected *InvalidArgument, got %T", err)
+		}
+
+		details := invalid.GetDetails()
+		if len(details) != 2 {
+			t.Fatalf("Expected one detail per offending parameter, got %+v", details)
+		}
+		if sorts := details["sort"].([]interface{}); len(sorts) != 1 || sorts[0].(map[string]interface{})["error"] != "sort_not_allowed" {
+			t.Errorf("Unexpected sort detail: %+v", details["sort"])
+		}
+		if details["filter[secret]"].(map[string]interface{})["field"] != "secret" {
+			t.Errorf("Unexpected filter detail: %+v", details["filter[secret]"])
+		}
+	})
+	t.Run("EveryRejectedSort", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=password,name,-token,name")
+		_, err := parser.Parse(values)
+
+		var invalid *exception.InvalidArgument
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected *InvalidArgument, got %T", err)
+		}
+
+		var got []string
+		for _, entry := range invalid.GetDetails()["sort"].([]interface{}) {
+			detail := entry.(map[string]interface{})
+			got = append(got, detail["field"].(string)+":"+detail["error"].(string))
+		}
+		want := []string{"password:sort_not_allowed", "token:sort_not_allowed", "name:sort_duplicated"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected every rejected sort field %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/ratelimit"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestTokenBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewTokenBucket(1, 2, clock.Now)
+
+	for i := 0; i < 2; i++ {
+		if d := limiter.Allow("a"); !d.Allowed || d.Remaining != 1-i {
+			t.Fatalf("Request %d: %+v", i, d)
+		}
+	}
+	denied := limiter.Allow("a")
+	if denied.Allowed || denied.RetryAfter != time.Second {
+		t.Fatalf("Expected a denial with a 1s retry, got %+v", denied)
+	}
+	if !limiter.Allow("b").Allowed {
+		t.Error("Keys should have independent buckets")
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if !limiter.Allow("a").Allowed {
+		t.Error("A token should have been refilled after one second")
+	}
+}
+
+func TestTokenBucketZeroRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewTokenBucket(0, 1, clock.Now)
+
+	if !limiter.Allow("a").Allowed {
+		t.Fatal("The burst should be allowed")
+	}
+	clock.now = clock.now.Add(time.Hour)
+	denied := limiter.Allow("a")
+	if denied.Allowed || denied.RetryAfter != ratelimit.MaxRetryAfter || !denied.ResetAt.Equal(clock.now.Add(ratelimit.MaxRetryAfter)) {
+		t.Errorf("A bucket that never refills should wait MaxRetryAfter, got %+v", denied)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewSlidingWindow(4, time.Minute, clock.Now)
+
+	for i := 0; i < 4; i++ {
+		if !limiter.Allow("a").Allowed {
+			t.Fatalf("Request %d should be allowed", i)
+		}
+	}
+	denied := limiter.Allow("a")
+	if denied.Allowed || denied.Remaining != 0 || denied.RetryAfter != time.Minute {
+		t.Fatalf("Expected a denial until the next window, got %+v", denied)
+	}
+
+	// Halfway through the next window, half of the previous count still applies.
+	clock.now = clock.now.Add(90 * time.Second)
+	for i := 0; i < 2; i++ {
+		if !limiter.Allow("a").Allowed {
+			t.Fatalf("Request %d in the next window should be allowed", i)
+		}
+	}
+	if d := limiter.Allow("a"); d.Allowed || d.RetryAfter != 15*time.Second {
+		t.Errorf("Expected a denial with a 15s retry, got %+v", d)
+	}
+}
+
+func TestDecisionErr(t *testing.T) {
+	allowed := ratelimit.Decision{Allowed: true}
+	if allowed.Err("a") != nil {
+		t.Error("An allowed decision should not return an error")
+	}
+
+	reset := time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC)
+	err := ratelimit.Decision{Limit: 10, ResetAt: reset, RetryAfter: 1500 * time.Millisecond}.Err("a")
+	var tooMany *exception.TooManyRequests
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("Expected a *TooManyRequests, got %T", err)
+	}
+	details := tooMany.GetDetails()
+	if details["limit"] != 10 || details["remaining"] != 0 || details["reset_at"] != "2026-01-01T12:00:30Z" ||
+		details["retry_after"] != 2 || details["key"] != "a" || details["error"] != "rate_limited" {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1767268800, 0)}
+	handler := ratelimit.Middleware(
+		ratelimit.NewTokenBucket(1, 1, clock.Now),
+		ratelimit.KeyByHeader("X-API-Key"),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := serve("secret")
+	if first.Code != http.StatusNoContent || first.Header().Get(ratelimit.LimitHeader) != "1" ||
+		first.Header().Get(ratelimit.RemainingHeader) != "0" || first.Header().Get(ratelimit.ResetHeader) != "1767268801" {
+		t.Fatalf("Unexpected first response: %d %v", first.Code, first.Header())
+	}
+
+	second := serve("secret")
+	if second.Code != http.StatusTooManyRequests || second.Header().Get(ratelimit.RetryAfterHeader) != "1" {
+		t.Fatalf("Unexpected denied response: %d %v", second.Code, second.Header())
+	}
+
+	if unkeyed := serve(""); unkeyed.Code != http.StatusNoContent || unkeyed.Header().Get(ratelimit.LimitHeader) != "" {
+		t.Errorf("Requests without a key should not be rate limited")
+	}
+}
+
+func TestKeyByIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:52100"
+	if key := ratelimit.KeyByIP(req); key != "203.0.113.7" {
+		t.Errorf("KeyByIP() = %q", key)
+	}
+}
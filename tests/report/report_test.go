@@ -0,0 +1,271 @@
+package report_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/ratelimit"
+	"github.com/osirisgate/golang-core/report"
+)
+
+// recorder collects the batches sent by a Dispatcher.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]report.Event
+}
+
+func (r *recorder) send(_ context.Context, events []report.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, events)
+	return nil
+}
+
+func (r *recorder) sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sizes []int
+	for _, batch := range r.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func TestSeverityOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want report.Severity
+	}{
+		{exception.NewNotFound(map[string]interface{}{}), report.SeverityWarning},
+		{exception.NewServiceUnavailable(map[string]interface{}{}), report.SeverityError},
+		{exception.NewError(map[string]interface{}{}), report.SeverityCritical},
+		{errors.New("plain"), report.SeverityCritical},
+	}
+	for _, tt := range tests {
+		if got := report.SeverityOf(tt.err); got != tt.want {
+			t.Errorf("SeverityOf(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDispatcherBatches(t *testing.T) {
+	rec := &recorder{}
+	d := report.NewDispatcher(report.Config{Send: rec.send, BatchSize: 2, FlushInterval: time.Hour})
+
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+	for i := 0; i < 5; i++ {
+		d.Report(ctx, exception.NewError(map[string]interface{}{}))
+	}
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if sizes := rec.sizes(); len(sizes) != 3 || sizes[0] != 2 || sizes[2] != 1 {
+		t.Errorf("batch sizes = %v, want [2 2 1]", sizes)
+	}
+	if event := rec.batches[0][0]; event.Severity != report.SeverityCritical || event.Fields["request_id"] != "req-1" {
+		t.Errorf("event = %+v", event)
+	}
+
+	d.Report(ctx, exception.NewError(map[string]interface{}{}))
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if sizes := rec.sizes(); len(sizes) != 4 {
+		t.Errorf("batch sizes after Close = %v, want the queued event flushed", sizes)
+	}
+
+	d.Report(ctx, exception.NewError(map[string]interface{}{}))
+	if d.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1 after Close", d.Dropped())
+	}
+}
+
+func TestDispatcherAppInfo(t *testing.T) {
+	defer exception.SetAppInfo("orders", "1.4.2", "production", "pod-7")()
+
+	rec := &recorder{}
+	d := report.NewDispatcher(report.Config{Send: rec.send, FlushInterval: time.Hour})
+	d.Report(ctxutil.WithRequestID(context.Background(), "req-1"), exception.NewError(map[string]interface{}{}))
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	fields := rec.batches[0][0].Fields
+	if fields["request_id"] != "req-1" || fields["service"] != "orders" || fields["environment"] != "production" || fields["hostname"] != "pod-7" {
+		t.Errorf("Fields = %v, want the request ID and the deployment context", fields)
+	}
+}
+
+func TestDispatcherBoundedQueue(t *testing.T) {
+	release := make(chan struct{})
+	d := report.NewDispatcher(report.Config{
+		Send: func(context.Context, []report.Event) error {
+			<-release
+			return nil
+		},
+		QueueSize: 1,
+		BatchSize: 1,
+	})
+
+	for i := 0; i < 10; i++ {
+		d.Report(context.Background(), exception.NewError(map[string]interface{}{}))
+	}
+	if d.Dropped() == 0 {
+		t.Error("Report() did not drop events beyond the queue size")
+	}
+	close(release)
+	if err := d.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	var mu sync.Mutex
+	var reported []exception.CoreInterface
+	uninstall := report.Install(report.ReporterFunc(func(_ context.Context, err exception.CoreInterface) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, err)
+	}), report.SeverityError)
+
+	exception.NewNotFound(map[string]interface{}{})
+	exception.NewServiceUnavailable(map[string]interface{}{"message": "The database is down."})
+	uninstall()
+	exception.NewError(map[string]interface{}{})
+
+	if len(reported) != 1 || reported[0].Error() != "The database is down." {
+		t.Errorf("reported = %v, want the server error created while installed", reported)
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, body)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	notifier := report.NewNotifier(report.NotifierConfig{
+		Endpoints: []report.Endpoint{
+			{URL: server.URL + "/generic"},
+			{URL: server.URL + "/slack", Format: report.FormatSlack},
+		},
+		DedupWindow: time.Minute,
+		Now:         func() time.Time { return now },
+	})
+
+	event := func(message string) report.Event {
+		err := exception.NewError(map[string]interface{}{"message": message})
+		return report.Event{Err: err, Severity: report.SeverityOf(err), At: now}
+	}
+	warning := exception.NewNotFound(map[string]interface{}{})
+
+	ctx := context.Background()
+	if err := notifier.Send(ctx, []report.Event{
+		event("The ledger is inconsistent."),
+		event("The ledger is inconsistent."), // Duplicate.
+		{Err: warning, Severity: report.SeverityOf(warning)},
+	}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("%d notifications, want 2 (one per endpoint)", len(bodies))
+	}
+	generic := bodies[0]
+	if generic["severity"] != "critical" || generic["fingerprint"] == "" || generic["error"].(map[string]interface{})["message"] != "The ledger is inconsistent." {
+		t.Errorf("generic payload = %v", generic)
+	}
+	if text, _ := bodies[1]["text"].(string); !strings.HasPrefix(text, "[critical] The ledger is inconsistent. (status 500") {
+		t.Errorf("slack payload = %v", bodies[1])
+	}
+
+	now = now.Add(time.Minute)
+	_ = notifier.Send(ctx, []report.Event{event("The ledger is inconsistent.")})
+	if len(bodies) != 4 || bodies[2]["repeated"] != float64(1) {
+		t.Errorf("notification after the window = %v, want 1 repeated event", bodies[len(bodies)-1])
+	}
+}
+
+func TestNotifierRateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := report.NewNotifier(report.NotifierConfig{
+		Endpoints: []report.Endpoint{{URL: server.URL}},
+		Limiter:   ratelimit.NewTokenBucket(0.001, 1, nil),
+	})
+	events := []report.Event{
+		{Err: exception.NewError(map[string]interface{}{"message": "first"}), Severity: report.SeverityCritical},
+		{Err: exception.NewError(map[string]interface{}{"message": "second"}), Severity: report.SeverityCritical},
+	}
+	err := notifier.Send(context.Background(), events)
+	if calls != 1 {
+		t.Errorf("%d notifications, want 1 within the rate limit", calls)
+	}
+	var coreErr exception.CoreInterface
+	if err == nil || errors.As(err, &coreErr) {
+		t.Errorf("Send() error = %v, want a plain error for the failed post", err)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var mu sync.Mutex
+	var forwarded []exception.CoreInterface
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	dedup := report.NewDedup(report.ReporterFunc(func(_ context.Context, err exception.CoreInterface) {
+		mu.Lock()
+		defer mu.Unlock()
+		forwarded = append(forwarded, err)
+	}), report.DedupConfig{Window: time.Minute, Now: func() time.Time { return now }})
+
+	uninstall := report.Install(dedup, report.SeverityError)
+	var returned []error
+	for i := 0; i < 3; i++ {
+		returned = append(returned, exception.NewError(map[string]interface{}{"message": "The ledger is inconsistent."}))
+	}
+	exception.NewError(map[string]interface{}{"message": "The cache is corrupted."})
+	now = now.Add(time.Minute)
+	exception.NewError(map[string]interface{}{"message": "The ledger is inconsistent."})
+	uninstall()
+
+	if len(returned) != 3 || returned[2] == nil {
+		t.Error("The duplicates should still be returned to the callers")
+	}
+	if len(forwarded) != 3 {
+		t.Errorf("%d exceptions forwarded, want 3 (one per fingerprint and window)", len(forwarded))
+	}
+	if dedup.Suppressed() != 2 {
+		t.Errorf("Suppressed() = %d, want 2", dedup.Suppressed())
+	}
+
+	fingerprint := report.DefaultFingerprint(forwarded[0])
+	now = now.Add(time.Minute)
+	if !dedup.Allow(forwarded[0]) {
+		t.Fatal("Allow() = false, want true for the first occurrence of the window")
+	}
+	if dedup.Allow(forwarded[0]) {
+		t.Error("Allow() = true, want false for a duplicate")
+	}
+	if got := dedup.SuppressedOf(fingerprint); got != 1 {
+		t.Errorf("SuppressedOf() = %d, want 1 in the current window", got)
+	}
+}
@@ -0,0 +1,57 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/repository"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func TestMemory(t *testing.T) {
+	var repo repository.Repository[user, int] = repository.NewMemory("user", func(u user) int { return u.ID })
+	memory := repo.(*repository.Memory[user, int])
+	ctx := context.Background()
+
+	_, err := repo.Find(ctx, 1)
+	var notFound *exception.NotFound
+	if !errors.As(err, &notFound) || !repository.IsNotFound(err) {
+		t.Fatalf("Expected a *NotFound, got %T", err)
+	}
+	if details := notFound.GetDetails(); details["entity"] != "user" || details["id"] != 1 || notFound.GetStatusCode() != 404 {
+		t.Errorf("Unexpected NotFound: %d %+v", notFound.GetStatusCode(), details)
+	}
+
+	if err := memory.Create(ctx, user{ID: 1, Name: "Jane"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := memory.Create(ctx, user{ID: 1}); !repository.IsConflict(err) {
+		t.Errorf("Creating a duplicate should return a Conflict, got %v", err)
+	}
+	if err := repo.Save(ctx, user{ID: 1, Name: "John"}); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := repo.Find(ctx, 1); err != nil || found.Name != "John" {
+		t.Errorf("Find() = %+v, %v", found, err)
+	}
+
+	if err := repo.Delete(ctx, 1); err != nil || memory.Len() != 0 {
+		t.Errorf("Delete() = %v, %d entities left", err, memory.Len())
+	}
+	if err := repo.Delete(ctx, 1); !repository.IsNotFound(err) {
+		t.Errorf("Deleting a missing entity should return a NotFound, got %v", err)
+	}
+}
+
+func TestNewConflict(t *testing.T) {
+	err := repository.NewConflict("order", "A-1", "stale_version")
+	if err.GetStatusCode() != 409 || err.GetDetails()["reason"] != "stale_version" || err.GetDetailsMessage() != "conflict" {
+		t.Errorf("Unexpected Conflict: %d %+v", err.GetStatusCode(), err.GetDetails())
+	}
+}
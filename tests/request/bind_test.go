@@ -0,0 +1,101 @@
+package request_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/negotiation"
+	"github.com/osirisgate/golang-core/request"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+type signup struct {
+	Name  string            `json:"name"`
+	Age   int               `json:"age"`
+	Email valueobject.Email `json:"email"`
+}
+
+func TestBindJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		maxBytes int64
+		strict   bool
+		expected map[string]interface{}
+	}{
+		{"Empty", "  ", 0, false, map[string]interface{}{"error": "empty_body"}},
+		{"TooLarge", `{"name":"Jane"}`, 5, false, map[string]interface{}{"error": "body_too_large", "limit": int64(5)}},
+		{"Malformed", "{\n  \"name\": Jane\n}", 0, false, map[string]interface{}{"error": "malformed_json", "line": 2, "column": 12}},
+		{"Truncated", `{"name":`, 0, false, map[string]interface{}{"error": "malformed_json", "offset": int64(8)}},
+		{"InvalidType", `{"age":"forty"}`, 0, false, map[string]interface{}{"error": "invalid_type", "field": "age", "expected": "int", "actual": "string"}},
+		{"UnknownField", `{"nickname":"jd"}`, 0, true, map[string]interface{}{"error": "unknown_field", "field": "nickname"}},
+		{"TrailingData", `{"name":"Jane"} {}`, 0, false, map[string]interface{}{"error": "trailing_data"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			var opts []request.Option
+			if tt.strict {
+				opts = append(opts, request.Strict())
+			}
+
+			err := request.BindJSON(r, &signup{}, tt.maxBytes, opts...)
+			var parseErr *exception.RequestParseBody
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected a *RequestParseBody, got %T: %v", err, err)
+			}
+			details := parseErr.GetDetails()
+			for key, value := range tt.expected {
+				if details[key] != value {
+					t.Errorf("details[%q] = %v (%T), expected %v; details: %+v", key, details[key], details[key], value, details)
+				}
+			}
+		})
+	}
+}
+
+func TestBindJSONSuccess(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Jane","age":42,"email":"jane@example.com","extra":true}`))
+	var dst signup
+	if err := request.BindJSON(r, &dst, 0); err != nil {
+		t.Fatalf("BindJSON() returned %v", err)
+	}
+	if dst.Name != "Jane" || dst.Age != 42 || dst.Email.String() != "jane@example.com" {
+		t.Errorf("Unexpected destination: %+v", dst)
+	}
+}
+
+func TestBindJSONValueObjectError(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"not-an-email"}`))
+	err := request.BindJSON(r, &signup{}, 0)
+	var invalid *exception.InvalidArgument
+	if !errors.As(err, &invalid) {
+		t.Errorf("Expected the value object exception, got %T: %v", err, err)
+	}
+
+	var logic *exception.Logic
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+	if err := request.BindJSON(r, signup{}, 0); !errors.As(err, &logic) {
+		t.Errorf("Expected a *Logic for a non-pointer destination, got %T", err)
+	}
+}
+
+func TestBindJSONContentType(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Jane"}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if err := request.BindJSON(r, &signup{}, 0, request.RequireContentType(negotiation.ApplicationJSON)); err != nil {
+		t.Fatalf("BindJSON() returned %v", err)
+	}
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Jane"}`))
+	r.Header.Set("Content-Type", negotiation.TextPlain)
+	err := request.BindJSON(r, &signup{}, 0, request.RequireContentType(negotiation.ApplicationJSON))
+	var unsupported *exception.UnsupportedMediaType
+	if !errors.As(err, &unsupported) || unsupported.GetDetails()["error"] != "unsupported_media_type" {
+		t.Errorf("Expected a *UnsupportedMediaType, got %T: %v", err, err)
+	}
+}
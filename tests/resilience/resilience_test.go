@@ -0,0 +1,161 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/resilience"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+func succeed(value string, delay time.Duration) resilience.Func[string] {
+	return func(ctx context.Context) (string, error) {
+		select {
+		case <-time.After(delay):
+			return value, nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+func fail(err error) resilience.Func[string] {
+	return func(context.Context) (string, error) {
+		return "", err
+	}
+}
+
+func TestFallback(t *testing.T) {
+	permanent := exception.NewNotFound(map[string]interface{}{})
+
+	tests := []struct {
+		name      string
+		primary   resilience.Func[string]
+		secondary resilience.Func[string]
+		want      string
+		wantPath  string
+		wantErr   error
+		wantPaths []string
+	}{
+		{
+			name:      "primary serves",
+			primary:   succeed("live", 0),
+			secondary: succeed("cached", 0),
+			want:      "live",
+			wantPath:  resilience.PathPrimary,
+		},
+		{
+			name:      "secondary serves",
+			primary:   fail(errUnavailable),
+			secondary: succeed("cached", 0),
+			want:      "cached",
+			wantPath:  resilience.PathSecondary,
+		},
+		{
+			name:      "classifier refuses",
+			primary:   fail(permanent),
+			secondary: succeed("cached", 0),
+			wantErr:   permanent,
+		},
+		{
+			name:      "every path fails",
+			primary:   fail(errUnavailable),
+			secondary: fail(errUnavailable),
+			wantPaths: []string{resilience.PathPrimary, resilience.PathSecondary},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outcomes []resilience.Outcome
+			fn := resilience.Fallback(tt.primary, tt.secondary,
+				resilience.FallbackIf(func(err error) bool { return errors.Is(err, errUnavailable) }),
+				resilience.OnServed(func(o resilience.Outcome) { outcomes = append(outcomes, o) }),
+			)
+			value, err := fn(context.Background())
+			checkResult(t, value, err, outcomes, tt.want, tt.wantPath, tt.wantErr, tt.wantPaths)
+		})
+	}
+}
+
+func TestHedge(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   resilience.Func[string]
+		backup    resilience.Func[string]
+		want      string
+		wantPath  string
+		wantPaths []string
+	}{
+		{
+			name:     "primary is fast",
+			primary:  succeed("primary", 0),
+			backup:   succeed("backup", 0),
+			want:     "primary",
+			wantPath: resilience.PathPrimary,
+		},
+		{
+			name:     "primary is slow",
+			primary:  succeed("primary", time.Second),
+			backup:   succeed("backup", 0),
+			want:     "backup",
+			wantPath: resilience.PathHedge,
+		},
+		{
+			name:     "primary fails fast",
+			primary:  fail(errUnavailable),
+			backup:   succeed("backup", time.Millisecond),
+			want:     "backup",
+			wantPath: resilience.PathHedge,
+		},
+		{
+			name:      "every attempt fails",
+			primary:   fail(errUnavailable),
+			backup:    fail(errUnavailable),
+			wantPaths: []string{resilience.PathPrimary, resilience.PathHedge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outcomes []resilience.Outcome
+			fn := resilience.Hedge(tt.primary, tt.backup, 20*time.Millisecond,
+				resilience.OnServed(func(o resilience.Outcome) { outcomes = append(outcomes, o) }),
+			)
+			value, err := fn(context.Background())
+			checkResult(t, value, err, outcomes, tt.want, tt.wantPath, nil, tt.wantPaths)
+		})
+	}
+}
+
+func checkResult(t *testing.T, value string, err error, outcomes []resilience.Outcome, want, wantPath string, wantErr error, wantPaths []string) {
+	t.Helper()
+	switch {
+	case wantErr != nil:
+		if !errors.Is(err, wantErr) || len(outcomes) != 0 {
+			t.Errorf("error = %v, outcomes = %v, want %v", err, outcomes, wantErr)
+		}
+
+	case wantPaths != nil:
+		var aggregate *exception.Aggregate
+		if !errors.As(err, &aggregate) || aggregate.Len() != 2 {
+			t.Fatalf("error = %v, want an aggregate of both errors", err)
+		}
+		if details := aggregate.GetDetails(); !reflect.DeepEqual(details["paths"], wantPaths) || details["error"] != "all_paths_failed" {
+			t.Errorf("details = %v", details)
+		}
+
+	default:
+		if err != nil || value != want {
+			t.Fatalf("result = %q, %v, want %q", value, err, want)
+		}
+		if len(outcomes) != 1 || outcomes[0].Path != wantPath {
+			t.Errorf("outcomes = %v, want path %q", outcomes, wantPath)
+		}
+	}
+}
@@ -0,0 +1,530 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+	"github.com/osirisgate/golang-core/tracing"
+)
+
+func TestSuccess(t *testing.T) {
+	t.Run("WithoutMeta", func(t *testing.T) {
+		got := response.Success(map[string]interface{}{"id": 1})
+		expected := map[string]interface{}{
+			"status": status.SUCCESS,
+			"data":   map[string]interface{}{"id": 1},
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Success() returned unexpected map:\n got %+v,\n expected %+v", got, expected)
+		}
+	})
+
+	t.Run("WithMergedMeta", func(t *testing.T) {
+		got := response.Success("payload",
+			map[string]interface{}{"page": 1, "total": 10},
+			map[string]interface{}{"total": 20},
+		)
+		expected := map[string]interface{}{"page": 1, "total": 20}
+		if !reflect.DeepEqual(got["meta"], expected) {
+			t.Errorf("Success() meta = %+v, expected %+v", got["meta"], expected)
+		}
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("CoreException", func(t *testing.T) {
+		err := exception.NewDomain(map[string]interface{}{"message": "Invalid order."})
+		got := response.Error(fmt.Errorf("placing order: %w", err))
+		if !reflect.DeepEqual(got, err.Format()) {
+			t.Errorf("Error() returned %+v, expected %+v", got, err.Format())
+		}
+	})
+
+	t.Run("PlainError", func(t *testing.T) {
+		got := response.Error(errors.New("connection refused"))
+		if got["error_code"] != 500 {
+			t.Errorf("Error() error_code = %v, expected 500", got["error_code"])
+		}
+		if got["message"] != status.InternalServerError.GetDescription() {
+			t.Errorf("Error() leaked the original message: %v", got["message"])
+		}
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		if got := response.Error(nil); got != nil {
+			t.Errorf("Error(nil) = %+v, expected nil", got)
+		}
+	})
+}
+
+func TestWriteError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := exception.NewInvalidArgument(map[string]interface{}{"message": "Bad email."})
+
+	if writeErr := response.WriteError(recorder, err); writeErr != nil {
+		t.Fatalf("WriteError() returned an error: %v", writeErr)
+	}
+
+	if recorder.Code != 400 {
+		t.Errorf("WriteError() wrote status %d, expected 400", recorder.Code)
+	}
+	if ct := recorder.Header().Get("Content-Type"); ct != response.ContentTypeJSON {
+		t.Errorf("WriteError() wrote Content-Type %q", ct)
+	}
+
+	var body map[string]interface{}
+	if decodeErr := json.Unmarshal(recorder.Body.Bytes(), &body); decodeErr != nil {
+		t.Fatalf("Response body is not valid JSON: %v", decodeErr)
+	}
+	if body["message"] != "Bad email." || body["status"] != status.ERROR {
+		t.Errorf("Unexpected body: %+v", body)
+	}
+}
+
+func TestWriteErrorContextSeals(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{"message": "Order not found."})
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+
+	if writeErr := response.WriteErrorContext(ctx, httptest.NewRecorder(), err); writeErr != nil {
+		t.Fatalf("WriteErrorContext() returned an error: %v", writeErr)
+	}
+	if !err.IsSealed() {
+		t.Fatal("WriteErrorContext() did not seal the exception")
+	}
+	if setErr := err.SetMessage("Changed."); setErr == nil {
+		t.Error("SetMessage() succeeded on a written exception")
+	}
+	if err.Error() != "Order not found." {
+		t.Errorf("written exception message = %q", err.Error())
+	}
+}
+
+func TestWriteErrorHeaders(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := exception.NewTooManyRequests(map[string]interface{}{"details": map[string]interface{}{"retry_after": 30}})
+	if writeErr := response.WriteError(recorder, err); writeErr != nil {
+		t.Fatalf("WriteError() returned an error: %v", writeErr)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want %q", got, "30")
+	}
+
+	recorder = httptest.NewRecorder()
+	recorder.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	_ = response.WriteErrorContext(context.Background(), recorder, exception.NewUnauthorized(map[string]interface{}{}))
+	if got := recorder.Header().Get("WWW-Authenticate"); got != `Bearer error="invalid_token"` {
+		t.Errorf("WWW-Authenticate = %q, want the header already set", got)
+	}
+
+	recorder = httptest.NewRecorder()
+	_ = response.WriteHTMLError(context.Background(), recorder, exception.NewUnauthorized(map[string]interface{}{}))
+	if got := recorder.Header().Get("WWW-Authenticate"); got != exception.DefaultChallenge {
+		t.Errorf("HTML WWW-Authenticate = %q, want %q", got, exception.DefaultChallenge)
+	}
+}
+
+func TestWriteErrorNilException(t *testing.T) {
+	var notFound *exception.NotFound
+	recorder := httptest.NewRecorder()
+	if err := response.WriteError(recorder, notFound); err != nil {
+		t.Fatalf("WriteError() returned an error: %v", err)
+	}
+	if recorder.Body.Len() != 0 || response.Error(notFound) != nil {
+		t.Errorf("A nil exception should write nothing, got %q", recorder.Body.String())
+	}
+	if envelope := response.Error(errors.Join(notFound)); envelope["error_code"] != 500 {
+		t.Errorf("Error() of a chain holding a nil exception = %v, want an internal error", envelope)
+	}
+}
+
+func TestWriteSuccess(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	if err := response.WriteSuccess(recorder, status.Created, map[string]interface{}{"id": "42"}); err != nil {
+		t.Fatalf("WriteSuccess() returned an error: %v", err)
+	}
+
+	if recorder.Code != 201 {
+		t.Errorf("WriteSuccess() wrote status %d, expected 201", recorder.Code)
+	}
+	expected := `{"data":{"id":"42"},"status":"success"}`
+	if recorder.Body.String() != expected {
+		t.Errorf("WriteSuccess() wrote %s, expected %s", recorder.Body.String(), expected)
+	}
+}
+
+func TestBatchResult(t *testing.T) {
+	invalid := exception.NewValidation(map[string]interface{}{"message": "Invalid row."})
+	conflict := exception.NewConflict(map[string]interface{}{"message": "Duplicate row."})
+
+	tests := []struct {
+		name     string
+		record   func(b *response.BatchResult)
+		expected status.StatusCode
+		envelope string
+	}{
+		{"Empty", func(b *response.BatchResult) {}, status.Created, status.SUCCESS},
+		{"AllSucceeded", func(b *response.BatchResult) { b.Succeed(1, "a"); b.Succeed(2, "b") }, status.Created, status.SUCCESS},
+		{"AllFailedAlike", func(b *response.BatchResult) { b.Fail(1, invalid); b.Fail(2, invalid) }, status.UnprocessableContent, status.ERROR},
+		{"AllFailedDifferently", func(b *response.BatchResult) { b.Fail(1, invalid); b.Fail(2, conflict) }, status.MultiStatusCode, status.ERROR},
+		{"Mixed", func(b *response.BatchResult) { b.Record(1, "a", nil); b.Record(2, nil, invalid) }, status.MultiStatusCode, status.SUCCESS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := response.NewBatchResult(status.Created)
+			tt.record(result)
+			if got := result.StatusCode(); got != tt.expected {
+				t.Errorf("StatusCode() = %d, expected %d", got, tt.expected)
+			}
+			if got := response.Batch(result)["status"]; got != tt.envelope {
+				t.Errorf("Batch() status = %v, expected %v", got, tt.envelope)
+			}
+		})
+	}
+}
+
+func TestSuccessKeyNaming(t *testing.T) {
+	defer exception.SetKeyNaming(exception.CamelCase)()
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+
+	meta := response.SuccessContext(ctx, nil)["meta"].(map[string]interface{})
+	if meta["requestId"] != "req-1" || meta["request_id"] != nil {
+		t.Errorf("The identifiers should follow the key naming: %+v", meta)
+	}
+
+	result := response.NewBatchResult(status.Created)
+	result.Succeed(1, "a")
+	envelope := response.BatchContext(ctx, result)
+	item := envelope["data"].([]map[string]interface{})[0]
+	if item["statusCode"] != 201 || envelope["meta"].(map[string]interface{})["requestId"] != "req-1" {
+		t.Errorf("The batch keys should follow the key naming: %+v", envelope)
+	}
+}
+
+func TestWriteBatch(t *testing.T) {
+	result := response.NewBatchResult(0)
+	result.Succeed("row-1", map[string]interface{}{"id": 7})
+	result.Fail("row-2", errors.New("database is down"))
+
+	rec := httptest.NewRecorder()
+	if err := response.WriteBatch(rec, result, map[string]interface{}{"import": "users"}); err != nil {
+		t.Fatalf("WriteBatch() returned an error: %v", err)
+	}
+	if rec.Code != 207 {
+		t.Errorf("WriteBatch() status = %d, expected 207", rec.Code)
+	}
+
+	var body struct {
+		Data []map[string]interface{} `json:"data"`
+		Meta map[string]interface{}   `json:"meta"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	expectedMeta := map[string]interface{}{"total": 2.0, "succeeded": 1.0, "failed": 1.0, "import": "users"}
+	if !reflect.DeepEqual(body.Meta, expectedMeta) {
+		t.Errorf("meta = %+v, expected %+v", body.Meta, expectedMeta)
+	}
+	if len(body.Data) != 2 ||
+		body.Data[0]["id"] != "row-1" || body.Data[0]["status_code"] != 200.0 || body.Data[0]["status"] != status.SUCCESS ||
+		body.Data[1]["id"] != "row-2" || body.Data[1]["status_code"] != 500.0 || body.Data[1]["status"] != status.ERROR {
+		t.Errorf("unexpected items %+v", body.Data)
+	}
+}
+
+func TestHTMLRenderer(t *testing.T) {
+	ctx := ctxutil.WithRequestID(context.Background(), "req-<1>")
+
+	t.Run("DefaultTemplate", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		err := exception.NewForbidden(map[string]interface{}{"message": "Admins <only>."})
+		if writeErr := response.WriteHTMLError(ctx, recorder, err); writeErr != nil {
+			t.Fatal(writeErr)
+		}
+		if recorder.Code != 403 || recorder.Header().Get("Content-Type") != response.ContentTypeHTML {
+			t.Errorf("response = %d %q", recorder.Code, recorder.Header().Get("Content-Type"))
+		}
+		body := recorder.Body.String()
+		for _, want := range []string{"<title>403 Forbidden</title>", "Admins &lt;only&gt;.", "req-&lt;1&gt;"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("page does not contain %q:\n%s", want, body)
+			}
+		}
+	})
+
+	t.Run("StatusTemplate", func(t *testing.T) {
+		notFound := template.Must(template.New("404").Parse(`missing {{.Code}} ({{.RequestID}})`))
+		renderer := response.NewHTMLRenderer(
+			response.WithHTMLTemplate(template.Must(template.New("error").Parse(`oops {{.StatusCode}}`))),
+			response.WithStatusTemplate(status.NotFound, notFound),
+		)
+
+		code, page, err := renderer.Render(ctx, exception.NewNotFound(map[string]interface{}{
+			"details": map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+		}))
+		if err != nil || code != status.NotFound || string(page) != "missing ORDER_NOT_FOUND (req-&lt;1&gt;)" {
+			t.Errorf("Render(404) = %d %q %v", code, page, err)
+		}
+		_, page, _ = response.NewHTMLRenderer().Render(ctx, exception.NewValidation(map[string]interface{}{exception.StatusTextKey: "Validation Failed"}))
+		if !strings.Contains(string(page), "<title>422 Validation Failed</title>") {
+			t.Errorf("Render(status text) =\n%s", page)
+		}
+		code, page, _ = renderer.Render(ctx, errors.New("secret"))
+		if code != status.InternalServerError || string(page) != "oops 500" {
+			t.Errorf("Render(plain) = %d %q", code, page)
+		}
+
+		renderer.SetTemplate(status.InternalServerError, template.Must(template.New("500").Parse(`{{.Missing}}`)))
+		recorder := httptest.NewRecorder()
+		if err := renderer.WriteError(ctx, recorder, errors.New("secret")); err == nil || recorder.Body.Len() != 0 {
+			t.Errorf("WriteError() with a failing template = %v, %q", err, recorder.Body.String())
+		}
+	})
+}
+
+func TestWriteNegotiated(t *testing.T) {
+	err := exception.NewNotFound(map[string]interface{}{
+		"message": "Order not found.",
+		"details": map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+	})
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		contains    string
+	}{
+		{"NoAccept", "", response.ContentTypeJSON, `"error_code":404`},
+		{"Anything", "*/*", response.ContentTypeJSON, `"error_code":404`},
+		{"Problem", "application/problem+json", response.ContentTypeProblem, `"instance":"/orders/42"`},
+		{"XML", "application/xml", response.ContentTypeXML, `<error_code>404</error_code>`},
+		{"TextXML", "text/xml, */*;q=0.1", response.ContentTypeXML, `<message>Order not found.</message>`},
+		{"Browser", "text/html,application/xhtml+xml,*/*;q=0.8", response.ContentTypeHTML, "<title>404 Not Found</title>"},
+		{"Unacceptable", "image/png", response.ContentTypeJSON, `"message":"Order not found."`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/orders/42", nil)
+			if tt.accept != "" {
+				request.Header.Set("Accept", tt.accept)
+			}
+			recorder := httptest.NewRecorder()
+			if writeErr := response.WriteNegotiated(recorder, request, err); writeErr != nil {
+				t.Fatal(writeErr)
+			}
+			if recorder.Code != 404 || recorder.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("response = %d %q, want 404 %q", recorder.Code, recorder.Header().Get("Content-Type"), tt.contentType)
+			}
+			if recorder.Header().Get("Vary") != "Accept" {
+				t.Errorf("Vary = %q", recorder.Header().Get("Vary"))
+			}
+			if body := recorder.Body.String(); !strings.Contains(body, tt.contains) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.contains)
+			}
+		})
+	}
+
+	t.Run("ProblemMembers", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/orders/42", nil)
+		request.Header.Set("Accept", "application/problem+json")
+		recorder := httptest.NewRecorder()
+		_ = response.WriteNegotiated(recorder, request, err)
+
+		var problem map[string]interface{}
+		if decodeErr := json.Unmarshal(recorder.Body.Bytes(), &problem); decodeErr != nil {
+			t.Fatal(decodeErr)
+		}
+		want := map[string]interface{}{
+			"type":     "about:blank",
+			"title":    "Not Found",
+			"status":   float64(404),
+			"detail":   "Order not found.",
+			"instance": "/orders/42",
+			"details":  map[string]interface{}{"error": "ORDER_NOT_FOUND"},
+		}
+		if !reflect.DeepEqual(problem, want) {
+			t.Errorf("problem = %v, want %v", problem, want)
+		}
+	})
+
+	t.Run("NilError", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		if writeErr := response.WriteNegotiated(recorder, httptest.NewRequest("GET", "/", nil), nil); writeErr != nil || recorder.Body.Len() != 0 {
+			t.Errorf("WriteNegotiated(nil) = %v, body %q", writeErr, recorder.Body.String())
+		}
+	})
+}
+
+func TestHintRendering(t *testing.T) {
+	err := exception.NewInvalidArgument(map[string]interface{}{
+		"message":         "The amount is invalid.",
+		exception.HintKey: "Check that the amount is positive.",
+	})
+
+	request := httptest.NewRequest("POST", "/payments", nil)
+	request.Header.Set("Accept", "application/problem+json")
+	recorder := httptest.NewRecorder()
+	_ = response.WriteNegotiated(recorder, request, err)
+	var problem map[string]interface{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &problem)
+	if problem["hint"] != "Check that the amount is positive." {
+		t.Errorf("problem = %v, want the hint extension member", problem)
+	}
+
+	recorder = httptest.NewRecorder()
+	_ = response.WriteHTMLError(context.Background(), recorder, err)
+	if body := recorder.Body.String(); !strings.Contains(body, "<p>Check that the amount is positive.</p>") {
+		t.Errorf("page = %s, want the hint", body)
+	}
+}
+
+func TestWriteSSEError(t *testing.T) {
+	ctx := ctxutil.WithRequestID(context.Background(), "req-1")
+	recorder := httptest.NewRecorder()
+	err := exception.NewNotFound(map[string]interface{}{"message": "Line one\nline two."})
+	if writeErr := response.WriteSSEError(ctx, recorder, err); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+
+	want := "event: error\ndata: " + `{"error_code":404,"message":"Line one\nline two.","request_id":"req-1","status":"error"}` + "\n\n"
+	if got := recorder.Body.String(); got != want {
+		t.Errorf("event = %q, want %q", got, want)
+	}
+	if !recorder.Flushed {
+		t.Error("the event was not flushed")
+	}
+
+	recorder = httptest.NewRecorder()
+	traced := tracing.Middleware(tracing.New(nil))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = response.WriteSSEError(r.Context(), w, err)
+	}))
+	traced.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if !recorder.Flushed {
+		t.Error("the event was not flushed behind the tracing middleware")
+	}
+
+	if event, formatErr := response.FormatSSE(ctx, nil); event != nil || formatErr != nil {
+		t.Errorf("FormatSSE(nil) = %q, %v", event, formatErr)
+	}
+}
+
+func TestCloseFrame(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"Nil", nil, response.CloseNormal},
+		{"InvalidArgument", exception.NewInvalidArgument(map[string]interface{}{}), response.CloseInvalidPayload},
+		{"Validation", exception.NewValidation(map[string]interface{}{}), response.CloseInvalidPayload},
+		{"Forbidden", exception.NewForbidden(map[string]interface{}{}), response.ClosePolicyViolation},
+		{"TooManyRequests", exception.NewTooManyRequests(map[string]interface{}{}), response.CloseTryAgainLater},
+		{"ServiceUnavailable", exception.NewServiceUnavailable(map[string]interface{}{}), response.CloseTryAgainLater},
+		{"Plain", errors.New("secret"), response.CloseInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := response.CloseCode(tt.err); got != tt.want {
+				t.Errorf("CloseCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Reason", func(t *testing.T) {
+		code, reason := response.CloseFrame(context.Background(), errors.New("secret"))
+		if code != response.CloseInternalError || reason != "Internal Server Error" {
+			t.Errorf("CloseFrame() = %d %q", code, reason)
+		}
+	})
+
+	t.Run("TruncatedReason", func(t *testing.T) {
+		message := strings.Repeat("a", 122) + "é and more"
+		payload := response.FormatCloseFrame(context.Background(), exception.NewForbidden(map[string]interface{}{"message": message}))
+		if len(payload) != 124 || payload[0] != 0x03 || payload[1] != 0xF0 {
+			t.Fatalf("payload = %d bytes, code %x", len(payload), payload[:2])
+		}
+		if reason := string(payload[2:]); reason != strings.Repeat("a", 122) {
+			t.Errorf("reason = %q", reason)
+		}
+	})
+}
+
+func TestWarnings(t *testing.T) {
+	ctx := response.WithWarnings(ctxutil.WithRequestID(context.Background(), "req-1"))
+	if !response.AddWarning(ctx, response.NewWarning("partial_data", "Recommendations are unavailable.", nil)) {
+		t.Fatal("AddWarning() did not add the warning")
+	}
+	response.AddWarning(ctx, response.WarningFromError(exception.NewTimeout(map[string]interface{}{
+		"details": map[string]interface{}{"error": "reviews_timeout", "service": "reviews"},
+	})))
+
+	body, _ := json.Marshal(response.SuccessContext(ctx, "page"))
+	want := `{"data":"page","meta":{"request_id":"req-1","warnings":[` +
+		`{"code":"partial_data","message":"Recommendations are unavailable."},` +
+		`{"code":"reviews_timeout","message":"Gateway Timeout","details":{"error":"reviews_timeout","service":"reviews"}}]},"status":"success"}`
+	if string(body) != want {
+		t.Errorf("envelope =\n%s\nwant\n%s", body, want)
+	}
+
+	if warning := response.WarningFromError(errors.New("secret")); warning.Code != "500" || warning.Message != "Internal Server Error" {
+		t.Errorf("WarningFromError(plain) = %+v", warning)
+	}
+	if response.AddWarning(context.Background(), response.Warning{}) || response.Warnings(context.Background()) != nil {
+		t.Error("a context without collector should collect no warning")
+	}
+	if _, ok := response.SuccessContext(context.Background(), nil)["meta"]; ok {
+		t.Error("an envelope without warnings should have no meta block")
+	}
+}
+
+func TestEnvelopeVersion(t *testing.T) {
+	if response.EnvelopeVersionOf(context.Background()) != response.EnvelopeV1 {
+		t.Fatal("EnvelopeVersionOf() should default to EnvelopeV1")
+	}
+
+	ctx := response.WithEnvelopeVersion(ctxutil.WithRequestID(context.Background(), "req-1"), response.EnvelopeV2)
+	recorder := httptest.NewRecorder()
+	if err := response.WriteErrorContext(ctx, recorder, exception.NewTooManyRequests(map[string]interface{}{"details": map[string]interface{}{"retry_after": 30}})); err != nil {
+		t.Fatalf("WriteErrorContext() returned an error: %v", err)
+	}
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	body, _ := envelope["error"].(map[string]interface{})
+	meta, _ := envelope["meta"].(map[string]interface{})
+	if recorder.Code != 429 || body["code"] != float64(429) || meta["request_id"] != "req-1" || meta["timestamp"] == nil {
+		t.Errorf("WriteErrorContext() = %d %v, want the v2 envelope", recorder.Code, envelope)
+	}
+	if recorder.Header().Get("Retry-After") != "30" {
+		t.Errorf("Retry-After = %q, want 30", recorder.Header().Get("Retry-After"))
+	}
+	if event, _ := response.FormatSSE(ctx, exception.NewNotFound(nil)); !strings.Contains(string(event), `"error":{"code":404`) {
+		t.Errorf("FormatSSE() = %s, want the v2 envelope", event)
+	}
+
+	restore := response.SetEnvelopeVersion(response.EnvelopeV2)
+	if _, nested := response.Error(exception.NewNotFound(nil))["error"]; !nested {
+		t.Error("Error() should follow SetEnvelopeVersion")
+	}
+	if formatted := response.ErrorContext(response.WithEnvelopeVersion(context.Background(), response.EnvelopeV1), exception.NewNotFound(nil)); formatted["error_code"] != 404 {
+		t.Errorf("ErrorContext() = %v, want the v1 envelope of the context", formatted)
+	}
+	restore()
+	if _, nested := response.Error(exception.NewNotFound(nil))["error"]; nested {
+		t.Error("Error() should be v1 after restore")
+	}
+}
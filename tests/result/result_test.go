@@ -0,0 +1,98 @@
+package result_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/result"
+)
+
+func TestResult(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		r := result.Map(result.Ok(21), func(v int) int { return v * 2 })
+		if value, err := r.Get(); err != nil || value != 42 {
+			t.Errorf("Get() = %d, %v, expected 42, nil", value, err)
+		}
+	})
+
+	t.Run("Err", func(t *testing.T) {
+		cause := errors.New("boom")
+		r := result.Then(result.Err[int](cause), func(v int) result.Result[string] {
+			t.Fatal("Then() called fn on a failed result")
+			return result.Ok("")
+		})
+		if !r.IsErr() || !errors.Is(r.Error(), cause) {
+			t.Errorf("Expected the original error to be propagated, got %v", r.Error())
+		}
+		if r.ValueOr("fallback") != "fallback" {
+			t.Errorf("ValueOr() did not return the fallback")
+		}
+	})
+
+	t.Run("ErrWithNil", func(t *testing.T) {
+		var logic *exception.Logic
+		if !errors.As(result.Err[int](nil).Error(), &logic) {
+			t.Error("Err(nil) should hold a *Logic exception")
+		}
+	})
+}
+
+func TestEither(t *testing.T) {
+	cached := result.Left[string, int]("cached")
+	fresh := result.Right[string](7)
+
+	describe := func(e result.Either[string, int]) string {
+		return result.Fold(e,
+			func(l string) string { return "left:" + l },
+			func(r int) string { return "right:" + strconv.Itoa(r) },
+		)
+	}
+
+	if got := describe(cached); got != "left:cached" {
+		t.Errorf("Fold() = %q", got)
+	}
+	if got := describe(result.MapRight(fresh, func(v int) int { return v + 1 })); got != "right:8" {
+		t.Errorf("Fold(MapRight()) = %q", got)
+	}
+	if value, ok := cached.Swap().Right(); !ok || value != "cached" {
+		t.Errorf("Swap().Right() = %q, %v", value, ok)
+	}
+}
+
+func TestEitherToResult(t *testing.T) {
+	t.Run("Right", func(t *testing.T) {
+		if value, err := result.ToResult(result.Right[error](5)).Get(); err != nil || value != 5 {
+			t.Errorf("ToResult() = %d, %v", value, err)
+		}
+	})
+
+	t.Run("ExceptionKept", func(t *testing.T) {
+		domainErr := exception.NewDomain(map[string]interface{}{"message": "Rule broken."})
+		r := result.ToResult(result.Left[error, int](domainErr))
+		if r.Error() != error(domainErr) {
+			t.Errorf("Expected the exception to be kept as-is, got %v", r.Error())
+		}
+	})
+
+	t.Run("PlainErrorWrapped", func(t *testing.T) {
+		plain := errors.New("timeout")
+		r := result.ToResult(result.Left[error, int](plain))
+		var coreErr exception.CoreInterface
+		if !errors.As(r.Error(), &coreErr) || coreErr.GetStatusCode() != 500 || !errors.Is(r.Error(), plain) {
+			t.Errorf("Expected an exception wrapping the error, got %v", r.Error())
+		}
+		if message := coreErr.Format()["message"]; message == plain.Error() {
+			t.Errorf("The message of the error should not be disclosed, got %q", message)
+		}
+	})
+
+	t.Run("NonErrorLeft", func(t *testing.T) {
+		r := result.ToResult(result.Left[string, int]("cached"))
+		var unexpected *exception.UnexpectedValue
+		if !errors.As(r.Error(), &unexpected) {
+			t.Errorf("Expected *UnexpectedValue, got %T", r.Error())
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/retry"
+)
+
+var fast = retry.Backoff(time.Millisecond, 5*time.Millisecond)
+
+func unavailable() error {
+	return exception.NewInstance(map[string]interface{}{"message": "Upstream down."}, status.ServiceUnavailable)
+}
+
+func TestDoSucceedsAfterRetries(t *testing.T) {
+	calls := 0
+	var attempts []retry.Attempt
+
+	value, err := retry.DoValue(context.Background(), func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", unavailable()
+		}
+		return "ok", nil
+	}, fast, retry.OnRetry(func(a retry.Attempt) { attempts = append(attempts, a) }))
+
+	if err != nil || value != "ok" {
+		t.Fatalf("DoValue() = %q, %v", value, err)
+	}
+	if len(attempts) != 2 || attempts[0].Number != 1 || attempts[1].Number != 2 {
+		t.Errorf("OnRetry() received %+v", attempts)
+	}
+}
+
+func TestDoTypedNil(t *testing.T) {
+	calls := 0
+	err := retry.Do(context.Background(), func(context.Context) error {
+		calls++
+		var notFound *exception.NotFound
+		return notFound
+	}, fast)
+
+	if err != nil || calls != 1 {
+		t.Errorf("Do() = %v after %d calls, want a nil exception treated as a success", err, calls)
+	}
+}
+
+func TestDoNonRetryable(t *testing.T) {
+	calls := 0
+	domainErr := exception.NewDomain(nil)
+
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return domainErr
+	}, fast)
+
+	if calls != 1 || err != error(domainErr) {
+		t.Errorf("Expected a single attempt returning the original error, got %d attempts and %v", calls, err)
+	}
+}
+
+func TestDoExhausted(t *testing.T) {
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		return unavailable()
+	}, fast, retry.MaxAttempts(4))
+
+	var exhausted *retry.Exhausted
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected *Exhausted, got %T", err)
+	}
+	if exhausted.Attempts != 4 || exhausted.Reason != retry.ReasonAttemptsExhausted {
+		t.Errorf("Unexpected metadata: attempts=%d reason=%s", exhausted.Attempts, exhausted.Reason)
+	}
+	if exhausted.GetStatusCode() != 503 || exhausted.Error() != "Upstream down." {
+		t.Errorf("The last failure should be preserved, got %d %q", exhausted.GetStatusCode(), exhausted.Error())
+	}
+	if meta := exhausted.Format()["retry"].(map[string]interface{}); meta["attempts"] != 4 {
+		t.Errorf("Format() retry metadata = %+v", meta)
+	}
+	if exception.IsRetryable(err) {
+		t.Error("An exhausted retry loop must not be retried again")
+	}
+
+	var core *exception.CoreException
+	if !errors.As(errors.Unwrap(err), &core) || core.StatusCode != status.ServiceUnavailable {
+		t.Error("Exhausted should unwrap to the last error")
+	}
+}
+
+func TestExhaustedHidesPlainMessages(t *testing.T) {
+	plain := errors.New("pq: connection to 10.0.0.3 refused")
+	err := retry.Do(context.Background(), func(context.Context) error { return plain },
+		fast, retry.MaxAttempts(2), retry.RetryIf(func(error) bool { return true }))
+
+	var exhausted *retry.Exhausted
+	if !errors.As(err, &exhausted) || !errors.Is(err, plain) {
+		t.Fatalf("Expected *Exhausted wrapping the last error, got %v", err)
+	}
+	if message := exhausted.Format()["message"]; message != status.InternalServerError.GetDescription() {
+		t.Errorf("Format() message = %q, want the generic description", message)
+	}
+	if causes := exhausted.GetErrorsForLog()[exception.CausesKey].([]interface{}); causes[0].(map[string]interface{})["message"] != plain.Error() {
+		t.Errorf("GetErrorsForLog() causes = %v, want the last error", causes)
+	}
+}
+
+func TestDoContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		cancel()
+		return unavailable()
+	}, retry.Backoff(time.Hour, time.Hour))
+
+	var exhausted *retry.Exhausted
+	if !errors.As(err, &exhausted) || exhausted.Reason != retry.ReasonContextDone {
+		t.Errorf("Expected *Exhausted with reason %q, got %v", retry.ReasonContextDone, err)
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	calls := 0
+	plain := errors.New("flaky")
+	_ = retry.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return plain
+	}, fast, retry.RetryIf(func(err error) bool { return errors.Is(err, plain) }))
+
+	if calls != retry.DefaultMaxAttempts {
+		t.Errorf("Expected %d attempts with a custom classifier, got %d", retry.DefaultMaxAttempts, calls)
+	}
+}
+
+func TestDoHonorsRetryAfter(t *testing.T) {
+	for _, seconds := range []interface{}{2, int64(2), float64(2)} {
+		var delays []time.Duration
+		limited := exception.NewTooManyRequests(map[string]interface{}{
+			"details": map[string]interface{}{exception.RetryAfterKey: seconds},
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+
+		_ = retry.Do(ctx, func(context.Context) error { return limited },
+			retry.MaxAttempts(2),
+			retry.Backoff(time.Millisecond, 20*time.Millisecond),
+			retry.Jitter(0),
+			retry.OnRetry(func(a retry.Attempt) { delays = append(delays, a.Delay); cancel() }),
+		)
+
+		if len(delays) != 1 || delays[0] != 2*time.Second {
+			t.Errorf("The retry_after detail %T should set the delay beyond the maximum delay; got %v", seconds, delays)
+		}
+	}
+}
+
+func TestDoJitterCappedByMaxDelay(t *testing.T) {
+	var delays []time.Duration
+	unavailable := exception.NewServiceUnavailable(map[string]interface{}{})
+
+	_ = retry.Do(context.Background(), func(context.Context) error { return unavailable },
+		retry.MaxAttempts(6),
+		retry.Backoff(time.Millisecond, time.Millisecond),
+		retry.Jitter(1),
+		retry.OnRetry(func(a retry.Attempt) { delays = append(delays, a.Delay) }),
+	)
+
+	if len(delays) != 5 {
+		t.Fatalf("Expected 5 retries, got %d", len(delays))
+	}
+	for _, delay := range delays {
+		if delay > time.Millisecond {
+			t.Errorf("The jittered delay %v exceeds the maximum delay", delay)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/rules"
+)
+
+type order struct {
+	amount    int
+	suspended bool
+	items     int
+}
+
+func placement() *rules.Set[order] {
+	return rules.NewSet("order.placement",
+		rules.Rule[order]{
+			ID:         "order.not_empty",
+			Code:       "empty_order",
+			MessageKey: "rules.order.not_empty",
+			Message:    "An order needs at least one item.",
+			Holds:      func(o order) bool { return o.items > 0 },
+		},
+		rules.Rule[order]{
+			ID:         "order.suspended_limit",
+			Code:       "amount_limit_exceeded",
+			MessageKey: "rules.order.suspended_limit",
+			Message:    "Suspended customers cannot order more than 100.",
+			Holds:      func(o order) bool { return !o.suspended || o.amount <= 100 },
+		},
+	).Add(rules.Rule[order]{
+		ID:    "order.positive_amount",
+		Holds: func(o order) bool { return o.amount >= 0 },
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		subject     order
+		wantRules   []string
+		wantMessage string
+	}{
+		{
+			name:    "satisfied",
+			subject: order{amount: 50, items: 1},
+		},
+		{
+			name:        "one violation",
+			subject:     order{amount: 500, suspended: true, items: 2},
+			wantRules:   []string{"order.suspended_limit"},
+			wantMessage: "Suspended customers cannot order more than 100.",
+		},
+		{
+			name:        "every violation",
+			subject:     order{amount: -1},
+			wantRules:   []string{"order.not_empty", "order.positive_amount"},
+			wantMessage: "2 business rules are violated.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := placement().Evaluate(tt.subject)
+			if tt.wantRules == nil {
+				if err != nil {
+					t.Errorf("Evaluate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var logic *exception.Logic
+			if !errors.As(err, &logic) {
+				t.Fatalf("Evaluate() = %v, want *exception.Logic", err)
+			}
+			if logic.Error() != tt.wantMessage {
+				t.Errorf("message = %q, want %q", logic.Error(), tt.wantMessage)
+			}
+			details := logic.GetDetails()
+			if details["rule_set"] != "order.placement" || details["rule"] != tt.wantRules[0] || details["error"] != "business_rule_violated" {
+				t.Errorf("details = %v", details)
+			}
+			var got []string
+			for _, violation := range details["violations"].([]rules.Violation) {
+				got = append(got, violation.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.wantRules) {
+				t.Errorf("violated rules = %v, want %v", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestViolationDefaults(t *testing.T) {
+	violations := placement().Violations(order{amount: -1, items: 1})
+	want := []rules.Violation{{Rule: "order.positive_amount", Code: "order.positive_amount"}}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("Violations() = %+v, want %+v", violations, want)
+	}
+}
+
+func TestEvaluateContext(t *testing.T) {
+	bundle := i18n.NewBundle("en")
+	bundle.Add("fr", map[string]string{"rules.order.not_empty": "Une commande doit contenir au moins un article."})
+	ctx := i18n.WithLocale(context.Background(), bundle, "fr")
+
+	err := placement().EvaluateContext(ctx, order{amount: 500, suspended: true})
+	var logic *exception.Logic
+	if !errors.As(err, &logic) {
+		t.Fatalf("EvaluateContext() = %v, want *exception.Logic", err)
+	}
+	violations := logic.GetDetails()["violations"].([]rules.Violation)
+	if violations[0].Message != "Une commande doit contenir au moins un article." {
+		t.Errorf("translated message = %q", violations[0].Message)
+	}
+	if violations[1].Message != "Suspended customers cannot order more than 100." {
+		t.Errorf("untranslated message = %q", violations[1].Message)
+	}
+}
@@ -0,0 +1,136 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/saga"
+)
+
+var errDeclined = errors.New("payment declined")
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name                   string
+		failAt                 string
+		panicAt                string
+		failCompensation       string
+		wantLog                []string
+		wantCode               string
+		wantCompensated        []string
+		wantCompensationFailed []string
+	}{
+		{
+			name:    "every step completes",
+			wantLog: []string{"reserve", "charge", "ship"},
+		},
+		{
+			name:            "step fails",
+			failAt:          "ship",
+			wantLog:         []string{"reserve", "charge", "ship", "refund", "release"},
+			wantCode:        "saga_failed",
+			wantCompensated: []string{"charge", "reserve"},
+		},
+		{
+			name:            "step panics",
+			panicAt:         "charge",
+			wantLog:         []string{"reserve", "charge", "release"},
+			wantCode:        "saga_failed",
+			wantCompensated: []string{"reserve"},
+		},
+		{
+			name:                   "compensation fails",
+			failAt:                 "ship",
+			failCompensation:       "refund",
+			wantLog:                []string{"reserve", "charge", "ship", "refund", "release"},
+			wantCode:               "saga_compensation_failed",
+			wantCompensated:        []string{"reserve"},
+			wantCompensationFailed: []string{"charge"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			action := func(name string) func(context.Context) error {
+				return func(context.Context) error {
+					log = append(log, name)
+					if name == tt.panicAt {
+						panic("boom")
+					}
+					if name == tt.failAt || name == tt.failCompensation {
+						return errDeclined
+					}
+					return nil
+				}
+			}
+
+			s := saga.New("place_order",
+				saga.Step{Name: "reserve", Execute: action("reserve"), Compensate: action("release")},
+				saga.Step{Name: "charge", Execute: action("charge"), Compensate: action("refund")},
+				saga.Step{Name: "ship", Execute: action("ship")},
+			)
+			err := s.Run(context.Background())
+
+			if !reflect.DeepEqual(log, tt.wantLog) {
+				t.Errorf("log = %v, want %v", log, tt.wantLog)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("Run() = %v, want nil", err)
+				}
+				return
+			}
+
+			var aggregate *exception.Aggregate
+			if !errors.As(err, &aggregate) {
+				t.Fatalf("Run() = %v, want *exception.Aggregate", err)
+			}
+			details := aggregate.GetDetails()
+			if details["error"] != tt.wantCode || details["saga"] != "place_order" {
+				t.Errorf("details = %v", details)
+			}
+			if !reflect.DeepEqual(details["compensated"], tt.wantCompensated) {
+				t.Errorf("compensated = %v, want %v", details["compensated"], tt.wantCompensated)
+			}
+			if tt.wantCompensationFailed == nil {
+				tt.wantCompensationFailed = []string{}
+			}
+			if !reflect.DeepEqual(details["compensation_failed"], tt.wantCompensationFailed) {
+				t.Errorf("compensation_failed = %v, want %v", details["compensation_failed"], tt.wantCompensationFailed)
+			}
+			if tt.failAt != "" && !errors.Is(err, errDeclined) {
+				t.Errorf("Run() = %v, want an error wrapping the step error", err)
+			}
+		})
+	}
+}
+
+func TestRunCompensatesCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	compensated := false
+	s := saga.New("checkout",
+		saga.Step{
+			Name:    "reserve",
+			Execute: func(context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error {
+				compensated = ctx.Err() == nil
+				return nil
+			},
+		},
+		saga.Step{
+			Name: "charge",
+			Execute: func(ctx context.Context) error {
+				cancel()
+				return ctx.Err()
+			},
+		},
+	)
+
+	if err := s.Run(ctx); err == nil || !compensated {
+		t.Errorf("Run() = %v, compensated with a live context = %v", err, compensated)
+	}
+}
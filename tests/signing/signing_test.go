@@ -0,0 +1,123 @@
+package signing_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/signing"
+)
+
+var (
+	current = signing.Key{ID: "2026-10", Secret: []byte("current-secret")}
+	retired = signing.Key{ID: "2026-04", Secret: []byte("retired-secret")}
+	payload = []byte(`{"event":"paid"}`)
+)
+
+func reasonOf(err error) string {
+	var unauthorized *exception.Unauthorized
+	if !errors.As(err, &unauthorized) {
+		return ""
+	}
+	return unauthorized.GetDetailsMessage()
+}
+
+func newSigner(t *testing.T, config signing.Config) *signing.Signer {
+	t.Helper()
+	signer, err := signing.New(config)
+	if err != nil {
+		t.Fatalf("New() returned %v", err)
+	}
+	return signer
+}
+
+func TestRotation(t *testing.T) {
+	old := newSigner(t, signing.Config{Keys: []signing.Key{retired}, Encoding: signing.Base64URL})
+	rotated := newSigner(t, signing.Config{Keys: []signing.Key{current, retired}, Encoding: signing.Base64URL})
+	stranger := newSigner(t, signing.Config{Keys: []signing.Key{{ID: "other", Secret: []byte("x")}}, Encoding: signing.Base64URL})
+
+	oldID, oldSignature := old.Sign(payload)
+	newID, newSignature := rotated.Sign(payload)
+	_, strangerSignature := stranger.Sign(payload)
+	if newID != current.ID || oldID != retired.ID {
+		t.Fatalf("Sign() key IDs = %q, %q", newID, oldID)
+	}
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		keyID     string
+		signature string
+		reason    string
+	}{
+		{"ActiveKey", payload, newID, newSignature, ""},
+		{"RetiredKey", payload, oldID, oldSignature, ""},
+		{"WithoutKeyID", payload, "", oldSignature, ""},
+		{"WrongKeyID", payload, newID, oldSignature, signing.ReasonInvalidSignature},
+		{"UnknownKey", payload, "other", strangerSignature, signing.ReasonUnknownKey},
+		{"TamperedPayload", []byte(`{"event":"refunded"}`), newID, newSignature, signing.ReasonInvalidSignature},
+		{"Malformed", payload, newID, "!!", signing.ReasonMalformedSignature},
+		{"Empty", payload, newID, "", signing.ReasonMalformedSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rotated.Verify(tt.payload, tt.keyID, tt.signature)
+			if got := reasonOf(err); got != tt.reason || (tt.reason == "" && err != nil) {
+				t.Errorf("Verify() = %v, expected reason %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	signer := newSigner(t, signing.Config{Keys: []signing.Key{current}, Prefix: "sha256="})
+	header := http.Header{}
+	signer.SignHeader(header, payload)
+
+	if header.Get(signing.DefaultKeyIDHeader) != current.ID || len(header.Get(signing.DefaultSignatureHeader)) != len("sha256=")+64 {
+		t.Fatalf("SignHeader() set %v", header)
+	}
+	if err := signer.VerifyHeader(header, payload); err != nil {
+		t.Errorf("VerifyHeader() = %v", err)
+	}
+
+	unprefixed := http.Header{}
+	unprefixed.Set(signing.DefaultSignatureHeader, "abcd")
+	var unauthorized *exception.Unauthorized
+	if err := signer.VerifyHeader(unprefixed, payload); !errors.As(err, &unauthorized) ||
+		unauthorized.GetDetails()["header"] != signing.DefaultSignatureHeader || reasonOf(err) != signing.ReasonMalformedSignature {
+		t.Errorf("VerifyHeader() without prefix = %v", err)
+	}
+	if err := signer.VerifyHeader(http.Header{}, payload); reasonOf(err) != signing.ReasonMissingSignature {
+		t.Errorf("VerifyHeader() without header = %v", err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !signing.EqualString("token", "token") || signing.EqualString("token", "tokens") || signing.Equal([]byte("a"), []byte("b")) {
+		t.Error("unexpected comparison result")
+	}
+}
+
+func TestNewValidatesKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []signing.Key
+		reason string
+	}{
+		{"NoKeys", nil, "missing_keys"},
+		{"EmptySecret", []signing.Key{current, {ID: "blank"}}, "missing_secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configuration *exception.Configuration
+			signer, err := signing.New(signing.Config{Keys: tt.keys})
+			if signer != nil || !errors.As(err, &configuration) || configuration.GetDetailsMessage() != tt.reason {
+				t.Errorf("New() = %v, %v, expected reason %q", signer, err, tt.reason)
+			}
+		})
+	}
+}
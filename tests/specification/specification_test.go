@@ -0,0 +1,79 @@
+package specification_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/specification"
+)
+
+type order struct {
+	Amount  int
+	Shipped bool
+	VIP     bool
+}
+
+// notShipped is a hand-written Specification.
+type notShipped struct{}
+
+func (notShipped) IsSatisfiedBy(o order) bool { return !o.Shipped }
+func (notShipped) Rule() string               { return "order.not_shipped" }
+
+var (
+	minimumAmount = specification.New("order.minimum_amount", func(o order) bool { return o.Amount >= 10 })
+	vip           = specification.New("customer.vip", func(o order) bool { return o.VIP })
+)
+
+func TestComposition(t *testing.T) {
+	cancellable := minimumAmount.Or(vip).And(notShipped{})
+
+	tests := []struct {
+		name      string
+		candidate order
+		expected  bool
+	}{
+		{"LargeOrder", order{Amount: 20}, true},
+		{"SmallVIPOrder", order{Amount: 5, VIP: true}, true},
+		{"SmallOrder", order{Amount: 5}, false},
+		{"ShippedOrder", order{Amount: 20, Shipped: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cancellable.IsSatisfiedBy(tt.candidate); got != tt.expected {
+				t.Errorf("IsSatisfiedBy() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if rule := cancellable.Rule(); rule != "and(or(order.minimum_amount,customer.vip),order.not_shipped)" {
+		t.Errorf("Rule() = %q", rule)
+	}
+	if got := specification.Filter[order](vip.Not(), []order{{VIP: true}, {Amount: 1}}); len(got) != 1 || got[0].Amount != 1 {
+		t.Errorf("Filter() = %+v", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	spec := specification.And[order](minimumAmount, notShipped{}, specification.Not[order](vip))
+
+	if err := specification.Check[order](spec, order{Amount: 10}); err != nil {
+		t.Fatalf("Check() returned %v", err)
+	}
+
+	err := specification.Check[order](spec, order{Amount: 1, Shipped: true})
+	var domain *exception.Domain
+	if !errors.As(err, &domain) {
+		t.Fatalf("Expected a *Domain, got %T", err)
+	}
+	details := domain.GetDetails()
+	if details["rule"] != "order.minimum_amount" || !reflect.DeepEqual(details["rules"], []string{"order.minimum_amount", "order.not_shipped"}) {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+
+	err = specification.Check[order](spec, order{Amount: 10, VIP: true})
+	if !errors.As(err, &domain) || domain.GetDetails()["rule"] != "not(customer.vip)" {
+		t.Errorf("Expected the negated rule to be reported, got %v", err)
+	}
+}
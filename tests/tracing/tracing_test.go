@@ -0,0 +1,103 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+	"github.com/osirisgate/golang-core/repository"
+	"github.com/osirisgate/golang-core/response"
+	"github.com/osirisgate/golang-core/tracing"
+)
+
+func TestTraceparent(t *testing.T) {
+	const header = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, ok := tracing.ParseTraceparent(header)
+	if !ok || !sc.Sampled || sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.Traceparent() != header {
+		t.Fatalf("Unexpected span context: %+v %v", sc, ok)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f35-00f067aa0ba902b7-01",
+	} {
+		if _, ok := tracing.ParseTraceparent(invalid); ok {
+			t.Errorf("ParseTraceparent(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var spans []tracing.Data
+	tracer := tracing.New(func(data tracing.Data) {
+		mu.Lock()
+		defer mu.Unlock()
+		spans = append(spans, data)
+	})
+
+	handler := tracing.Middleware(tracer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			_ = response.WriteSuccessContext(r.Context(), w, 200, "ok")
+			return
+		}
+		_ = response.WriteErrorContext(r.Context(), w, repository.NewNotFound("Order", 42))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	req.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if len(spans) != 1 {
+		t.Fatalf("Expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent.String() != "00f067aa0ba902b7" {
+		t.Errorf("The span should continue the remote trace: %+v", span.SpanContext)
+	}
+	if span.Attributes["http.response.status_code"] != 404 || span.Status != tracing.Unset {
+		t.Errorf("Unexpected attributes or status: %v %v", span.Attributes, span.Status)
+	}
+	if len(span.Events) != 1 || span.Events[0].Attributes["error.code"] != "not_found" || span.Events[0].Attributes["exception.type"] != "*exception.NotFound" {
+		t.Errorf("Expected the exception to be recorded: %+v", span.Events)
+	}
+
+	var envelope map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &envelope)
+	if envelope[ctxutil.TraceIDField] != "4bf92f3577b34da6a3ce929d0e0e4736" || envelope[ctxutil.SpanIDField] != span.SpanContext.SpanID.String() {
+		t.Errorf("The error envelope should carry the trace IDs: %v", envelope)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	_ = json.Unmarshal(rec.Body.Bytes(), &envelope)
+	meta, _ := envelope["meta"].(map[string]interface{})
+	if len(spans) != 2 || spans[1].Parent.IsValid() || meta[ctxutil.TraceIDField] != spans[1].SpanContext.TraceID.String() {
+		t.Errorf("Expected a new root trace reported in the meta block: %v", envelope)
+	}
+}
+
+func TestMiddlewareFlush(t *testing.T) {
+	handler := tracing.Middleware(tracing.New(nil))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("The traced writer should be an http.Flusher")
+		}
+		_, _ = w.Write([]byte("data: tick\n\n"))
+		flusher.Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if !rec.Flushed {
+		t.Error("The stream should be flushed through the tracing recorder")
+	}
+}
@@ -0,0 +1,88 @@
+package uow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/uow"
+)
+
+type txKey struct{}
+
+type fakeUnitOfWork struct {
+	commits, rollbacks int
+	rollbackErr        error
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, txKey{}, true), nil
+}
+
+func (u *fakeUnitOfWork) Commit(ctx context.Context) error {
+	u.commits++
+	return nil
+}
+
+func (u *fakeUnitOfWork) Rollback(ctx context.Context) error {
+	u.rollbacks++
+	return u.rollbackErr
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Commit", func(t *testing.T) {
+		u := &fakeUnitOfWork{}
+		err := uow.Do(ctx, u, func(ctx context.Context) error {
+			if ctx.Value(txKey{}) != true {
+				t.Error("fn should receive the transaction context")
+			}
+			return nil
+		})
+		if err != nil || u.commits != 1 || u.rollbacks != 0 {
+			t.Errorf("Do() = %v, commits=%d rollbacks=%d", err, u.commits, u.rollbacks)
+		}
+	})
+
+	t.Run("RollbackOnError", func(t *testing.T) {
+		u := &fakeUnitOfWork{}
+		cause := errors.New("constraint violated")
+		err := uow.Do(ctx, u, func(ctx context.Context) error { return cause })
+
+		var coreErr exception.CoreInterface
+		if !errors.As(err, &coreErr) || !errors.Is(err, cause) || u.rollbacks != 1 || u.commits != 0 {
+			t.Errorf("Do() = %v, commits=%d rollbacks=%d", err, u.commits, u.rollbacks)
+		}
+	})
+
+	t.Run("RollbackOnPanic", func(t *testing.T) {
+		u := &fakeUnitOfWork{}
+		err := uow.Do(ctx, u, func(ctx context.Context) error { panic("boom") })
+
+		var runtime *exception.Runtime
+		if !errors.As(err, &runtime) || runtime.GetDetailsMessage() != "transaction_panicked" || u.rollbacks != 1 {
+			t.Errorf("Do() = %v, rollbacks=%d", err, u.rollbacks)
+		}
+	})
+
+	t.Run("RollbackFailure", func(t *testing.T) {
+		u := &fakeUnitOfWork{rollbackErr: errors.New("connection lost")}
+		cause := exception.NewDomain(nil)
+		err := uow.Do(ctx, u, func(ctx context.Context) error { return cause })
+
+		var aggregate *exception.Aggregate
+		if !errors.As(err, &aggregate) || aggregate.Len() != 2 || !errors.Is(err, cause) {
+			t.Errorf("Expected an Aggregate of the cause and the rollback error, got %v", err)
+		}
+	})
+
+	t.Run("Transactor", func(t *testing.T) {
+		u := &fakeUnitOfWork{}
+		tx := uow.Transactor{UnitOfWork: u}
+		if err := tx.WithinTransaction(ctx, func(context.Context) error { return nil }); err != nil || u.commits != 1 {
+			t.Errorf("WithinTransaction() = %v, commits=%d", err, u.commits)
+		}
+	})
+}
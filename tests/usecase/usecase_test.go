@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/usecase"
+)
+
+type registerRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type registerResponse struct {
+	ID int `json:"id"`
+}
+
+var register = usecase.Func[registerRequest, registerResponse](func(ctx context.Context, req registerRequest) (registerResponse, error) {
+	switch req.Email {
+	case "taken@example.com":
+		return registerResponse{}, exception.NewDomain(map[string]interface{}{"message": "Email already taken."})
+	case "panic@example.com":
+		panic("database handle is nil")
+	}
+	return registerResponse{ID: 7}, nil
+})
+
+func TestExecutor(t *testing.T) {
+	var timings []error
+	executor := usecase.NewExecutor(register, usecase.Config{
+		Name:       "RegisterUser",
+		OnComplete: func(name string, d time.Duration, err error) { timings = append(timings, err) },
+	})
+	ctx := context.Background()
+
+	if resp, err := executor.Execute(ctx, registerRequest{Email: "jane@example.com"}); err != nil || resp.ID != 7 {
+		t.Fatalf("Execute() = %+v, %v", resp, err)
+	}
+
+	var validation *exception.Validation
+	if _, err := executor.Execute(ctx, registerRequest{Email: "jane"}); !errors.As(err, &validation) {
+		t.Errorf("Expected a *Validation, got %T", err)
+	}
+
+	var runtime *exception.Runtime
+	if _, err := executor.Execute(ctx, registerRequest{Email: "panic@example.com"}); !errors.As(err, &runtime) ||
+		runtime.GetDetails()["use_case"] != "RegisterUser" {
+		t.Errorf("Expected a *Runtime naming the use case, got %v", err)
+	}
+
+	if len(timings) != 3 || timings[0] != nil || timings[1] == nil {
+		t.Errorf("Unexpected timings: %v", timings)
+	}
+}
+
+func TestPresent(t *testing.T) {
+	presenter := usecase.EnvelopePresenter[registerResponse]{}
+	ctx := context.Background()
+
+	body, code := usecase.Present(ctx, register, presenter, registerRequest{Email: "jane@example.com"})
+	if code != 200 || body["status"] != status.SUCCESS || body["data"] != (registerResponse{ID: 7}) {
+		t.Errorf("Unexpected success presentation: %d %+v", code, body)
+	}
+
+	body, code = usecase.Present(ctx, register, presenter, registerRequest{Email: "taken@example.com"})
+	if code != status.BadRequest.GetValue() || body["message"] != "Email already taken." {
+		t.Errorf("Unexpected error presentation: %d %+v", code, body)
+	}
+
+	failing := usecase.Func[registerRequest, registerResponse](func(context.Context, registerRequest) (registerResponse, error) {
+		return registerResponse{}, errors.New("dial tcp: connection refused")
+	})
+	if body, code = usecase.Present(ctx, failing, presenter, registerRequest{}); code != 500 || body["message"] == "dial tcp: connection refused" {
+		t.Errorf("Plain errors should be presented as hidden internal errors: %d %+v", code, body)
+	}
+}
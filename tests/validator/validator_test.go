@@ -0,0 +1,191 @@
+package validator_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/i18n"
+	"github.com/osirisgate/golang-core/validator"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type item struct {
+	Name     string `json:"name" validate:"required,max=5"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+type order struct {
+	Email    string   `json:"email" validate:"required,email"`
+	Color    string   `json:"color" validate:"omitempty,oneof=red blue"`
+	Note     *string  `json:"note" validate:"min=2"`
+	Address  address  `json:"address"`
+	Items    []item   `json:"items" validate:"required"`
+	Internal string   `validate:"-"`
+	Billing  *address `json:"billing"`
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		input := order{
+			Email:   "john@example.com",
+			Address: address{City: "Lomé"},
+			Items:   []item{{Name: "pen", Quantity: 2}},
+		}
+		if err := validator.Validate(&input); err != nil {
+			t.Errorf("Validate() returned an error for a valid struct: %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		input := order{
+			Email:   "not-an-email",
+			Color:   "green",
+			Items:   []item{{Name: "notebook", Quantity: 0}},
+			Billing: &address{},
+		}
+		err := validator.Validate(input)
+
+		var validation *exception.Validation
+		if !errors.As(err, &validation) {
+			t.Fatalf("Expected *Validation, got %T", err)
+		}
+		if validation.StatusCode != status.UnprocessableContent {
+			t.Errorf("Expected status %v, got %v", status.UnprocessableContent, validation.StatusCode)
+		}
+
+		fields, ok := validation.Format()["errors"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Format() has no per-field errors map: %+v", validation.Format())
+		}
+
+		expectedRules := map[string]string{
+			"email":             "email",
+			"color":             "oneof",
+			"address.city":      "required",
+			"items[0].name":     "max",
+			"items[0].quantity": "min",
+			"billing.city":      "required",
+		}
+		if len(fields) != len(expectedRules) {
+			t.Errorf("Expected %d invalid fields, got %+v", len(expectedRules), fields)
+		}
+		for field, rule := range expectedRules {
+			violations, ok := fields[field].([]map[string]interface{})
+			if !ok || len(violations) != 1 || violations[0]["rule"] != rule {
+				t.Errorf("Field %q: expected rule %q, got %+v", field, rule, fields[field])
+			}
+		}
+	})
+
+	t.Run("NotAStruct", func(t *testing.T) {
+		var logic *exception.Logic
+		if !errors.As(validator.Validate("text"), &logic) {
+			t.Error("Expected *Logic when validating a non-struct value")
+		}
+	})
+}
+
+func TestCustomRule(t *testing.T) {
+	v := validator.New()
+	v.RegisterRule("even", func(string) (validator.Rule, error) {
+		return validator.NewRule("even", func(value reflect.Value) (string, bool) {
+			return "Must be even.", value.Int()%2 == 0
+		}), nil
+	})
+
+	type input struct {
+		Count int `validate:"even"`
+	}
+	if err := v.Validate(input{Count: 3}); err == nil {
+		t.Error("Expected the custom rule to reject an odd number")
+	}
+	if err := v.Validate(input{Count: 4}); err != nil {
+		t.Errorf("Expected the custom rule to accept an even number, got %v", err)
+	}
+}
+
+func TestErrorsWithRuleObjects(t *testing.T) {
+	errs := &validator.Errors{}
+	errs.Check("username", "ab", validator.Required(), validator.Min(3))
+	errs.Check("age", 30, validator.Min(18), validator.Max(120))
+
+	if !errs.HasErrors() {
+		t.Fatal("Expected a violation for username")
+	}
+
+	expected := map[string]interface{}{
+		"username": []map[string]interface{}{
+			{"rule": "min", "message": "Must contain at least 3 items or characters."},
+		},
+	}
+	if !reflect.DeepEqual(errs.Fields(), expected) {
+		t.Errorf("Fields() returned:\n got %+v,\n expected %+v", errs.Fields(), expected)
+	}
+}
+
+// fieldError mimics `validator.FieldError` of go-playground/validator.
+type fieldError struct {
+	tag, namespace, param string
+	value                 interface{}
+}
+
+func (e fieldError) Tag() string        { return e.tag }
+func (e fieldError) Namespace() string  { return e.namespace }
+func (e fieldError) Field() string      { return e.namespace[strings.LastIndex(e.namespace, ".")+1:] }
+func (e fieldError) Param() string      { return e.param }
+func (e fieldError) Value() interface{} { return e.value }
+func (e fieldError) Error() string      { return "invalid " + e.namespace }
+
+// validationErrors mimics `validator.ValidationErrors`.
+type validationErrors []fieldError
+
+func (e validationErrors) Error() string { return fmt.Sprintf("%d errors", len(e)) }
+
+func TestFromPlayground(t *testing.T) {
+	err := fmt.Errorf("validate: %w", validationErrors{
+		{tag: "required", namespace: "User.email"},
+		{tag: "min", namespace: "User.name", param: "3", value: "Al"},
+		{tag: "max", namespace: "User.items[0].quantity", param: "10", value: 12},
+		{tag: "uuid", namespace: "User.id", value: "x"},
+	})
+
+	bundle := i18n.NewBundle("en")
+	bundle.Add("fr", map[string]string{"validation.required": "Le champ {field} est obligatoire."})
+	ctx := i18n.WithLocale(context.Background(), bundle, "fr")
+
+	got := validator.FromPlayground(ctx, err)
+	var validation *exception.Validation
+	if !errors.As(got, &validation) {
+		t.Fatalf("FromPlayground() = %T, want *exception.Validation", got)
+	}
+	want := map[string]interface{}{
+		"email":             []map[string]interface{}{{"rule": "required", "message": "Le champ email est obligatoire."}},
+		"name":              []map[string]interface{}{{"rule": "min", "message": "Must contain at least 3 items or characters."}},
+		"items[0].quantity": []map[string]interface{}{{"rule": "max", "message": "Must be less than or equal to 10."}},
+		"id":                []map[string]interface{}{{"rule": "uuid", "message": "Must satisfy the uuid rule."}},
+	}
+	if fields := validation.GetErrors()["errors"]; !reflect.DeepEqual(fields, want) {
+		t.Errorf("errors = %v, want %v", fields, want)
+	}
+
+	plain := errors.New("not validation")
+	if validator.FromPlayground(ctx, plain) != plain || validator.FromPlayground(ctx, nil) != nil {
+		t.Error("other errors are not returned unchanged")
+	}
+}
+
+func TestJSONTagName(t *testing.T) {
+	field, _ := reflect.TypeOf(item{}).FieldByName("Quantity")
+	if got := validator.JSONTagName(field); got != "quantity" {
+		t.Errorf("JSONTagName() = %q, want quantity", got)
+	}
+}
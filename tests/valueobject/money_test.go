@@ -0,0 +1,109 @@
+package valueobject_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+func mustMoney(t *testing.T, amount int64, currency string) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("NewMoney(%d, %q) returned an error: %v", amount, currency, err)
+	}
+	return money
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	sum, err := mustMoney(t, 1050, "eur").Add(mustMoney(t, 250, "EUR"))
+	if err != nil || sum.Amount() != 1300 || sum.String() != "13.00 EUR" {
+		t.Errorf("Add() = %v, %v", sum, err)
+	}
+
+	_, err = mustMoney(t, 100, "EUR").Add(mustMoney(t, 100, "USD"))
+	var domain *exception.Domain
+	if !errors.As(err, &domain) {
+		t.Errorf("Expected *Domain for mixed currencies, got %T", err)
+	}
+
+	var overflow *exception.Overflow
+	if _, err := mustMoney(t, math.MaxInt64, "EUR").Add(mustMoney(t, 1, "EUR")); !errors.As(err, &overflow) {
+		t.Errorf("Expected *Overflow on addition, got %T", err)
+	}
+	if _, err := mustMoney(t, math.MinInt64, "EUR").Subtract(mustMoney(t, 1, "EUR")); !errors.As(err, &overflow) {
+		t.Errorf("Expected *Overflow on subtraction, got %T", err)
+	}
+	if _, err := mustMoney(t, math.MaxInt64/2+1, "EUR").Multiply(2); !errors.As(err, &overflow) {
+		t.Errorf("Expected *Overflow on multiplication, got %T", err)
+	}
+}
+
+func TestMoneyRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		mode     valueobject.RoundingMode
+		expected int64
+	}{
+		{name: "HalfUp", amount: 25, mode: valueobject.RoundHalfUp, expected: 13},
+		{name: "HalfEven", amount: 25, mode: valueobject.RoundHalfEven, expected: 12},
+		{name: "Down", amount: 27, mode: valueobject.RoundDown, expected: 13},
+		{name: "Up", amount: 21, mode: valueobject.RoundUp, expected: 11},
+		{name: "NegativeHalfUp", amount: -25, mode: valueobject.RoundHalfUp, expected: -13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustMoney(t, tt.amount, "EUR").MultiplyRatio(1, 2, tt.mode)
+			if err != nil || got.Amount() != tt.expected {
+				t.Errorf("MultiplyRatio() = %d, %v; expected %d", got.Amount(), err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	shares, err := mustMoney(t, 100, "EUR").Split(3)
+	if err != nil {
+		t.Fatalf("Split() returned an error: %v", err)
+	}
+	if shares[0].Amount() != 34 || shares[1].Amount() != 33 || shares[2].Amount() != 33 {
+		t.Errorf("Split() = %v", shares)
+	}
+
+	shares, err = mustMoney(t, -5, "EUR").Allocate(70, 0, 30)
+	if err != nil {
+		t.Fatalf("Allocate() returned an error: %v", err)
+	}
+	if shares[0].Amount()+shares[1].Amount()+shares[2].Amount() != -5 || shares[1].Amount() != 0 {
+		t.Errorf("Allocate() = %v", shares)
+	}
+
+	if _, err := mustMoney(t, 100, "EUR").Allocate(0, 0); err == nil {
+		t.Error("Allocate() accepted ratios summing to zero")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	encoded, err := json.Marshal(mustMoney(t, 500, "JPY"))
+	if err != nil || string(encoded) != `{"amount":500,"currency":"JPY"}` {
+		t.Errorf("json.Marshal() = %s, %v", encoded, err)
+	}
+
+	var decoded valueobject.Money
+	if err := json.Unmarshal([]byte(`{"amount":-5,"currency":"kwd"}`), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() returned an error: %v", err)
+	}
+	if decoded.String() != "-0.005 KWD" {
+		t.Errorf("String() = %q", decoded.String())
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":1,"currency":"E1"}`), &decoded); err == nil {
+		t.Error("json.Unmarshal() accepted an invalid currency")
+	}
+}
@@ -0,0 +1,169 @@
+package valueobject_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/valueobject"
+)
+
+func TestEmail(t *testing.T) {
+	email, err := valueobject.NewEmail(" John.Doe@Example.COM ")
+	if err != nil {
+		t.Fatalf("NewEmail() returned an error: %v", err)
+	}
+	if email.String() != "John.Doe@example.com" || email.Domain() != "example.com" {
+		t.Errorf("NewEmail() normalized to %q", email.String())
+	}
+
+	_, err = valueobject.NewEmail("John <john@example.com>")
+	var invalid *exception.InvalidArgument
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected *InvalidArgument for an address with a display name, got %T", err)
+	}
+	if invalid.GetDetails()["field"] != "email" || invalid.GetDetailsMessage() != "invalid_email" {
+		t.Errorf("Unexpected details: %+v", invalid.GetDetails())
+	}
+}
+
+func TestURL(t *testing.T) {
+	if _, err := valueobject.NewURL("https://osirisgate.com/docs?page=1"); err != nil {
+		t.Errorf("NewURL() returned an error: %v", err)
+	}
+	if _, err := valueobject.NewURL("/relative/path"); err == nil {
+		t.Error("NewURL() accepted a relative URL")
+	}
+}
+
+func TestUUID(t *testing.T) {
+	generated := valueobject.NewUUID()
+	if generated.Version() != 4 {
+		t.Errorf("NewUUID() generated version %d", generated.Version())
+	}
+
+	parsed, err := valueobject.ParseUUID("5F0E9C3A-8B1D-4C2E-9F3A-1B2C3D4E5F60")
+	if err != nil {
+		t.Fatalf("ParseUUID() returned an error: %v", err)
+	}
+	if parsed.String() != "5f0e9c3a-8b1d-4c2e-9f3a-1b2c3d4e5f60" {
+		t.Errorf("ParseUUID() normalized to %q", parsed.String())
+	}
+
+	for _, raw := range []string{"", "not-a-uuid", "5f0e9c3a-8b1d-4c2e-9f3a-1b2c3d4e5f6z", "5f0e9c3a-8b1d-4c2e-9f3a--b2c3d4e5f60"} {
+		if _, err := valueobject.ParseUUID(raw); err == nil {
+			t.Errorf("ParseUUID(%q) did not return an error", raw)
+		}
+	}
+}
+
+func TestPhoneNumber(t *testing.T) {
+	phone, err := valueobject.NewPhoneNumber("00228 (90) 00-00-00")
+	if err != nil {
+		t.Fatalf("NewPhoneNumber() returned an error: %v", err)
+	}
+	if phone.String() != "+22890000000" {
+		t.Errorf("NewPhoneNumber() normalized to %q", phone.String())
+	}
+
+	for _, raw := range []string{"90000000", "+12", "+0123456789", "+228abc00000"} {
+		if _, err := valueobject.NewPhoneNumber(raw); err == nil {
+			t.Errorf("NewPhoneNumber(%q) did not return an error", raw)
+		}
+	}
+}
+
+func TestMarshaling(t *testing.T) {
+	type contact struct {
+		Email valueobject.Email `json:"email"`
+		ID    valueobject.UUID  `json:"id"`
+	}
+
+	var decoded contact
+	input := `{"email":"jane@example.com","id":"5f0e9c3a-8b1d-4c2e-9f3a-1b2c3d4e5f60"}`
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() returned an error: %v", err)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil || string(encoded) != input {
+		t.Errorf("json.Marshal() = %s, %v; expected %s", encoded, err, input)
+	}
+
+	err = json.Unmarshal([]byte(`{"email":"invalid"}`), &decoded)
+	var invalid *exception.InvalidArgument
+	if !errors.As(err, &invalid) {
+		t.Errorf("Expected *InvalidArgument when decoding an invalid e-mail, got %T", err)
+	}
+
+	type profile struct {
+		Email   valueobject.Email       `json:"email"`
+		ID      valueobject.UUID        `json:"id"`
+		Website valueobject.URL         `json:"website"`
+		Phone   valueobject.PhoneNumber `json:"phone"`
+	}
+	encoded, err = json.Marshal(profile{})
+	if err != nil || string(encoded) != `{"email":null,"id":null,"website":null,"phone":null}` {
+		t.Errorf("The zero values should be encoded as null, got %s, %v", encoded, err)
+	}
+	var zero profile
+	if err := json.Unmarshal(encoded, &zero); err != nil || zero != (profile{}) {
+		t.Errorf("The zero values should round-trip, got %+v, %v", zero, err)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var email valueobject.Email
+	if err := email.Scan([]byte("jane@example.com")); err != nil || email.String() != "jane@example.com" {
+		t.Errorf("Scan() = %q, %v", email.String(), err)
+	}
+
+	if err := email.Scan(nil); err != nil || !email.IsZero() {
+		t.Errorf("Scan(nil) = %q, %v; expected the zero value", email.String(), err)
+	}
+
+	if value, err := email.Value(); err != nil || value != nil {
+		t.Errorf("Value() of the zero value = %v, %v; expected NULL", value, err)
+	}
+
+	var unexpected *exception.UnexpectedValue
+	if err := email.Scan(42); !errors.As(err, &unexpected) {
+		t.Errorf("Expected *UnexpectedValue when scanning an integer, got %T", err)
+	}
+}
+
+func TestSecret(t *testing.T) {
+	secret := valueobject.NewSecret("hunter2")
+	if secret.Expose() != "hunter2" {
+		t.Fatalf("Expose() = %q", secret.Expose())
+	}
+
+	err := exception.NewInvalidArgument(map[string]interface{}{
+		"details": map[string]interface{}{"password": secret, "token": valueobject.Redact([]byte("t0ken"))},
+	})
+	formatted, _ := json.Marshal(err.Format())
+	logged := fmt.Sprintf("%v %+v %#v %s", err.GetErrorsForLog(), err.GetErrorsForLog(), secret, secret)
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("login failed", "secret", secret, "errors", err.GetErrors())
+
+	for name, output := range map[string]string{"json": string(formatted), "fmt": logged, "slog": buf.String()} {
+		if strings.Contains(output, "hunter2") || strings.Contains(output, "t0ken") || !strings.Contains(output, valueobject.RedactedText) {
+			t.Errorf("The %s output leaks the secret: %s", name, output)
+		}
+	}
+
+	var decoded struct {
+		Password valueobject.Secret `json:"password"`
+	}
+	if err := json.Unmarshal([]byte(`{"password":"s3cret"}`), &decoded); err != nil || decoded.Password.Expose() != "s3cret" {
+		t.Errorf("Unexpected decoding: %v %q", err, decoded.Password.Expose())
+	}
+	if err := decoded.Password.UnmarshalText([]byte("from-env")); err != nil || decoded.Password.Expose() != "from-env" {
+		t.Errorf("Unexpected text decoding: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package versioning_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+	"github.com/osirisgate/golang-core/versioning"
+)
+
+func TestResolve(t *testing.T) {
+	resolver := versioning.New(versioning.Config{Supported: []string{"1", "2"}, Vendor: "acme"})
+
+	tests := []struct {
+		name       string
+		path       string
+		header     http.Header
+		want       versioning.Version
+		wantStatus status.StatusCode
+		wantReason string
+	}{
+		{
+			name: "path",
+			path: "/v2/users",
+			want: versioning.Version{Value: "2", Source: versioning.SourcePath},
+		},
+		{
+			name:   "path before header",
+			path:   "/v1/users",
+			header: http.Header{"Api-Version": {"2"}},
+			want:   versioning.Version{Value: "1", Source: versioning.SourcePath},
+		},
+		{
+			name:   "header",
+			path:   "/users",
+			header: http.Header{"Api-Version": {"v2"}},
+			want:   versioning.Version{Value: "2", Source: versioning.SourceHeader},
+		},
+		{
+			name:   "vendor media type",
+			path:   "/users",
+			header: http.Header{"Accept": {"application/vnd.acme.v1+json"}},
+			want:   versioning.Version{Value: "1", Source: versioning.SourceMediaType},
+		},
+		{
+			name:   "version parameter",
+			path:   "/users",
+			header: http.Header{"Accept": {"text/html;q=0.5, application/json; version=2"}},
+			want:   versioning.Version{Value: "2", Source: versioning.SourceMediaType},
+		},
+		{
+			name:       "unsupported path version",
+			path:       "/v3/users",
+			wantStatus: status.BadRequest,
+			wantReason: versioning.ReasonUnsupportedVersion,
+		},
+		{
+			name:       "unsupported media type version",
+			path:       "/users",
+			header:     http.Header{"Accept": {"application/vnd.acme.v3+json"}},
+			wantStatus: status.NotAcceptable,
+			wantReason: versioning.ReasonUnsupportedVersion,
+		},
+		{
+			name:       "missing version",
+			path:       "/users",
+			wantStatus: status.BadRequest,
+			wantReason: versioning.ReasonMissingVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for name, values := range tt.header {
+				r.Header[name] = values
+			}
+
+			version, err := resolver.Resolve(r)
+			if tt.wantReason == "" {
+				if err != nil || version != tt.want {
+					t.Fatalf("Resolve() = %v, %v, want %v", version, err, tt.want)
+				}
+				return
+			}
+
+			var unsupported *exception.UnsupportedVersion
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("Resolve() error = %v, want *exception.UnsupportedVersion", err)
+			}
+			if unsupported.GetStatusCode() != tt.wantStatus.GetValue() {
+				t.Errorf("status code = %d, want %d", unsupported.GetStatusCode(), tt.wantStatus.GetValue())
+			}
+			details := unsupported.GetDetails()
+			if details["error"] != tt.wantReason || !reflect.DeepEqual(details["supported"], []string{"1", "2"}) {
+				t.Errorf("details = %v", details)
+			}
+		})
+	}
+}
+
+func TestResolveDefault(t *testing.T) {
+	resolver := versioning.New(versioning.Config{Supported: []string{"1", "2"}, Default: "2", Header: "-", IgnorePath: true})
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+	r.Header.Set("Api-Version", "1")
+	version, err := resolver.Resolve(r)
+	if err != nil || version != (versioning.Version{Value: "2", Source: versioning.SourceDefault}) {
+		t.Errorf("Resolve() = %v, %v, want the default version", version, err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	resolver := versioning.New(versioning.Config{Supported: []string{"1", "2"}})
+	handler := versioning.Middleware(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, _ := versioning.FromContext(r.Context())
+		_, _ = w.Write([]byte(version.Value))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/users", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "2" || rec.Header().Get("Api-Version") != "2" {
+		t.Errorf("supported version: code = %d, body = %q, header = %q", rec.Code, rec.Body.String(), rec.Header().Get("Api-Version"))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v9/users", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unsupported version: code = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestMiddlewareEnvelopes(t *testing.T) {
+	resolver := versioning.New(versioning.Config{Supported: []string{"1", "2"}, Envelopes: map[string]response.EnvelopeVersion{"v2": response.EnvelopeV2}})
+	handler := versioning.Middleware(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = response.WriteErrorContext(r.Context(), w, exception.NewNotFound(nil))
+	}))
+
+	for path, want := range map[string]bool{"/v1/users": false, "/v2/users": true} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var envelope map[string]interface{}
+		_ = json.Unmarshal(rec.Body.Bytes(), &envelope)
+		if _, nested := envelope["error"]; nested != want {
+			t.Errorf("%s: envelope = %v, want v2 = %t", path, envelope, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package webhook_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/signing"
+	"github.com/osirisgate/golang-core/webhook"
+)
+
+func reason(err error) string {
+	var unauthorized *exception.Unauthorized
+	if !errors.As(err, &unauthorized) {
+		return ""
+	}
+	return unauthorized.GetDetailsMessage()
+}
+
+func newVerifier(t *testing.T, config webhook.Config) *webhook.Verifier {
+	t.Helper()
+	verifier, err := webhook.New(config)
+	if err != nil {
+		t.Fatalf("New() returned %v", err)
+	}
+	return verifier
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	verifier := newVerifier(t, webhook.Config{
+		Secret:          []byte("s3cret"),
+		Header:          "X-Hub-Signature-256",
+		Prefix:          "sha256=",
+		TimestampHeader: "X-Timestamp",
+		Now:             func() time.Time { return now },
+	})
+	body := []byte(`{"event":"order.paid"}`)
+	signed := verifier.Sign(body, now.Add(-time.Minute))
+
+	with := func(name, value string) http.Header {
+		header := signed.Clone()
+		if value == "" {
+			header.Del(name)
+		} else {
+			header.Set(name, value)
+		}
+		return header
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   []byte
+		want   string
+	}{
+		{"valid", signed, body, ""},
+		{"tampered body", signed, []byte(`{"event":"order.refunded"}`), webhook.ReasonInvalidSignature},
+		{"missing signature", with("X-Hub-Signature-256", ""), body, webhook.ReasonMissingSignature},
+		{"missing prefix", with("X-Hub-Signature-256", strings.TrimPrefix(signed.Get("X-Hub-Signature-256"), "sha256=")), body, webhook.ReasonMalformedSignature},
+		{"not hex", with("X-Hub-Signature-256", "sha256=zz"), body, webhook.ReasonMalformedSignature},
+		{"missing timestamp", with("X-Timestamp", ""), body, webhook.ReasonMissingTimestamp},
+		{"malformed timestamp", with("X-Timestamp", "yesterday"), body, webhook.ReasonMalformedTimestamp},
+		{"replayed", verifier.Sign(body, now.Add(-time.Hour)), body, webhook.ReasonExpiredTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifier.Verify(tt.header, tt.body)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Verify() returned %v", err)
+				}
+				return
+			}
+			if got := reason(err); got != tt.want {
+				t.Errorf("Expected reason %q, got %q (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	verifier := newVerifier(t, webhook.Config{Secret: []byte("s3cret"), Encoding: webhook.Base64})
+	handler := webhook.Middleware(verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+
+	body := `{"event":"order.paid"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	for name, values := range verifier.Sign([]byte(body), time.Now()) {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Errorf("Expected the verified body to reach the handler, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body)))
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), webhook.ReasonMissingSignature) {
+		t.Errorf("Expected a 401 response, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	retired := signing.Key{ID: "v1", Secret: []byte("old")}
+	current := signing.Key{ID: "v2", Secret: []byte("new")}
+	sender := newVerifier(t, webhook.Config{Keys: []signing.Key{retired}})
+	receiver := newVerifier(t, webhook.Config{Keys: []signing.Key{current, retired}})
+	body := []byte(`{"id":1}`)
+
+	header := sender.Sign(body, time.Time{})
+	if header.Get(signing.DefaultKeyIDHeader) != "v1" {
+		t.Fatalf("Sign() headers = %v", header)
+	}
+	if err := receiver.Verify(header, body); err != nil {
+		t.Errorf("Verify() with the retired key = %v", err)
+	}
+
+	header.Set(signing.DefaultKeyIDHeader, "v0")
+	if err := receiver.Verify(header, body); reason(err) != webhook.ReasonUnknownKey {
+		t.Errorf("Verify() with an unknown key = %v", err)
+	}
+}
+
+func TestNewRejectsEmptySecret(t *testing.T) {
+	for name, config := range map[string]webhook.Config{
+		"no secret":        {},
+		"empty key secret": {Keys: []signing.Key{{ID: "v1", Secret: []byte("s3cret")}, {ID: "v2"}}},
+	} {
+		var configuration *exception.Configuration
+		verifier, err := webhook.New(config)
+		if verifier != nil || !errors.As(err, &configuration) || configuration.GetDetailsMessage() != "missing_secret" {
+			t.Errorf("%s: New() = %v, %v, expected a missing_secret exception", name, verifier, err)
+		}
+	}
+}
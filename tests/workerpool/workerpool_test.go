@@ -0,0 +1,110 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/workerpool"
+)
+
+func TestPool(t *testing.T) {
+	var mu sync.Mutex
+	var failures []error
+	var running, peak atomic.Int32
+
+	pool := workerpool.New(context.Background(), workerpool.Config{
+		Name:    "emails",
+		Workers: 2,
+		OnError: func(_ context.Context, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, err)
+		},
+	})
+
+	var completed atomic.Int32
+	for i := 0; i < 10; i++ {
+		i := i
+		err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				if old := peak.Load(); current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+			switch i {
+			case 3:
+				panic("boom")
+			case 5:
+				return errors.New("smtp unavailable")
+			}
+			completed.Add(1)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Submit(%d) returned %v", i, err)
+		}
+	}
+	pool.Close()
+
+	if completed.Load() != 8 || peak.Load() > 2 {
+		t.Errorf("Expected 8 completed tasks with at most 2 concurrent, got %d and %d", completed.Load(), peak.Load())
+	}
+	if len(failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", failures)
+	}
+	for _, err := range failures {
+		var coreErr exception.CoreInterface
+		if !errors.As(err, &coreErr) || coreErr.GetStatusCode() != 500 {
+			t.Errorf("Expected a 500 exception, got %T: %v", err, err)
+		}
+		if runtimeErr, ok := err.(*exception.Runtime); ok && (runtimeErr.GetDetailsMessage() != "task_panicked" || runtimeErr.GetDetails()["pool"] != "emails") {
+			t.Errorf("Unexpected panic details: %v", runtimeErr.GetDetails())
+		}
+	}
+
+	err := pool.Submit(context.Background(), func(context.Context) error { return nil })
+	var unavailable *exception.ServiceUnavailable
+	if !errors.As(err, &unavailable) || unavailable.GetDetailsMessage() != "pool_closed" {
+		t.Errorf("Expected pool_closed after Close, got %v", err)
+	}
+}
+
+func TestTrySubmitAndCancel(t *testing.T) {
+	release := make(chan struct{})
+	pool := workerpool.New(context.Background(), workerpool.Config{Workers: 1, QueueSize: 1})
+	block := func(ctx context.Context) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}
+
+	started := make(chan struct{})
+	_ = pool.Submit(context.Background(), func(ctx context.Context) error { close(started); return block(ctx) })
+	<-started
+	if err := pool.TrySubmit(block); err != nil {
+		t.Fatalf("The queue should accept one task: %v", err)
+	}
+	err := pool.TrySubmit(block)
+	if !errors.As(err, new(*exception.ServiceUnavailable)) || !exception.IsRetryable(err) {
+		t.Errorf("Expected a retryable pool_full error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pool.Submit(ctx, block); err == nil {
+		t.Error("Submit should fail once its context is canceled")
+	}
+
+	pool.Cancel()
+	if pool.TrySubmit(block) == nil {
+		t.Error("A canceled pool should reject tasks")
+	}
+}
@@ -0,0 +1,66 @@
+// Package tracing provides distributed tracing propagated with the W3C Trace
+// Context headers. This file defines the recording of exceptions on spans.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// RecordException records an error on a span as an "exception" event, with
+// the OpenTelemetry "exception.type", "exception.message" and
+// "exception.stacktrace" attributes and, for exceptions, their
+// "error.status_code", "error.code" (the details "error" code) and
+// "error.retryable" attributes. Following the OpenTelemetry conventions for
+// servers, the span status is set to Error for server errors (5xx) only.
+//
+// Parameters:
+//
+//	span: The span to annotate.
+//	err: The error to record. A nil error records nothing.
+func RecordException(span Span, err error) {
+	if exception.IsNil(err) {
+		return
+	}
+
+	var coreErr exception.CoreInterface
+	if !errors.As(err, &coreErr) || exception.IsNil(coreErr) {
+		span.RecordError(err, map[string]interface{}{"exception.type": fmt.Sprintf("%T", err)})
+		span.SetStatus(Error, err.Error())
+		return
+	}
+
+	attributes := map[string]interface{}{
+		"exception.type":       fmt.Sprintf("%T", coreErr),
+		"exception.stacktrace": coreErr.GetStackTrace(),
+		"error.status_code":    coreErr.GetStatusCode(),
+		"error.retryable":      exception.IsRetryable(err),
+	}
+	if code := coreErr.GetDetailsMessage(); code != "" {
+		attributes["error.code"] = code
+	}
+	span.RecordError(coreErr, attributes)
+	if coreErr.GetStatusCode() >= 500 {
+		span.SetStatus(Error, coreErr.Error())
+	}
+}
+
+// Record records an error on the span carried by ctx (see RecordException).
+// It does nothing when ctx carries no span. The response package calls it
+// for every error envelope it writes with a context.
+//
+// Parameters:
+//
+//	ctx: The context carrying the span.
+//	err: The error to record.
+//
+// Returns:
+//
+//	The same error, for convenient use in return statements.
+func Record(ctx context.Context, err error) error {
+	RecordException(SpanFromContext(ctx), err)
+	return err
+}
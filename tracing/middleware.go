@@ -0,0 +1,90 @@
+// Package tracing provides distributed tracing propagated with the W3C Trace
+// Context headers. This file defines the HTTP middleware tracing requests.
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/osirisgate/golang-core/ctxutil"
+)
+
+// Middleware starts a span per request, child of the span of the caller
+// when the request carries a valid `traceparent` header. The span is stored
+// in the request context along with its trace and span IDs (see
+// `ctxutil.WithTraceID`), so that they are added to the logs, exceptions and
+// envelope meta blocks built from that context. The span records the
+// request method and path and the response status, and is marked as failed
+// for server errors; the exceptions written by `response.WriteErrorContext`
+// are recorded on it.
+//
+// Parameters:
+//
+//	tracer: The tracer starting the spans.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the tracing.
+func Middleware(tracer Tracer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if parent, ok := ParseTraceparent(r.Header.Get(TraceparentHeader)); ok {
+				ctx = ContextWithRemoteSpanContext(ctx, parent)
+			}
+
+			ctx, span := tracer.Start(ctx, "HTTP "+r.Method)
+			defer span.End()
+			sc := span.SpanContext()
+			ctx = ctxutil.WithSpanID(ctxutil.WithTraceID(ctx, sc.TraceID.String()), sc.SpanID.String())
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			span.SetAttributes(map[string]interface{}{
+				"http.request.method":       r.Method,
+				"url.path":                  r.URL.Path,
+				"http.response.status_code": rec.status,
+			})
+			if rec.status >= 500 {
+				span.SetStatus(Error, http.StatusText(rec.status))
+			}
+		})
+	}
+}
+
+// statusRecorder records the status code of a response. The middleware and
+// httpadapter packages have their own recorders, as they depend on this
+// package through response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records and sends the status code.
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status, r.wroteHeader = status, true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write sends the body, with an implicit 200 status.
+func (r *statusRecorder) Write(body []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(body)
+}
+
+// Flush sends the buffered body, with an implicit 200 status, so that the
+// streaming handlers asserting `http.Flusher` keep working under tracing.
+// It flushes through the `http.ResponseController` of the wrapped writer,
+// which may be a recorder itself.
+func (r *statusRecorder) Flush() {
+	r.wroteHeader = true
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Unwrap returns the wrapped writer, for `http.ResponseController`.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,118 @@
+// Package tracing provides distributed tracing for services built with the
+// core. Spans are propagated with the W3C Trace Context `traceparent` header
+// used by OpenTelemetry, their trace and span IDs are stored in the context
+// utilities (so that they appear in logs, exceptions and envelope meta
+// blocks), and the exceptions written by the response package are recorded
+// on the span of the request. The Tracer and Span interfaces mirror the
+// OpenTelemetry API, so that an adapter can plug an OpenTelemetry SDK in.
+// This file defines span contexts and their propagation.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// TraceparentHeader is the W3C Trace Context header propagating spans.
+const TraceparentHeader = "traceparent"
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the ID in lower-case hexadecimal.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the ID is not all zeros.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the ID in lower-case hexadecimal.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the ID is not all zeros.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanContext is the propagated part of a span.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool // Whether the trace is recorded by the callers.
+}
+
+// IsValid reports whether both IDs are valid.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats the span context as a `traceparent` header value,
+// e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parses a `traceparent` header value.
+//
+// Parameters:
+//
+//	value: The header value.
+//
+// Returns:
+//
+//	The span context of the caller, and false when the value is missing or
+//	malformed, in which case a new trace should be started.
+func ParseTraceparent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	var flags [1]byte
+	if !decodeHex(sc.TraceID[:], parts[1]) || !decodeHex(sc.SpanID[:], parts[2]) || !decodeHex(flags[:], parts[3]) {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&0x01 == 1
+	return sc, sc.IsValid()
+}
+
+// decodeHex decodes a lower-case hexadecimal string filling dst exactly.
+func decodeHex(dst []byte, value string) bool {
+	if len(value) != 2*len(dst) || strings.ToLower(value) != value {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(value))
+	return err == nil
+}
+
+// newTraceID generates a random trace ID.
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// newSpanID generates a random span ID.
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,214 @@
+// Package tracing provides distributed tracing propagated with the W3C Trace
+// Context headers. This file defines the Tracer and Span contracts and the
+// built-in tracer.
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StatusCode is the status of a span, as in OpenTelemetry.
+type StatusCode int
+
+const (
+	Unset StatusCode = iota // Unset is the default status of a span.
+	OK                      // OK marks a span as explicitly successful.
+	Error                   // Error marks a span as failed.
+)
+
+// Span is an operation being traced. Its methods mirror those of the
+// OpenTelemetry `trace.Span`.
+type Span interface {
+	// SpanContext returns the propagated identifiers of the span.
+	SpanContext() SpanContext
+
+	// SetAttributes adds attributes to the span.
+	SetAttributes(attributes map[string]interface{})
+
+	// RecordError adds an "exception" event describing err to the span.
+	RecordError(err error, attributes map[string]interface{})
+
+	// SetStatus sets the status of the span.
+	SetStatus(code StatusCode, description string)
+
+	// End completes the span.
+	End()
+}
+
+// Tracer starts spans. The parent of a new span is the span carried by ctx
+// (see ContextWithSpan) or, failing that, the remote span context carried
+// by ctx (see ContextWithRemoteSpanContext).
+type Tracer interface {
+	// Start starts a span and returns a copy of ctx carrying it.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// contextKey is the unexported type of the keys defined by this package.
+type contextKey int
+
+const (
+	spanKey   contextKey = iota // The key of the current span.
+	remoteKey                   // The key of the span context of a remote caller.
+)
+
+// ContextWithSpan returns a copy of ctx carrying a span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey, span)
+}
+
+// SpanFromContext returns the span carried by ctx, or a span doing nothing
+// when there is none, so that callers need not check.
+func SpanFromContext(ctx context.Context) Span {
+	if ctx != nil {
+		if span, ok := ctx.Value(spanKey).(Span); ok {
+			return span
+		}
+	}
+	return noopSpan{}
+}
+
+// ContextWithRemoteSpanContext returns a copy of ctx carrying the span
+// context received from a remote caller, e.g. parsed from its `traceparent`
+// header.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey, sc)
+}
+
+// parentOf returns the span context of the parent of a new span.
+func parentOf(ctx context.Context) (SpanContext, bool) {
+	if span, ok := ctx.Value(spanKey).(Span); ok {
+		return span.SpanContext(), true
+	}
+	sc, ok := ctx.Value(remoteKey).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// noopSpan is a Span doing nothing.
+type noopSpan struct{}
+
+func (noopSpan) SpanContext() SpanContext                  { return SpanContext{} }
+func (noopSpan) SetAttributes(map[string]interface{})      {}
+func (noopSpan) RecordError(error, map[string]interface{}) {}
+func (noopSpan) SetStatus(StatusCode, string)              {}
+func (noopSpan) End()                                      {}
+
+// Event is an event recorded on a span.
+type Event struct {
+	Name       string
+	Time       time.Time
+	Attributes map[string]interface{}
+}
+
+// Data is the record of a completed span, passed to an Exporter.
+type Data struct {
+	Name              string
+	SpanContext       SpanContext
+	Parent            SpanID // Zero for root spans.
+	Start             time.Time
+	End               time.Time
+	Attributes        map[string]interface{}
+	Events            []Event
+	Status            StatusCode
+	StatusDescription string
+}
+
+// Exporter receives the completed spans of the built-in tracer, e.g. to log
+// them or to forward them to a collector. It must be safe for concurrent use.
+type Exporter func(data Data)
+
+// New creates the built-in Tracer, which generates W3C compatible IDs and
+// exports every completed span. Use an OpenTelemetry adapter instead to
+// benefit from sampling, batching and OTLP exporters.
+//
+// Parameters:
+//
+//	export: The Exporter receiving the completed spans. Nil discards them.
+//
+// Returns:
+//
+//	The Tracer.
+func New(export Exporter) Tracer {
+	if export == nil {
+		export = func(Data) {}
+	}
+	return tracer{export: export}
+}
+
+// tracer is the built-in Tracer.
+type tracer struct {
+	export Exporter
+}
+
+// Start starts a span, child of the span or remote span context of ctx.
+func (t tracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &span{export: t.export, data: Data{
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: map[string]interface{}{},
+	}}
+	if parent, ok := parentOf(ctx); ok {
+		s.data.SpanContext = SpanContext{TraceID: parent.TraceID, SpanID: newSpanID(), Sampled: parent.Sampled}
+		s.data.Parent = parent.SpanID
+	} else {
+		s.data.SpanContext = SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: true}
+	}
+	return ContextWithSpan(ctx, s), s
+}
+
+// span is a Span of the built-in tracer.
+type span struct {
+	export Exporter
+
+	mu    sync.Mutex
+	data  Data
+	ended bool
+}
+
+func (s *span) SpanContext() SpanContext {
+	return s.data.SpanContext
+}
+
+func (s *span) SetAttributes(attributes map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, value := range attributes {
+		s.data.Attributes[key] = value
+	}
+}
+
+func (s *span) RecordError(err error, attributes map[string]interface{}) {
+	if err == nil {
+		return
+	}
+	event := Event{Name: "exception", Time: time.Now(), Attributes: map[string]interface{}{"exception.message": err.Error()}}
+	for key, value := range attributes {
+		event.Attributes[key] = value
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Events = append(s.data.Events, event)
+}
+
+func (s *span) SetStatus(code StatusCode, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Status = code
+	s.data.StatusDescription = description
+}
+
+func (s *span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	s.export(data)
+}
@@ -0,0 +1,98 @@
+// Package uow defines the unit of work contract of the persistence adapters
+// and the Do helper running a function within a transaction scope. Failures
+// that roll the transaction back, including panics, are reported as
+// exceptions.
+package uow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// UnitOfWork opens transactions. The transaction is carried by the context
+// returned by Begin, from which the repositories of the adapter retrieve it.
+type UnitOfWork interface {
+	// Begin starts a transaction and returns a context carrying it.
+	Begin(ctx context.Context) (context.Context, error)
+
+	// Commit commits the transaction carried by ctx.
+	Commit(ctx context.Context) error
+
+	// Rollback rolls back the transaction carried by ctx.
+	Rollback(ctx context.Context) error
+}
+
+// Do runs fn within a transaction of u. The transaction is committed when fn
+// returns nil, and rolled back when fn returns an error or panics.
+//
+// Parameters:
+//
+//	ctx: The parent context.
+//	u: The unit of work opening the transaction.
+//	fn: The function to run, receiving the context carrying the transaction.
+//
+// Returns:
+//
+//	Nil once committed. Otherwise an error whose chain contains an
+//	`exception.CoreInterface`: the error of fn (normalized with
+//	`exception.Normalize`), an `exception.Runtime` when fn panicked, or the
+//	error of Begin or Commit. When the rollback itself fails, both the cause
+//	and the rollback error are grouped into an `exception.Aggregate`.
+func Do(ctx context.Context, u UnitOfWork, fn func(ctx context.Context) error) (err error) {
+	txCtx, err := u.Begin(ctx)
+	if err != nil {
+		return exception.Normalize(err)
+	}
+
+	ended := false // Whether the transaction ended through Commit.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = exception.NewRuntime(map[string]interface{}{
+				"message": "The transaction was rolled back after a panic.",
+				"details": map[string]interface{}{
+					"panic": fmt.Sprint(recovered),
+					"error": "transaction_panicked",
+				},
+			})
+		}
+		if err != nil && !ended {
+			err = rollback(txCtx, u, exception.Normalize(err))
+		}
+	}()
+
+	if err = fn(txCtx); err != nil {
+		return err
+	}
+	// A failed commit ends the transaction as well: there is nothing left to
+	// roll back.
+	ended = true
+	return exception.Normalize(u.Commit(txCtx))
+}
+
+// rollback rolls the transaction back after a failure.
+func rollback(ctx context.Context, u UnitOfWork, cause error) error {
+	rollbackErr := u.Rollback(ctx)
+	if rollbackErr == nil {
+		return cause
+	}
+
+	return exception.NewAggregate(map[string]interface{}{
+		"message": "The transaction failed and could not be rolled back.",
+		"details": map[string]interface{}{
+			"error": "rollback_failed",
+		},
+	}, cause, exception.Normalize(rollbackErr))
+}
+
+// Transactor adapts a UnitOfWork to the `WithinTransaction` contract of
+// `cqrs.Transactor`, so that command handlers run within a unit of work.
+type Transactor struct {
+	UnitOfWork UnitOfWork // The unit of work opening the transactions.
+}
+
+// WithinTransaction runs fn within a transaction. See Do.
+func (t Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return Do(ctx, t.UnitOfWork, fn)
+}
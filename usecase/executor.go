@@ -0,0 +1,97 @@
+// Package usecase provides the clean architecture scaffolding of the core.
+// This file defines the Executor wrapping use cases with cross-cutting
+// behavior.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/validator"
+)
+
+// Config holds the settings of an Executor.
+type Config struct {
+	// Name identifies the use case in panics and timings (e.g., "RegisterUser").
+	Name string
+
+	// Validator validates the requests with `validator.Validator.ValidateInput`
+	// before executing the use case. Nil uses the default Validator.
+	Validator *validator.Validator
+
+	// SkipValidation disables the validation of the requests.
+	SkipValidation bool
+
+	// OnComplete, when set, is called after each execution with its duration
+	// and its error, normalized with `exception.Normalize` (nil on success).
+	OnComplete func(name string, duration time.Duration, err error)
+}
+
+// Executor is a UseCase decorating another one: it validates the request,
+// recovers panics into `exception.Runtime` errors, normalizes the errors into
+// exceptions and reports the duration of each execution.
+type Executor[Req any, Resp any] struct {
+	useCase UseCase[Req, Resp]
+	config  Config
+}
+
+// NewExecutor wraps a use case into an Executor.
+//
+// Parameters:
+//
+//	useCase: The use case to wrap.
+//	config: The executor settings.
+//
+// Returns:
+//
+//	A pointer to a new Executor, itself a UseCase.
+func NewExecutor[Req any, Resp any](useCase UseCase[Req, Resp], config Config) *Executor[Req, Resp] {
+	return &Executor[Req, Resp]{useCase: useCase, config: config}
+}
+
+// Execute validates the request and runs the wrapped use case.
+//
+// Parameters:
+//
+//	ctx: The context passed to the use case.
+//	req: The request of the use case.
+//
+// Returns:
+//
+//	The response of the use case, or an error whose chain contains an
+//	`exception.CoreInterface`: the validation error, the use case error or
+//	an `exception.Runtime` when the use case panicked.
+func (e *Executor[Req, Resp]) Execute(ctx context.Context, req Req) (resp Resp, err error) {
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			var zero Resp
+			resp, err = zero, exception.NewRuntime(map[string]interface{}{
+				"message": "The use case panicked.",
+				"details": map[string]interface{}{
+					"use_case": e.config.Name,
+					"panic":    fmt.Sprint(recovered),
+					"error":    "use_case_panicked",
+				},
+			})
+		}
+		err = exception.Normalize(err)
+		if e.config.OnComplete != nil {
+			e.config.OnComplete(e.config.Name, time.Since(start), err)
+		}
+	}()
+
+	if !e.config.SkipValidation {
+		validate := validator.ValidateInput
+		if e.config.Validator != nil {
+			validate = e.config.Validator.ValidateInput
+		}
+		if err := validate(req); err != nil {
+			var zero Resp
+			return zero, err
+		}
+	}
+	return e.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,78 @@
+// Package usecase provides the clean architecture scaffolding of the core: a
+// generic UseCase contract for application services, a Presenter turning
+// their outcome into the response envelope, and an Executor wrapping use
+// cases with validation, panic recovery and timing.
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// UseCase is an application service handling a request of type Req and
+// producing a response of type Resp.
+type UseCase[Req any, Resp any] interface {
+	// Execute runs the use case. Failures should be reported as exceptions.
+	Execute(ctx context.Context, req Req) (Resp, error)
+}
+
+// Func adapts a function to the UseCase interface.
+type Func[Req any, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// Execute calls f.
+func (f Func[Req, Resp]) Execute(ctx context.Context, req Req) (Resp, error) {
+	return f(ctx, req)
+}
+
+// Presenter converts the outcome of a use case into the response envelope.
+type Presenter[Resp any] interface {
+	// Present converts a successful response.
+	Present(ctx context.Context, resp Resp) map[string]interface{}
+
+	// PresentError converts a failure.
+	PresentError(ctx context.Context, err exception.CoreInterface) map[string]interface{}
+}
+
+// EnvelopePresenter is the default Presenter, producing the standard
+// envelopes of `response.SuccessContext` and `response.ErrorContext`. The
+// zero value is ready to use.
+type EnvelopePresenter[Resp any] struct{}
+
+// Present returns the success envelope holding resp as its data.
+func (EnvelopePresenter[Resp]) Present(ctx context.Context, resp Resp) map[string]interface{} {
+	return response.SuccessContext(ctx, resp)
+}
+
+// PresentError returns the error envelope of err.
+func (EnvelopePresenter[Resp]) PresentError(ctx context.Context, err exception.CoreInterface) map[string]interface{} {
+	return response.ErrorContext(ctx, err)
+}
+
+// Present executes a use case and converts its outcome with a presenter.
+// Errors that are not exceptions are normalized with `exception.Normalize`
+// before being presented.
+//
+// Parameters:
+//
+//	ctx: The context passed to the use case and the presenter.
+//	useCase: The use case to execute.
+//	presenter: The presenter converting the outcome.
+//	req: The request of the use case.
+//
+// Returns:
+//
+//	The response envelope and the status code of the outcome: 200 on
+//	success, the status code of the exception on failure.
+func Present[Req any, Resp any](ctx context.Context, useCase UseCase[Req, Resp], presenter Presenter[Resp], req Req) (map[string]interface{}, int) {
+	resp, err := useCase.Execute(ctx, req)
+	if exception.IsNil(err) {
+		return presenter.Present(ctx, resp), 200
+	}
+
+	var coreErr exception.CoreInterface
+	errors.As(exception.Normalize(err), &coreErr)
+	return presenter.PresentError(ctx, coreErr), coreErr.GetStatusCode()
+}
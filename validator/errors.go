@@ -0,0 +1,118 @@
+// Package validator provides input validation producing `exception.Validation`
+// errors. This file defines the collector used to accumulate per-field
+// violations and convert them into a single exception.
+package validator
+
+import (
+	"reflect"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// DefaultMessage is the primary message of the Validation exceptions
+// produced by this package.
+const DefaultMessage = "Validation failed."
+
+// Violation describes a single rule violated by a field.
+type Violation struct {
+	Rule    string // The identifier of the violated rule (e.g., "required").
+	Message string // The human-readable description of the violation.
+}
+
+// Errors accumulates violations keyed by field path (e.g., "email",
+// "address.city", "items[0].name"). The zero value is ready to use.
+type Errors struct {
+	fields map[string][]Violation
+}
+
+// Add records a violation for the given field.
+func (e *Errors) Add(field string, rule string, message string) {
+	if e.fields == nil {
+		e.fields = map[string][]Violation{}
+	}
+	e.fields[field] = append(e.fields[field], Violation{Rule: rule, Message: message})
+}
+
+// Check applies the rules to a value and records every violation under the
+// given field. It returns true when the value satisfies all the rules.
+//
+// Parameters:
+//
+//	field: The field path under which violations are reported.
+//	value: The value to validate.
+//	rules: The rules to apply, in order.
+//
+// Returns:
+//
+//	True if no rule was violated, false otherwise.
+func (e *Errors) Check(field string, value interface{}, rules ...Rule) bool {
+	return e.check(field, reflect.ValueOf(value), rules)
+}
+
+// check applies the rules to a reflected value. Nil pointers are only
+// checked by the "required" rule; other rules validate the pointed-to value.
+func (e *Errors) check(field string, value reflect.Value, rules []Rule) bool {
+	valid := true
+	target, present := indirect(value)
+	for _, rule := range rules {
+		checked := target
+		if rule.Name() == "required" {
+			checked = value
+		} else if !present {
+			continue // A nil optional value has nothing to validate.
+		}
+		if message, ok := rule.Check(checked); !ok {
+			e.Add(field, rule.Name(), message)
+			valid = false
+		}
+	}
+	return valid
+}
+
+// indirect dereferences pointers and interfaces, reporting false when a nil
+// one is encountered along the way.
+func indirect(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, value.IsValid()
+}
+
+// HasErrors reports whether at least one violation was recorded.
+func (e *Errors) HasErrors() bool {
+	return len(e.fields) > 0
+}
+
+// Fields returns the per-field error map, shaped as it appears under the
+// "errors" key of the exception's `Format()` output:
+//
+//	{"email": [{"rule": "email", "message": "Must be a valid e-mail address."}]}
+func (e *Errors) Fields() map[string]interface{} {
+	fields := make(map[string]interface{}, len(e.fields))
+	for field, violations := range e.fields {
+		entries := make([]map[string]interface{}, 0, len(violations))
+		for _, violation := range violations {
+			entries = append(entries, map[string]interface{}{
+				"rule":    violation.Rule,
+				"message": violation.Message,
+			})
+		}
+		fields[field] = entries
+	}
+	return fields
+}
+
+// Err returns nil when no violation was recorded, or an `exception.Validation`
+// holding the per-field error map under its "errors" key otherwise.
+func (e *Errors) Err() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return exception.NewValidation(map[string]interface{}{
+		"message": DefaultMessage,
+		"errors":  e.Fields(),
+	})
+}
@@ -0,0 +1,44 @@
+// Package validator provides input validation producing `exception.Validation`
+// errors. This file defines the validation of arbitrary inputs such as
+// commands, queries and use case requests.
+package validator
+
+import "reflect"
+
+// Validatable is implemented by values validating themselves, in addition to
+// or instead of `validate` struct tags.
+type Validatable interface {
+	// Validate returns nil when the value is valid, or an exception
+	// (typically an `exception.Validation`) otherwise.
+	Validate() error
+}
+
+// ValidateInput validates an input with the default Validator.
+// See `Validator.ValidateInput` for details.
+func ValidateInput(value interface{}) error {
+	return defaultValidator.ValidateInput(value)
+}
+
+// ValidateInput validates an input of any type. Structs, or non-nil pointers
+// to structs, are validated according to their `validate` tags; values
+// implementing Validatable are then validated by their Validate method.
+// Unlike `Validator.Validate`, other values are accepted as they are.
+//
+// Parameters:
+//
+//	value: The input to validate.
+//
+// Returns:
+//
+//	Nil when the input is valid, or the first failing validation error.
+func (v *Validator) ValidateInput(value interface{}) error {
+	if target, present := indirect(reflect.ValueOf(value)); present && target.Kind() == reflect.Struct {
+		if err := v.Validate(value); err != nil {
+			return err
+		}
+	}
+	if validatable, ok := value.(Validatable); ok {
+		return validatable.Validate()
+	}
+	return nil
+}
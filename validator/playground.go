@@ -0,0 +1,136 @@
+// Package validator provides input validation producing `exception.Validation`
+// errors. This file defines the conversion of the errors of
+// go-playground/validator into the same exceptions.
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/osirisgate/golang-core/i18n"
+)
+
+// RuleKeyPrefix prefixes the tag of a violated rule in the translation
+// catalogs (e.g., "validation.required"), the template parameters being
+// "{field}" and "{param}".
+const RuleKeyPrefix = "validation."
+
+// PlaygroundFieldError holds the methods of `validator.FieldError`, from
+// github.com/go-playground/validator, read by FromPlayground.
+type PlaygroundFieldError interface {
+	Tag() string        // The violated rule (e.g., "required").
+	Namespace() string  // The path of the field from the validated struct (e.g., "User.address.city").
+	Field() string      // The name of the field.
+	Param() string      // The parameter of the rule (e.g., "3" for `min=3`).
+	Value() interface{} // The invalid value.
+}
+
+// JSONTagName names the fields after their `json` tag, like the Validator of
+// this package. Registered with `validate.RegisterTagNameFunc`, it makes the
+// fields of go-playground/validator errors, and so of FromPlayground, match
+// those of the JSON documents:
+//
+//	validate := playground.New()
+//	validate.RegisterTagNameFunc(validator.JSONTagName)
+func JSONTagName(field reflect.StructField) string {
+	return fieldName(field)
+}
+
+// FromPlayground converts the `validator.ValidationErrors` of
+// go-playground/validator found in the chain of err into an
+// `exception.Validation`, with the per-field error map of `Errors`: the
+// fields are keyed by their path without the name of the validated struct
+// (e.g., "address.city"), and the messages are those of the rules of this
+// package, translated when ctx carries a Localizer having a message under
+// RuleKeyPrefix followed by the tag of the rule.
+//
+// Parameters:
+//
+//	ctx: The context carrying the Localizer, if any.
+//	err: The error returned by `validate.Struct`.
+//
+// Returns:
+//
+//	The exception, or err unchanged when it is nil or holds no
+//	`validator.ValidationErrors`.
+func FromPlayground(ctx context.Context, err error) error {
+	fieldErrs, ok := playgroundErrors(err)
+	if !ok {
+		return err
+	}
+
+	errs := &Errors{}
+	for _, fieldErr := range fieldErrs {
+		path := fieldErr.Field()
+		if _, rest, found := strings.Cut(fieldErr.Namespace(), "."); found {
+			path = rest
+		}
+		errs.Add(path, fieldErr.Tag(), playgroundMessage(ctx, path, fieldErr))
+	}
+	if !errs.HasErrors() {
+		return err
+	}
+	return errs.Err()
+}
+
+// playgroundErrors finds the `validator.ValidationErrors`, a slice of
+// `validator.FieldError`, in the chain of err.
+func playgroundErrors(err error) ([]PlaygroundFieldError, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		value := reflect.ValueOf(err)
+		if value.Kind() != reflect.Slice {
+			continue
+		}
+		fieldErrs := make([]PlaygroundFieldError, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			fieldErr, ok := value.Index(i).Interface().(PlaygroundFieldError)
+			if !ok {
+				return nil, false
+			}
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+		return fieldErrs, true
+	}
+	return nil, false
+}
+
+// playgroundMessage returns the message of a violated rule: its translation,
+// or the message of the matching rule of this package.
+func playgroundMessage(ctx context.Context, path string, fieldErr PlaygroundFieldError) string {
+	tag, param := fieldErr.Tag(), fieldErr.Param()
+	if localizer, ok := i18n.FromContext(ctx); ok {
+		params := map[string]interface{}{"field": path, "param": param}
+		if message, found := localizer.Bundle.Translate(localizer.Locale, RuleKeyPrefix+tag, params); found {
+			return message
+		}
+	}
+
+	_, isLength, _ := measure(reflect.ValueOf(fieldErr.Value()))
+	switch {
+	case tag == "required":
+		return "This field is required."
+	case tag == "email":
+		return "Must be a valid e-mail address."
+	case tag == "oneof":
+		return fmt.Sprintf("Must be one of: %s.", strings.Join(strings.Fields(param), ", "))
+	case (tag == "min" || tag == "gte") && isLength:
+		return fmt.Sprintf("Must contain at least %s items or characters.", param)
+	case tag == "min" || tag == "gte":
+		return fmt.Sprintf("Must be greater than or equal to %s.", param)
+	case (tag == "max" || tag == "lte") && isLength:
+		return fmt.Sprintf("Must contain at most %s items or characters.", param)
+	case tag == "max" || tag == "lte":
+		return fmt.Sprintf("Must be less than or equal to %s.", param)
+	case tag == "len" && isLength:
+		return fmt.Sprintf("Must contain exactly %s items or characters.", param)
+	case tag == "len":
+		return fmt.Sprintf("Must be equal to %s.", param)
+	case param != "":
+		return fmt.Sprintf("Must satisfy the %s=%s rule.", tag, param)
+	default:
+		return fmt.Sprintf("Must satisfy the %s rule.", tag)
+	}
+}
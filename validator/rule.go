@@ -0,0 +1,159 @@
+// Package validator provides input validation producing `exception.Validation`
+// errors. Values can be validated either with rule objects applied explicitly
+// to individual fields, or declaratively through `validate` struct tags.
+// This file defines the Rule contract and the built-in rules.
+package validator
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule is a reusable validation rule. Check receives the value under
+// validation and returns a human-readable message with ok set to false
+// when the value violates the rule.
+type Rule interface {
+	// Name returns the identifier of the rule (e.g., "required", "min"),
+	// reported as "rule" in the per-field error map.
+	Name() string
+
+	// Check validates the given value.
+	Check(value reflect.Value) (message string, ok bool)
+}
+
+// ruleFunc adapts a plain function into a Rule.
+type ruleFunc struct {
+	name  string
+	check func(value reflect.Value) (string, bool)
+}
+
+func (r ruleFunc) Name() string { return r.name }
+
+func (r ruleFunc) Check(value reflect.Value) (string, bool) { return r.check(value) }
+
+// NewRule creates a Rule from a name and a check function, for application
+// specific rules that do not warrant a dedicated type.
+//
+// Parameters:
+//
+//	name: The identifier of the rule, reported as "rule" in field errors.
+//	check: The function validating a value, returning a message and false on failure.
+//
+// Returns:
+//
+//	A Rule wrapping the function.
+func NewRule(name string, check func(value reflect.Value) (string, bool)) Rule {
+	return ruleFunc{name: name, check: check}
+}
+
+// Required returns a rule rejecting nil, empty and zero values.
+func Required() Rule {
+	return NewRule("required", func(value reflect.Value) (string, bool) {
+		if isEmpty(value) {
+			return "This field is required.", false
+		}
+		return "", true
+	})
+}
+
+// Min returns a rule requiring a number to be at least limit, or a string,
+// slice or map to hold at least limit characters or elements.
+func Min(limit float64) Rule {
+	return NewRule("min", func(value reflect.Value) (string, bool) {
+		measured, isLength, ok := measure(value)
+		if !ok || measured >= limit {
+			return "", true
+		}
+		if isLength {
+			return fmt.Sprintf("Must contain at least %s items or characters.", formatNumber(limit)), false
+		}
+		return fmt.Sprintf("Must be greater than or equal to %s.", formatNumber(limit)), false
+	})
+}
+
+// Max returns a rule requiring a number to be at most limit, or a string,
+// slice or map to hold at most limit characters or elements.
+func Max(limit float64) Rule {
+	return NewRule("max", func(value reflect.Value) (string, bool) {
+		measured, isLength, ok := measure(value)
+		if !ok || measured <= limit {
+			return "", true
+		}
+		if isLength {
+			return fmt.Sprintf("Must contain at most %s items or characters.", formatNumber(limit)), false
+		}
+		return fmt.Sprintf("Must be less than or equal to %s.", formatNumber(limit)), false
+	})
+}
+
+// Email returns a rule requiring a string to be a bare e-mail address
+// (without a display name).
+func Email() Rule {
+	return NewRule("email", func(value reflect.Value) (string, bool) {
+		if value.Kind() != reflect.String {
+			return "", true
+		}
+		address, err := mail.ParseAddress(value.String())
+		if err != nil || address.Address != value.String() {
+			return "Must be a valid e-mail address.", false
+		}
+		return "", true
+	})
+}
+
+// OneOf returns a rule requiring the string representation of a value to be
+// one of the allowed options.
+func OneOf(options ...string) Rule {
+	options = slices.Clone(options)
+	return NewRule("oneof", func(value reflect.Value) (string, bool) {
+		if slices.Contains(options, fmt.Sprint(value.Interface())) {
+			return "", true
+		}
+		return fmt.Sprintf("Must be one of: %s.", strings.Join(options, ", ")), false
+	})
+}
+
+// isEmpty reports whether a value is nil, empty or the zero value of its type.
+func isEmpty(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// measure returns the numeric value of numbers, or the length of strings,
+// slices, arrays and maps. isLength reports which of the two was measured,
+// and ok is false for kinds that cannot be measured.
+func measure(value reflect.Value) (measured float64, isLength bool, ok bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), false, true
+	default:
+		return 0, false, false
+	}
+}
+
+// formatNumber renders a rule limit without a trailing ".0" for whole numbers.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
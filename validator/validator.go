@@ -0,0 +1,222 @@
+// Package validator provides input validation producing `exception.Validation`
+// errors. This file defines the struct tag driven Validator.
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// TagName is the struct tag read by the Validator, e.g.
+//
+//	Email string `json:"email" validate:"required,email"`
+const TagName = "validate"
+
+// omitEmpty is the tag option skipping every other rule of a field when its
+// value is empty.
+const omitEmpty = "omitempty"
+
+// RuleFactory builds a Rule from the parameter written after "=" in a struct
+// tag (e.g., "3" for `min=3`). It returns an error if the parameter is invalid.
+type RuleFactory func(param string) (Rule, error)
+
+// Validator validates structs according to their `validate` tags. Nested
+// structs, pointers to structs and slices or arrays of structs are validated
+// recursively, with field paths such as "address.city" or "items[0].name".
+// Field names are taken from the `json` tag when present.
+type Validator struct {
+	mu    sync.RWMutex           // Guards the rules map.
+	rules map[string]RuleFactory // The rule factories indexed by tag name.
+}
+
+// New creates a Validator preloaded with the built-in rules: required, min,
+// max, email and oneof.
+//
+// Returns:
+//
+//	A pointer to a new Validator.
+func New() *Validator {
+	return &Validator{
+		rules: map[string]RuleFactory{
+			"required": func(string) (Rule, error) { return Required(), nil },
+			"email":    func(string) (Rule, error) { return Email(), nil },
+			"min":      numberRule(Min),
+			"max":      numberRule(Max),
+			"oneof": func(param string) (Rule, error) {
+				return OneOf(strings.Fields(param)...), nil
+			},
+		},
+	}
+}
+
+// defaultValidator is the Validator used by the package-level Validate function.
+var defaultValidator = New()
+
+// Validate validates a struct with the default Validator.
+// See `Validator.Validate` for details.
+func Validate(value interface{}) error {
+	return defaultValidator.Validate(value)
+}
+
+// RegisterRule makes a custom rule available to struct tags under the given
+// name, replacing any rule previously registered under that name.
+//
+// Parameters:
+//
+//	name: The name used in the `validate` tag.
+//	factory: The function building the rule from its tag parameter.
+func (v *Validator) RegisterRule(name string, factory RuleFactory) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = factory
+}
+
+// Validate validates a struct, or a pointer to a struct, according to its
+// `validate` tags, reporting every violation rather than only the first one.
+//
+// Parameters:
+//
+//	value: The struct to validate.
+//
+// Returns:
+//
+//	Nil when the struct is valid, an `exception.Validation` holding the
+//	per-field error map otherwise. A value that is not a struct, or a tag
+//	referencing an unknown rule, yields an `exception.Logic` as it denotes
+//	a programming error rather than invalid input.
+func (v *Validator) Validate(value interface{}) error {
+	target, present := indirect(reflect.ValueOf(value))
+	if !present || target.Kind() != reflect.Struct {
+		return exception.NewLogic(map[string]interface{}{
+			"message": "Only structs can be validated.",
+			"details": map[string]interface{}{"type": fmt.Sprintf("%T", value)},
+		})
+	}
+
+	errs := &Errors{}
+	if err := v.validateStruct(errs, "", target); err != nil {
+		return err
+	}
+	return errs.Err()
+}
+
+// validateStruct validates every exported field of a struct value, recursing
+// into nested structs, slices and arrays.
+func (v *Validator) validateStruct(errs *Errors, prefix string, value reflect.Value) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := joinPath(prefix, fieldName(field))
+		fieldValue := value.Field(i)
+
+		if tag, ok := field.Tag.Lookup(TagName); ok && tag != "-" {
+			rules, optional, err := v.parseTag(tag)
+			if err != nil {
+				return err
+			}
+			if optional && isEmpty(fieldValue) {
+				continue
+			}
+			errs.check(path, fieldValue, rules)
+		}
+
+		if err := v.validateNested(errs, path, fieldValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNested recurses into structs and collections of structs.
+func (v *Validator) validateNested(errs *Errors, path string, value reflect.Value) error {
+	value, present := indirect(value)
+	if !present {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return v.validateStruct(errs, path, value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.validateNested(errs, path+"["+strconv.Itoa(i)+"]", value.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseTag converts a `validate` tag into rules, also reporting whether the
+// omitempty option is present.
+func (v *Validator) parseTag(tag string) ([]Rule, bool, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	var rules []Rule
+	optional := false
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name == "" {
+			continue
+		}
+		if name == omitEmpty {
+			optional = true
+			continue
+		}
+
+		factory, ok := v.rules[name]
+		if !ok {
+			return nil, false, exception.NewLogic(map[string]interface{}{
+				"message": "Unknown validation rule.",
+				"details": map[string]interface{}{"rule": name, "tag": tag},
+			})
+		}
+		rule, err := factory(param)
+		if err != nil {
+			return nil, false, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, optional, nil
+}
+
+// numberRule adapts a rule constructor taking a numeric limit into a RuleFactory.
+func numberRule(constructor func(float64) Rule) RuleFactory {
+	return func(param string) (Rule, error) {
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, exception.NewLogic(map[string]interface{}{
+				"message": "Validation rule parameter must be a number.",
+				"details": map[string]interface{}{"param": param},
+			})
+		}
+		return constructor(limit), nil
+	}
+}
+
+// fieldName returns the name under which a struct field is reported: its
+// `json` tag name when set, its Go name otherwise.
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// joinPath appends a field name to a parent path.
+func joinPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,106 @@
+// Package valueobject provides immutable, parse-validated value types.
+// This file defines the Email value object.
+package valueobject
+
+import (
+	"database/sql/driver"
+	"net/mail"
+	"strings"
+)
+
+// Email is a validated e-mail address without a display name. Its domain
+// part is normalized to lower case. The zero value represents an absent address.
+type Email struct {
+	value string
+}
+
+// NewEmail parses and validates an e-mail address.
+//
+// Parameters:
+//
+//	raw: The address to parse (e.g., "John.Doe@Example.com"). Surrounding
+//	     whitespace is ignored.
+//
+// Returns:
+//
+//	The Email, or an `exception.InvalidArgument` when the address is invalid.
+func NewEmail(raw string) (Email, error) {
+	trimmed := strings.TrimSpace(raw)
+	address, err := mail.ParseAddress(trimmed)
+	if err != nil || address.Address != trimmed {
+		return Email{}, invalid("email", raw, "invalid_email", "Invalid e-mail address.")
+	}
+
+	local, domain, _ := strings.Cut(address.Address, "@")
+	return Email{value: local + "@" + strings.ToLower(domain)}, nil
+}
+
+// String returns the normalized address.
+func (e Email) String() string {
+	return e.value
+}
+
+// IsZero reports whether the Email is the zero value.
+func (e Email) IsZero() bool {
+	return e.value == ""
+}
+
+// Local returns the part of the address before the "@".
+func (e Email) Local() string {
+	local, _, _ := strings.Cut(e.value, "@")
+	return local
+}
+
+// Domain returns the part of the address after the "@".
+func (e Email) Domain() string {
+	_, domain, _ := strings.Cut(e.value, "@")
+	return domain
+}
+
+// Equal reports whether two addresses are identical.
+func (e Email) Equal(other Email) bool {
+	return e.value == other.value
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (e Email) MarshalText() ([]byte, error) {
+	return []byte(e.value), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`, validating the input.
+func (e *Email) UnmarshalText(text []byte) error {
+	parsed, err := NewEmail(string(text))
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`. The zero value is encoded as null.
+func (e Email) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(e.value)
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`, validating the input.
+func (e *Email) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, func(raw string) error { return e.UnmarshalText([]byte(raw)) })
+}
+
+// Value implements `driver.Valuer`. The zero value is stored as NULL.
+func (e Email) Value() (driver.Value, error) {
+	if e.IsZero() {
+		return nil, nil
+	}
+	return e.value, nil
+}
+
+// Scan implements `sql.Scanner`. NULL is scanned into the zero value.
+func (e *Email) Scan(src interface{}) error {
+	raw, ok, err := scanString("email", src)
+	if err != nil || !ok {
+		*e = Email{}
+		return err
+	}
+	return e.UnmarshalText([]byte(raw))
+}
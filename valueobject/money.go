@@ -0,0 +1,386 @@
+// Package valueobject provides immutable, parse-validated value types.
+// This file defines the Currency and Money value objects.
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Currency is a validated ISO 4217 alphabetic currency code (e.g., "EUR").
+type Currency struct {
+	code string
+}
+
+// currencyExponents lists the currencies whose minor unit is not 1/100.
+var currencyExponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3,
+	"PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+}
+
+// NewCurrency parses and validates an ISO 4217 alphabetic currency code.
+//
+// Parameters:
+//
+//	code: The three-letter code, in any case (e.g., "eur").
+//
+// Returns:
+//
+//	The Currency, or an `exception.InvalidArgument` when the code is not
+//	made of exactly three letters.
+func NewCurrency(code string) (Currency, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if len(normalized) != 3 || strings.IndexFunc(normalized, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return Currency{}, invalid("currency", code, "invalid_currency", "Invalid currency code.")
+	}
+	return Currency{code: normalized}, nil
+}
+
+// String returns the currency code.
+func (c Currency) String() string {
+	return c.code
+}
+
+// Exponent returns the number of decimal digits of the currency's minor unit
+// (e.g., 2 for EUR, 0 for JPY, 3 for KWD).
+func (c Currency) Exponent() int {
+	if exponent, ok := currencyExponents[c.code]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// RoundingMode selects how fractional minor units are rounded.
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // RoundHalfUp rounds halves away from zero.
+	RoundHalfEven                     // RoundHalfEven rounds halves to the nearest even value (banker's rounding).
+	RoundDown                         // RoundDown truncates towards zero.
+	RoundUp                           // RoundUp rounds away from zero.
+)
+
+// Money is an amount expressed in the minor unit of its currency (e.g., cents),
+// so that arithmetic never suffers from floating point errors. Operations
+// between different currencies fail with an `exception.Domain`, and operations
+// exceeding the int64 range fail with an `exception.Overflow`.
+type Money struct {
+	amount   int64    // The amount in minor units.
+	currency Currency // The currency of the amount.
+}
+
+// NewMoney creates a Money from an amount in minor units and a currency code.
+//
+// Parameters:
+//
+//	amount: The amount in minor units (e.g., 1050 for 10.50 EUR).
+//	currency: The ISO 4217 currency code.
+//
+// Returns:
+//
+//	The Money, or an `exception.InvalidArgument` when the currency is invalid.
+func NewMoney(amount int64, currency string) (Money, error) {
+	parsed, err := NewCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: parsed}, nil
+}
+
+// Amount returns the amount in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Equal reports whether two amounts have the same value and currency.
+func (m Money) Equal(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+// Compare returns -1, 0 or 1 depending on whether m is lower than, equal to
+// or greater than other, or an `exception.Domain` when the currencies differ.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.sameCurrency("compare", other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Add returns the sum of two amounts of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency("add", other); err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, overflow("add", m, other.amount)
+	}
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Subtract returns the difference of two amounts of the same currency.
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.sameCurrency("subtract", other); err != nil {
+		return Money{}, err
+	}
+	difference := m.amount - other.amount
+	if (other.amount > 0 && difference > m.amount) || (other.amount < 0 && difference < m.amount) {
+		return Money{}, overflow("subtract", m, other.amount)
+	}
+	return Money{amount: difference, currency: m.currency}, nil
+}
+
+// Negate returns the opposite amount.
+func (m Money) Negate() (Money, error) {
+	if m.amount == math.MinInt64 {
+		return Money{}, overflow("negate", m, -1)
+	}
+	return Money{amount: -m.amount, currency: m.currency}, nil
+}
+
+// Multiply returns the amount multiplied by an integer factor.
+func (m Money) Multiply(factor int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(factor))
+	if !product.IsInt64() {
+		return Money{}, overflow("multiply", m, factor)
+	}
+	return Money{amount: product.Int64(), currency: m.currency}, nil
+}
+
+// MultiplyRatio returns the amount multiplied by numerator/denominator,
+// rounded to a whole minor unit with the given mode. It is the building
+// block for percentages, taxes and exchange rates without floating point.
+//
+// Parameters:
+//
+//	numerator: The numerator of the ratio (e.g., 20 for 20%).
+//	denominator: The denominator of the ratio (e.g., 100 for 20%). Must not be zero.
+//	mode: The rounding mode applied to the fractional minor unit.
+//
+// Returns:
+//
+//	The resulting Money, an `exception.InvalidArgument` for a zero
+//	denominator, or an `exception.Overflow` when the result exceeds int64.
+func (m Money) MultiplyRatio(numerator, denominator int64, mode RoundingMode) (Money, error) {
+	if denominator == 0 {
+		return Money{}, exception.NewInvalidArgument(map[string]interface{}{
+			"message": "Ratio denominator must not be zero.",
+			"details": map[string]interface{}{"field": "denominator", "error": "division_by_zero"},
+		})
+	}
+
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(numerator))
+	rounded := divideRounded(product, big.NewInt(denominator), mode)
+	if !rounded.IsInt64() {
+		return Money{}, overflow("multiply_ratio", m, numerator)
+	}
+	return Money{amount: rounded.Int64(), currency: m.currency}, nil
+}
+
+// Allocate splits the amount proportionally to the given ratios without
+// losing any minor unit: the remainder left by integer division is handed
+// out one unit at a time, starting with the first share.
+//
+// Parameters:
+//
+//	ratios: The non-negative weights of each share (e.g., 70, 30). At least
+//	        one ratio must be positive.
+//
+// Returns:
+//
+//	One Money per ratio whose sum equals the original amount, or an
+//	`exception.InvalidArgument` when the ratios are invalid.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			total.SetInt64(0)
+			break
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, exception.NewInvalidArgument(map[string]interface{}{
+			"message": "Allocation ratios must be non-negative with a positive sum.",
+			"details": map[string]interface{}{"field": "ratios", "error": "invalid_ratios"},
+		})
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := m.amount
+	amount := big.NewInt(m.amount)
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(ratio))
+		share.Quo(share, total) // Truncates towards zero; always fits in int64.
+		shares[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+
+	// Distribute the leftover minor units to the first non-zero shares.
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].amount += step
+		remainder -= step
+	}
+
+	return shares, nil
+}
+
+// Split divides the amount into n shares as equal as possible.
+// See Allocate for how remainders are distributed.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, exception.NewInvalidArgument(map[string]interface{}{
+			"message": "The number of shares must be positive.",
+			"details": map[string]interface{}{"field": "n", "value": n, "error": "invalid_share_count"},
+		})
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// String returns the amount in major units followed by the currency code
+// (e.g., "10.50 EUR").
+func (m Money) String() string {
+	exponent := m.currency.Exponent()
+	sign := ""
+	if m.amount < 0 {
+		sign = "-"
+	}
+
+	magnitude := new(big.Int).Abs(big.NewInt(m.amount)).String()
+	if exponent == 0 {
+		return fmt.Sprintf("%s%s %s", sign, magnitude, m.currency.code)
+	}
+	if len(magnitude) <= exponent {
+		magnitude = strings.Repeat("0", exponent-len(magnitude)+1) + magnitude
+	}
+	cut := len(magnitude) - exponent
+	return fmt.Sprintf("%s%s.%s %s", sign, magnitude[:cut], magnitude[cut:], m.currency.code)
+}
+
+// moneyJSON is the wire representation of Money.
+type moneyJSON struct {
+	Amount   int64  `json:"amount"`   // The amount in minor units.
+	Currency string `json:"currency"` // The ISO 4217 currency code.
+}
+
+// MarshalJSON implements `json.Marshaler`, producing {"amount": 1050, "currency": "EUR"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.amount, Currency: m.currency.code})
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`, validating the currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	parsed, err := NewMoney(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// sameCurrency returns an `exception.Domain` when two amounts have different currencies.
+func (m Money) sameCurrency(operation string, other Money) error {
+	if m.currency == other.currency {
+		return nil
+	}
+	return exception.NewDomain(map[string]interface{}{
+		"message": "Cannot operate on amounts of different currencies.",
+		"details": map[string]interface{}{
+			"operation":  operation,
+			"currencies": []string{m.currency.code, other.currency.code},
+			"error":      "currency_mismatch",
+		},
+	})
+}
+
+// overflow builds the `exception.Overflow` returned when an operation exceeds int64.
+func overflow(operation string, m Money, operand int64) error {
+	return exception.NewOverflow(map[string]interface{}{
+		"message": "Monetary amount exceeds the supported range.",
+		"details": map[string]interface{}{
+			"operation": operation,
+			"amount":    m.amount,
+			"operand":   operand,
+			"currency":  m.currency.code,
+			"error":     "amount_overflow",
+		},
+	})
+}
+
+// divideRounded divides numerator by denominator, rounding with the given mode.
+func divideRounded(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// The direction in which rounding "away from zero" moves the quotient.
+	direction := int64(numerator.Sign() * denominator.Sign())
+	awayFromZero := func() *big.Int { return quotient.Add(quotient, big.NewInt(direction)) }
+
+	switch mode {
+	case RoundDown:
+		return quotient
+	case RoundUp:
+		return awayFromZero()
+	}
+
+	// Compare twice the remainder to the denominator to locate the halfway point.
+	doubled := new(big.Int).Abs(remainder)
+	doubled.Lsh(doubled, 1)
+	switch doubled.Cmp(new(big.Int).Abs(denominator)) {
+	case 1:
+		return awayFromZero()
+	case 0:
+		if mode == RoundHalfEven && quotient.Bit(0) == 0 {
+			return quotient
+		}
+		return awayFromZero()
+	default:
+		return quotient
+	}
+}
@@ -0,0 +1,116 @@
+// Package valueobject provides immutable, parse-validated value types.
+// This file defines the PhoneNumber value object.
+package valueobject
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// PhoneNumber is a validated international phone number, stored in its
+// E.164 form ("+" followed by 8 to 15 digits, e.g. "+22890000000").
+// The zero value represents an absent number.
+type PhoneNumber struct {
+	value string
+}
+
+// NewPhoneNumber parses and validates an international phone number.
+// Spaces, dots, dashes and parentheses used as separators are removed, and
+// a leading "00" international prefix is accepted in place of "+".
+//
+// Parameters:
+//
+//	raw: The number to parse (e.g., "+228 90 00 00 00", "00228-90000000").
+//
+// Returns:
+//
+//	The PhoneNumber, or an `exception.InvalidArgument` when the input is not
+//	a valid E.164 number.
+func NewPhoneNumber(raw string) (PhoneNumber, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+
+	digits := strings.TrimPrefix(normalized, "+")
+	if digits == normalized || len(digits) < 8 || len(digits) > 15 || digits[0] == '0' || !isDigits(digits) {
+		return PhoneNumber{}, invalid("phone_number", raw, "invalid_phone_number", "Invalid phone number.")
+	}
+
+	return PhoneNumber{value: normalized}, nil
+}
+
+// isDigits reports whether s only contains ASCII digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the number in E.164 form.
+func (p PhoneNumber) String() string {
+	return p.value
+}
+
+// IsZero reports whether the PhoneNumber is the zero value.
+func (p PhoneNumber) IsZero() bool {
+	return p.value == ""
+}
+
+// Equal reports whether two numbers are identical.
+func (p PhoneNumber) Equal(other PhoneNumber) bool {
+	return p.value == other.value
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (p PhoneNumber) MarshalText() ([]byte, error) {
+	return []byte(p.value), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`, validating the input.
+func (p *PhoneNumber) UnmarshalText(text []byte) error {
+	parsed, err := NewPhoneNumber(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`. The zero value is encoded as null.
+func (p PhoneNumber) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(p.value)
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`, validating the input.
+func (p *PhoneNumber) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, func(raw string) error { return p.UnmarshalText([]byte(raw)) })
+}
+
+// Value implements `driver.Valuer`. The zero value is stored as NULL.
+func (p PhoneNumber) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return p.value, nil
+}
+
+// Scan implements `sql.Scanner`. NULL is scanned into the zero value.
+func (p *PhoneNumber) Scan(src interface{}) error {
+	raw, ok, err := scanString("phone_number", src)
+	if err != nil || !ok {
+		*p = PhoneNumber{}
+		return err
+	}
+	return p.UnmarshalText([]byte(raw))
+}
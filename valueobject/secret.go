@@ -0,0 +1,111 @@
+// Package valueobject provides immutable, parse-validated value types.
+// This file defines the Redacted wrapper and the Secret value object, which
+// never render their value.
+package valueobject
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// RedactedText is the rendering of every Redacted value.
+const RedactedText = "***"
+
+// Redacted wraps a sensitive value (a password, an API key, a token, ...) so
+// that it cannot leak by accident: its String, GoString and Format methods,
+// its JSON and text encodings and its slog value all render RedactedText.
+// Exceptions holding a Redacted value in their details therefore render
+// "***" in their `Format()` and `GetErrorsForLog()` output, whether encoded
+// as JSON or printed. The value is only available through Expose.
+type Redacted[T any] struct {
+	value T
+}
+
+// Redact wraps a value.
+//
+// Parameters:
+//
+//	value: The sensitive value.
+//
+// Returns:
+//
+//	The Redacted value.
+func Redact[T any](value T) Redacted[T] {
+	return Redacted[T]{value: value}
+}
+
+// Expose returns the wrapped value. Calls to Expose mark the places where
+// the value deliberately leaves its wrapper (e.g., to authenticate with it).
+func (r Redacted[T]) Expose() T {
+	return r.value
+}
+
+// String returns RedactedText.
+func (r Redacted[T]) String() string {
+	return RedactedText
+}
+
+// GoString returns RedactedText, for the %#v verb.
+func (r Redacted[T]) GoString() string {
+	return RedactedText
+}
+
+// Format writes RedactedText for every fmt verb, including %v with the "+"
+// and "#" flags.
+func (r Redacted[T]) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(RedactedText))
+}
+
+// LogValue returns RedactedText as the value of a `log/slog` attribute.
+func (r Redacted[T]) LogValue() slog.Value {
+	return slog.StringValue(RedactedText)
+}
+
+// MarshalJSON encodes RedactedText as a JSON string.
+func (r Redacted[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedText)
+}
+
+// MarshalText encodes RedactedText.
+func (r Redacted[T]) MarshalText() ([]byte, error) {
+	return []byte(RedactedText), nil
+}
+
+// UnmarshalJSON decodes the wrapped value, so that secrets can be read from
+// JSON requests and configuration files.
+func (r *Redacted[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.value)
+}
+
+// UnmarshalText decodes the wrapped value from text, e.g. an environment
+// variable read by the config package. It supports strings and the types
+// implementing `encoding.TextUnmarshaler`.
+func (r *Redacted[T]) UnmarshalText(text []byte) error {
+	switch target := any(&r.value).(type) {
+	case *string:
+		*target = string(text)
+		return nil
+	case encoding.TextUnmarshaler:
+		return target.UnmarshalText(text)
+	default:
+		return invalid("secret", RedactedText, "unsupported_secret_type", fmt.Sprintf("A %T secret cannot be decoded from text.", r.value))
+	}
+}
+
+// Secret is a sensitive string, such as a password or an API key.
+type Secret = Redacted[string]
+
+// NewSecret wraps a sensitive string.
+//
+// Parameters:
+//
+//	value: The sensitive string.
+//
+// Returns:
+//
+//	The Secret.
+func NewSecret(value string) Secret {
+	return Redact(value)
+}
@@ -0,0 +1,99 @@
+// Package valueobject provides immutable, parse-validated value types.
+// This file defines the URL value object.
+package valueobject
+
+import (
+	"database/sql/driver"
+	"net/url"
+	"strings"
+)
+
+// URL is a validated absolute URL with a scheme and a host.
+// The zero value represents an absent URL.
+type URL struct {
+	value string
+}
+
+// NewURL parses and validates an absolute URL.
+//
+// Parameters:
+//
+//	raw: The URL to parse (e.g., "https://osirisgate.com/docs"). Surrounding
+//	     whitespace is ignored.
+//
+// Returns:
+//
+//	The URL, or an `exception.InvalidArgument` when the input is not an
+//	absolute URL with a host.
+func NewURL(raw string) (URL, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return URL{}, invalid("url", raw, "invalid_url", "Invalid URL.")
+	}
+	return URL{value: parsed.String()}, nil
+}
+
+// String returns the URL as a string.
+func (u URL) String() string {
+	return u.value
+}
+
+// IsZero reports whether the URL is the zero value.
+func (u URL) IsZero() bool {
+	return u.value == ""
+}
+
+// URL returns a freshly parsed `*url.URL`, so that callers cannot alter the
+// value object through the returned pointer.
+func (u URL) URL() *url.URL {
+	parsed, _ := url.Parse(u.value)
+	return parsed
+}
+
+// Equal reports whether two URLs are identical.
+func (u URL) Equal(other URL) bool {
+	return u.value == other.value
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (u URL) MarshalText() ([]byte, error) {
+	return []byte(u.value), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`, validating the input.
+func (u *URL) UnmarshalText(text []byte) error {
+	parsed, err := NewURL(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`. The zero value is encoded as null.
+func (u URL) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(u.value)
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`, validating the input.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, func(raw string) error { return u.UnmarshalText([]byte(raw)) })
+}
+
+// Value implements `driver.Valuer`. The zero value is stored as NULL.
+func (u URL) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.value, nil
+}
+
+// Scan implements `sql.Scanner`. NULL is scanned into the zero value.
+func (u *URL) Scan(src interface{}) error {
+	raw, ok, err := scanString("url", src)
+	if err != nil || !ok {
+		*u = URL{}
+		return err
+	}
+	return u.UnmarshalText([]byte(raw))
+}
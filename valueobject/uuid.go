@@ -0,0 +1,152 @@
+// Package valueobject provides immutable, parse-validated value types.
+// This file defines the UUID value object.
+package valueobject
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UUID is a validated RFC 9562 UUID, stored in its canonical lower-case
+// 8-4-4-4-12 form. The zero value represents an absent UUID.
+type UUID struct {
+	value string
+}
+
+// NewUUID generates a random (version 4) UUID.
+//
+// Returns:
+//
+//	A new random UUID.
+func NewUUID() UUID {
+	var raw [16]byte
+	_, _ = rand.Read(raw[:])    // crypto/rand.Read never returns an error.
+	raw[6] = raw[6]&0x0f | 0x40 // Version 4.
+	raw[8] = raw[8]&0x3f | 0x80 // RFC 9562 variant.
+
+	return UUID{value: formatUUID(raw)}
+}
+
+// NewUUIDv7 generates a time-ordered (version 7) UUID: its first 48 bits hold
+// the Unix time in milliseconds, so that identifiers generated later sort
+// after earlier ones, which keeps database indexes compact.
+//
+// Parameters:
+//
+//	now: The generation time, typically read from a clock.
+//
+// Returns:
+//
+//	A new time-ordered UUID.
+func NewUUIDv7(now time.Time) UUID {
+	var raw [16]byte
+	_, _ = rand.Read(raw[6:]) // crypto/rand.Read never returns an error.
+	millis := uint64(now.UnixMilli())
+	for i := 0; i < 6; i++ {
+		raw[i] = byte(millis >> (40 - 8*i))
+	}
+	raw[6] = raw[6]&0x0f | 0x70 // Version 7.
+	raw[8] = raw[8]&0x3f | 0x80 // RFC 9562 variant.
+
+	return UUID{value: formatUUID(raw)}
+}
+
+// ParseUUID parses and validates a UUID in its canonical form, in any case.
+//
+// Parameters:
+//
+//	raw: The UUID to parse (e.g., "5F0E9C3A-8B1D-4C2E-9F3A-1B2C3D4E5F60").
+//
+// Returns:
+//
+//	The UUID, or an `exception.InvalidArgument` when the input is not a UUID.
+func ParseUUID(raw string) (UUID, error) {
+	trimmed := strings.TrimSpace(raw)
+	if len(trimmed) != 36 || trimmed[8] != '-' || trimmed[13] != '-' || trimmed[18] != '-' || trimmed[23] != '-' {
+		return UUID{}, invalid("uuid", raw, "invalid_uuid", "Invalid UUID.")
+	}
+
+	var decoded [16]byte
+	if n, err := hex.Decode(decoded[:], []byte(strings.ReplaceAll(trimmed, "-", ""))); err != nil || n != len(decoded) {
+		return UUID{}, invalid("uuid", raw, "invalid_uuid", "Invalid UUID.")
+	}
+
+	return UUID{value: formatUUID(decoded)}, nil
+}
+
+// formatUUID renders 16 bytes in the canonical 8-4-4-4-12 form.
+func formatUUID(raw [16]byte) string {
+	encoded := hex.EncodeToString(raw[:])
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:]
+}
+
+// String returns the canonical form of the UUID.
+func (u UUID) String() string {
+	return u.value
+}
+
+// IsZero reports whether the UUID is the zero value.
+func (u UUID) IsZero() bool {
+	return u.value == ""
+}
+
+// Version returns the version number of the UUID, or 0 for the zero value.
+func (u UUID) Version() int {
+	if u.IsZero() {
+		return 0
+	}
+	version, _ := strconv.ParseUint(u.value[14:15], 16, 8)
+	return int(version)
+}
+
+// Equal reports whether two UUIDs are identical.
+func (u UUID) Equal(other UUID) bool {
+	return u.value == other.value
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.value), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`, validating the input.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`. The zero value is encoded as null.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(u.value)
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`, validating the input.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, func(raw string) error { return u.UnmarshalText([]byte(raw)) })
+}
+
+// Value implements `driver.Valuer`. The zero value is stored as NULL.
+func (u UUID) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.value, nil
+}
+
+// Scan implements `sql.Scanner`. NULL is scanned into the zero value.
+func (u *UUID) Scan(src interface{}) error {
+	raw, ok, err := scanString("uuid", src)
+	if err != nil || !ok {
+		*u = UUID{}
+		return err
+	}
+	return u.UnmarshalText([]byte(raw))
+}
@@ -0,0 +1,73 @@
+// Package valueobject provides immutable, parse-validated value types for
+// common primitives (e-mail addresses, URLs, UUIDs and phone numbers).
+// A value object can only be obtained through its constructor, which returns
+// an `exception.InvalidArgument` describing the offending field when the
+// input is invalid. All value objects implement the JSON, text and SQL
+// marshaling interfaces so they can be used directly in DTOs and entities.
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// invalid builds the `exception.InvalidArgument` returned by constructors
+// when the raw input cannot be parsed into a value object.
+func invalid(field string, value string, code string, message string) error {
+	return exception.NewInvalidArgument(map[string]interface{}{
+		"message": message,
+		"details": map[string]interface{}{
+			"field": field,
+			"value": value,
+			"error": code,
+		},
+	})
+}
+
+// scanString converts a value read from a SQL driver into a string.
+// A NULL value yields an empty string and ok set to false.
+func scanString(field string, src interface{}) (value string, ok bool, err error) {
+	switch typed := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return typed, true, nil
+	case []byte:
+		return string(typed), true, nil
+	default:
+		return "", false, exception.NewUnexpectedValue(map[string]interface{}{
+			"message": "Unsupported database value.",
+			"details": map[string]interface{}{
+				"field": field,
+				"type":  fmt.Sprintf("%T", src),
+				"error": "unsupported_type",
+			},
+		})
+	}
+}
+
+// marshalJSONString encodes the canonical form of a value object as a JSON
+// string, and the zero value, whose form is empty, as null: like `Value`
+// storing it as NULL, so that it decodes back into the zero value.
+func marshalJSONString(value string) ([]byte, error) {
+	if value == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(value)
+}
+
+// unmarshalJSONString decodes a JSON string and hands it to parse.
+// A JSON null leaves the value object untouched, as encoding/json does
+// for other types.
+func unmarshalJSONString(data []byte, parse func(string) error) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return parse(raw)
+}
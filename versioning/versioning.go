@@ -0,0 +1,274 @@
+// Package versioning resolves the API version requested by a client. The
+// version is read from the first segment of the path ("/v2/users"), from a
+// header ("Api-Version: 2") or from the media type of the Accept header
+// ("application/vnd.acme.v2+json" or "application/json; version=2"), and
+// validated against the versions the server supports. Unsupported versions
+// yield an `exception.UnsupportedVersion` listing the supported ones.
+package versioning
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	// status "github.com/osirisgate/golang-core/enum" is expected to provide
+	// the `status.NotAcceptable` status of versions negotiated by media type.
+	status "github.com/osirisgate/golang-core/enum"
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/negotiation"
+	"github.com/osirisgate/golang-core/response"
+)
+
+// DefaultHeader is the header carrying the requested version.
+const DefaultHeader = "Api-Version"
+
+// Source tells where a version was read from.
+type Source string
+
+// Sources of versions.
+const (
+	SourcePath      Source = "path"
+	SourceHeader    Source = "header"
+	SourceMediaType Source = "media_type"
+	SourceDefault   Source = "default"
+)
+
+// Reasons reported in the "error" key of the exception details.
+const (
+	ReasonMissingVersion     = "missing_version"
+	ReasonUnsupportedVersion = "unsupported_version"
+)
+
+// Version is a resolved API version.
+type Version struct {
+	Value  string // The supported version, as configured (e.g., "2").
+	Source Source // Where the version was read from.
+}
+
+// Config holds the settings of a Resolver.
+type Config struct {
+	// Supported lists the supported versions (e.g., "1", "2"). A leading "v"
+	// is ignored when comparing versions, so "v2" and "2" are the same. It is
+	// required.
+	Supported []string
+
+	// Default is the version of requests that do not specify one. Empty
+	// makes the version mandatory.
+	Default string
+
+	// Header is the header carrying the version. Defaults to DefaultHeader;
+	// "-" disables it.
+	Header string
+
+	// Vendor, when set, enables the vendor media types of the Accept header
+	// (e.g., "acme" for "application/vnd.acme.v2+json"). The "version"
+	// parameter of the media types is always honored.
+	Vendor string
+
+	// IgnorePath disables the resolution from the first segment of the path.
+	IgnorePath bool
+
+	// Envelopes maps supported versions to the version of the error
+	// envelopes of their requests, set by Middleware (see
+	// `response.WithEnvelopeVersion`), so that the clients of a new API
+	// version can get `response.EnvelopeV2` while the others keep the
+	// default of `response.SetEnvelopeVersion` (e.g.,
+	// {"2": response.EnvelopeV2}). The versions are compared like Supported.
+	Envelopes map[string]response.EnvelopeVersion
+}
+
+// Resolver resolves the versions requested by clients. It is safe for
+// concurrent use.
+type Resolver struct {
+	config Config
+}
+
+// New creates a Resolver.
+//
+// Parameters:
+//
+//	config: The resolver settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Resolver.
+func New(config Config) *Resolver {
+	if config.Header == "" {
+		config.Header = DefaultHeader
+	}
+	config.Supported = append([]string{}, config.Supported...)
+	envelopes := make(map[string]response.EnvelopeVersion, len(config.Envelopes))
+	for version, envelope := range config.Envelopes {
+		envelopes[normalize(version)] = envelope
+	}
+	config.Envelopes = envelopes
+	return &Resolver{config: config}
+}
+
+// Supported returns the supported versions.
+func (s *Resolver) Supported() []string {
+	return append([]string{}, s.config.Supported...)
+}
+
+// Resolve resolves the version of a request, trying the path, the header and
+// the media types of the Accept header in turn, then the default version.
+//
+// Parameters:
+//
+//	r: The request.
+//
+// Returns:
+//
+//	The version, or an `exception.UnsupportedVersion` whose details hold the
+//	"requested" version, its "source" and the "supported" versions, and
+//	whose "error" is one of the Reason constants. Its status code is 406 Not
+//	Acceptable for versions read from the Accept header, and 400 Bad Request
+//	otherwise.
+func (s *Resolver) Resolve(r *http.Request) (Version, error) {
+	for _, candidate := range []struct {
+		source Source
+		read   func(*http.Request) string
+	}{
+		{SourcePath, s.fromPath},
+		{SourceHeader, s.fromHeader},
+		{SourceMediaType, s.fromMediaType},
+	} {
+		if requested := candidate.read(r); requested != "" {
+			return s.validate(requested, candidate.source)
+		}
+	}
+
+	if s.config.Default != "" {
+		return Version{Value: s.config.Default, Source: SourceDefault}, nil
+	}
+	return Version{}, s.failure("", "", ReasonMissingVersion, "The request does not specify an API version.")
+}
+
+// fromPath reads the version of the first segment of the path, e.g. "v2".
+func (s *Resolver) fromPath(r *http.Request) string {
+	if s.config.IgnorePath {
+		return ""
+	}
+	segment, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(segment) < 2 || (segment[0] != 'v' && segment[0] != 'V') || segment[1] < '0' || segment[1] > '9' {
+		return ""
+	}
+	return segment
+}
+
+// fromHeader reads the version of the version header.
+func (s *Resolver) fromHeader(r *http.Request) string {
+	if s.config.Header == "-" {
+		return ""
+	}
+	return strings.TrimSpace(r.Header.Get(s.config.Header))
+}
+
+// fromMediaType reads the version of the preferred media type of the Accept
+// header specifying one.
+func (s *Resolver) fromMediaType(r *http.Request) string {
+	prefix := "application/vnd." + strings.ToLower(s.config.Vendor) + "."
+	for _, preference := range negotiation.Parse(r.Header.Get("Accept")) {
+		if preference.Quality == 0 {
+			continue
+		}
+		if version := preference.Params["version"]; version != "" {
+			return version
+		}
+		if s.config.Vendor == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(strings.ToLower(preference.Value), prefix); ok {
+			version, _, _ := strings.Cut(rest, "+")
+			if version != "" {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+// validate matches a requested version against the supported ones.
+func (s *Resolver) validate(requested string, source Source) (Version, error) {
+	for _, supported := range s.config.Supported {
+		if normalize(supported) == normalize(requested) {
+			return Version{Value: supported, Source: source}, nil
+		}
+	}
+	return Version{}, s.failure(requested, source, ReasonUnsupportedVersion, fmt.Sprintf("The API version %q is not supported.", requested))
+}
+
+// failure builds the exception reporting an unresolved version.
+func (s *Resolver) failure(requested string, source Source, reason string, message string) error {
+	details := map[string]interface{}{
+		"supported": s.Supported(),
+		"error":     reason,
+	}
+	if requested != "" {
+		details["requested"] = requested
+		details["source"] = string(source)
+	}
+	e := exception.NewUnsupportedVersion(map[string]interface{}{
+		"message": message,
+		"details": details,
+	})
+	if source == SourceMediaType {
+		e.StatusCode = status.NotAcceptable
+	}
+	return e
+}
+
+// normalize strips the leading "v" of a version.
+func normalize(version string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+}
+
+// contextKey is the unexported type of the key defined by this package.
+type contextKey struct{}
+
+// WithVersion returns a copy of ctx carrying the version of the request.
+func WithVersion(ctx context.Context, version Version) context.Context {
+	return context.WithValue(ctx, contextKey{}, version)
+}
+
+// FromContext returns the version carried by ctx, and false when there is
+// none.
+func FromContext(ctx context.Context) (Version, bool) {
+	version, ok := ctx.Value(contextKey{}).(Version)
+	return version, ok
+}
+
+// Middleware resolves the version of each request. Requests of a supported
+// version reach next with the version in the context (see FromContext) and
+// in the version header of the response; their context also carries the
+// envelope version of `Config.Envelopes`, if any. Other requests receive the
+// error envelope rendered by `response.WriteErrorContext`, and next is not
+// called.
+//
+// Parameters:
+//
+//	resolver: The resolver of the versions.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the version resolution.
+func Middleware(resolver *Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			version, err := resolver.Resolve(r)
+			if err != nil {
+				_ = response.WriteErrorContext(r.Context(), w, err)
+				return
+			}
+			if resolver.config.Header != "-" {
+				w.Header().Set(resolver.config.Header, version.Value)
+			}
+			ctx := WithVersion(r.Context(), version)
+			if envelope, ok := resolver.config.Envelopes[normalize(version.Value)]; ok {
+				ctx = response.WithEnvelopeVersion(ctx, envelope)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package webhook verifies the HMAC signatures of incoming webhook requests.
+// This file defines the HTTP middleware rejecting unsigned requests.
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/osirisgate/golang-core/response"
+)
+
+// Middleware verifies the signature of each request with a Verifier. Requests
+// failing the verification receive a 401 response rendered by
+// `response.WriteError`, and next is not called; verified requests reach next
+// with their body restored.
+//
+// Parameters:
+//
+//	verifier: The verifier checking the requests.
+//
+// Returns:
+//
+//	A function wrapping an `http.Handler` with the verification.
+func Middleware(verifier *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := verifier.VerifyRequest(r); err != nil {
+				_ = response.WriteErrorContext(r.Context(), w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Package webhook verifies the HMAC signatures of incoming webhook requests.
+// A sender signs the request body (prefixed with a timestamp when replay
+// protection is enabled) with a shared secret; the receiver recomputes the
+// signature and rejects mismatches, stale timestamps and missing headers with
+// an `exception.Unauthorized` whose details "error" names the reason.
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/osirisgate/golang-core/exception"
+	"github.com/osirisgate/golang-core/signing"
+)
+
+// Encoding is the text encoding of a signature.
+type Encoding = signing.Encoding
+
+const (
+	Hex    = signing.Hex    // Hex encodes signatures in lower-case hexadecimal.
+	Base64 = signing.Base64 // Base64 encodes signatures in standard padded base64.
+)
+
+// Default verifier settings.
+const (
+	DefaultHeader    = "X-Signature"   // DefaultHeader is the header carrying the signature.
+	DefaultTolerance = 5 * time.Minute // DefaultTolerance is the maximum age of a signed timestamp.
+	DefaultMaxBytes  = 1 << 20         // DefaultMaxBytes is the maximum size of a verified body (1 MiB).
+)
+
+// Reason codes reported under the details "error" key of verification failures.
+const (
+	ReasonMissingSignature   = signing.ReasonMissingSignature   // ReasonMissingSignature reports a missing signature header.
+	ReasonMalformedSignature = signing.ReasonMalformedSignature // ReasonMalformedSignature reports a signature that cannot be decoded.
+	ReasonInvalidSignature   = signing.ReasonInvalidSignature   // ReasonInvalidSignature reports a signature that does not match the body.
+	ReasonUnknownKey         = signing.ReasonUnknownKey         // ReasonUnknownKey reports a signature made with a key that is not configured.
+	ReasonMissingTimestamp   = "missing_timestamp"              // ReasonMissingTimestamp reports a missing timestamp header.
+	ReasonMalformedTimestamp = "malformed_timestamp"            // ReasonMalformedTimestamp reports a timestamp that is not a Unix time.
+	ReasonExpiredTimestamp   = "expired_timestamp"              // ReasonExpiredTimestamp reports a timestamp outside the tolerance.
+	ReasonBodyTooLarge       = "body_too_large"                 // ReasonBodyTooLarge reports a body exceeding the size limit.
+)
+
+// Config holds the settings of a Verifier. Zero values fall back to the
+// defaults.
+type Config struct {
+	// Secret is the shared secret keying the HMAC. It is required unless
+	// Keys is set.
+	Secret []byte
+
+	// Keys, when set, replace Secret to rotate the secrets: the first key signs
+	// and every key verifies (see `signing.Config.Keys`).
+	Keys []signing.Key
+
+	// KeyIDHeader is the header naming the signing key, sent and read when the
+	// keys have IDs. Defaults to `signing.DefaultKeyIDHeader`.
+	KeyIDHeader string
+
+	// Header is the header carrying the signature. Defaults to DefaultHeader.
+	Header string
+
+	// Prefix is the scheme prefixing the signature in the header, e.g.
+	// "sha256=" for GitHub style signatures. Defaults to no prefix.
+	Prefix string
+
+	// Hash builds the hash function of the HMAC. Defaults to `sha256.New`.
+	Hash func() hash.Hash
+
+	// Encoding is the text encoding of the signature. Defaults to Hex.
+	Encoding Encoding
+
+	// TimestampHeader, when set, names the header carrying the Unix time at
+	// which the request was signed. The signed content then becomes the
+	// timestamp, a "." and the body, and requests whose timestamp is further
+	// than Tolerance from the current time are rejected as replays.
+	TimestampHeader string
+
+	// Tolerance is the maximum distance between a signed timestamp and the
+	// current time. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+
+	// MaxBytes is the maximum size of a body read by VerifyRequest. Defaults
+	// to DefaultMaxBytes.
+	MaxBytes int64
+
+	// Now returns the current time. Defaults to `time.Now`; overridable in tests.
+	Now func() time.Time
+}
+
+// Verifier signs and verifies webhook payloads. It is safe for concurrent use.
+type Verifier struct {
+	config Config
+	signer *signing.Signer
+}
+
+// New creates a Verifier.
+//
+// Parameters:
+//
+//	config: The verifier settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Verifier, or the `exception.Configuration` of
+//	`signing.New` whose details "error" is "missing_secret" when neither
+//	Secret nor Keys is set, or a key has an empty secret: an empty HMAC key
+//	would let anyone forge the signatures.
+func New(config Config) (*Verifier, error) {
+	if config.Header == "" {
+		config.Header = DefaultHeader
+	}
+	if config.Tolerance <= 0 {
+		config.Tolerance = DefaultTolerance
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = DefaultMaxBytes
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	keys := config.Keys
+	if len(keys) == 0 {
+		keys = []signing.Key{{Secret: config.Secret}}
+	}
+	signer, err := signing.New(signing.Config{
+		Keys:            keys,
+		Hash:            config.Hash,
+		Encoding:        config.Encoding,
+		SignatureHeader: config.Header,
+		Prefix:          config.Prefix,
+		KeyIDHeader:     config.KeyIDHeader,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{config: config, signer: signer}, nil
+}
+
+// Sign computes the signature headers of a payload, e.g. to send webhooks or
+// to build test requests.
+//
+// Parameters:
+//
+//	body: The payload to sign.
+//	timestamp: The signing time, used when a TimestampHeader is configured.
+//
+// Returns:
+//
+//	The headers to add to the request: the signature header, with its prefix,
+//	and the timestamp header when configured.
+func (v *Verifier) Sign(body []byte, timestamp time.Time) http.Header {
+	header := http.Header{}
+	unix := ""
+	if v.config.TimestampHeader != "" {
+		unix = strconv.FormatInt(timestamp.Unix(), 10)
+		header.Set(v.config.TimestampHeader, unix)
+	}
+	v.signer.SignHeader(header, signedContent(unix, body))
+	return header
+}
+
+// Verify checks the signature of a payload against its headers.
+//
+// Parameters:
+//
+//	header: The headers of the request.
+//	body: The raw payload, exactly as received.
+//
+// Returns:
+//
+//	Nil when the signature is valid, or an `exception.Unauthorized` whose
+//	details "error" is one of the Reason constants.
+func (v *Verifier) Verify(header http.Header, body []byte) error {
+	unix := ""
+	if v.config.TimestampHeader != "" {
+		unix = header.Get(v.config.TimestampHeader)
+		if err := v.checkTimestamp(unix); err != nil {
+			return err
+		}
+	}
+
+	return v.signer.VerifyHeader(header, signedContent(unix, body))
+}
+
+// VerifyRequest reads the body of a request and checks its signature. The
+// body is restored so that it can be read again by the handler.
+//
+// Parameters:
+//
+//	r: The incoming request.
+//
+// Returns:
+//
+//	The raw body and nil when the signature is valid, or an error as for
+//	Verify; a body exceeding MaxBytes yields ReasonBodyTooLarge.
+func (v *Verifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	var body []byte
+	if r.Body != nil {
+		read, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, v.config.MaxBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, failure(fmt.Sprintf("The webhook body exceeds %d bytes.", tooLarge.Limit), ReasonBodyTooLarge, v.config.Header)
+			}
+			return nil, exception.Normalize(err)
+		}
+		body = read
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if err := v.Verify(r.Header, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// checkTimestamp rejects missing, malformed and out of tolerance timestamps.
+func (v *Verifier) checkTimestamp(unix string) error {
+	name := v.config.TimestampHeader
+	if unix == "" {
+		return failure(fmt.Sprintf("The %s header is missing.", name), ReasonMissingTimestamp, name)
+	}
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return failure("The webhook timestamp is malformed.", ReasonMalformedTimestamp, name)
+	}
+
+	age := v.config.Now().Sub(time.Unix(seconds, 0))
+	if age > v.config.Tolerance || age < -v.config.Tolerance {
+		return exception.NewUnauthorized(map[string]interface{}{
+			"message": "The webhook timestamp is outside the tolerance.",
+			"details": map[string]interface{}{
+				"header":    name,
+				"tolerance": int(v.config.Tolerance.Seconds()),
+				"error":     ReasonExpiredTimestamp,
+			},
+		})
+	}
+	return nil
+}
+
+// signedContent returns the signed content of a payload and its optional
+// timestamp.
+func signedContent(unix string, body []byte) []byte {
+	if unix == "" {
+		return body
+	}
+	return append([]byte(unix+"."), body...)
+}
+
+// failure builds the Unauthorized exception of a verification failure.
+func failure(message string, reason string, header string) error {
+	return exception.NewUnauthorized(map[string]interface{}{
+		"message": message,
+		"details": map[string]interface{}{
+			"header": header,
+			"error":  reason,
+		},
+	})
+}
@@ -0,0 +1,215 @@
+// Package workerpool runs background tasks with bounded concurrency. Tasks
+// share the error contract of HTTP handlers: their errors are normalized into
+// exceptions (see `exception.Normalize`), their panics are recovered as
+// `exception.Runtime` errors, and both are delivered to an error hook instead
+// of crashing the process or being lost.
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/osirisgate/golang-core/exception"
+)
+
+// Task is a unit of background work. The context is canceled when the pool
+// is canceled.
+type Task func(ctx context.Context) error
+
+// Config holds the settings of a Pool. Zero values fall back to the defaults.
+type Config struct {
+	// Name identifies the pool in the details of its exceptions.
+	Name string
+
+	// Workers is the number of tasks run concurrently. Defaults to
+	// `runtime.GOMAXPROCS(0)`.
+	Workers int
+
+	// QueueSize is the number of submitted tasks waiting for a worker before
+	// Submit blocks and TrySubmit fails. Defaults to Workers.
+	QueueSize int
+
+	// OnError, when set, receives the error of every failed task: the
+	// normalized error it returned, or an `exception.Runtime` with the
+	// "task_panicked" error code when it panicked. It is called from the
+	// worker goroutines and must be safe for concurrent use.
+	OnError func(ctx context.Context, err error)
+}
+
+// Pool is a bounded pool of workers. It is safe for concurrent use.
+type Pool struct {
+	config Config
+	ctx    context.Context
+	cancel context.CancelFunc
+	tasks  chan Task
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// New creates a Pool and starts its workers.
+//
+// Parameters:
+//
+//	ctx: The context of the pool. Canceling it cancels the running tasks and
+//	     stops the workers; queued tasks are then dropped.
+//	config: The pool settings. Zero values fall back to the defaults.
+//
+// Returns:
+//
+//	A pointer to a new Pool. Call Close to release its workers.
+func New(ctx context.Context, config Config) *Pool {
+	if config.Workers <= 0 {
+		config.Workers = runtime.GOMAXPROCS(0)
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = config.Workers
+	}
+
+	poolCtx, cancel := context.WithCancel(ctx)
+	p := &Pool{
+		config: config,
+		ctx:    poolCtx,
+		cancel: cancel,
+		tasks:  make(chan Task, config.QueueSize),
+	}
+	p.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues a task, waiting for room in the queue.
+//
+// Parameters:
+//
+//	ctx: The context bounding the wait. It is not passed to the task.
+//	task: The task to run.
+//
+// Returns:
+//
+//	Nil once the task is queued, an `exception.ServiceUnavailable` with the
+//	"pool_closed" error code when the pool is closed or canceled, or the
+//	normalized error of ctx when it ends first.
+func (p *Pool) Submit(ctx context.Context, task Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed || p.ctx.Err() != nil {
+		return p.unavailable("The worker pool is closed.", "pool_closed")
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.ctx.Done():
+		return p.unavailable("The worker pool is closed.", "pool_closed")
+	case <-ctx.Done():
+		return exception.Normalize(ctx.Err())
+	}
+}
+
+// TrySubmit queues a task without waiting, e.g. to shed load.
+//
+// Parameters:
+//
+//	task: The task to run.
+//
+// Returns:
+//
+//	Nil once the task is queued, or an `exception.ServiceUnavailable` with
+//	the "pool_full" error code when the queue is full (retryable), or the
+//	"pool_closed" error code when the pool is closed or canceled.
+func (p *Pool) TrySubmit(task Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed || p.ctx.Err() != nil {
+		return p.unavailable("The worker pool is closed.", "pool_closed")
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return p.unavailable("The worker pool is full.", "pool_full")
+	}
+}
+
+// Pending returns the number of queued tasks waiting for a worker.
+func (p *Pool) Pending() int {
+	return len(p.tasks)
+}
+
+// Close stops accepting tasks and waits for the queued and running tasks to
+// complete. It is safe to call several times.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.cancel()
+}
+
+// Cancel cancels the running tasks, drops the queued ones and waits for the
+// workers to stop.
+func (p *Pool) Cancel() {
+	p.cancel()
+	p.Close()
+}
+
+// work runs queued tasks until the queue is closed or the pool canceled.
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case task, ok := <-p.tasks:
+			if !ok {
+				return
+			}
+			if p.ctx.Err() != nil {
+				return
+			}
+			if err := p.run(task); err != nil && p.config.OnError != nil {
+				p.config.OnError(p.ctx, err)
+			}
+		}
+	}
+}
+
+// run runs a task, converting its panic into an exception.
+func (p *Pool) run(task Task) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = exception.NewRuntime(map[string]interface{}{
+				"message": "A background task panicked.",
+				"details": map[string]interface{}{
+					"pool":  p.config.Name,
+					"panic": fmt.Sprint(recovered),
+					"error": "task_panicked",
+				},
+			})
+		}
+	}()
+
+	return exception.Normalize(task(p.ctx))
+}
+
+// unavailable builds the exception of a rejected submission.
+func (p *Pool) unavailable(message string, code string) error {
+	return exception.NewServiceUnavailable(map[string]interface{}{
+		"message": message,
+		"details": map[string]interface{}{
+			"pool":  p.config.Name,
+			"error": code,
+		},
+	})
+}